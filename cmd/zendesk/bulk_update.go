@@ -0,0 +1,160 @@
+package main
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/phil-inc/zendesk/zendesk"
+)
+
+// batchSize is the maximum number of tickets Zendesk accepts in a batch update.
+const batchSize = 100
+
+// runBulkUpdate updates tickets from a CSV file. The header names the updated fields:
+// id (required), subject, status, priority, type, assignee_id, group_id, tags
+// (separated by spaces) and custom_field_<id> for custom fields.
+func runBulkUpdate(client zendesk.Client, args []string) error {
+	flags := flag.NewFlagSet("bulk-update", flag.ContinueOnError)
+	file := flags.String("file", "", "CSV file of the ticket updates")
+	dryRun := flags.Bool("dry-run", false, "parse the file without updating the tickets")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if *file == "" {
+		return errors.New("bulk-update: missing -file")
+	}
+
+	f, err := os.Open(*file)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	tickets, err := readTicketUpdates(f)
+	if err != nil {
+		return err
+	}
+	if *dryRun {
+		fmt.Fprintf(os.Stderr, "%d tickets to update\n", len(tickets))
+		return nil
+	}
+
+	failed := 0
+	for start := 0; start < len(tickets); start += batchSize {
+		end := start + batchSize
+		if end > len(tickets) {
+			end = len(tickets)
+		}
+
+		job, err := client.BatchUpdateManyTickets(tickets[start:end])
+		if err != nil {
+			return err
+		}
+		if job == nil {
+			return fmt.Errorf("bulk-update: no job status returned for tickets %d to %d", start+1, end)
+		}
+		job, err = client.WaitForJobCompletion(context.Background(), job.ID, 2*time.Second)
+		if job != nil {
+			for _, result := range job.Results {
+				if result.Error != "" {
+					failed++
+					fmt.Fprintf(os.Stderr, "ticket %d: %s %s\n", result.ID, result.Error, result.Details)
+				}
+			}
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	fmt.Fprintf(os.Stderr, "updated %d tickets, %d failed\n", len(tickets)-failed, failed)
+	if failed > 0 {
+		return fmt.Errorf("bulk-update: %d tickets failed", failed)
+	}
+	return nil
+}
+
+func readTicketUpdates(r io.Reader) ([]zendesk.Ticket, error) {
+	reader := csv.NewReader(r)
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("bulk-update: reading header: %w", err)
+	}
+
+	hasID := false
+	for _, column := range header {
+		hasID = hasID || column == "id"
+	}
+	if !hasID {
+		return nil, errors.New("bulk-update: missing id column")
+	}
+
+	tickets := make([]zendesk.Ticket, 0)
+	for line := 2; ; line++ {
+		row, err := reader.Read()
+		if err == io.EOF {
+			return tickets, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		ticket, err := ticketUpdate(header, row)
+		if err != nil {
+			return nil, fmt.Errorf("bulk-update: line %d: %w", line, err)
+		}
+		tickets = append(tickets, ticket)
+	}
+}
+
+func ticketUpdate(header, row []string) (zendesk.Ticket, error) {
+	var ticket zendesk.Ticket
+	for i, column := range header {
+		value := strings.TrimSpace(row[i])
+		if value == "" {
+			continue
+		}
+
+		var err error
+		switch {
+		case column == "id":
+			ticket.ID, err = strconv.ParseInt(value, 10, 64)
+		case column == "subject":
+			ticket.Subject = value
+		case column == "status":
+			ticket.Status = value
+		case column == "priority":
+			ticket.Priority = value
+		case column == "type":
+			ticket.Type = value
+		case column == "assignee_id":
+			ticket.AssigneeID, err = strconv.ParseInt(value, 10, 64)
+		case column == "group_id":
+			ticket.GroupID, err = strconv.ParseInt(value, 10, 64)
+		case column == "tags":
+			ticket.Tags = strings.Fields(value)
+		case strings.HasPrefix(column, "custom_field_"):
+			var id int64
+			id, err = strconv.ParseInt(strings.TrimPrefix(column, "custom_field_"), 10, 64)
+			ticket.CustomFields = append(ticket.CustomFields, zendesk.CustomField{ID: id, Value: value})
+		default:
+			return ticket, fmt.Errorf("unknown column %q", column)
+		}
+		if err != nil {
+			return ticket, fmt.Errorf("column %s: %w", column, err)
+		}
+	}
+
+	if ticket.ID == 0 {
+		return ticket, errors.New("missing ticket id")
+	}
+	return ticket, nil
+}
@@ -0,0 +1,127 @@
+package main
+
+import (
+	"encoding/hex"
+	"errors"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"github.com/phil-inc/zendesk/zendesk"
+)
+
+// runExport streams an incremental export as JSON lines. The report of the run, with the
+// cursor to resume the export from, is printed on stderr even when the export fails
+// midway, and can be written as a JSON manifest alongside the data. With a key file,
+// holding a hex encoded AES key, each line is encrypted with AES-GCM. With a checkpoint
+// file, the cursor is saved after each page and the export resumes from it when no
+// cursor is given, so an interrupted export is simply run again with the same flags.
+func runExport(client zendesk.Client, args []string) error {
+	if len(args) == 0 {
+		return errors.New("export: missing record type, tickets, users or satisfaction_ratings")
+	}
+	kind := args[0]
+
+	flags := flag.NewFlagSet("export", flag.ContinueOnError)
+	startTime := flags.Int64("start-time", 0, "export the records updated since this unix time")
+	cursor := flags.String("cursor", "", "resume a previous export from its cursor")
+	checkpointFile := flags.String("checkpoint", "", "save the cursor to this file after each page and resume from it")
+	out := flags.String("out", "", "write the records to this file instead of stdout")
+	keyFile := flags.String("key-file", "", "encrypt the records with the hex encoded AES key of this file")
+	report := flags.String("report", "", "write the run report as a JSON manifest to this file")
+	if err := flags.Parse(args[1:]); err != nil {
+		return err
+	}
+
+	export := client.ExportTickets
+	switch kind {
+	case "tickets":
+	case "users":
+		export = client.ExportUsers
+	case "satisfaction_ratings":
+		export = client.ExportSatisfactionRatings
+	default:
+		return fmt.Errorf("export: unknown record type %q", kind)
+	}
+
+	var encryptor zendesk.Encryptor
+	if *keyFile != "" {
+		key, err := readKey(*keyFile)
+		if err != nil {
+			return err
+		}
+		if encryptor, err = zendesk.NewAESGCMEncryptor(key); err != nil {
+			return err
+		}
+	}
+
+	w, err := output(*out)
+	if err != nil {
+		return err
+	}
+	defer w.Close()
+
+	sink := zendesk.NewJSONLSink(w)
+	if encryptor != nil {
+		sink = zendesk.NewEncryptedJSONLSink(w, encryptor)
+	}
+	opts := &zendesk.IncrementalExportOptions{StartTime: *startTime, Cursor: *cursor}
+	if *checkpointFile != "" {
+		opts.Checkpoint = &flushingCheckpoint{Checkpoint: zendesk.NewFileCheckpoint(*checkpointFile), sink: sink}
+	}
+	checkpoint, err := export(opts, sink)
+	if ferr := sink.Flush(); err == nil {
+		err = ferr
+	}
+	if checkpoint == nil {
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "%s\n", checkpoint.Report)
+	if *report != "" {
+		if rerr := writeManifest(*report, checkpoint.Report); err == nil {
+			err = rerr
+		}
+	}
+	return err
+}
+
+// flushingCheckpoint flushes the records written to the sink before saving a cursor, so
+// that the saved cursor never gets ahead of the records written to the output.
+type flushingCheckpoint struct {
+	zendesk.Checkpoint
+	sink *zendesk.JSONLSink
+}
+
+func (c *flushingCheckpoint) Save(stream, cursor string) error {
+	if err := c.sink.Flush(); err != nil {
+		return err
+	}
+	return c.Checkpoint.Save(stream, cursor)
+}
+
+// writeManifest writes the export manifest holding the report to a file.
+func writeManifest(path string, report *zendesk.RunReport) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	manifest := new(zendesk.ExportManifest)
+	manifest.Add(report)
+	if err := manifest.WriteJSON(f); err != nil {
+		return err
+	}
+	return f.Close()
+}
+
+// readKey reads a hex encoded key from a file.
+func readKey(path string) ([]byte, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return hex.DecodeString(strings.TrimSpace(string(data)))
+}
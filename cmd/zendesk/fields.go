@@ -0,0 +1,104 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"text/tabwriter"
+
+	"github.com/phil-inc/zendesk/zendesk"
+)
+
+// runFields lists the ticket fields, or exports and applies them, along with the
+// ticket forms and triggers, as a provisioning spec.
+func runFields(client zendesk.Client, args []string) error {
+	if len(args) == 0 {
+		return errors.New("fields: missing command, list, export or apply")
+	}
+
+	switch args[0] {
+	case "list":
+		return listFields(client)
+	case "export":
+		return exportFields(client, args[1:])
+	case "apply":
+		return applyFields(client, args[1:])
+	}
+	return fmt.Errorf("fields: unknown command %q", args[0])
+}
+
+func listFields(client zendesk.Client) error {
+	fields, err := client.ListTicketFields()
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tTYPE\tTITLE")
+	for _, field := range fields {
+		fmt.Fprintf(w, "%d\t%s\t%s\n", field.ID, field.Type, field.Title)
+	}
+	return w.Flush()
+}
+
+func exportFields(client zendesk.Client, args []string) error {
+	flags := flag.NewFlagSet("fields export", flag.ContinueOnError)
+	out := flags.String("out", "", "write the spec to this file instead of stdout")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	spec, err := client.ExportProvisioningSpec()
+	if err != nil {
+		return err
+	}
+
+	w, err := output(*out)
+	if err != nil {
+		return err
+	}
+	defer w.Close()
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(spec)
+}
+
+func applyFields(client zendesk.Client, args []string) error {
+	flags := flag.NewFlagSet("fields apply", flag.ContinueOnError)
+	file := flags.String("file", "", "provisioning spec to apply")
+	prune := flags.Bool("prune", false, "delete the fields, forms and triggers missing from the spec")
+	dryRun := flags.Bool("dry-run", false, "print the changes without applying them")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if *file == "" {
+		return errors.New("fields apply: missing -file")
+	}
+
+	data, err := ioutil.ReadFile(*file)
+	if err != nil {
+		return err
+	}
+	spec := new(zendesk.ProvisioningSpec)
+	if err := json.Unmarshal(data, spec); err != nil {
+		return fmt.Errorf("fields apply: %s: %w", *file, err)
+	}
+
+	opts := &zendesk.ProvisioningOptions{Prune: *prune}
+	var plan *zendesk.ProvisioningPlan
+	if *dryRun {
+		plan, err = client.PlanProvisioning(spec, opts)
+	} else {
+		plan, err = client.ApplyProvisioningSpec(spec, opts)
+	}
+	if plan != nil {
+		for _, change := range plan.Changes {
+			fmt.Printf("%s %s %q (%d)\n", change.Action, change.Resource, change.Name, change.ID)
+		}
+	}
+	return err
+}
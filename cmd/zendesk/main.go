@@ -0,0 +1,92 @@
+// Command zendesk runs common operations against a Zendesk account, and serves as
+// reference usage of the zendesk package.
+//
+// The account is configured through the environment:
+//
+//	ZENDESK_DOMAIN     the subdomain of the account, as in <domain>.zendesk.com
+//	ZENDESK_EMAIL      the email of the agent
+//	ZENDESK_API_TOKEN  an API token of the account
+//
+// Usage:
+//
+//	zendesk export tickets|users|satisfaction_ratings [-start-time unix] [-cursor cursor] [-checkpoint file] [-out file] [-key-file file] [-report file]
+//	zendesk bulk-update -file updates.csv [-dry-run]
+//	zendesk fields list
+//	zendesk fields export [-out file]
+//	zendesk fields apply -file spec.json [-prune] [-dry-run]
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/phil-inc/zendesk/zendesk"
+)
+
+const usage = `usage:
+  zendesk export tickets|users|satisfaction_ratings [-start-time unix] [-cursor cursor] [-checkpoint file] [-out file] [-key-file file] [-report file]
+  zendesk bulk-update -file updates.csv [-dry-run]
+  zendesk fields list
+  zendesk fields export [-out file]
+  zendesk fields apply -file spec.json [-prune] [-dry-run]
+`
+
+func main() {
+	if err := run(os.Args[1:]); err != nil {
+		fmt.Fprintln(os.Stderr, "zendesk:", err)
+		os.Exit(1)
+	}
+}
+
+func run(args []string) error {
+	if len(args) == 0 {
+		fmt.Fprint(os.Stderr, usage)
+		return errors.New("missing command")
+	}
+
+	client, err := newClient()
+	if err != nil {
+		return err
+	}
+
+	switch args[0] {
+	case "export":
+		return runExport(client, args[1:])
+	case "bulk-update":
+		return runBulkUpdate(client, args[1:])
+	case "fields":
+		return runFields(client, args[1:])
+	}
+
+	fmt.Fprint(os.Stderr, usage)
+	return fmt.Errorf("unknown command %q", args[0])
+}
+
+func newClient() (zendesk.Client, error) {
+	domain := os.Getenv("ZENDESK_DOMAIN")
+	email := os.Getenv("ZENDESK_EMAIL")
+	token := os.Getenv("ZENDESK_API_TOKEN")
+	if domain == "" || email == "" || token == "" {
+		return nil, errors.New("ZENDESK_DOMAIN, ZENDESK_EMAIL and ZENDESK_API_TOKEN must be set")
+	}
+
+	return zendesk.New(domain, zendesk.WithToken(email, token))
+}
+
+// output returns the file at path, or stdout when path is empty.
+func output(path string) (io.WriteCloser, error) {
+	if path == "" {
+		return nopCloser{os.Stdout}, nil
+	}
+	return os.Create(path)
+}
+
+type nopCloser struct {
+	io.Writer
+}
+
+func (nopCloser) Close() error {
+	return nil
+}
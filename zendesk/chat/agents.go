@@ -0,0 +1,65 @@
+package chat
+
+import (
+	"fmt"
+	"time"
+)
+
+// Agent is a chat agent.
+//
+// Zendesk Chat API docs: https://developer.zendesk.com/api-reference/live-chat/chat-api/agents/
+type Agent struct {
+	ID          int64      `json:"id"`
+	Email       string     `json:"email,omitempty"`
+	DisplayName string     `json:"display_name,omitempty"`
+	FirstName   string     `json:"first_name,omitempty"`
+	LastName    string     `json:"last_name,omitempty"`
+	RoleID      int64      `json:"role_id,omitempty"`
+	Departments []int64    `json:"departments,omitempty"`
+	CreateDate  *time.Time `json:"create_date,omitempty"`
+}
+
+// ListAgents lists the chat agents of the account.
+//
+// Zendesk Chat API docs: https://developer.zendesk.com/api-reference/live-chat/chat-api/agents/#list-agents
+func (c *Client) ListAgents() ([]Agent, error) {
+	var out []Agent
+	if err := c.get(c.baseURL, "agents", &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ShowAgent fetches a chat agent by its ID.
+//
+// Zendesk Chat API docs: https://developer.zendesk.com/api-reference/live-chat/chat-api/agents/#show-agent
+func (c *Client) ShowAgent(id int64) (*Agent, error) {
+	out := new(Agent)
+	if err := c.get(c.baseURL, fmt.Sprintf("agents/%d", id), out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// AgentStatusCounts counts the chat agents by status at the time of the call.
+type AgentStatusCounts struct {
+	Online    int `json:"agents_online"`
+	Away      int `json:"agents_away"`
+	Invisible int `json:"agents_invisible"`
+}
+
+// ShowAgentStatusCounts counts the agents online, away and invisible, with the Real Time
+// Chat API.
+//
+// Zendesk Chat API docs: https://developer.zendesk.com/api-reference/live-chat/real-time-chat-api/rest_api/#agents-metrics
+func (c *Client) ShowAgentStatusCounts() (*AgentStatusCounts, error) {
+	out := struct {
+		Content struct {
+			Data AgentStatusCounts `json:"data"`
+		} `json:"content"`
+	}{}
+	if err := c.get(c.realTimeURL, "agents", &out); err != nil {
+		return nil, err
+	}
+	return &out.Content.Data, nil
+}
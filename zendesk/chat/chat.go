@@ -0,0 +1,129 @@
+// Package chat is a client for the Zendesk Chat API, to pull the chat transcripts and
+// the agent statuses alongside the tickets fetched with the zendesk package:
+//
+//	c, err := chat.New("mycompany", os.Getenv("ZENDESK_OAUTH_TOKEN"))
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	chats, endTime, err := c.GetChatsIncrementally(startTime)
+//
+// The Chat API only accepts OAuth access tokens, with the "chat" scope or the "read"
+// scope: the email and API token used by the zendesk package are rejected. API errors
+// are returned as *zendesk.APIError, so that errors.Is(err, zendesk.ErrNotFound) and
+// the like hold.
+package chat
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/phil-inc/zendesk/zendesk"
+)
+
+// Client is a client for the Zendesk Chat API.
+type Client struct {
+	baseURL     *url.URL
+	realTimeURL *url.URL
+	token       string
+	client      *http.Client
+}
+
+// Option configures a client created by New.
+type Option func(*Client) error
+
+// New creates a Client for the chats of the given Zendesk domain, authenticated with an
+// OAuth access token.
+func New(domain, accessToken string, opts ...Option) (*Client, error) {
+	c := &Client{token: accessToken, client: http.DefaultClient}
+	if domain != "" {
+		baseURL, err := url.Parse(fmt.Sprintf("https://%s.zendesk.com/api/v2/chat/", domain))
+		if err != nil {
+			return nil, err
+		}
+		c.baseURL = baseURL
+	}
+	c.realTimeURL, _ = url.Parse("https://rtm.zopim.com/stream/")
+
+	for _, opt := range opts {
+		if err := opt(c); err != nil {
+			return nil, err
+		}
+	}
+
+	if c.baseURL == nil {
+		return nil, errors.New("chat: a domain or a base URL is required")
+	}
+	return c, nil
+}
+
+// WithBaseURL sets the base URL of the Chat API, such as https://www.zopim.com/api/v2/
+// for the accounts not yet migrated to Zendesk, or a test server.
+func WithBaseURL(endpoint string) Option {
+	return func(c *Client) error {
+		return parseBaseURL(endpoint, &c.baseURL)
+	}
+}
+
+// WithRealTimeURL sets the base URL of the Real Time Chat API, which serves the agent statuses.
+func WithRealTimeURL(endpoint string) Option {
+	return func(c *Client) error {
+		return parseBaseURL(endpoint, &c.realTimeURL)
+	}
+}
+
+// WithHTTPClient sets the HTTP client sending the requests, to configure timeouts or proxies.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) error {
+		if httpClient == nil {
+			return errors.New("chat: nil HTTP client")
+		}
+		c.client = httpClient
+		return nil
+	}
+}
+
+// parseBaseURL parses endpoint into dst, with a trailing slash so that the endpoints
+// resolve below it.
+func parseBaseURL(endpoint string, dst **url.URL) error {
+	if endpoint != "" && endpoint[len(endpoint)-1] != '/' {
+		endpoint += "/"
+	}
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return err
+	}
+	*dst = u
+	return nil
+}
+
+// get fetches endpoint, relative to base, or absolute for the next pages, into out.
+func (c *Client) get(base *url.URL, endpoint string, out interface{}) error {
+	rel, err := url.Parse(endpoint)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequest("GET", base.ResolveReference(rel).String(), nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+c.token)
+	req.Header.Set("Accept", "application/json")
+
+	res, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		apierr := &zendesk.APIError{Response: res}
+		if err := json.NewDecoder(res.Body).Decode(apierr); err != nil {
+			apierr.Type = "Unknown"
+		}
+		return apierr
+	}
+	return json.NewDecoder(res.Body).Decode(out)
+}
@@ -0,0 +1,160 @@
+package chat
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// Chat is a chat or an offline message, with its transcript in History.
+//
+// Zendesk Chat API docs: https://developer.zendesk.com/api-reference/live-chat/chat-api/chats/
+type Chat struct {
+	ID              string     `json:"id"`
+	Type            string     `json:"type"`
+	Timestamp       *time.Time `json:"timestamp,omitempty"`
+	UpdateTimestamp *time.Time `json:"update_timestamp,omitempty"`
+	// Duration is the length of the chat in seconds.
+	Duration        int64           `json:"duration,omitempty"`
+	Visitor         Visitor         `json:"visitor"`
+	AgentIDs        []string        `json:"agent_ids,omitempty"`
+	AgentNames      []string        `json:"agent_names,omitempty"`
+	DepartmentID    int64           `json:"department_id,omitempty"`
+	DepartmentName  string          `json:"department_name,omitempty"`
+	Tags            []string        `json:"tags,omitempty"`
+	Rating          string          `json:"rating,omitempty"`
+	Comment         string          `json:"comment,omitempty"`
+	Missed          bool            `json:"missed"`
+	Unread          bool            `json:"unread"`
+	StartedBy       string          `json:"started_by,omitempty"`
+	ZendeskTicketID int64           `json:"zendesk_ticket_id,omitempty"`
+	Count           MessageCount    `json:"count"`
+	ResponseTime    *ResponseTime   `json:"response_time,omitempty"`
+	History         []ChatEvent     `json:"history,omitempty"`
+	Session         *VisitorSession `json:"session,omitempty"`
+}
+
+// Chat types.
+const (
+	TypeChat           = "chat"
+	TypeOfflineMessage = "offline_msg"
+)
+
+// Visitor is the visitor of a chat.
+type Visitor struct {
+	ID    string `json:"id,omitempty"`
+	Name  string `json:"name,omitempty"`
+	Email string `json:"email,omitempty"`
+	Phone string `json:"phone,omitempty"`
+	Notes string `json:"notes,omitempty"`
+}
+
+// VisitorSession describes the browser and location of the visitor of a chat.
+type VisitorSession struct {
+	Browser     string `json:"browser,omitempty"`
+	Platform    string `json:"platform,omitempty"`
+	UserAgent   string `json:"user_agent,omitempty"`
+	IP          string `json:"ip,omitempty"`
+	City        string `json:"city,omitempty"`
+	Region      string `json:"region,omitempty"`
+	CountryCode string `json:"country_code,omitempty"`
+	CountryName string `json:"country_name,omitempty"`
+}
+
+// MessageCount counts the messages of a chat.
+type MessageCount struct {
+	Total   int `json:"total"`
+	Agent   int `json:"agent"`
+	Visitor int `json:"visitor"`
+}
+
+// ResponseTime gives the response times of the agents of a chat, in seconds.
+type ResponseTime struct {
+	First int64   `json:"first"`
+	Avg   float64 `json:"avg"`
+	Max   int64   `json:"max"`
+}
+
+// ChatEvent is an event of the transcript of a chat, such as a message, of type
+// "chat.msg", or an agent joining, of type "chat.memberjoin".
+type ChatEvent struct {
+	Type      string     `json:"type"`
+	Name      string     `json:"name,omitempty"`
+	Nick      string     `json:"nick,omitempty"`
+	Msg       string     `json:"msg,omitempty"`
+	Channel   string     `json:"channel,omitempty"`
+	Timestamp *time.Time `json:"timestamp,omitempty"`
+}
+
+// ListChats lists the chats of the account, following the pages until the last one.
+//
+// Zendesk Chat API docs: https://developer.zendesk.com/api-reference/live-chat/chat-api/chats/#list-chats
+func (c *Client) ListChats() ([]Chat, error) {
+	result := make([]Chat, 0)
+	endpoint := "chats"
+	for endpoint != "" {
+		out := struct {
+			Chats   []Chat `json:"chats"`
+			NextURL string `json:"next_url"`
+		}{}
+		if err := c.get(c.baseURL, endpoint, &out); err != nil {
+			return nil, err
+		}
+		result = append(result, out.Chats...)
+		if len(out.Chats) == 0 || out.NextURL == endpoint {
+			break
+		}
+		endpoint = out.NextURL
+	}
+	return result, nil
+}
+
+// ShowChat fetches a chat, with its transcript, by its ID.
+//
+// Zendesk Chat API docs: https://developer.zendesk.com/api-reference/live-chat/chat-api/chats/#show-chat
+func (c *Client) ShowChat(id string) (*Chat, error) {
+	out := new(Chat)
+	if err := c.get(c.baseURL, fmt.Sprintf("chats/%s", url.PathEscape(id)), out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// incrementalChatsLimit is the size of the pages of the incremental chat export, a
+// shorter page being the last one.
+const incrementalChatsLimit = 1000
+
+// GetChatsIncrementally pulls the chats updated since the given unix time, with their
+// transcripts. The returned end time resumes the export later on, as the start time of
+// the next one.
+//
+// Zendesk Chat API docs: https://developer.zendesk.com/api-reference/live-chat/chat-api/incremental_export/#incremental-chat-export
+func (c *Client) GetChatsIncrementally(startTime int64) ([]Chat, int64, error) {
+	result := make([]Chat, 0)
+	params := url.Values{}
+	params.Set("start_time", strconv.FormatInt(startTime, 10))
+	params.Set("fields", "chats(*)")
+	endpoint := "incremental/chats?" + params.Encode()
+	endTime := startTime
+	for {
+		out := struct {
+			Chats    []Chat `json:"chats"`
+			Count    int    `json:"count"`
+			EndTime  int64  `json:"end_time"`
+			NextPage string `json:"next_page"`
+		}{}
+		if err := c.get(c.baseURL, endpoint, &out); err != nil {
+			return result, endTime, err
+		}
+		result = append(result, out.Chats...)
+		if out.EndTime != 0 {
+			endTime = out.EndTime
+		}
+		if out.Count < incrementalChatsLimit || out.NextPage == "" || out.NextPage == endpoint {
+			break
+		}
+		endpoint = out.NextPage
+	}
+	return result, endTime, nil
+}
@@ -0,0 +1,87 @@
+package sunco
+
+import (
+	"net/url"
+	"time"
+)
+
+// Conversation is a conversation between the business and users of the app. Personal
+// conversations have a single user.
+//
+// Sunshine Conversations API docs: https://developer.zendesk.com/api-reference/conversations/#tag/Conversations
+type Conversation struct {
+	ID               string                 `json:"id,omitempty"`
+	Type             string                 `json:"type,omitempty"`
+	DisplayName      string                 `json:"displayName,omitempty"`
+	Description      string                 `json:"description,omitempty"`
+	IsDefault        bool                   `json:"isDefault,omitempty"`
+	BusinessLastRead *time.Time             `json:"businessLastRead,omitempty"`
+	LastUpdatedAt    *time.Time             `json:"lastUpdatedAt,omitempty"`
+	Metadata         map[string]interface{} `json:"metadata,omitempty"`
+}
+
+// Conversation types.
+const (
+	ConversationPersonal = "personal"
+	ConversationSDKGroup = "sdkGroup"
+)
+
+// Participant identifies a user joining a conversation, by its ID or its external ID.
+type Participant struct {
+	UserID         string `json:"userId,omitempty"`
+	UserExternalID string `json:"userExternalId,omitempty"`
+}
+
+// ListUserConversations lists the conversations of a user, identified by its ID,
+// following the pages until the last one.
+//
+// Sunshine Conversations API docs: https://developer.zendesk.com/api-reference/conversations/#operation/ListConversations
+func (c *Client) ListUserConversations(userID string) ([]Conversation, error) {
+	params := url.Values{}
+	params.Set("filter[userId]", userID)
+
+	result := make([]Conversation, 0)
+	for {
+		out := struct {
+			Conversations []Conversation `json:"conversations"`
+			Meta          struct {
+				HasMore     bool   `json:"hasMore"`
+				AfterCursor string `json:"afterCursor"`
+			} `json:"meta"`
+		}{}
+		if err := c.do("GET", c.appEndpoint("conversations?%s", params.Encode()), nil, &out); err != nil {
+			return nil, err
+		}
+		result = append(result, out.Conversations...)
+		if !out.Meta.HasMore || out.Meta.AfterCursor == "" || len(out.Conversations) == 0 {
+			break
+		}
+		params.Set("page[after]", out.Meta.AfterCursor)
+	}
+	return result, nil
+}
+
+// CreateConversation starts a personal conversation with a user, to message users who
+// have none yet.
+//
+// Sunshine Conversations API docs: https://developer.zendesk.com/api-reference/conversations/#operation/CreateConversation
+func (c *Client) CreateConversation(participant Participant, conversation *Conversation) (*Conversation, error) {
+	in := struct {
+		Conversation
+		Participants []Participant `json:"participants"`
+	}{Participants: []Participant{participant}}
+	if conversation != nil {
+		in.Conversation = *conversation
+	}
+	if in.Type == "" {
+		in.Type = ConversationPersonal
+	}
+
+	out := struct {
+		Conversation *Conversation `json:"conversation"`
+	}{}
+	if err := c.do("POST", c.appEndpoint("conversations"), in, &out); err != nil {
+		return nil, err
+	}
+	return out.Conversation, nil
+}
@@ -0,0 +1,133 @@
+package sunco
+
+import (
+	"net/url"
+	"time"
+)
+
+// Message is a message of a conversation.
+//
+// Sunshine Conversations API docs: https://developer.zendesk.com/api-reference/conversations/#tag/Messages
+type Message struct {
+	ID       string                 `json:"id,omitempty"`
+	Received *time.Time             `json:"received,omitempty"`
+	Author   Author                 `json:"author"`
+	Content  Content                `json:"content"`
+	Source   *Source                `json:"source,omitempty"`
+	Metadata map[string]interface{} `json:"metadata,omitempty"`
+}
+
+// Author is the author of a message, a user of the app or the business.
+type Author struct {
+	Type           string `json:"type"`
+	UserID         string `json:"userId,omitempty"`
+	UserExternalID string `json:"userExternalId,omitempty"`
+	DisplayName    string `json:"displayName,omitempty"`
+	AvatarURL      string `json:"avatarUrl,omitempty"`
+}
+
+// Author types.
+const (
+	AuthorBusiness = "business"
+	AuthorUser     = "user"
+)
+
+// Content is the content of a message: a text, or a file or an image for which MediaURL
+// is set. Text messages may carry actions, such as links or replies, rendered as buttons.
+type Content struct {
+	Type      string   `json:"type"`
+	Text      string   `json:"text,omitempty"`
+	MediaURL  string   `json:"mediaUrl,omitempty"`
+	MediaType string   `json:"mediaType,omitempty"`
+	AltText   string   `json:"altText,omitempty"`
+	Actions   []Action `json:"actions,omitempty"`
+}
+
+// Content types.
+const (
+	ContentText  = "text"
+	ContentImage = "image"
+	ContentFile  = "file"
+)
+
+// Action is a button of a message, such as a link, of type "link" with a URI, or a
+// reply, of type "reply" with a payload.
+type Action struct {
+	Type    string `json:"type"`
+	Text    string `json:"text"`
+	URI     string `json:"uri,omitempty"`
+	Payload string `json:"payload,omitempty"`
+}
+
+// Source describes the channel a message was sent or received on.
+type Source struct {
+	Type              string `json:"type,omitempty"`
+	IntegrationID     string `json:"integrationId,omitempty"`
+	OriginalMessageID string `json:"originalMessageId,omitempty"`
+}
+
+// TextMessage is a text message of the business, for PostMessage.
+func TextMessage(text string) *Message {
+	return &Message{
+		Author:  Author{Type: AuthorBusiness},
+		Content: Content{Type: ContentText, Text: text},
+	}
+}
+
+// PostMessage sends a message to a conversation. It returns the messages created, the
+// message itself and the automatic replies it may have triggered.
+//
+// Sunshine Conversations API docs: https://developer.zendesk.com/api-reference/conversations/#operation/PostMessage
+func (c *Client) PostMessage(conversationID string, message *Message) ([]Message, error) {
+	out := struct {
+		Messages []Message `json:"messages"`
+	}{}
+	err := c.do("POST", c.appEndpoint("conversations/%s/messages", url.PathEscape(conversationID)), message, &out)
+	if err != nil {
+		return nil, err
+	}
+	return out.Messages, nil
+}
+
+// ListMessages lists the messages of a conversation, from the oldest to the latest,
+// following the pages back to the start of the conversation.
+//
+// Sunshine Conversations API docs: https://developer.zendesk.com/api-reference/conversations/#operation/ListMessages
+func (c *Client) ListMessages(conversationID string) ([]Message, error) {
+	var pages [][]Message
+	endpoint := c.appEndpoint("conversations/%s/messages", url.PathEscape(conversationID))
+	for endpoint != "" {
+		out := struct {
+			Messages []Message `json:"messages"`
+			Meta     struct {
+				HasPrevious  bool   `json:"hasPrevious"`
+				BeforeCursor string `json:"beforeCursor"`
+			} `json:"meta"`
+		}{}
+		if err := c.do("GET", endpoint, nil, &out); err != nil {
+			return nil, err
+		}
+		pages = append(pages, out.Messages)
+		if !out.Meta.HasPrevious || out.Meta.BeforeCursor == "" || len(out.Messages) == 0 {
+			break
+		}
+		params := url.Values{}
+		params.Set("page[before]", out.Meta.BeforeCursor)
+		endpoint = c.appEndpoint("conversations/%s/messages?%s", url.PathEscape(conversationID), params.Encode())
+	}
+
+	// The pages come from the latest to the oldest, each in chronological order.
+	result := make([]Message, 0)
+	for i := len(pages) - 1; i >= 0; i-- {
+		result = append(result, pages[i]...)
+	}
+	return result, nil
+}
+
+// DeleteMessage deletes a message of a conversation.
+//
+// Sunshine Conversations API docs: https://developer.zendesk.com/api-reference/conversations/#operation/DeleteMessage
+func (c *Client) DeleteMessage(conversationID, messageID string) error {
+	endpoint := c.appEndpoint("conversations/%s/messages/%s", url.PathEscape(conversationID), url.PathEscape(messageID))
+	return c.do("DELETE", endpoint, nil, nil)
+}
@@ -0,0 +1,155 @@
+// Package sunco is a client for the Sunshine Conversations API, which sends and lists
+// the messages of the messaging channels of Zendesk, such as the Web Widget, WhatsApp
+// or Messenger:
+//
+//	c, err := sunco.New("mycompany", appID, keyID, secret)
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	messages, err := c.PostMessage(conversationID, sunco.TextMessage("Your order has shipped."))
+//
+// The API authenticates with the ID and secret of an API key of the Sunshine
+// Conversations app, created in the Admin Center, rather than the credentials of the
+// zendesk package. API errors are returned as *zendesk.APIError, so that
+// errors.Is(err, zendesk.ErrNotFound) and the like hold.
+package sunco
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+
+	"github.com/phil-inc/zendesk/zendesk"
+)
+
+// Client is a client for the Sunshine Conversations API of an app.
+type Client struct {
+	baseURL *url.URL
+	appID   string
+	keyID   string
+	secret  string
+	client  *http.Client
+}
+
+// Option configures a client created by New.
+type Option func(*Client) error
+
+// New creates a Client for the Sunshine Conversations app of the given Zendesk domain,
+// authenticated with the ID and secret of an API key of the app.
+func New(domain, appID, keyID, secret string, opts ...Option) (*Client, error) {
+	c := &Client{appID: appID, keyID: keyID, secret: secret, client: http.DefaultClient}
+	if domain != "" {
+		baseURL, err := url.Parse(fmt.Sprintf("https://%s.zendesk.com/sc/", domain))
+		if err != nil {
+			return nil, err
+		}
+		c.baseURL = baseURL
+	}
+
+	for _, opt := range opts {
+		if err := opt(c); err != nil {
+			return nil, err
+		}
+	}
+
+	if c.baseURL == nil {
+		return nil, errors.New("sunco: a domain or a base URL is required")
+	}
+	if c.appID == "" {
+		return nil, errors.New("sunco: an app ID is required")
+	}
+	return c, nil
+}
+
+// WithBaseURL sets the base URL of the API, such as https://api.smooch.io/ for the apps
+// outside of Zendesk, or a test server.
+func WithBaseURL(endpoint string) Option {
+	return func(c *Client) error {
+		if endpoint != "" && endpoint[len(endpoint)-1] != '/' {
+			endpoint += "/"
+		}
+		u, err := url.Parse(endpoint)
+		if err != nil {
+			return err
+		}
+		c.baseURL = u
+		return nil
+	}
+}
+
+// WithHTTPClient sets the HTTP client sending the requests, to configure timeouts or proxies.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) error {
+		if httpClient == nil {
+			return errors.New("sunco: nil HTTP client")
+		}
+		c.client = httpClient
+		return nil
+	}
+}
+
+// appEndpoint is the endpoint of a resource of the app of the client.
+func (c *Client) appEndpoint(format string, a ...interface{}) string {
+	return fmt.Sprintf("v2/apps/%s/", url.PathEscape(c.appID)) + fmt.Sprintf(format, a...)
+}
+
+// do sends in, unless nil, as the JSON body of a request to endpoint, relative to the
+// base URL or absolute for the next pages, and decodes the response into out.
+func (c *Client) do(method, endpoint string, in, out interface{}) error {
+	rel, err := url.Parse(endpoint)
+	if err != nil {
+		return err
+	}
+	var body io.Reader
+	if in != nil {
+		buf, err := json.Marshal(in)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewReader(buf)
+	}
+	req, err := http.NewRequest(method, c.baseURL.ResolveReference(rel).String(), body)
+	if err != nil {
+		return err
+	}
+	req.SetBasicAuth(c.keyID, c.secret)
+	req.Header.Set("Accept", "application/json")
+	if in != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	res, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		return decodeError(res)
+	}
+	if out == nil {
+		return nil
+	}
+	return json.NewDecoder(res.Body).Decode(out)
+}
+
+// decodeError reads an error of the API, shaped as {"errors": [{"code", "title"}]},
+// into an APIError.
+func decodeError(res *http.Response) error {
+	apierr := &zendesk.APIError{Response: res, Type: "Unknown"}
+	out := struct {
+		Errors []struct {
+			Code  string `json:"code"`
+			Title string `json:"title"`
+		} `json:"errors"`
+	}{}
+	if err := json.NewDecoder(res.Body).Decode(&out); err == nil && len(out.Errors) > 0 {
+		apierr.Type = out.Errors[0].Code
+		apierr.Description = out.Errors[0].Title
+	}
+	return apierr
+}
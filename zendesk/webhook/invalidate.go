@@ -0,0 +1,48 @@
+package webhook
+
+import (
+	"context"
+)
+
+// Invalidator is implemented by the clients of the zendesk package, whose cached ticket
+// fields, ticket forms and brands can be dropped.
+type Invalidator interface {
+	InvalidateSchemas()
+	InvalidateBrands()
+}
+
+// Event types of ticket field and ticket form changes. Zendesk sends no event subscription
+// when fields or forms change, so these are the types the account's admin tooling or
+// automation sets in the payload of the webhook it sends on such changes.
+const (
+	TicketFieldChanged = "ticket_field.changed"
+	TicketFormChanged  = "ticket_form.changed"
+)
+
+// InvalidateSchemas returns a handler dropping the cached ticket fields and forms of client,
+// so that a long-running service picks up the changes of admins. Register it for the field
+// and form changes only, with HandleSchemaChanges.
+func InvalidateSchemas(client Invalidator) HandlerFunc {
+	return func(ctx context.Context, e *Event) error {
+		client.InvalidateSchemas()
+		return nil
+	}
+}
+
+// HandleSchemaChanges registers InvalidateSchemas for the TicketFieldChanged and
+// TicketFormChanged events of h:
+//
+//	webhook.HandleSchemaChanges(h, client)
+func HandleSchemaChanges(h *Handler, client Invalidator) {
+	invalidate := InvalidateSchemas(client)
+	h.Handle(TicketFieldChanged, invalidate)
+	h.Handle(TicketFormChanged, invalidate)
+}
+
+// InvalidateBrands returns a handler dropping the cached brands of client.
+func InvalidateBrands(client Invalidator) HandlerFunc {
+	return func(ctx context.Context, e *Event) error {
+		client.InvalidateBrands()
+		return nil
+	}
+}
@@ -0,0 +1,31 @@
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+)
+
+// Headers set by Zendesk on webhook requests.
+const (
+	SignatureHeader          = "X-Zendesk-Webhook-Signature"
+	SignatureTimestampHeader = "X-Zendesk-Webhook-Signature-Timestamp"
+)
+
+// Sign returns the signature Zendesk computes for a webhook request: the base64
+// encoded HMAC-SHA256 of the timestamp followed by the body, keyed with the
+// signing secret of the webhook.
+//
+// Zendesk docs: https://developer.zendesk.com/documentation/webhooks/verifying/
+func Sign(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write(body)
+	return base64.StdEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// VerifySignature reports whether the signature matches the timestamp and body.
+func VerifySignature(secret, signature, timestamp string, body []byte) bool {
+	expected := Sign(secret, timestamp, body)
+	return hmac.Equal([]byte(signature), []byte(expected))
+}
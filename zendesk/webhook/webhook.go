@@ -0,0 +1,293 @@
+// Package webhook receives Zendesk webhooks. Its Handler validates the signature
+// of the requests, parses the ticket and user payloads into the types of the
+// zendesk package, and dispatches them to the registered handler functions:
+//
+//	h := webhook.NewHandler(os.Getenv("ZENDESK_WEBHOOK_SECRET"))
+//	h.HandleTicket(webhook.TicketCreated, func(ctx context.Context, e *webhook.Event, t *zendesk.Ticket) error {
+//		log.Printf("ticket %d created", t.ID)
+//		return nil
+//	})
+//	http.Handle("/zendesk/webhook", h)
+//
+// Both event subscriptions, whose payload is a Zendesk event, and trigger or
+// automation webhooks, whose payload is a JSON object with a "ticket" or "user"
+// key, are supported.
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/phil-inc/zendesk/zendesk"
+)
+
+// Event types of the Zendesk event subscriptions.
+const (
+	TicketCreated       = "zen:event-type:ticket.created"
+	TicketStatusChanged = "zen:event-type:ticket.status_changed"
+	TicketCommentAdded  = "zen:event-type:ticket.comment_added"
+	TicketTagsChanged   = "zen:event-type:ticket.tags_changed"
+	TicketSoftDeleted   = "zen:event-type:ticket.soft_deleted"
+	UserCreated         = "zen:event-type:user.created"
+	UserDeleted         = "zen:event-type:user.deleted"
+	UserActiveChanged   = "zen:event-type:user.active_changed"
+	UserIdentityChanged = "zen:event-type:user.identity_changed"
+	UserRoleChanged     = "zen:event-type:user.role_changed"
+)
+
+// AnyEvent registers a handler for every event.
+const AnyEvent = ""
+
+// Event is a webhook payload. Trigger and automation webhooks have no event type.
+type Event struct {
+	Type      string          `json:"type"`
+	ID        string          `json:"id"`
+	AccountID int64           `json:"account_id"`
+	Time      *time.Time      `json:"time"`
+	Subject   string          `json:"subject"`
+	Detail    json.RawMessage `json:"detail"`
+	Change    json.RawMessage `json:"event"`
+
+	// Body is the raw payload of the request.
+	Body []byte `json:"-"`
+
+	ticket *zendesk.Ticket
+	user   *zendesk.User
+}
+
+// Ticket returns the ticket of the event, or nil if it carries no ticket.
+func (e *Event) Ticket() *zendesk.Ticket {
+	return e.ticket
+}
+
+// User returns the user of the event, or nil if it carries no user.
+func (e *Event) User() *zendesk.User {
+	return e.user
+}
+
+// HandlerFunc handles an event. An error makes the handler respond with a server
+// error, so that Zendesk retries the delivery.
+type HandlerFunc func(ctx context.Context, e *Event) error
+
+// Handler is an http.Handler receiving Zendesk webhooks.
+type Handler struct {
+	// MaxAge is the maximum age of the signature timestamp, protecting against
+	// replayed requests. Zero disables the check.
+	MaxAge time.Duration
+	// Now returns the current time, used to check the signature timestamp.
+	Now func() time.Time
+	// MaxBodySize is the maximum size of the request bodies, which are read before
+	// their signature is verified. Larger requests are rejected.
+	MaxBodySize int64
+	// Logger receives the rejected requests and the failed events. It defaults to a
+	// logger writing to the standard error, like the standard logger of the log package.
+	Logger zendesk.Logger
+
+	secret   string
+	mu       sync.RWMutex
+	handlers map[string][]HandlerFunc
+}
+
+// defaultMaxBodySize is the default MaxBodySize of a Handler.
+const defaultMaxBodySize = 4 << 20
+
+// NewHandler creates a handler validating the requests with the signing secret of the webhook.
+func NewHandler(secret string) *Handler {
+	return &Handler{
+		MaxAge:      5 * time.Minute,
+		Now:         time.Now,
+		MaxBodySize: defaultMaxBodySize,
+		Logger:      log.New(os.Stderr, "", log.LstdFlags),
+		secret:      secret,
+		handlers:    make(map[string][]HandlerFunc),
+	}
+}
+
+// Handle registers fn for the events of the given type, or every event with AnyEvent.
+func (h *Handler) Handle(eventType string, fn HandlerFunc) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.handlers[eventType] = append(h.handlers[eventType], fn)
+}
+
+// HandleTicket registers fn for the events of the given type carrying a ticket.
+func (h *Handler) HandleTicket(eventType string, fn func(ctx context.Context, e *Event, t *zendesk.Ticket) error) {
+	h.Handle(eventType, func(ctx context.Context, e *Event) error {
+		if e.ticket == nil {
+			return nil
+		}
+		return fn(ctx, e, e.ticket)
+	})
+}
+
+// HandleUser registers fn for the events of the given type carrying a user.
+func (h *Handler) HandleUser(eventType string, fn func(ctx context.Context, e *Event, u *zendesk.User) error) {
+	h.Handle(eventType, func(ctx context.Context, e *Event) error {
+		if e.user == nil {
+			return nil
+		}
+		return fn(ctx, e, e.user)
+	})
+}
+
+// ServeHTTP validates, parses and dispatches a webhook request.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	if h.MaxBodySize > 0 {
+		r.Body = http.MaxBytesReader(w, r.Body, h.MaxBodySize)
+	}
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		h.Logger.Printf("[webhook][ServeHTTP] cannot read body: %s\n", err)
+		if h.MaxBodySize > 0 && int64(len(body)) >= h.MaxBodySize {
+			http.Error(w, "body too large", http.StatusRequestEntityTooLarge)
+			return
+		}
+		http.Error(w, "cannot read body", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.verify(r.Header, body); err != nil {
+		h.Logger.Printf("[webhook][ServeHTTP] rejected request: %s\n", err)
+		http.Error(w, "invalid signature", http.StatusUnauthorized)
+		return
+	}
+
+	event, err := Parse(body)
+	if err != nil {
+		h.Logger.Printf("[webhook][ServeHTTP] invalid payload: %s\n", err)
+		http.Error(w, "invalid payload", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.dispatch(r.Context(), event); err != nil {
+		h.Logger.Printf("[webhook][ServeHTTP] handling %q event %s failed: %s\n", event.Type, event.ID, err)
+		http.Error(w, "handler failed", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+func (h *Handler) verify(header http.Header, body []byte) error {
+	signature := header.Get(SignatureHeader)
+	timestamp := header.Get(SignatureTimestampHeader)
+	if signature == "" || timestamp == "" {
+		return errors.New("missing signature")
+	}
+	if !VerifySignature(h.secret, signature, timestamp, body) {
+		return errors.New("signature mismatch")
+	}
+
+	if h.MaxAge > 0 {
+		signed, err := time.Parse(time.RFC3339, timestamp)
+		if err != nil {
+			return fmt.Errorf("invalid timestamp %q", timestamp)
+		}
+		if age := h.Now().Sub(signed); age > h.MaxAge || age < -h.MaxAge {
+			return fmt.Errorf("timestamp %q out of range", timestamp)
+		}
+	}
+	return nil
+}
+
+func (h *Handler) dispatch(ctx context.Context, event *Event) error {
+	h.mu.RLock()
+	handlers := append([]HandlerFunc(nil), h.handlers[event.Type]...)
+	if event.Type != AnyEvent {
+		handlers = append(handlers, h.handlers[AnyEvent]...)
+	}
+	h.mu.RUnlock()
+
+	for _, handler := range handlers {
+		if err := handler(ctx, event); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Parse parses a webhook payload, decoding the ticket or user it carries.
+func Parse(body []byte) (*Event, error) {
+	event := &Event{Body: body}
+	if err := json.Unmarshal(body, event); err != nil {
+		return nil, err
+	}
+
+	switch {
+	case strings.HasPrefix(event.Type, "zen:event-type:ticket."):
+		event.ticket = new(zendesk.Ticket)
+		if err := decodeDetail(event.Detail, event.ticket); err != nil {
+			return nil, fmt.Errorf("decoding ticket: %w", err)
+		}
+	case strings.HasPrefix(event.Type, "zen:event-type:user."):
+		event.user = new(zendesk.User)
+		if err := decodeDetail(event.Detail, event.user); err != nil {
+			return nil, fmt.Errorf("decoding user: %w", err)
+		}
+	case event.Type == "":
+		// Trigger and automation payloads are defined by the account, usually
+		// with the placeholders of a ticket or user under the matching key.
+		payload := new(zendesk.APIPayload)
+		if err := decodeDetail(body, payload); err != nil {
+			return nil, err
+		}
+		event.ticket = payload.Ticket
+		event.user = payload.User
+	}
+
+	return event, nil
+}
+
+// decodeDetail decodes a payload into out. Zendesk events send IDs as strings,
+// so the string values of ID fields are turned into numbers first.
+func decodeDetail(data []byte, out interface{}) error {
+	if len(data) == 0 {
+		return nil
+	}
+
+	var raw interface{}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	normalized, err := json.Marshal(normalizeIDs(raw, ""))
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(normalized, out)
+}
+
+func normalizeIDs(value interface{}, key string) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for k, item := range v {
+			v[k] = normalizeIDs(item, k)
+		}
+	case []interface{}:
+		for i, item := range v {
+			v[i] = normalizeIDs(item, key)
+		}
+	case string:
+		if key == "id" || (key != "external_id" && (strings.HasSuffix(key, "_id") || strings.HasSuffix(key, "_ids"))) {
+			if n, err := strconv.ParseInt(v, 10, 64); err == nil {
+				return n
+			}
+		}
+	}
+	return value
+}
@@ -0,0 +1,114 @@
+package zendesk
+
+// AccountSettings holds the settings of the account, by group. The settings are pointers,
+// nil when unknown, so that UpdateAccountSettings only changes the settings which are set:
+//
+//	client.UpdateAccountSettings(&AccountSettings{
+//		Tickets: &TicketSettings{Collaboration: Bool(false)},
+//	})
+//
+// Zendesk Core API docs: https://developer.zendesk.com/api-reference/ticketing/account-configuration/account_settings/
+type AccountSettings struct {
+	ActiveFeatures *ActiveFeatureSettings `json:"active_features,omitempty"`
+	Agents         *AgentSettings         `json:"agents,omitempty"`
+	Tickets        *TicketSettings        `json:"tickets,omitempty"`
+	Users          *UserSettings          `json:"user,omitempty"`
+	Localization   *LocalizationSettings  `json:"localization,omitempty"`
+}
+
+// ActiveFeatureSettings tells which features are enabled for the account.
+type ActiveFeatureSettings struct {
+	AllowCCs                  *bool `json:"allow_ccs,omitempty"`
+	BusinessHours             *bool `json:"business_hours,omitempty"`
+	CustomerSatisfaction      *bool `json:"customer_satisfaction,omitempty"`
+	Explore                   *bool `json:"explore,omitempty"`
+	LightAgents               *bool `json:"light_agents,omitempty"`
+	Markdown                  *bool `json:"markdown,omitempty"`
+	OnHoldStatus              *bool `json:"on_hold_status,omitempty"`
+	OrganizationAccessEnabled *bool `json:"organization_access_enabled,omitempty"`
+	Sandbox                   *bool `json:"sandbox,omitempty"`
+	TicketForms               *bool `json:"ticket_forms,omitempty"`
+	TicketTagging             *bool `json:"ticket_tagging,omitempty"`
+	UserTagging               *bool `json:"user_tagging,omitempty"`
+	OrganizationTagging       *bool `json:"organization_tagging,omitempty"`
+	GoogleLogin               *bool `json:"google_login,omitempty"`
+	FacebookLogin             *bool `json:"facebook_login,omitempty"`
+	TwitterLogin              *bool `json:"twitter_login,omitempty"`
+}
+
+// AgentSettings holds the settings of the agent workspace.
+type AgentSettings struct {
+	AgentWorkspace *bool `json:"agent_workspace,omitempty"`
+	FocusMode      *bool `json:"focus_mode,omitempty"`
+}
+
+// TicketSettings holds the settings of the tickets.
+type TicketSettings struct {
+	AcceptedNewCollaboration         *bool  `json:"accepted_new_collaboration,omitempty"`
+	AgentCanChangeRequester          *bool  `json:"agent_can_change_requester,omitempty"`
+	AgentCollision                   *bool  `json:"agent_collision,omitempty"`
+	AllowGroupReset                  *bool  `json:"allow_group_reset,omitempty"`
+	AssignTicketsUponSolve           *bool  `json:"assign_tickets_upon_solve,omitempty"`
+	Collaboration                    *bool  `json:"collaboration,omitempty"`
+	CommentsPublicByDefault          *bool  `json:"comments_public_by_default,omitempty"`
+	CustomStatusesEnabled            *bool  `json:"custom_statuses_enabled,omitempty"`
+	EmailAttachments                 *bool  `json:"email_attachments,omitempty"`
+	EmojiAutocompletion              *bool  `json:"emoji_autocompletion,omitempty"`
+	FollowerAndEmailCCCollaborations *bool  `json:"follower_and_email_cc_collaborations,omitempty"`
+	IsFirstCommentPrivateEnabled     *bool  `json:"is_first_comment_private_enabled,omitempty"`
+	LightAgentEmailCCsAllowed        *bool  `json:"light_agent_email_ccs_allowed,omitempty"`
+	ListNewestCommentsFirst          *bool  `json:"list_newest_comments_first,omitempty"`
+	MarkdownTicketComments           *bool  `json:"markdown_ticket_comments,omitempty"`
+	PrivateAttachments               *bool  `json:"private_attachments,omitempty"`
+	RichTextComments                 *bool  `json:"rich_text_comments,omitempty"`
+	StatusHold                       *bool  `json:"status_hold,omitempty"`
+	Tagging                          *bool  `json:"tagging,omitempty"`
+	MaximumPersonalViewsToList       *int64 `json:"maximum_personal_views_to_list,omitempty"`
+}
+
+// UserSettings holds the settings of the users.
+type UserSettings struct {
+	AgentCreatedWelcomeEmails *bool `json:"agent_created_welcome_emails,omitempty"`
+	HaveGravatarsEnabled      *bool `json:"have_gravatars_enabled,omitempty"`
+	LanguageSelection         *bool `json:"language_selection,omitempty"`
+	MultipleOrganizations     *bool `json:"multiple_organizations,omitempty"`
+	Tagging                   *bool `json:"tagging,omitempty"`
+	TimeZoneSelection         *bool `json:"time_zone_selection,omitempty"`
+}
+
+// LocalizationSettings lists the locales enabled for the account.
+type LocalizationSettings struct {
+	LocaleIDs []int64 `json:"locale_ids,omitempty"`
+}
+
+// CCsEnabled reports whether CCs can be added to tickets, which needs the CC feature with
+// the ticket collaboration setting.
+func (s *AccountSettings) CCsEnabled() bool {
+	if s.ActiveFeatures == nil || s.ActiveFeatures.AllowCCs == nil || !*s.ActiveFeatures.AllowCCs {
+		return false
+	}
+	return s.Tickets == nil || s.Tickets.Collaboration == nil || *s.Tickets.Collaboration
+}
+
+type accountSettingsPayload struct {
+	Settings *AccountSettings `json:"settings"`
+}
+
+// ShowAccountSettings fetches the settings of the account.
+//
+// Zendesk Core API docs: https://developer.zendesk.com/api-reference/ticketing/account-configuration/account_settings/#show-settings
+func (c *client) ShowAccountSettings() (*AccountSettings, error) {
+	out := new(accountSettingsPayload)
+	err := c.get("/api/v2/account/settings.json", out)
+	return out.Settings, err
+}
+
+// UpdateAccountSettings changes the settings which are set, and returns all the settings.
+//
+// Zendesk Core API docs: https://developer.zendesk.com/api-reference/ticketing/account-configuration/account_settings/#update-account-settings
+func (c *client) UpdateAccountSettings(settings *AccountSettings) (*AccountSettings, error) {
+	in := &accountSettingsPayload{Settings: settings}
+	out := new(accountSettingsPayload)
+	err := c.put("/api/v2/account/settings.json", in, out)
+	return out.Settings, err
+}
@@ -0,0 +1,98 @@
+package zendesk
+
+import (
+	"fmt"
+	"net/url"
+	"time"
+)
+
+// Brand represents a Zendesk brand.
+//
+// Zendesk Core API docs: https://developer.zendesk.com/api-reference/ticketing/account-configuration/brands/
+type Brand struct {
+	ID                int64       `json:"id,omitempty"`
+	URL               string      `json:"url,omitempty"`
+	Name              string      `json:"name,omitempty"`
+	BrandURL          string      `json:"brand_url,omitempty"`
+	Subdomain         string      `json:"subdomain,omitempty"`
+	HostMapping       string      `json:"host_mapping,omitempty"`
+	HasHelpCenter     bool        `json:"has_help_center,omitempty"`
+	HelpCenterState   string      `json:"help_center_state,omitempty"`
+	Active            bool        `json:"active,omitempty"`
+	Default           bool        `json:"default,omitempty"`
+	IsDeleted         bool        `json:"is_deleted,omitempty"`
+	Logo              *Attachment `json:"logo,omitempty"`
+	TicketFormIDs     []int64     `json:"ticket_form_ids,omitempty"`
+	SignatureTemplate string      `json:"signature_template,omitempty"`
+	CreatedAt         *time.Time  `json:"created_at,omitempty"`
+	UpdatedAt         *time.Time  `json:"updated_at,omitempty"`
+}
+
+// HostMappingCheck is the result of a host mapping validation.
+type HostMappingCheck struct {
+	IsValid        bool     `json:"is_valid"`
+	Reason         string   `json:"reason,omitempty"`
+	CNAME          string   `json:"cname,omitempty"`
+	ExpectedCNAMEs []string `json:"expected_cnames,omitempty"`
+}
+
+// ListBrands lists the brands of the account.
+//
+// Zendesk Core API docs: https://developer.zendesk.com/api-reference/ticketing/account-configuration/brands/#list-brands
+func (c *client) ListBrands() ([]Brand, error) {
+	out := new(APIPayload)
+	err := c.get("/api/v2/brands.json", out)
+	return out.Brands, err
+}
+
+// ShowBrand fetches a brand by its ID.
+//
+// Zendesk Core API docs: https://developer.zendesk.com/api-reference/ticketing/account-configuration/brands/#show-a-brand
+func (c *client) ShowBrand(id int64) (*Brand, error) {
+	out := new(APIPayload)
+	err := c.get(fmt.Sprintf("/api/v2/brands/%d.json", id), out)
+	return out.Brand, err
+}
+
+// CreateBrand creates a brand.
+//
+// Zendesk Core API docs: https://developer.zendesk.com/api-reference/ticketing/account-configuration/brands/#create-brand
+func (c *client) CreateBrand(brand *Brand) (*Brand, error) {
+	defer c.InvalidateBrands()
+	in := &APIPayload{Brand: brand}
+	out := new(APIPayload)
+	err := c.post("/api/v2/brands.json", in, out)
+	return out.Brand, err
+}
+
+// UpdateBrand updates a brand.
+//
+// Zendesk Core API docs: https://developer.zendesk.com/api-reference/ticketing/account-configuration/brands/#update-a-brand
+func (c *client) UpdateBrand(id int64, brand *Brand) (*Brand, error) {
+	defer c.InvalidateBrands()
+	in := &APIPayload{Brand: brand}
+	out := new(APIPayload)
+	err := c.put(fmt.Sprintf("/api/v2/brands/%d.json", id), in, out)
+	return out.Brand, err
+}
+
+// DeleteBrand deletes a brand.
+//
+// Zendesk Core API docs: https://developer.zendesk.com/api-reference/ticketing/account-configuration/brands/#delete-a-brand
+func (c *client) DeleteBrand(id int64) error {
+	defer c.InvalidateBrands()
+	return c.delete(fmt.Sprintf("/api/v2/brands/%d.json", id), nil)
+}
+
+// CheckHostMapping checks that the host mapping of a brand is a CNAME of its subdomain.
+//
+// Zendesk Core API docs: https://developer.zendesk.com/api-reference/ticketing/account-configuration/brands/#check-host-mapping-validity
+func (c *client) CheckHostMapping(hostMapping, subdomain string) (*HostMappingCheck, error) {
+	params := url.Values{}
+	params.Set("host_mapping", hostMapping)
+	params.Set("subdomain", subdomain)
+
+	out := new(HostMappingCheck)
+	err := c.get("/api/v2/brands/check_host_mapping.json?"+params.Encode(), out)
+	return out, err
+}
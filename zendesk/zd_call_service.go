@@ -1,13 +1,12 @@
 package zendesk
 
 import (
-	"bytes"
+	"encoding/json"
+	"errors"
 	"fmt"
-	"log"
-	"net/url"
-	"strconv"
-	"strings"
 	"time"
+
+	"github.com/google/go-querystring/query"
 )
 
 type Call struct {
@@ -20,7 +19,7 @@ type Call struct {
 	CallbackSource               interface{} `json:"callback_source"`
 	CompletionStatus             string      `json:"completion_status"`
 	ConsultationTime             int         `json:"consultation_time"`
-	CreatedAt                    time.Time   `json:"created_at"`
+	CreatedAt                    *time.Time  `json:"created_at"`
 	CustomerID                   int         `json:"customer_id"`
 	CustomerRequestedVoicemail   bool        `json:"customer_requested_voicemail"`
 	DefaultGroup                 bool        `json:"default_group"`
@@ -49,7 +48,7 @@ type Call struct {
 	TalkTime                     int         `json:"talk_time"`
 	TicketID                     int         `json:"ticket_id"`
 	TimeToAnswer                 int         `json:"time_to_answer"`
-	UpdatedAt                    time.Time   `json:"updated_at"`
+	UpdatedAt                    *time.Time  `json:"updated_at"`
 	Voicemail                    bool        `json:"voicemail"`
 	WaitTime                     int         `json:"wait_time"`
 	WrapUpTime                   int         `json:"wrap_up_time"`
@@ -67,7 +66,7 @@ type CallLeg struct {
 	ConsultationFrom interface{} `json:"consultation_from"`
 	ConsultationTime interface{} `json:"consultation_time"`
 	ConsultationTo   interface{} `json:"consultation_to"`
-	CreatedAt        time.Time   `json:"created_at"`
+	CreatedAt        *time.Time  `json:"created_at"`
 	Duration         int         `json:"duration"`
 	ForwardedTo      interface{} `json:"forwarded_to"`
 	HoldTime         int         `json:"hold_time"`
@@ -78,80 +77,213 @@ type CallLeg struct {
 	TransferredFrom  interface{} `json:"transferred_from"`
 	TransferredTo    interface{} `json:"transferred_to"`
 	Type             string      `json:"type"`
-	UpdatedAt        time.Time   `json:"updated_at"`
+	UpdatedAt        *time.Time  `json:"updated_at"`
 	UserID           int         `json:"user_id"`
 	WrapUpTime       interface{} `json:"wrap_up_time"`
 }
 
-//https://developer.zendesk.com/api-reference/voice/talk-api/incremental_exports/#incremental-call-legs-export
+// UnmarshalJSON decodes a call, accepting null timestamps and the non-UTC timestamp
+// formats of the Talk API.
+func (c *Call) UnmarshalJSON(data []byte) error {
+	type call Call
+	aux := struct {
+		*call
+		CreatedAt *timestamp `json:"created_at"`
+		UpdatedAt *timestamp `json:"updated_at"`
+	}{call: (*call)(c)}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	c.CreatedAt = aux.CreatedAt.ptr()
+	c.UpdatedAt = aux.UpdatedAt.ptr()
+	return nil
+}
+
+// UnmarshalJSON decodes a call leg, accepting null timestamps and the non-UTC timestamp
+// formats of the Talk API.
+func (l *CallLeg) UnmarshalJSON(data []byte) error {
+	type callLeg CallLeg
+	aux := struct {
+		*callLeg
+		CreatedAt *timestamp `json:"created_at"`
+		UpdatedAt *timestamp `json:"updated_at"`
+	}{callLeg: (*callLeg)(l)}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	l.CreatedAt = aux.CreatedAt.ptr()
+	l.UpdatedAt = aux.UpdatedAt.ptr()
+	return nil
+}
+
+// https://developer.zendesk.com/api-reference/voice/talk-api/incremental_exports/#incremental-call-legs-export
 func (c *client) GetCallLegIncrementally(unixTime int64) ([]CallLeg, error) {
-	log.Printf("[zd_ticket_service][GetCallLegsIncrementally] Start GetCallLegsIncrementally")
-	callLegs, err := c.getCallLegsIncrementally(unixTime, nil)
-	log.Printf("[zd_ticket_service][GetCallLegsIncrementally] Number of CallLegs: %v", len(callLegs))
+	c.logger.Printf("[zd_ticket_service][GetCallLegsIncrementally] Start GetCallLegsIncrementally")
+	callLegs, err := c.getCallLegsIncrementally(unixTime)
+	c.logger.Printf("[zd_ticket_service][GetCallLegsIncrementally] Number of CallLegs: %v", len(callLegs))
 	return callLegs, err
 }
 
-func (c *client) getCallLegsIncrementally(unixTime int64, in interface{}) ([]CallLeg, error) {
-	log.Printf("[zd_ticket_service][getCallLegsIncrementally] Start getCallLegsIncrementally")
+func (c *client) getCallLegsIncrementally(unixTime int64) ([]CallLeg, error) {
 	result := make([]CallLeg, 0)
-	payload, err := marshall(in)
-	if err != nil {
-		return nil, err
-	}
+	endpoint := fmt.Sprintf("/api/v2/channels/voice/stats/incremental/legs?start_time=%d", unixTime)
 
 	// For Business level, content type must be application/json
 	// https://developer.zendesk.com/api-reference/ticketing/introduction/#400-range
-	headers := map[string]string{}
-	headers["Content-Type"] = "application/json"
+	headers := map[string]string{"Content-Type": "application/json"}
 
-	apiV2 := "/api/v2/channels/voice/stats/incremental/legs?start_time="
-	rel, err := url.Parse(apiV2)
+	err := c.forEachPageWithHeaders(endpoint, headers, func(page *APIPayload) error {
+		result = append(result, page.CallLegs...)
+		return nil
+	})
 	if err != nil {
-		return nil, err
+		result = getUniqCallLegs(result)
+		return result, partialResult(err, result)
+	}
+
+	c.logger.Printf("[zd_call_service][getCallLegsIncrementally] number of records pulled: %v\n", len(result))
+	return getUniqCallLegs(result), nil
+}
+
+// getUniqCallLegs is to remove the duplicate records due to pagination
+// more details can be found int the following link
+// https://developer.zendesk.com/api-reference/voice/talk-api/incremental_exports/#pagination
+
+func getUniqCallLegs(callLegs []CallLeg) []CallLeg {
+	var Empty struct{}
+	keys := make(map[string]struct{})
+	result := make([]CallLeg, 0)
+	for _, callLeg := range callLegs {
+		key := fmt.Sprintf("%v %v\n", callLeg.ID, callLeg.UpdatedAt)
+		if _, ok := keys[key]; ok {
+			continue
+		} else {
+			keys[key] = Empty
+			result = append(result, callLeg)
+		}
 	}
-	url := c.baseURL.ResolveReference(rel)
-	apiStartIndex := strings.Index(url.String(), apiV2)
-	endpoint := fmt.Sprintf("%s%v", apiV2, unixTime)
+	return result
+}
 
-	res, err := c.request("GET", endpoint, headers, bytes.NewReader(payload))
+// IncludeAgents sideloads the agents involved in the exported calls.
+const IncludeAgents = "agents"
+
+// IncrementalCallExportOptions specifies the optional parameters for the Talk incremental exports.
+type IncrementalCallExportOptions struct {
+	// StartTime is the unix time to start the export from. It is ignored when Cursor is set.
+	StartTime int64 `url:"start_time,omitempty"`
+	// Cursor resumes the export from the AfterCursor returned by a previous export.
+	Cursor string `url:"cursor,omitempty"`
+	// Include lists the records to sideload, such as IncludeAgents.
+	Include []string `url:"include,comma,omitempty"`
+}
+
+// CallLegExport is the result of a cursor based call legs export.
+type CallLegExport struct {
+	CallLegs []CallLeg
+	// Agents holds the sideloaded agents when IncludeAgents was requested.
+	Agents []User
+	// AfterCursor can be passed as Cursor to resume the export later on.
+	AfterCursor string
+}
+
+// GetCallLegsIncrementallyWithOptions pulls the call legs modified since the start time or cursor,
+// following the cursors until the end of the stream. When a page after the first one fails,
+// the call legs pulled so far are returned along with a *PartialResultError, and the
+// AfterCursor of the export resumes it from the failed page.
+//
+// Zendesk Talk API docs: https://developer.zendesk.com/api-reference/voice/talk-api/incremental_exports/#incremental-call-legs-export
+func (c *client) GetCallLegsIncrementallyWithOptions(opts *IncrementalCallExportOptions) (*CallLegExport, error) {
+	if opts != nil && opts.Cursor != "" {
+		o := *opts
+		o.StartTime = 0
+		opts = &o
+	}
+
+	params, err := query.Values(opts)
 	if err != nil {
 		return nil, err
 	}
-	defer res.Body.Close()
-
-	dataPerPage := new(APIPayload)
-	currentPage := "emptypage"
-	var totalWaitTime int64
-	log.Printf("[zd_ticket_service][getCallLegsIncrementally] Start for loop in getCallLegsIncrementally")
-	for currentPage != dataPerPage.NextPage {
-		// if too many requests(res.StatusCode == 429), delay sending request
-		if res.StatusCode == 429 {
-			after, err := strconv.ParseInt(res.Header.Get("Retry-After"), 10, 64)
-			log.Printf("[zd_ticket_service][getCallLegsIncrementally] too many requests. Wait for %v seconds\n", after)
-			totalWaitTime += after
-			if err != nil {
-				return nil, err
-			}
-			time.Sleep(time.Duration(after) * time.Second)
-			dataPerPage.NextPage = currentPage
-		} else {
-			err = unmarshall(res, dataPerPage)
-			if err != nil {
-				return nil, err
-			}
-			result = append(result, dataPerPage.CallLegs...)
-			if currentPage == dataPerPage.NextPage {
-				break
+
+	result := &CallLegExport{}
+	agents := make(map[int64]bool)
+	endpoint := "/api/v2/channels/voice/stats/incremental/legs.json?" + params.Encode()
+
+	// For Business level, content type must be application/json
+	headers := map[string]string{"Content-Type": "application/json"}
+
+	err = c.forEachCursorPage(endpoint, headers, func(out *APIPayload) error {
+		result.CallLegs = append(result.CallLegs, out.CallLegs...)
+		for _, agent := range out.Agents {
+			if !agents[agent.ID] {
+				agents[agent.ID] = true
+				result.Agents = append(result.Agents, agent)
 			}
-			currentPage = dataPerPage.NextPage
 		}
+		if out.AfterCursor != "" {
+			result.AfterCursor = out.AfterCursor
+		}
+		return nil
+	})
+	result.CallLegs = getUniqCallLegs(result.CallLegs)
+	var partial *PartialResultError
+	if errors.As(err, &partial) {
+		partial.Cursor = result.AfterCursor
+		return result, partialResult(err, result.CallLegs)
+	}
+	if err != nil {
+		return nil, err
+	}
 
-		res, _ = c.request("GET", dataPerPage.NextPage[apiStartIndex:], headers, bytes.NewReader(payload))
+	c.logger.Printf("[zd_call_service][GetCallLegsIncrementallyWithOptions] number of records pulled: %v\n", len(result.CallLegs))
+	return result, nil
+}
+
+// GetCallsIncrementally pulls the calls modified since the given unix time, following the
+// cursors until the end of the stream.
+//
+// Zendesk Talk API docs: https://developer.zendesk.com/api-reference/voice/talk-api/incremental_exports/#incremental-calls-export
+func (c *client) GetCallsIncrementally(unixTime int64) ([]Call, error) {
+	result := make([]Call, 0)
+	endpoint := fmt.Sprintf("/api/v2/channels/voice/stats/incremental/calls.json?start_time=%d", unixTime)
+
+	// For Business level, content type must be application/json
+	headers := map[string]string{"Content-Type": "application/json"}
 
-		dataPerPage = new(APIPayload)
+	cursor := ""
+	err := c.forEachCursorPage(endpoint, headers, func(out *APIPayload) error {
+		result = append(result, out.Calls...)
+		if out.AfterCursor != "" {
+			cursor = out.AfterCursor
+		}
+		return nil
+	})
+	result = getUniqCalls(result)
+	var partial *PartialResultError
+	if errors.As(err, &partial) {
+		partial.Cursor = cursor
+		return result, partialResult(err, result)
+	}
+	if err != nil {
+		return nil, err
 	}
-	log.Printf("[zd_ticket_service][getTicketsIncrementally] number of records pulled: %v\n", len(result))
-	log.Printf("[zd_ticket_service][getTicketsIncrementally] total waiting time due to rate limit: %v\n", totalWaitTime)
 
-	return result, err
+	c.logger.Printf("[zd_call_service][GetCallsIncrementally] number of records pulled: %v\n", len(result))
+	return result, nil
+}
+
+// getUniqCalls removes the calls exported twice due to pagination, as getUniqCallLegs does
+// for call legs.
+func getUniqCalls(calls []Call) []Call {
+	keys := make(map[string]bool)
+	result := make([]Call, 0, len(calls))
+	for _, call := range calls {
+		key := fmt.Sprintf("%v %v", call.ID, call.UpdatedAt)
+		if keys[key] {
+			continue
+		}
+		keys[key] = true
+		result = append(result, call)
+	}
+	return result
 }
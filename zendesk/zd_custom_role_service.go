@@ -0,0 +1,118 @@
+package zendesk
+
+import (
+	"fmt"
+	"time"
+)
+
+// CustomRole represents an agent role of an Enterprise account.
+//
+// Zendesk Core API docs: https://developer.zendesk.com/api-reference/ticketing/account-configuration/custom_roles/
+type CustomRole struct {
+	ID              int64                    `json:"id,omitempty"`
+	Name            string                   `json:"name,omitempty"`
+	Description     string                   `json:"description,omitempty"`
+	RoleType        int64                    `json:"role_type,omitempty"`
+	TeamMemberCount int64                    `json:"team_member_count,omitempty"`
+	Configuration   *CustomRoleConfiguration `json:"configuration,omitempty"`
+	CreatedAt       *time.Time               `json:"created_at,omitempty"`
+	UpdatedAt       *time.Time               `json:"updated_at,omitempty"`
+}
+
+// CustomRoleConfiguration holds the permissions of a custom role. The booleans are always
+// sent, so a configuration given to UpdateCustomRole replaces the whole one of the role:
+// start from the configuration returned by ShowCustomRole to change some permissions.
+type CustomRoleConfiguration struct {
+	AssignTicketsToAnyGroup      bool   `json:"assign_tickets_to_any_group"`
+	ChatAccess                   bool   `json:"chat_access"`
+	EndUserListAccess            string `json:"end_user_list_access,omitempty"`
+	EndUserProfileAccess         string `json:"end_user_profile_access,omitempty"`
+	ExploreAccess                string `json:"explore_access,omitempty"`
+	ForumAccess                  string `json:"forum_access,omitempty"`
+	ForumAccessRestrictedContent bool   `json:"forum_access_restricted_content"`
+	GroupAccess                  bool   `json:"group_access"`
+	LightAgent                   bool   `json:"light_agent"`
+	MacroAccess                  string `json:"macro_access,omitempty"`
+	ManageBusinessRules          bool   `json:"manage_business_rules"`
+	ManageDynamicContent         bool   `json:"manage_dynamic_content"`
+	ManageExtensionsAndChannels  bool   `json:"manage_extensions_and_channels"`
+	ManageFacebook               bool   `json:"manage_facebook"`
+	ModerateForums               bool   `json:"moderate_forums"`
+	OrganizationEditing          bool   `json:"organization_editing"`
+	OrganizationNotesEditing     bool   `json:"organization_notes_editing"`
+	ReportAccess                 string `json:"report_access,omitempty"`
+	SideConversationCreate       bool   `json:"side_conversation_create"`
+	TicketAccess                 string `json:"ticket_access,omitempty"`
+	TicketCommentAccess          string `json:"ticket_comment_access,omitempty"`
+	TicketDeletion               bool   `json:"ticket_deletion"`
+	TicketEditing                bool   `json:"ticket_editing"`
+	TicketMerge                  bool   `json:"ticket_merge"`
+	TicketTagEditing             bool   `json:"ticket_tag_editing"`
+	TwitterSearchAccess          bool   `json:"twitter_search_access"`
+	UserViewAccess               string `json:"user_view_access,omitempty"`
+	ViewAccess                   string `json:"view_access,omitempty"`
+	ViewDeletedTickets           bool   `json:"view_deleted_tickets"`
+	VoiceAccess                  bool   `json:"voice_access"`
+	VoiceDashboardAccess         bool   `json:"voice_dashboard_access"`
+}
+
+// Values of the access levels of a custom role configuration. Not every level applies to
+// every permission.
+const (
+	RoleAccessNone               = "none"
+	RoleAccessReadonly           = "readonly"
+	RoleAccessFull               = "full"
+	RoleAccessAll                = "all"
+	RoleAccessWithinGroups       = "within-groups"
+	RoleAccessWithinOrganization = "within-organization"
+	RoleAccessAssignedOnly       = "assigned-only"
+	RoleAccessManageGroup        = "manage-group"
+	RoleAccessManagePersonal     = "manage-personal"
+	RoleAccessPublic             = "public"
+	RoleAccessEdit               = "edit"
+)
+
+// ListCustomRoles lists the custom roles of the account.
+//
+// Zendesk Core API docs: https://developer.zendesk.com/api-reference/ticketing/account-configuration/custom_roles/#list-custom-roles
+func (c *client) ListCustomRoles() ([]CustomRole, error) {
+	out := new(APIPayload)
+	err := c.get("/api/v2/custom_roles.json", out)
+	return out.CustomRoles, err
+}
+
+// ShowCustomRole fetches a custom role by its ID.
+//
+// Zendesk Core API docs: https://developer.zendesk.com/api-reference/ticketing/account-configuration/custom_roles/#show-custom-role
+func (c *client) ShowCustomRole(id int64) (*CustomRole, error) {
+	out := new(APIPayload)
+	err := c.get(fmt.Sprintf("/api/v2/custom_roles/%d.json", id), out)
+	return out.CustomRole, err
+}
+
+// CreateCustomRole creates a custom role.
+//
+// Zendesk Core API docs: https://developer.zendesk.com/api-reference/ticketing/account-configuration/custom_roles/#create-custom-role
+func (c *client) CreateCustomRole(role *CustomRole) (*CustomRole, error) {
+	in := &APIPayload{CustomRole: role}
+	out := new(APIPayload)
+	err := c.post("/api/v2/custom_roles.json", in, out)
+	return out.CustomRole, err
+}
+
+// UpdateCustomRole updates a custom role.
+//
+// Zendesk Core API docs: https://developer.zendesk.com/api-reference/ticketing/account-configuration/custom_roles/#update-custom-role
+func (c *client) UpdateCustomRole(id int64, role *CustomRole) (*CustomRole, error) {
+	in := &APIPayload{CustomRole: role}
+	out := new(APIPayload)
+	err := c.put(fmt.Sprintf("/api/v2/custom_roles/%d.json", id), in, out)
+	return out.CustomRole, err
+}
+
+// DeleteCustomRole deletes a custom role, which must not be assigned to any agent.
+//
+// Zendesk Core API docs: https://developer.zendesk.com/api-reference/ticketing/account-configuration/custom_roles/#delete-custom-role
+func (c *client) DeleteCustomRole(id int64) error {
+	return c.delete(fmt.Sprintf("/api/v2/custom_roles/%d.json", id), nil)
+}
@@ -0,0 +1,97 @@
+package zendesk
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/go-querystring/query"
+)
+
+// CustomStatus is a ticket status defined by the account, within one of the status
+// categories. Tickets set to it have its category as status.
+//
+// Zendesk Core API docs: https://developer.zendesk.com/api-reference/ticketing/tickets/custom_ticket_statuses/
+type CustomStatus struct {
+	ID                    int64      `json:"id,omitempty"`
+	URL                   string     `json:"url,omitempty"`
+	StatusCategory        string     `json:"status_category,omitempty"`
+	AgentLabel            string     `json:"agent_label,omitempty"`
+	RawAgentLabel         string     `json:"raw_agent_label,omitempty"`
+	EndUserLabel          string     `json:"end_user_label,omitempty"`
+	RawEndUserLabel       string     `json:"raw_end_user_label,omitempty"`
+	Description           string     `json:"description,omitempty"`
+	RawDescription        string     `json:"raw_description,omitempty"`
+	EndUserDescription    string     `json:"end_user_description,omitempty"`
+	RawEndUserDescription string     `json:"raw_end_user_description,omitempty"`
+	Active                *bool      `json:"active,omitempty"`
+	Default               bool       `json:"default,omitempty"`
+	CreatedAt             *time.Time `json:"created_at,omitempty"`
+	UpdatedAt             *time.Time `json:"updated_at,omitempty"`
+}
+
+// Status categories of the custom statuses.
+const (
+	StatusCategoryNew     = "new"
+	StatusCategoryOpen    = "open"
+	StatusCategoryPending = "pending"
+	StatusCategoryHold    = "hold"
+	StatusCategorySolved  = "solved"
+)
+
+// CustomStatusListOptions filters the custom statuses listed by ListCustomTicketStatuses.
+type CustomStatusListOptions struct {
+	// StatusCategories restricts the list to the statuses of these categories.
+	StatusCategories []string `url:"status_categories,comma,omitempty"`
+	// Active and Default, when set, restrict the list to the statuses that are, or are
+	// not, active or the default of their category.
+	Active  *bool `url:"active,omitempty"`
+	Default *bool `url:"default,omitempty"`
+}
+
+// ListCustomTicketStatuses lists the custom ticket statuses of the account, filtered by
+// the options, which may be nil.
+//
+// Zendesk Core API docs: https://developer.zendesk.com/api-reference/ticketing/tickets/custom_ticket_statuses/#list-custom-ticket-statuses
+func (c *client) ListCustomTicketStatuses(opts *CustomStatusListOptions) ([]CustomStatus, error) {
+	params, err := query.Values(opts)
+	if err != nil {
+		return nil, err
+	}
+	endpoint := "/api/v2/custom_statuses.json"
+	if len(params) > 0 {
+		endpoint += "?" + params.Encode()
+	}
+
+	out := new(APIPayload)
+	err = c.get(endpoint, out)
+	return out.CustomStatuses, err
+}
+
+// ShowCustomStatus fetches a custom ticket status by its ID.
+//
+// Zendesk Core API docs: https://developer.zendesk.com/api-reference/ticketing/tickets/custom_ticket_statuses/#show-custom-ticket-status
+func (c *client) ShowCustomStatus(id int64) (*CustomStatus, error) {
+	out := new(APIPayload)
+	err := c.get(fmt.Sprintf("/api/v2/custom_statuses/%d.json", id), out)
+	return out.CustomStatus, err
+}
+
+// CreateCustomStatus creates a custom ticket status. StatusCategory and AgentLabel are required.
+//
+// Zendesk Core API docs: https://developer.zendesk.com/api-reference/ticketing/tickets/custom_ticket_statuses/#create-custom-ticket-status
+func (c *client) CreateCustomStatus(status *CustomStatus) (*CustomStatus, error) {
+	in := &APIPayload{CustomStatus: status}
+	out := new(APIPayload)
+	err := c.post("/api/v2/custom_statuses.json", in, out)
+	return out.CustomStatus, err
+}
+
+// UpdateCustomStatus updates a custom ticket status. Its status category cannot change.
+//
+// Zendesk Core API docs: https://developer.zendesk.com/api-reference/ticketing/tickets/custom_ticket_statuses/#update-custom-ticket-status
+func (c *client) UpdateCustomStatus(id int64, status *CustomStatus) (*CustomStatus, error) {
+	in := &APIPayload{CustomStatus: status}
+	out := new(APIPayload)
+	err := c.put(fmt.Sprintf("/api/v2/custom_statuses/%d.json", id), in, out)
+	return out.CustomStatus, err
+}
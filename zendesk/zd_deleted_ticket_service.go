@@ -0,0 +1,84 @@
+package zendesk
+
+import (
+	"fmt"
+	"time"
+)
+
+// DeletedTicket is a soft deleted ticket. It can be restored or permanently deleted
+// until Zendesk purges it, 30 days after its deletion.
+//
+// Zendesk Core API docs: https://developer.zendesk.com/api-reference/ticketing/tickets/tickets/#list-deleted-tickets
+type DeletedTicket struct {
+	ID            int64      `json:"id"`
+	Subject       string     `json:"subject,omitempty"`
+	Description   string     `json:"description,omitempty"`
+	Actor         *Actor     `json:"actor,omitempty"`
+	PreviousState string     `json:"previous_state,omitempty"`
+	DeletedAt     *time.Time `json:"deleted_at,omitempty"`
+}
+
+// Actor is the user who made a change.
+type Actor struct {
+	ID   int64  `json:"id"`
+	Name string `json:"name,omitempty"`
+}
+
+// ListDeletedTickets lists the soft deleted tickets, following the pages until the last one.
+//
+// Zendesk Core API docs: https://developer.zendesk.com/api-reference/ticketing/tickets/tickets/#list-deleted-tickets
+func (c *client) ListDeletedTickets() ([]DeletedTicket, error) {
+	result := make([]DeletedTicket, 0)
+	endpoint := "/api/v2/deleted_tickets.json"
+	err := c.forEachPage(endpoint, func(page *APIPayload) error {
+		result = append(result, page.DeletedTickets...)
+		return nil
+	})
+	if err != nil {
+		return result, partialResult(err, result)
+	}
+
+	c.logger.Printf("[zd_deleted_ticket_service][ListDeletedTickets] number of records pulled: %v\n", len(result))
+	return result, nil
+}
+
+// RestoreTicket restores a soft deleted ticket.
+//
+// Zendesk Core API docs: https://developer.zendesk.com/api-reference/ticketing/tickets/tickets/#restore-a-previously-deleted-ticket
+func (c *client) RestoreTicket(id int64) error {
+	return c.put(fmt.Sprintf("/api/v2/deleted_tickets/%d/restore.json", id), nil, nil)
+}
+
+// RestoreManyTickets restores up to 100 soft deleted tickets.
+//
+// Zendesk Core API docs: https://developer.zendesk.com/api-reference/ticketing/tickets/tickets/#restore-previously-deleted-tickets-in-bulk
+func (c *client) RestoreManyTickets(ids []int64) error {
+	if len(ids) > manyTicketsLimit {
+		return fmt.Errorf("zendesk: at most %d tickets can be restored at once, got %d", manyTicketsLimit, len(ids))
+	}
+	return c.put("/api/v2/deleted_tickets/restore_many.json?ids="+joinIDs(ids), nil, nil)
+}
+
+// PurgeTicket permanently deletes a soft deleted ticket, along with its personal data.
+// The deletion runs as a background job whose status is returned.
+//
+// Zendesk Core API docs: https://developer.zendesk.com/api-reference/ticketing/tickets/tickets/#delete-ticket-permanently
+func (c *client) PurgeTicket(id int64) (*JobStatus, error) {
+	out := new(APIPayload)
+	err := c.delete(fmt.Sprintf("/api/v2/deleted_tickets/%d.json", id), out)
+	return out.JobStatus, err
+}
+
+// PurgeManyTickets permanently deletes up to 100 soft deleted tickets. The deletion runs
+// as a background job whose status is returned.
+//
+// Zendesk Core API docs: https://developer.zendesk.com/api-reference/ticketing/tickets/tickets/#delete-multiple-tickets-permanently
+func (c *client) PurgeManyTickets(ids []int64) (*JobStatus, error) {
+	if len(ids) > manyTicketsLimit {
+		return nil, fmt.Errorf("zendesk: at most %d tickets can be purged at once, got %d", manyTicketsLimit, len(ids))
+	}
+
+	out := new(APIPayload)
+	err := c.delete("/api/v2/deleted_tickets/destroy_many.json?ids="+joinIDs(ids), out)
+	return out.JobStatus, err
+}
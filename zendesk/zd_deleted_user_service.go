@@ -0,0 +1,42 @@
+package zendesk
+
+import (
+	"fmt"
+)
+
+// ListDeletedUsers lists the soft deleted users, following the pages until the last one.
+//
+// Zendesk Core API docs: https://developer.zendesk.com/api-reference/ticketing/users/users/#list-deleted-users
+func (c *client) ListDeletedUsers() ([]User, error) {
+	result := make([]User, 0)
+	endpoint := "/api/v2/deleted_users.json"
+	err := c.forEachPage(endpoint, func(page *APIPayload) error {
+		result = append(result, page.DeletedUsers...)
+		return nil
+	})
+	if err != nil {
+		return result, partialResult(err, result)
+	}
+
+	c.logger.Printf("[zd_deleted_user_service][ListDeletedUsers] number of records pulled: %v\n", len(result))
+	return result, nil
+}
+
+// ShowDeletedUser fetches a soft deleted user by its ID.
+//
+// Zendesk Core API docs: https://developer.zendesk.com/api-reference/ticketing/users/users/#show-deleted-user
+func (c *client) ShowDeletedUser(id int64) (*User, error) {
+	out := new(APIPayload)
+	err := c.get(fmt.Sprintf("/api/v2/deleted_users/%d.json", id), out)
+	return out.DeletedUser, err
+}
+
+// PermanentlyDeleteUser erases the personal data of a soft deleted user, as required by
+// data protection regulations. It cannot be undone.
+//
+// Zendesk Core API docs: https://developer.zendesk.com/api-reference/ticketing/users/users/#permanently-delete-user
+func (c *client) PermanentlyDeleteUser(id int64) (*User, error) {
+	out := new(APIPayload)
+	err := c.delete(fmt.Sprintf("/api/v2/deleted_users/%d.json", id), out)
+	return out.DeletedUser, err
+}
@@ -0,0 +1,149 @@
+package zendesk
+
+import (
+	"fmt"
+	"time"
+)
+
+// DynamicContentItem is a piece of text, referenced by its placeholder in macros, triggers
+// and automations, with a variant per locale.
+//
+// Zendesk Core API docs: https://developer.zendesk.com/api-reference/ticketing/ticket-management/dynamic_content/
+type DynamicContentItem struct {
+	ID              int64                   `json:"id,omitempty"`
+	URL             string                  `json:"url,omitempty"`
+	Name            string                  `json:"name,omitempty"`
+	Placeholder     string                  `json:"placeholder,omitempty"`
+	DefaultLocaleID int64                   `json:"default_locale_id,omitempty"`
+	Outdated        bool                    `json:"outdated,omitempty"`
+	Variants        []DynamicContentVariant `json:"variants,omitempty"`
+	CreatedAt       *time.Time              `json:"created_at,omitempty"`
+	UpdatedAt       *time.Time              `json:"updated_at,omitempty"`
+}
+
+// DynamicContentVariant is the text of a dynamic content item in one locale.
+//
+// Zendesk Core API docs: https://developer.zendesk.com/api-reference/ticketing/ticket-management/dynamic_content_item_variants/
+type DynamicContentVariant struct {
+	ID        int64      `json:"id,omitempty"`
+	URL       string     `json:"url,omitempty"`
+	Content   string     `json:"content,omitempty"`
+	LocaleID  int64      `json:"locale_id,omitempty"`
+	Active    bool       `json:"active,omitempty"`
+	Default   bool       `json:"default,omitempty"`
+	Outdated  bool       `json:"outdated,omitempty"`
+	CreatedAt *time.Time `json:"created_at,omitempty"`
+	UpdatedAt *time.Time `json:"updated_at,omitempty"`
+}
+
+type dynamicContentItemPayload struct {
+	Item *DynamicContentItem `json:"item"`
+}
+
+type dynamicContentVariantPayload struct {
+	Variant *DynamicContentVariant `json:"variant"`
+}
+
+// ListDynamicContentItems lists the dynamic content items with their variants, following
+// the pages until the last one.
+//
+// Zendesk Core API docs: https://developer.zendesk.com/api-reference/ticketing/ticket-management/dynamic_content/#list-items
+func (c *client) ListDynamicContentItems() ([]DynamicContentItem, error) {
+	result := make([]DynamicContentItem, 0)
+	endpoint := "/api/v2/dynamic_content/items.json"
+	type page struct {
+		Items    []DynamicContentItem `json:"items"`
+		NextPage string               `json:"next_page"`
+	}
+	err := c.forEachPageOf(endpoint, func() interface{} { return new(page) }, func(p interface{}) error {
+		result = append(result, p.(*page).Items...)
+		return nil
+	})
+	return result, partialResult(err, result)
+}
+
+// ShowDynamicContentItem fetches a dynamic content item by its ID.
+//
+// Zendesk Core API docs: https://developer.zendesk.com/api-reference/ticketing/ticket-management/dynamic_content/#show-item
+func (c *client) ShowDynamicContentItem(id int64) (*DynamicContentItem, error) {
+	out := new(dynamicContentItemPayload)
+	err := c.get(fmt.Sprintf("/api/v2/dynamic_content/items/%d.json", id), out)
+	return out.Item, err
+}
+
+// CreateDynamicContentItem creates a dynamic content item. It needs a name, a default
+// locale and the variant of that locale.
+//
+// Zendesk Core API docs: https://developer.zendesk.com/api-reference/ticketing/ticket-management/dynamic_content/#create-item
+func (c *client) CreateDynamicContentItem(item *DynamicContentItem) (*DynamicContentItem, error) {
+	in := &dynamicContentItemPayload{Item: item}
+	out := new(dynamicContentItemPayload)
+	err := c.post("/api/v2/dynamic_content/items.json", in, out)
+	return out.Item, err
+}
+
+// UpdateDynamicContentItem updates the name or the default locale of a dynamic content
+// item. The variants are updated with UpdateDynamicContentVariant.
+//
+// Zendesk Core API docs: https://developer.zendesk.com/api-reference/ticketing/ticket-management/dynamic_content/#update-item
+func (c *client) UpdateDynamicContentItem(id int64, item *DynamicContentItem) (*DynamicContentItem, error) {
+	in := &dynamicContentItemPayload{Item: item}
+	out := new(dynamicContentItemPayload)
+	err := c.put(fmt.Sprintf("/api/v2/dynamic_content/items/%d.json", id), in, out)
+	return out.Item, err
+}
+
+// DeleteDynamicContentItem deletes a dynamic content item and its variants.
+//
+// Zendesk Core API docs: https://developer.zendesk.com/api-reference/ticketing/ticket-management/dynamic_content/#delete-item
+func (c *client) DeleteDynamicContentItem(id int64) error {
+	return c.delete(fmt.Sprintf("/api/v2/dynamic_content/items/%d.json", id), nil)
+}
+
+// ListDynamicContentVariants lists the variants of a dynamic content item.
+//
+// Zendesk Core API docs: https://developer.zendesk.com/api-reference/ticketing/ticket-management/dynamic_content_item_variants/#list-variants
+func (c *client) ListDynamicContentVariants(itemID int64) ([]DynamicContentVariant, error) {
+	out := struct {
+		Variants []DynamicContentVariant `json:"variants"`
+	}{}
+	err := c.get(fmt.Sprintf("/api/v2/dynamic_content/items/%d/variants.json", itemID), &out)
+	return out.Variants, err
+}
+
+// ShowDynamicContentVariant fetches a variant of a dynamic content item.
+//
+// Zendesk Core API docs: https://developer.zendesk.com/api-reference/ticketing/ticket-management/dynamic_content_item_variants/#show-variant
+func (c *client) ShowDynamicContentVariant(itemID, id int64) (*DynamicContentVariant, error) {
+	out := new(dynamicContentVariantPayload)
+	err := c.get(fmt.Sprintf("/api/v2/dynamic_content/items/%d/variants/%d.json", itemID, id), out)
+	return out.Variant, err
+}
+
+// CreateDynamicContentVariant adds the variant of a locale to a dynamic content item.
+//
+// Zendesk Core API docs: https://developer.zendesk.com/api-reference/ticketing/ticket-management/dynamic_content_item_variants/#create-variant
+func (c *client) CreateDynamicContentVariant(itemID int64, variant *DynamicContentVariant) (*DynamicContentVariant, error) {
+	in := &dynamicContentVariantPayload{Variant: variant}
+	out := new(dynamicContentVariantPayload)
+	err := c.post(fmt.Sprintf("/api/v2/dynamic_content/items/%d/variants.json", itemID), in, out)
+	return out.Variant, err
+}
+
+// UpdateDynamicContentVariant updates a variant of a dynamic content item.
+//
+// Zendesk Core API docs: https://developer.zendesk.com/api-reference/ticketing/ticket-management/dynamic_content_item_variants/#update-variant
+func (c *client) UpdateDynamicContentVariant(itemID, id int64, variant *DynamicContentVariant) (*DynamicContentVariant, error) {
+	in := &dynamicContentVariantPayload{Variant: variant}
+	out := new(dynamicContentVariantPayload)
+	err := c.put(fmt.Sprintf("/api/v2/dynamic_content/items/%d/variants/%d.json", itemID, id), in, out)
+	return out.Variant, err
+}
+
+// DeleteDynamicContentVariant deletes a variant of a dynamic content item. The default
+// variant cannot be deleted.
+//
+// Zendesk Core API docs: https://developer.zendesk.com/api-reference/ticketing/ticket-management/dynamic_content_item_variants/#delete-variant
+func (c *client) DeleteDynamicContentVariant(itemID, id int64) error {
+	return c.delete(fmt.Sprintf("/api/v2/dynamic_content/items/%d/variants/%d.json", itemID, id), nil)
+}
@@ -0,0 +1,446 @@
+package zendesk
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// Category is a top-level group of sections of the Help Center.
+//
+// Zendesk Help Center API docs: https://developer.zendesk.com/api-reference/help_center/help-center-api/categories/
+type Category struct {
+	ID           int64      `json:"id,omitempty"`
+	URL          string     `json:"url,omitempty"`
+	HTMLURL      string     `json:"html_url,omitempty"`
+	Name         string     `json:"name,omitempty"`
+	Description  string     `json:"description,omitempty"`
+	Locale       string     `json:"locale,omitempty"`
+	SourceLocale string     `json:"source_locale,omitempty"`
+	Position     int64      `json:"position,omitempty"`
+	Outdated     bool       `json:"outdated,omitempty"`
+	CreatedAt    *time.Time `json:"created_at,omitempty"`
+	UpdatedAt    *time.Time `json:"updated_at,omitempty"`
+}
+
+// Section is a group of articles of the Help Center, within a category or a parent section.
+//
+// Zendesk Help Center API docs: https://developer.zendesk.com/api-reference/help_center/help-center-api/sections/
+type Section struct {
+	ID              int64      `json:"id,omitempty"`
+	URL             string     `json:"url,omitempty"`
+	HTMLURL         string     `json:"html_url,omitempty"`
+	CategoryID      int64      `json:"category_id,omitempty"`
+	ParentSectionID int64      `json:"parent_section_id,omitempty"`
+	Name            string     `json:"name,omitempty"`
+	Description     string     `json:"description,omitempty"`
+	Locale          string     `json:"locale,omitempty"`
+	SourceLocale    string     `json:"source_locale,omitempty"`
+	Position        int64      `json:"position,omitempty"`
+	Sorting         string     `json:"sorting,omitempty"`
+	Outdated        bool       `json:"outdated,omitempty"`
+	CreatedAt       *time.Time `json:"created_at,omitempty"`
+	UpdatedAt       *time.Time `json:"updated_at,omitempty"`
+}
+
+// Article is a knowledge base article of the Help Center. Its title and body are those of
+// its translation in Locale.
+//
+// Zendesk Help Center API docs: https://developer.zendesk.com/api-reference/help_center/help-center-api/articles/
+type Article struct {
+	ID                int64      `json:"id,omitempty"`
+	URL               string     `json:"url,omitempty"`
+	HTMLURL           string     `json:"html_url,omitempty"`
+	AuthorID          int64      `json:"author_id,omitempty"`
+	SectionID         int64      `json:"section_id,omitempty"`
+	PermissionGroupID int64      `json:"permission_group_id,omitempty"`
+	UserSegmentID     *int64     `json:"user_segment_id,omitempty"`
+	Title             string     `json:"title,omitempty"`
+	Body              string     `json:"body,omitempty"`
+	Locale            string     `json:"locale,omitempty"`
+	SourceLocale      string     `json:"source_locale,omitempty"`
+	Draft             bool       `json:"draft,omitempty"`
+	Promoted          bool       `json:"promoted,omitempty"`
+	Position          int64      `json:"position,omitempty"`
+	CommentsDisabled  bool       `json:"comments_disabled,omitempty"`
+	Outdated          bool       `json:"outdated,omitempty"`
+	LabelNames        []string   `json:"label_names,omitempty"`
+	VoteSum           int64      `json:"vote_sum,omitempty"`
+	VoteCount         int64      `json:"vote_count,omitempty"`
+	EditedAt          *time.Time `json:"edited_at,omitempty"`
+	CreatedAt         *time.Time `json:"created_at,omitempty"`
+	UpdatedAt         *time.Time `json:"updated_at,omitempty"`
+}
+
+// Translation is the content of an article in a locale.
+//
+// Zendesk Help Center API docs: https://developer.zendesk.com/api-reference/help_center/help-center-api/translations/
+type Translation struct {
+	ID          int64      `json:"id,omitempty"`
+	URL         string     `json:"url,omitempty"`
+	HTMLURL     string     `json:"html_url,omitempty"`
+	SourceID    int64      `json:"source_id,omitempty"`
+	SourceType  string     `json:"source_type,omitempty"`
+	Locale      string     `json:"locale,omitempty"`
+	Title       string     `json:"title,omitempty"`
+	Body        string     `json:"body,omitempty"`
+	Draft       bool       `json:"draft,omitempty"`
+	Outdated    bool       `json:"outdated,omitempty"`
+	CreatedByID int64      `json:"created_by_id,omitempty"`
+	UpdatedByID int64      `json:"updated_by_id,omitempty"`
+	CreatedAt   *time.Time `json:"created_at,omitempty"`
+	UpdatedAt   *time.Time `json:"updated_at,omitempty"`
+}
+
+// ListCategories lists the categories of the Help Center.
+//
+// Zendesk Help Center API docs: https://developer.zendesk.com/api-reference/help_center/help-center-api/categories/#list-categories
+func (c *client) ListCategories() ([]Category, error) {
+	result := make([]Category, 0)
+	err := c.forEachPage("/api/v2/help_center/categories.json", func(page *APIPayload) error {
+		result = append(result, page.Categories...)
+		return nil
+	})
+	return result, partialResult(err, result)
+}
+
+// ShowCategory fetches a category by its ID.
+//
+// Zendesk Help Center API docs: https://developer.zendesk.com/api-reference/help_center/help-center-api/categories/#show-category
+func (c *client) ShowCategory(id int64) (*Category, error) {
+	out := new(APIPayload)
+	err := c.get(fmt.Sprintf("/api/v2/help_center/categories/%d.json", id), out)
+	return out.Category, err
+}
+
+// CreateCategory creates a category.
+//
+// Zendesk Help Center API docs: https://developer.zendesk.com/api-reference/help_center/help-center-api/categories/#create-category
+func (c *client) CreateCategory(category *Category) (*Category, error) {
+	in := &APIPayload{Category: category}
+	out := new(APIPayload)
+	err := c.post("/api/v2/help_center/categories.json", in, out)
+	return out.Category, err
+}
+
+// UpdateCategory updates a category.
+//
+// Zendesk Help Center API docs: https://developer.zendesk.com/api-reference/help_center/help-center-api/categories/#update-category
+func (c *client) UpdateCategory(id int64, category *Category) (*Category, error) {
+	in := &APIPayload{Category: category}
+	out := new(APIPayload)
+	err := c.put(fmt.Sprintf("/api/v2/help_center/categories/%d.json", id), in, out)
+	return out.Category, err
+}
+
+// DeleteCategory deletes a category, along with its sections and articles.
+//
+// Zendesk Help Center API docs: https://developer.zendesk.com/api-reference/help_center/help-center-api/categories/#delete-category
+func (c *client) DeleteCategory(id int64) error {
+	return c.delete(fmt.Sprintf("/api/v2/help_center/categories/%d.json", id), nil)
+}
+
+// ListSections lists the sections of a category, or all the sections of the Help Center
+// when categoryID is 0.
+//
+// Zendesk Help Center API docs: https://developer.zendesk.com/api-reference/help_center/help-center-api/sections/#list-sections
+func (c *client) ListSections(categoryID int64) ([]Section, error) {
+	endpoint := "/api/v2/help_center/sections.json"
+	if categoryID != 0 {
+		endpoint = fmt.Sprintf("/api/v2/help_center/categories/%d/sections.json", categoryID)
+	}
+
+	result := make([]Section, 0)
+	err := c.forEachPage(endpoint, func(page *APIPayload) error {
+		result = append(result, page.Sections...)
+		return nil
+	})
+	return result, partialResult(err, result)
+}
+
+// ShowSection fetches a section by its ID.
+//
+// Zendesk Help Center API docs: https://developer.zendesk.com/api-reference/help_center/help-center-api/sections/#show-section
+func (c *client) ShowSection(id int64) (*Section, error) {
+	out := new(APIPayload)
+	err := c.get(fmt.Sprintf("/api/v2/help_center/sections/%d.json", id), out)
+	return out.Section, err
+}
+
+// CreateSection creates a section in a category.
+//
+// Zendesk Help Center API docs: https://developer.zendesk.com/api-reference/help_center/help-center-api/sections/#create-section
+func (c *client) CreateSection(categoryID int64, section *Section) (*Section, error) {
+	in := &APIPayload{Section: section}
+	out := new(APIPayload)
+	err := c.post(fmt.Sprintf("/api/v2/help_center/categories/%d/sections.json", categoryID), in, out)
+	return out.Section, err
+}
+
+// UpdateSection updates a section.
+//
+// Zendesk Help Center API docs: https://developer.zendesk.com/api-reference/help_center/help-center-api/sections/#update-section
+func (c *client) UpdateSection(id int64, section *Section) (*Section, error) {
+	in := &APIPayload{Section: section}
+	out := new(APIPayload)
+	err := c.put(fmt.Sprintf("/api/v2/help_center/sections/%d.json", id), in, out)
+	return out.Section, err
+}
+
+// DeleteSection deletes a section, along with its articles.
+//
+// Zendesk Help Center API docs: https://developer.zendesk.com/api-reference/help_center/help-center-api/sections/#delete-section
+func (c *client) DeleteSection(id int64) error {
+	return c.delete(fmt.Sprintf("/api/v2/help_center/sections/%d.json", id), nil)
+}
+
+// ListArticles lists the articles of a section, or all the articles of the Help Center
+// when sectionID is 0.
+//
+// Zendesk Help Center API docs: https://developer.zendesk.com/api-reference/help_center/help-center-api/articles/#list-articles
+func (c *client) ListArticles(sectionID int64) ([]Article, error) {
+	endpoint := "/api/v2/help_center/articles.json"
+	if sectionID != 0 {
+		endpoint = fmt.Sprintf("/api/v2/help_center/sections/%d/articles.json", sectionID)
+	}
+
+	result := make([]Article, 0)
+	err := c.forEachPage(endpoint, func(page *APIPayload) error {
+		result = append(result, page.Articles...)
+		return nil
+	})
+	return result, partialResult(err, result)
+}
+
+// ShowArticle fetches an article by its ID.
+//
+// Zendesk Help Center API docs: https://developer.zendesk.com/api-reference/help_center/help-center-api/articles/#show-article
+func (c *client) ShowArticle(id int64) (*Article, error) {
+	out := new(APIPayload)
+	err := c.get(fmt.Sprintf("/api/v2/help_center/articles/%d.json", id), out)
+	return out.Article, err
+}
+
+// CreateArticle creates an article in a section, with its translation in the locale of the article.
+//
+// Zendesk Help Center API docs: https://developer.zendesk.com/api-reference/help_center/help-center-api/articles/#create-article
+func (c *client) CreateArticle(sectionID int64, article *Article) (*Article, error) {
+	in := &APIPayload{Article: article}
+	out := new(APIPayload)
+	err := c.post(fmt.Sprintf("/api/v2/help_center/sections/%d/articles.json", sectionID), in, out)
+	return out.Article, err
+}
+
+// UpdateArticle updates the metadata of an article, such as its section or labels. Its
+// title and body are updated with UpdateArticleTranslation.
+//
+// Zendesk Help Center API docs: https://developer.zendesk.com/api-reference/help_center/help-center-api/articles/#update-article
+func (c *client) UpdateArticle(id int64, article *Article) (*Article, error) {
+	in := &APIPayload{Article: article}
+	out := new(APIPayload)
+	err := c.put(fmt.Sprintf("/api/v2/help_center/articles/%d.json", id), in, out)
+	return out.Article, err
+}
+
+// DeleteArticle archives an article.
+//
+// Zendesk Help Center API docs: https://developer.zendesk.com/api-reference/help_center/help-center-api/articles/#archive-article
+func (c *client) DeleteArticle(id int64) error {
+	return c.delete(fmt.Sprintf("/api/v2/help_center/articles/%d.json", id), nil)
+}
+
+// SearchArticles returns the articles matching the full-text query, in the given locale
+// or all of them when locale is empty.
+//
+// Zendesk Help Center API docs: https://developer.zendesk.com/api-reference/help_center/help-center-api/articles/#search-articles
+func (c *client) SearchArticles(query, locale string) ([]Article, error) {
+	params := url.Values{"query": {query}}
+	if locale != "" {
+		params.Set("locale", locale)
+	}
+
+	result := make([]Article, 0)
+	endpoint := "/api/v2/help_center/articles/search.json?" + params.Encode()
+	type page struct {
+		Results  []Article `json:"results"`
+		NextPage string    `json:"next_page"`
+	}
+	err := c.forEachPageOf(endpoint, func() interface{} { return new(page) }, func(p interface{}) error {
+		result = append(result, p.(*page).Results...)
+		return nil
+	})
+	return result, partialResult(err, result)
+}
+
+// ListArticleTranslations lists the translations of an article.
+//
+// Zendesk Help Center API docs: https://developer.zendesk.com/api-reference/help_center/help-center-api/translations/#list-translations
+func (c *client) ListArticleTranslations(articleID int64) ([]Translation, error) {
+	result := make([]Translation, 0)
+	err := c.forEachPage(fmt.Sprintf("/api/v2/help_center/articles/%d/translations.json", articleID), func(page *APIPayload) error {
+		result = append(result, page.Translations...)
+		return nil
+	})
+	return result, partialResult(err, result)
+}
+
+// ShowArticleTranslation fetches the translation of an article in a locale.
+//
+// Zendesk Help Center API docs: https://developer.zendesk.com/api-reference/help_center/help-center-api/translations/#show-translation
+func (c *client) ShowArticleTranslation(articleID int64, locale string) (*Translation, error) {
+	out := new(APIPayload)
+	err := c.get(fmt.Sprintf("/api/v2/help_center/articles/%d/translations/%s.json", articleID, url.PathEscape(locale)), out)
+	return out.Translation, err
+}
+
+// CreateArticleTranslation adds a translation to an article, in a locale it has none in.
+//
+// Zendesk Help Center API docs: https://developer.zendesk.com/api-reference/help_center/help-center-api/translations/#create-translation
+func (c *client) CreateArticleTranslation(articleID int64, translation *Translation) (*Translation, error) {
+	in := &APIPayload{Translation: translation}
+	out := new(APIPayload)
+	err := c.post(fmt.Sprintf("/api/v2/help_center/articles/%d/translations.json", articleID), in, out)
+	return out.Translation, err
+}
+
+// UpdateArticleTranslation updates the translation of an article in a locale.
+//
+// Zendesk Help Center API docs: https://developer.zendesk.com/api-reference/help_center/help-center-api/translations/#update-translation
+func (c *client) UpdateArticleTranslation(articleID int64, locale string, translation *Translation) (*Translation, error) {
+	in := &APIPayload{Translation: translation}
+	out := new(APIPayload)
+	err := c.put(fmt.Sprintf("/api/v2/help_center/articles/%d/translations/%s.json", articleID, url.PathEscape(locale)), in, out)
+	return out.Translation, err
+}
+
+// ArticleAttachment is a file attached to an article, such as an image shown in its body.
+//
+// Zendesk Help Center API docs: https://developer.zendesk.com/api-reference/help_center/help-center-api/article_attachments/
+type ArticleAttachment struct {
+	ID          int64      `json:"id,omitempty"`
+	URL         string     `json:"url,omitempty"`
+	ArticleID   int64      `json:"article_id,omitempty"`
+	FileName    string     `json:"file_name,omitempty"`
+	ContentURL  string     `json:"content_url,omitempty"`
+	ContentType string     `json:"content_type,omitempty"`
+	Size        int64      `json:"size,omitempty"`
+	Inline      bool       `json:"inline,omitempty"`
+	CreatedAt   *time.Time `json:"created_at,omitempty"`
+	UpdatedAt   *time.Time `json:"updated_at,omitempty"`
+}
+
+// Vote is the up or down vote of a user on an article.
+//
+// Zendesk Help Center API docs: https://developer.zendesk.com/api-reference/help_center/help-center-api/votes/
+type Vote struct {
+	ID     int64  `json:"id,omitempty"`
+	URL    string `json:"url,omitempty"`
+	UserID int64  `json:"user_id,omitempty"`
+	// Value is 1 for an up vote and -1 for a down vote.
+	Value     int64      `json:"value,omitempty"`
+	ItemID    int64      `json:"item_id,omitempty"`
+	ItemType  string     `json:"item_type,omitempty"`
+	CreatedAt *time.Time `json:"created_at,omitempty"`
+	UpdatedAt *time.Time `json:"updated_at,omitempty"`
+}
+
+// ArticleComment is a comment left by a user on an article.
+//
+// Zendesk Help Center API docs: https://developer.zendesk.com/api-reference/help_center/help-center-api/article_comments/
+type ArticleComment struct {
+	ID         int64      `json:"id,omitempty"`
+	URL        string     `json:"url,omitempty"`
+	HTMLURL    string     `json:"html_url,omitempty"`
+	Body       string     `json:"body,omitempty"`
+	AuthorID   int64      `json:"author_id,omitempty"`
+	SourceID   int64      `json:"source_id,omitempty"`
+	SourceType string     `json:"source_type,omitempty"`
+	Locale     string     `json:"locale,omitempty"`
+	VoteSum    int64      `json:"vote_sum,omitempty"`
+	VoteCount  int64      `json:"vote_count,omitempty"`
+	CreatedAt  *time.Time `json:"created_at,omitempty"`
+	UpdatedAt  *time.Time `json:"updated_at,omitempty"`
+}
+
+// ListArticleAttachments lists the attachments of an article.
+//
+// Zendesk Help Center API docs: https://developer.zendesk.com/api-reference/help_center/help-center-api/article_attachments/#list-article-attachments
+func (c *client) ListArticleAttachments(articleID int64) ([]ArticleAttachment, error) {
+	out := new(APIPayload)
+	err := c.get(fmt.Sprintf("/api/v2/help_center/articles/%d/attachments.json", articleID), out)
+	return out.ArticleAttachments, err
+}
+
+// CreateArticleAttachment attaches a file to an article. Inline attachments are meant to
+// be shown in the body of the article, such as images, while the others are listed below it.
+//
+// Zendesk Help Center API docs: https://developer.zendesk.com/api-reference/help_center/help-center-api/article_attachments/#create-article-attachment
+func (c *client) CreateArticleAttachment(articleID int64, filename string, content io.Reader, inline bool) (*ArticleAttachment, error) {
+	body := new(bytes.Buffer)
+	form := multipart.NewWriter(body)
+	if err := form.WriteField("inline", strconv.FormatBool(inline)); err != nil {
+		return nil, err
+	}
+	part, err := form.CreateFormFile("file", filename)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := io.Copy(part, content); err != nil {
+		return nil, err
+	}
+	if err := form.Close(); err != nil {
+		return nil, err
+	}
+
+	headers := map[string]string{"Content-Type": form.FormDataContentType()}
+	res, err := c.request("POST", fmt.Sprintf("/api/v2/help_center/articles/%d/attachments.json", articleID), headers, bytes.NewReader(body.Bytes()))
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	out := new(APIPayload)
+	err = c.unmarshall(res, out)
+	return out.ArticleAttachment, err
+}
+
+// VoteArticleUp votes for an article as the authenticated user, replacing their previous vote.
+//
+// Zendesk Help Center API docs: https://developer.zendesk.com/api-reference/help_center/help-center-api/votes/#create-vote
+func (c *client) VoteArticleUp(articleID int64) (*Vote, error) {
+	out := new(APIPayload)
+	err := c.post(fmt.Sprintf("/api/v2/help_center/articles/%d/up.json", articleID), nil, out)
+	return out.Vote, err
+}
+
+// VoteArticleDown votes against an article as the authenticated user, replacing their
+// previous vote.
+//
+// Zendesk Help Center API docs: https://developer.zendesk.com/api-reference/help_center/help-center-api/votes/#create-vote
+func (c *client) VoteArticleDown(articleID int64) (*Vote, error) {
+	out := new(APIPayload)
+	err := c.post(fmt.Sprintf("/api/v2/help_center/articles/%d/down.json", articleID), nil, out)
+	return out.Vote, err
+}
+
+// ListArticleComments lists the comments left by users on an article.
+//
+// Zendesk Help Center API docs: https://developer.zendesk.com/api-reference/help_center/help-center-api/article_comments/#list-comments
+func (c *client) ListArticleComments(articleID int64) ([]ArticleComment, error) {
+	result := make([]ArticleComment, 0)
+	endpoint := fmt.Sprintf("/api/v2/help_center/articles/%d/comments.json", articleID)
+	// The comments of articles come under the same key as those of tickets.
+	type page struct {
+		Comments []ArticleComment `json:"comments"`
+		NextPage string           `json:"next_page"`
+	}
+	err := c.forEachPageOf(endpoint, func() interface{} { return new(page) }, func(p interface{}) error {
+		result = append(result, p.(*page).Comments...)
+		return nil
+	})
+	return result, partialResult(err, result)
+}
@@ -0,0 +1,88 @@
+package zendesk
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"time"
+)
+
+// Job status values reported by Zendesk for background jobs.
+const (
+	JobStatusQueued    = "queued"
+	JobStatusWorking   = "working"
+	JobStatusFailed    = "failed"
+	JobStatusCompleted = "completed"
+	JobStatusKilled    = "killed"
+)
+
+// JobStatus represents the status of a Zendesk background job such as a bulk update.
+//
+// Zendesk Core API docs: https://developer.zendesk.com/api-reference/ticketing/ticket-management/job_statuses/
+type JobStatus struct {
+	ID       string            `json:"id,omitempty"`
+	URL      string            `json:"url,omitempty"`
+	Total    int64             `json:"total,omitempty"`
+	Progress int64             `json:"progress,omitempty"`
+	Status   string            `json:"status,omitempty"`
+	Message  string            `json:"message,omitempty"`
+	Results  []JobStatusResult `json:"results,omitempty"`
+}
+
+// JobStatusResult represents the outcome of a single item processed by a background job.
+type JobStatusResult struct {
+	ID      int64  `json:"id,omitempty"`
+	Index   int64  `json:"index,omitempty"`
+	Action  string `json:"action,omitempty"`
+	Success bool   `json:"success,omitempty"`
+	Status  string `json:"status,omitempty"`
+	Error   string `json:"error,omitempty"`
+	Details string `json:"details,omitempty"`
+}
+
+// Done reports whether the job has stopped running, successfully or not.
+func (j *JobStatus) Done() bool {
+	switch j.Status {
+	case JobStatusCompleted, JobStatusFailed, JobStatusKilled:
+		return true
+	}
+	return false
+}
+
+// ShowJobStatus fetches the status of a background job by its ID.
+//
+// Zendesk Core API docs: https://developer.zendesk.com/api-reference/ticketing/ticket-management/job_statuses/#show-job-status
+func (c *client) ShowJobStatus(id string) (*JobStatus, error) {
+	out := new(APIPayload)
+	err := c.get(fmt.Sprintf("/api/v2/job_statuses/%s.json", url.PathEscape(id)), out)
+	return out.JobStatus, err
+}
+
+// WaitForJobCompletion polls the status of a background job every pollInterval
+// until the job completes, fails or the context is done. A failed or killed job
+// is returned along with an error.
+func (c *client) WaitForJobCompletion(ctx context.Context, id string, pollInterval time.Duration) (*JobStatus, error) {
+	for {
+		status, err := c.ShowJobStatus(id)
+		if err != nil {
+			return nil, err
+		}
+		if status == nil {
+			return nil, fmt.Errorf("zendesk: no status returned for job %s", id)
+		}
+
+		if status.Done() {
+			if status.Status != JobStatusCompleted {
+				c.logger.Printf("[zd_job_status_service][WaitForJobCompletion] job %s %s: %s\n", id, status.Status, status.Message)
+				return status, fmt.Errorf("zendesk: job %s %s: %s", id, status.Status, status.Message)
+			}
+			return status, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return status, ctx.Err()
+		case <-time.After(pollInterval):
+		}
+	}
+}
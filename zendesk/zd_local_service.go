@@ -2,6 +2,7 @@ package zendesk
 
 import (
 	"fmt"
+	"strings"
 	"time"
 )
 
@@ -9,12 +10,16 @@ import (
 //
 // Zendesk Core API docs: https://developer.zendesk.com/rest_api/docs/core/locales
 type Locale struct {
-	ID        int64      `json:"id,omitempty"`
-	URL       string     `json:"url,omitempty"`
-	Locale    string     `json:"locale,omitempty"`
-	Name      string     `json:"name,omitempty"`
-	CreatedAt *time.Time `json:"created_at,omitempty"`
-	UpdatedAt *time.Time `json:"updated_at,omitempty"`
+	ID               int64      `json:"id,omitempty"`
+	URL              string     `json:"url,omitempty"`
+	Locale           string     `json:"locale,omitempty"`
+	Name             string     `json:"name,omitempty"`
+	NativeName       string     `json:"native_name,omitempty"`
+	PresentationName string     `json:"presentation_name,omitempty"`
+	RTL              bool       `json:"rtl,omitempty"`
+	Default          bool       `json:"default,omitempty"`
+	CreatedAt        *time.Time `json:"created_at,omitempty"`
+	UpdatedAt        *time.Time `json:"updated_at,omitempty"`
 }
 
 func (c *client) ListLocales() ([]Locale, error) {
@@ -34,3 +39,55 @@ func (c *client) ShowLocaleByCode(code string) (*Locale, error) {
 	err := c.get(fmt.Sprintf("/api/v2/locales/%s.json", code), out)
 	return out.Locale, err
 }
+
+// ListLocalesForAgent lists the locales available to the agents of the account.
+//
+// Zendesk Core API docs: https://developer.zendesk.com/api-reference/ticketing/account-configuration/locales/#list-locales-for-agent
+func (c *client) ListLocalesForAgent() ([]Locale, error) {
+	out := new(APIPayload)
+	err := c.get("/api/v2/locales/agent.json", out)
+	return out.Locales, err
+}
+
+// ListAvailablePublicLocales lists the locales the account can offer to end users.
+//
+// Zendesk Core API docs: https://developer.zendesk.com/api-reference/ticketing/account-configuration/locales/#list-available-public-locales
+func (c *client) ListAvailablePublicLocales() ([]Locale, error) {
+	out := new(APIPayload)
+	err := c.get("/api/v2/locales/public.json", out)
+	return out.Locales, err
+}
+
+// DetectBestLocale returns the locale of the account best matching the languages, given
+// by order of preference like in an Accept-Language header.
+//
+// Zendesk Core API docs: https://developer.zendesk.com/api-reference/ticketing/account-configuration/locales/#detect-best-language-for-user
+func (c *client) DetectBestLocale(acceptLanguages []string) (*Locale, error) {
+	res, err := c.request("GET", "/api/v2/locales/detect_best_locale.json", map[string]string{"Accept-Language": acceptLanguage(acceptLanguages)}, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	defer res.Body.Close()
+
+	out := new(APIPayload)
+	err = c.decode(res, out)
+	return out.Locale, err
+}
+
+// acceptLanguage builds an Accept-Language header value, weighting the languages by order.
+func acceptLanguage(languages []string) string {
+	values := make([]string, 0, len(languages))
+	for i, language := range languages {
+		q := 10 - i
+		if q < 1 {
+			q = 1
+		}
+		if q == 10 {
+			values = append(values, language)
+		} else {
+			values = append(values, fmt.Sprintf("%s;q=0.%d", language, q))
+		}
+	}
+	return strings.Join(values, ", ")
+}
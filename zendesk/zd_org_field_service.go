@@ -0,0 +1,61 @@
+package zendesk
+
+import (
+	"fmt"
+)
+
+// ListOrganizationFields lists the custom organization fields of the account.
+//
+// Zendesk Core API docs: https://developer.zendesk.com/api-reference/ticketing/organizations/organization_fields/#list-organization-fields
+func (c *client) ListOrganizationFields() ([]FieldDefinition, error) {
+	out := new(APIPayload)
+	err := c.get("/api/v2/organization_fields.json", out)
+	return out.OrganizationFields, err
+}
+
+// ShowOrganizationField fetches a custom organization field by its ID.
+//
+// Zendesk Core API docs: https://developer.zendesk.com/api-reference/ticketing/organizations/organization_fields/#show-organization-field
+func (c *client) ShowOrganizationField(id int64) (*FieldDefinition, error) {
+	out := new(APIPayload)
+	err := c.get(fmt.Sprintf("/api/v2/organization_fields/%d.json", id), out)
+	return out.OrganizationField, err
+}
+
+// CreateOrganizationField creates a custom organization field.
+//
+// Zendesk Core API docs: https://developer.zendesk.com/api-reference/ticketing/organizations/organization_fields/#create-organization-field
+func (c *client) CreateOrganizationField(field *FieldDefinition) (*FieldDefinition, error) {
+	in := &APIPayload{OrganizationField: field}
+	out := new(APIPayload)
+	err := c.post("/api/v2/organization_fields.json", in, out)
+	return out.OrganizationField, err
+}
+
+// UpdateOrganizationField updates a custom organization field.
+//
+// Zendesk Core API docs: https://developer.zendesk.com/api-reference/ticketing/organizations/organization_fields/#update-organization-field
+func (c *client) UpdateOrganizationField(id int64, field *FieldDefinition) (*FieldDefinition, error) {
+	in := &APIPayload{OrganizationField: field}
+	out := new(APIPayload)
+	err := c.put(fmt.Sprintf("/api/v2/organization_fields/%d.json", id), in, out)
+	return out.OrganizationField, err
+}
+
+// DeleteOrganizationField deletes a custom organization field.
+//
+// Zendesk Core API docs: https://developer.zendesk.com/api-reference/ticketing/organizations/organization_fields/#delete-organization-field
+func (c *client) DeleteOrganizationField(id int64) error {
+	return c.delete(fmt.Sprintf("/api/v2/organization_fields/%d.json", id), nil)
+}
+
+// ReorderOrganizationFields sets the position of the custom organization fields to their
+// order in ids.
+//
+// Zendesk Core API docs: https://developer.zendesk.com/api-reference/ticketing/organizations/organization_fields/#reorder-organization-field
+func (c *client) ReorderOrganizationFields(ids []int64) error {
+	in := struct {
+		IDs []int64 `json:"organization_field_ids"`
+	}{IDs: ids}
+	return c.put("/api/v2/organization_fields/reorder.json", in, nil)
+}
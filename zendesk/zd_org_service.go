@@ -2,6 +2,10 @@ package zendesk
 
 import (
 	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"sync"
 	"time"
 
 	"github.com/google/go-querystring/query"
@@ -24,17 +28,98 @@ type Organization struct {
 	SharedTickets      bool                   `json:"shared_tickets,omitempty"`
 	SharedComments     bool                   `json:"shared_comments,omitempty"`
 	OrganizationFields map[string]interface{} `json:"organization_fields,omitempty"`
+	Tags               []string               `json:"tags,omitempty"`
+
+	// Sideloads holds the records requested with Include options.
+	Sideloads *Sideloads `json:"-"`
 }
 
 // ShowOrganization fetches an organization by its ID.
 //
 // Zendesk Core API docs: https://developer.zendesk.com/rest_api/docs/core/organizations#show-organization
-func (c *client) ShowOrganization(id int64) (*Organization, error) {
+func (c *client) ShowOrganization(id int64, includes ...Include) (*Organization, error) {
 	out := new(APIPayload)
-	err := c.get(fmt.Sprintf("/api/v2/organizations/%d.json", id), out)
+	err := c.get(withIncludes(fmt.Sprintf("/api/v2/organizations/%d.json", id), includes), out)
+	if out.Organization != nil {
+		out.Organization.Sideloads = sideloads(out, includes)
+	}
 	return out.Organization, err
 }
 
+// ShowManyOrganizations fetches organizations by their IDs, in requests of up to 100 IDs.
+// Organizations that do not exist are missing from the result.
+//
+// Zendesk Core API docs: https://developer.zendesk.com/api-reference/ticketing/organizations/organizations/#show-many-organizations
+func (c *client) ShowManyOrganizations(ids []int64) ([]Organization, error) {
+	result := make([]Organization, 0, len(ids))
+	for _, chunk := range chunkIDs(ids, showManyOrganizationsLimit) {
+		out := new(APIPayload)
+		if err := c.get("/api/v2/organizations/show_many.json?ids="+joinIDs(chunk), out); err != nil {
+			return result, err
+		}
+		result = append(result, out.Organizations...)
+	}
+	return result, nil
+}
+
+// SearchOrganizations returns the organizations matching a search query, such as
+// `tags:vip` or `external_id:42`, following the pages until the last one.
+//
+// Zendesk Core API docs: https://developer.zendesk.com/api-reference/ticketing/ticket-management/search/#list-search-results
+func (c *client) SearchOrganizations(query string) ([]Organization, error) {
+	params := url.Values{"query": {"type:organization " + query}}
+
+	result := make([]Organization, 0)
+	endpoint := "/api/v2/search.json?" + params.Encode()
+	type page struct {
+		Results  []Organization `json:"results"`
+		NextPage string         `json:"next_page"`
+	}
+	err := c.forEachPageOf(endpoint, func() interface{} { return new(page) }, func(p interface{}) error {
+		result = append(result, p.(*page).Results...)
+		return nil
+	})
+	return result, partialResult(err, result)
+}
+
+// AutocompleteOrganizations returns the organizations whose name starts with the given
+// name, which needs at least 2 characters.
+//
+// Zendesk Core API docs: https://developer.zendesk.com/api-reference/ticketing/organizations/organizations/#autocomplete-organizations
+func (c *client) AutocompleteOrganizations(name string) ([]Organization, error) {
+	out := new(APIPayload)
+	err := c.get("/api/v2/organizations/autocomplete.json?name="+url.QueryEscape(name), out)
+	return out.Organizations, err
+}
+
+// AddOrganizationTags adds tags to an organization.
+//
+// Zendesk Core API docs: https://developer.zendesk.com/api-reference/ticketing/ticket-management/tags/#add-tags
+func (c *client) AddOrganizationTags(id int64, tags []string) ([]string, error) {
+	in := &APIPayload{Tags: tags}
+	out := new(APIPayload)
+	err := c.put(fmt.Sprintf("/api/v2/organizations/%d/tags.json", id), in, out)
+	return out.Tags, err
+}
+
+// SetOrganizationTags replaces the tags of an organization. Setting no tags clears them.
+//
+// Zendesk Core API docs: https://developer.zendesk.com/api-reference/ticketing/ticket-management/tags/#set-tags
+func (c *client) SetOrganizationTags(id int64, tags []string) ([]string, error) {
+	in := newSetTagsPayload(tags)
+	out := new(APIPayload)
+	err := c.post(fmt.Sprintf("/api/v2/organizations/%d/tags.json", id), in, out)
+	return out.Tags, err
+}
+
+// RemoveOrganizationTags removes tags from an organization.
+//
+// Zendesk Core API docs: https://developer.zendesk.com/api-reference/ticketing/ticket-management/tags/#remove-tags
+func (c *client) RemoveOrganizationTags(id int64, tags []string) error {
+	in := &APIPayload{Tags: tags}
+	return c.do("DELETE", fmt.Sprintf("/api/v2/organizations/%d/tags.json", id), in, nil)
+}
+
 // CreateOrganization creates an organization.
 //
 // Zendesk Core API docs: https://developer.zendesk.com/rest_api/docs/core/organizations#create-organization
@@ -58,14 +143,28 @@ func (c *client) UpdateOrganization(id int64, org *Organization) (*Organization,
 // ListOrganizations list all organizations.
 //
 // Zendesk Core API docs: https://developer.zendesk.com/rest_api/docs/core/organizations#list-organizations
-func (c *client) ListOrganizations(opts *ListOptions) ([]Organization, error) {
+func (c *client) ListOrganizations(opts *ListOptions, includes ...Include) ([]Organization, error) {
 	params, err := query.Values(opts)
 	if err != nil {
 		return nil, err
 	}
 
 	out := new(APIPayload)
-	err = c.get("/api/v2/organizations.json?"+params.Encode(), out)
+	err = c.get(withIncludes("/api/v2/organizations.json?"+params.Encode(), includes), out)
+	if s := sideloads(out, includes); s != nil {
+		for i := range out.Organizations {
+			out.Organizations[i].Sideloads = s
+		}
+	}
+	return out.Organizations, err
+}
+
+// ListOrganizationsForUser lists the organizations a user belongs to.
+//
+// Zendesk Core API docs: https://developer.zendesk.com/rest_api/docs/core/organizations#list-organizations
+func (c *client) ListOrganizationsForUser(userID int64) ([]Organization, error) {
+	out := new(APIPayload)
+	err := c.get(fmt.Sprintf("/api/v2/users/%d/organizations.json", userID), out)
 	return out.Organizations, err
 }
 
@@ -108,9 +207,354 @@ func (c *client) ListOrganizationMembershipsByUserID(id int64) ([]OrganizationMe
 	return out.OrganizationMemberships, err
 }
 
+// ListOrganizationMembershipsByOrgID lists the memberships of the users of an organization.
+//
+// Zendesk Core API docs: https://developer.zendesk.com/api-reference/ticketing/organizations/organization_memberships/#list-memberships
+func (c *client) ListOrganizationMembershipsByOrgID(orgID int64) ([]OrganizationMembership, error) {
+	result := make([]OrganizationMembership, 0)
+	err := c.forEachPage(fmt.Sprintf("/api/v2/organizations/%d/organization_memberships.json", orgID), func(page *APIPayload) error {
+		result = append(result, page.OrganizationMemberships...)
+		return nil
+	})
+	return result, partialResult(err, result)
+}
+
+// ShowOrganizationMembership fetches an organization membership by its ID.
+//
+// Zendesk Core API docs: https://developer.zendesk.com/api-reference/ticketing/organizations/organization_memberships/#show-membership
+func (c *client) ShowOrganizationMembership(id int64) (*OrganizationMembership, error) {
+	out := new(APIPayload)
+	err := c.get(fmt.Sprintf("/api/v2/organization_memberships/%d.json", id), out)
+	return out.OrganizationMembership, err
+}
+
+// manyOrganizationMembershipsLimit is the maximum number of memberships of a bulk job.
+const manyOrganizationMembershipsLimit = 100
+
+// CreateManyOrganizationMemberships creates up to 100 organization memberships. The
+// creation runs as a background job whose status is returned.
+//
+// Zendesk Core API docs: https://developer.zendesk.com/api-reference/ticketing/organizations/organization_memberships/#create-many-memberships
+func (c *client) CreateManyOrganizationMemberships(memberships []OrganizationMembership) (*JobStatus, error) {
+	if len(memberships) > manyOrganizationMembershipsLimit {
+		return nil, fmt.Errorf("zendesk: at most %d organization memberships can be created at once, got %d", manyOrganizationMembershipsLimit, len(memberships))
+	}
+
+	in := &APIPayload{OrganizationMemberships: memberships}
+	out := new(APIPayload)
+	err := c.post("/api/v2/organization_memberships/create_many.json", in, out)
+	return out.JobStatus, err
+}
+
+// ListOrganizationMemberships lists the organization memberships of all the users.
+//
+// Zendesk Core API docs: https://developer.zendesk.com/api-reference/ticketing/organizations/organization_memberships/#list-memberships
+func (c *client) ListOrganizationMemberships() ([]OrganizationMembership, error) {
+	result := make([]OrganizationMembership, 0)
+	err := c.forEachPage("/api/v2/organization_memberships.json", func(page *APIPayload) error {
+		result = append(result, page.OrganizationMemberships...)
+		return nil
+	})
+	return result, partialResult(err, result)
+}
+
 // DeleteOrganizationMembership removes an organization membership
 //
 // Zendesk Core API docs: https://developer.zendesk.com/rest_api/docs/core/organization_memberships#delete-membership
 func (c *client) DeleteOrganizationMembershipByID(id int64) error {
 	return c.delete(fmt.Sprintf("/api/v2/organization_memberships/%d.json", id), nil)
 }
+
+// EnsureDefaultOrganization makes the organization the default one of the user,
+// creating the membership first when the user does not belong to it yet. A membership
+// created by this call is removed again when it cannot be made the default.
+//
+// Zendesk Core API docs: https://developer.zendesk.com/rest_api/docs/core/organization_memberships#set-membership-as-default
+func (c *client) EnsureDefaultOrganization(userID, orgID int64) (*OrganizationMembership, error) {
+	memberships, err := c.ListOrganizationMembershipsByUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	var membership *OrganizationMembership
+	for i := range memberships {
+		if memberships[i].OrganizationID == orgID {
+			membership = &memberships[i]
+			break
+		}
+	}
+
+	if membership != nil && membership.Default {
+		return membership, nil
+	}
+
+	created := false
+	if membership == nil {
+		membership, err = c.CreateOrganizationMembership(&OrganizationMembership{UserID: userID, OrganizationID: orgID})
+		if err != nil {
+			return nil, err
+		}
+		created = true
+	}
+
+	memberships, err = c.SetDefaultOrganizationMembership(userID, membership.ID)
+	if err != nil {
+		if created {
+			if derr := c.DeleteOrganizationMembershipByID(membership.ID); derr != nil {
+				c.logger.Printf("[zd_org_service][EnsureDefaultOrganization] failed to roll back membership %d: %s\n", membership.ID, derr)
+			}
+		}
+		return nil, err
+	}
+
+	for i := range memberships {
+		if memberships[i].ID == membership.ID {
+			return &memberships[i], nil
+		}
+	}
+
+	membership.Default = true
+	return membership, nil
+}
+
+// SetDefaultOrganizationMembership makes the membership the default one of the user,
+// whose organization_id becomes the organization of the membership. It returns the
+// updated memberships of the user.
+//
+// Zendesk Core API docs: https://developer.zendesk.com/api-reference/ticketing/organizations/organization_memberships/#set-membership-as-default
+func (c *client) SetDefaultOrganizationMembership(userID, membershipID int64) ([]OrganizationMembership, error) {
+	out := new(APIPayload)
+	err := c.put(fmt.Sprintf("/api/v2/users/%d/organization_memberships/%d/make_default.json", userID, membershipID), nil, out)
+	return out.OrganizationMemberships, err
+}
+
+// GetOrganizationsIncrementally pulls the organizations modified since a specific time point.
+//
+// Zendesk Core API docs: https://developer.zendesk.com/api-reference/ticketing/ticket-management/incremental_exports/#incremental-organization-export
+func (c *client) GetOrganizationsIncrementally(unixTime int64) ([]Organization, error) {
+	result := make([]Organization, 0)
+	_, err := c.ExportOrganizations(&IncrementalExportOptions{StartTime: unixTime}, SinkFunc(func(record interface{}) error {
+		result = append(result, *record.(*Organization))
+		return nil
+	}))
+	if err != nil {
+		if len(result) == 0 {
+			return nil, err
+		}
+		return result, &PartialResultError{Records: result, Err: err}
+	}
+
+	c.logger.Printf("[zd_org_service][GetOrganizationsIncrementally] number of records pulled: %v\n", len(result))
+	return result, nil
+}
+
+// ExportOrganizations streams the organizations modified since the start point to the sink.
+// The organization export is time based, so the cursor of the checkpoint is the unix time
+// to resume from. On failure, the checkpoint of the records already written is returned
+// along with the error.
+//
+// Zendesk Core API docs: https://developer.zendesk.com/api-reference/ticketing/ticket-management/incremental_exports/#incremental-organization-export
+func (c *client) ExportOrganizations(opts *IncrementalExportOptions, sink RecordSink) (checkpoint *ExportCheckpoint, err error) {
+	checkpoint = new(ExportCheckpoint)
+	if opts == nil {
+		opts = new(IncrementalExportOptions)
+	}
+	if opts, err = opts.resume("organizations"); err != nil {
+		return checkpoint, err
+	}
+	report := newRunReport("organizations", opts.Cursor)
+	defer func() {
+		report.finish(checkpoint, err)
+		c.logger.Printf("[zd_org_service][ExportOrganizations] %s\n", report)
+	}()
+
+	startTime := opts.StartTime
+	if opts.Cursor != "" {
+		cursor, err := strconv.ParseInt(opts.Cursor, 10, 64)
+		if err != nil {
+			return checkpoint, fmt.Errorf("invalid cursor %q: %w", opts.Cursor, err)
+		}
+		startTime = cursor
+	}
+	checkpoint.Cursor = strconv.FormatInt(startTime, 10)
+
+	// Organizations updated during the export are returned again in later pages, with the
+	// same update time, so they are deduplicated like users.
+	seen := make(map[string]bool)
+	endpoint := fmt.Sprintf("/api/v2/incremental/organizations.json?start_time=%d", startTime)
+	for {
+		out := new(APIPayload)
+		trace, err := c.getTraced(endpoint, out)
+		report.addPage(trace)
+		if err != nil {
+			return checkpoint, err
+		}
+
+		for i := range out.Organizations {
+			key := fmt.Sprintf("%v %v", out.Organizations[i].ID, out.Organizations[i].UpdatedAt)
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+
+			if err := sink.WriteRecord(&out.Organizations[i]); err != nil {
+				return checkpoint, err
+			}
+			checkpoint.Records++
+		}
+		if out.EndTime != 0 {
+			checkpoint.Cursor = strconv.FormatInt(out.EndTime, 10)
+			if err := opts.save("organizations", checkpoint.Cursor); err != nil {
+				return checkpoint, err
+			}
+		}
+
+		if out.EndOfStream || out.NextPage == "" || len(out.Organizations) == 0 {
+			break
+		}
+		if stopped(opts.Stop) {
+			return checkpoint, ErrExportStopped
+		}
+
+		next, err := url.Parse(out.NextPage)
+		if err != nil {
+			return checkpoint, err
+		}
+		if next.RequestURI() == endpoint {
+			break
+		}
+		endpoint = next.RequestURI()
+	}
+
+	return checkpoint, nil
+}
+
+// showManyOrganizationsLimit is the maximum number of IDs accepted by the show many
+// organizations endpoint.
+const showManyOrganizationsLimit = 100
+
+// Default bounds of an OrganizationCache.
+const (
+	defaultOrganizationCacheTTL  = time.Hour
+	defaultOrganizationCacheSize = 10000
+)
+
+// OrganizationCache resolves organization IDs to organizations, fetching the unknown ones
+// in batches with ShowManyOrganizations and keeping them for later lookups. It is safe for
+// concurrent use.
+type OrganizationCache struct {
+	// TTL is how long an organization is kept before it is fetched again. Zero keeps
+	// organizations until they are forgotten or evicted.
+	TTL time.Duration
+	// MaxSize bounds the number of cached organizations. The oldest ones are evicted
+	// first. Zero does not bound the cache.
+	MaxSize int
+
+	client Client
+	mu     sync.Mutex
+	orgs   map[int64]cachedOrganization
+}
+
+// cachedOrganization is an entry of an OrganizationCache. org is nil for the IDs of
+// organizations that do not exist.
+type cachedOrganization struct {
+	org       *Organization
+	fetchedAt time.Time
+}
+
+// NewOrganizationCache creates an empty cache fetching organizations with the client,
+// keeping up to 10000 organizations for an hour. Change TTL and MaxSize before the first
+// lookup to use other bounds.
+func NewOrganizationCache(client Client) *OrganizationCache {
+	return &OrganizationCache{
+		TTL:     defaultOrganizationCacheTTL,
+		MaxSize: defaultOrganizationCacheSize,
+		client:  client,
+		orgs:    make(map[int64]cachedOrganization),
+	}
+}
+
+// Organizations returns the organizations with the given IDs. Organizations that do not
+// exist are missing from the result.
+func (c *OrganizationCache) Organizations(ids []int64) (map[int64]*Organization, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := time.Now()
+	missing := make([]int64, 0)
+	seen := make(map[int64]bool)
+	for _, id := range ids {
+		if id == 0 || seen[id] {
+			continue
+		}
+		seen[id] = true
+		if entry, ok := c.orgs[id]; !ok || c.expired(entry, now) {
+			missing = append(missing, id)
+		}
+	}
+
+	if len(missing) > 0 {
+		orgs, err := c.client.ShowManyOrganizations(missing)
+		if err != nil {
+			return nil, err
+		}
+		for _, id := range missing {
+			c.orgs[id] = cachedOrganization{fetchedAt: now}
+		}
+		for i := range orgs {
+			c.orgs[orgs[i].ID] = cachedOrganization{org: &orgs[i], fetchedAt: now}
+		}
+	}
+
+	result := make(map[int64]*Organization)
+	for _, id := range ids {
+		if org := c.orgs[id].org; org != nil {
+			result[id] = org
+		}
+	}
+
+	c.evict(now)
+	return result, nil
+}
+
+func (c *OrganizationCache) expired(entry cachedOrganization, now time.Time) bool {
+	return c.TTL > 0 && now.Sub(entry.fetchedAt) >= c.TTL
+}
+
+// evict removes the expired organizations, then the oldest ones until the cache fits in
+// MaxSize.
+func (c *OrganizationCache) evict(now time.Time) {
+	if c.MaxSize <= 0 || len(c.orgs) <= c.MaxSize {
+		return
+	}
+
+	for id, entry := range c.orgs {
+		if c.expired(entry, now) {
+			delete(c.orgs, id)
+		}
+	}
+	if len(c.orgs) <= c.MaxSize {
+		return
+	}
+
+	ids := make([]int64, 0, len(c.orgs))
+	for id := range c.orgs {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		return c.orgs[ids[i]].fetchedAt.Before(c.orgs[ids[j]].fetchedAt)
+	})
+	for _, id := range ids[:len(ids)-c.MaxSize] {
+		delete(c.orgs, id)
+	}
+}
+
+// Forget removes organizations from the cache, such as after they were updated, so that
+// they are fetched again on their next lookup.
+func (c *OrganizationCache) Forget(ids ...int64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, id := range ids {
+		delete(c.orgs, id)
+	}
+}
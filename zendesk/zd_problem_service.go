@@ -0,0 +1,45 @@
+package zendesk
+
+import (
+	"fmt"
+)
+
+// Ticket types of the problem and incident workflow.
+const (
+	TicketTypeProblem  = "problem"
+	TicketTypeIncident = "incident"
+)
+
+// ListProblemTickets lists the tickets of type problem, following the pages until the last one.
+//
+// Zendesk Core API docs: https://developer.zendesk.com/api-reference/ticketing/tickets/ticket-problems/#list-ticket-problems
+func (c *client) ListProblemTickets(includes ...Include) ([]Ticket, error) {
+	result := make([]Ticket, 0)
+	err := c.forEachPage(withIncludes("/api/v2/problems.json", includes), func(out *APIPayload) error {
+		result = append(result, withTicketSideloads(out, includes)...)
+		return nil
+	})
+	return result, partialResult(err, result)
+}
+
+// AutocompleteProblems returns the problem tickets whose subject matches the text, which
+// needs at least 2 characters.
+//
+// Zendesk Core API docs: https://developer.zendesk.com/api-reference/ticketing/tickets/ticket-problems/#autocomplete-problems
+func (c *client) AutocompleteProblems(text string) ([]Ticket, error) {
+	in := map[string]string{"text": text}
+	out := new(APIPayload)
+	err := c.post("/api/v2/problems/autocomplete.json", in, out)
+	return out.Tickets, err
+}
+
+// LinkIncidentToProblem makes a ticket an incident of a problem ticket, so that it is
+// solved along with the problem.
+//
+// Zendesk Core API docs: https://developer.zendesk.com/api-reference/ticketing/tickets/tickets/#update-ticket
+func (c *client) LinkIncidentToProblem(incidentID, problemID int64) (*Ticket, error) {
+	in := &APIPayload{Ticket: &Ticket{Type: TicketTypeIncident, ProblemID: problemID}}
+	out := new(APIPayload)
+	err := c.put(fmt.Sprintf("/api/v2/tickets/%d.json", incidentID), in, out)
+	return out.Ticket, err
+}
@@ -0,0 +1,476 @@
+package zendesk
+
+import (
+	"fmt"
+	"reflect"
+	"time"
+)
+
+// Trigger represents a Zendesk ticket trigger.
+//
+// Zendesk Core API docs: https://developer.zendesk.com/api-reference/ticketing/business-rules/triggers/
+type Trigger struct {
+	ID          int64              `json:"id,omitempty"`
+	URL         string             `json:"url,omitempty"`
+	Title       string             `json:"title,omitempty"`
+	Description string             `json:"description,omitempty"`
+	Active      bool               `json:"active"`
+	Position    int64              `json:"position,omitempty"`
+	CategoryID  string             `json:"category_id,omitempty"`
+	Conditions  *TriggerConditions `json:"conditions,omitempty"`
+	Actions     []TriggerAction    `json:"actions,omitempty"`
+	CreatedAt   *time.Time         `json:"created_at,omitempty"`
+	UpdatedAt   *time.Time         `json:"updated_at,omitempty"`
+}
+
+// TriggerConditions holds the conditions that must all, or any of them, be met for a trigger to fire.
+type TriggerConditions struct {
+	All []TriggerCondition `json:"all"`
+	Any []TriggerCondition `json:"any"`
+}
+
+// TriggerCondition is a single condition of a trigger.
+type TriggerCondition struct {
+	Field    string      `json:"field"`
+	Operator string      `json:"operator"`
+	Value    interface{} `json:"value"`
+}
+
+// TriggerAction is a single action performed by a trigger.
+type TriggerAction struct {
+	Field string      `json:"field"`
+	Value interface{} `json:"value"`
+}
+
+// ProvisioningSpec is a declarative description of the ticket fields, ticket forms
+// and triggers of an account.
+//
+// Resources are matched between the spec and the account by their title (name for
+// forms), so a spec exported from one account can be applied to another. The ticket
+// field IDs of the forms are translated through the fields of the spec. Field IDs
+// referenced by trigger conditions and actions are applied as is.
+type ProvisioningSpec struct {
+	TicketFields []TicketField `json:"ticket_fields"`
+	TicketForms  []TicketForm  `json:"ticket_forms"`
+	Triggers     []Trigger     `json:"triggers"`
+}
+
+// ProvisioningOptions specifies the optional parameters for planning and applying a ProvisioningSpec.
+type ProvisioningOptions struct {
+	// Prune deletes the custom fields, forms and triggers of the account that are not in the spec.
+	Prune bool
+	// Limits, when set, are checked against the changes of the plan. PlanProvisioning
+	// returns the plan along with an ErrLimitExceeded listing the limits that would be
+	// exceeded, and ApplyProvisioningSpec refuses to apply the spec, returning the error.
+	Limits *AccountLimits
+}
+
+// ProvisioningAction is the kind of change made to an account resource.
+type ProvisioningAction string
+
+const (
+	ProvisioningCreate ProvisioningAction = "create"
+	ProvisioningUpdate ProvisioningAction = "update"
+	ProvisioningDelete ProvisioningAction = "delete"
+)
+
+// Resource kinds managed by a ProvisioningSpec.
+const (
+	ProvisioningTicketField = "ticket_field"
+	ProvisioningTicketForm  = "ticket_form"
+	ProvisioningTrigger     = "trigger"
+)
+
+// ProvisioningChange describes a single change needed to bring an account in line with a spec.
+type ProvisioningChange struct {
+	Action   ProvisioningAction `json:"action"`
+	Resource string             `json:"resource"`
+	Name     string             `json:"name"`
+	// ID is the ID of the resource in the account, or of the created resource once applied.
+	ID int64 `json:"id,omitempty"`
+}
+
+// ProvisioningPlan lists the changes needed to bring an account in line with a spec.
+type ProvisioningPlan struct {
+	Changes []ProvisioningChange `json:"changes"`
+}
+
+// ExportProvisioningSpec exports the current ticket fields, ticket forms and triggers of the account.
+func (c *client) ExportProvisioningSpec() (*ProvisioningSpec, error) {
+	fields, err := c.ListTicketFields()
+	if err != nil {
+		return nil, err
+	}
+
+	forms, err := c.ListTicketForms()
+	if err != nil {
+		return nil, err
+	}
+
+	triggers, err := c.listTriggers()
+	if err != nil {
+		return nil, err
+	}
+
+	return &ProvisioningSpec{TicketFields: fields, TicketForms: forms, Triggers: triggers}, nil
+}
+
+// PlanProvisioning compares the spec with the account and returns the changes
+// ApplyProvisioningSpec would make, without making them.
+func (c *client) PlanProvisioning(spec *ProvisioningSpec, opts *ProvisioningOptions) (*ProvisioningPlan, error) {
+	current, err := c.ExportProvisioningSpec()
+	if err != nil {
+		return nil, err
+	}
+
+	plan := planProvisioning(spec, current, opts)
+	if opts != nil && opts.Limits != nil {
+		if err := checkProvisioningLimits(c.logger, opts.Limits, current, plan); err != nil {
+			return plan, err
+		}
+	}
+
+	return plan, nil
+}
+
+// ApplyProvisioningSpec creates, updates and, when pruning, deletes the ticket fields,
+// ticket forms and triggers of the account so that they match the spec.
+// The applied changes are returned, including when an error stops the run.
+func (c *client) ApplyProvisioningSpec(spec *ProvisioningSpec, opts *ProvisioningOptions) (*ProvisioningPlan, error) {
+	current, err := c.ExportProvisioningSpec()
+	if err != nil {
+		return nil, err
+	}
+
+	plan := planProvisioning(spec, current, opts)
+	if opts != nil && opts.Limits != nil {
+		if err := checkProvisioningLimits(c.logger, opts.Limits, current, plan); err != nil {
+			return &ProvisioningPlan{}, err
+		}
+	}
+	applied := &ProvisioningPlan{}
+
+	// Fields are applied first so that forms can reference the fields they create.
+	fieldIDs := make(map[string]int64)
+	for _, field := range current.TicketFields {
+		fieldIDs[field.Title] = field.ID
+	}
+
+	for _, change := range plan.Changes {
+		if change.Resource != ProvisioningTicketField || change.Action == ProvisioningDelete {
+			continue
+		}
+
+		field := *findSpecField(spec, change.Name)
+		field.ID = 0
+		field.CreatedAt = nil
+		field.UpdatedAt = nil
+
+		var saved *TicketField
+		if change.Action == ProvisioningCreate {
+			saved, err = c.CreateTicketField(&field)
+		} else {
+			saved, err = c.UpdateTicketField(change.ID, &field)
+		}
+		if err != nil {
+			return applied, err
+		}
+
+		fieldIDs[saved.Title] = saved.ID
+		change.ID = saved.ID
+		applied.Changes = append(applied.Changes, change)
+	}
+
+	specFieldTitles := make(map[int64]string)
+	for _, field := range spec.TicketFields {
+		specFieldTitles[field.ID] = field.Title
+	}
+
+	for _, change := range plan.Changes {
+		if change.Action == ProvisioningDelete {
+			continue
+		}
+
+		switch change.Resource {
+		case ProvisioningTicketForm:
+			form := *findSpecForm(spec, change.Name)
+			form.ID = 0
+			form.URL = ""
+			form.CreatedAt = nil
+			form.UpdatedAt = nil
+			form.TicketFieldIDs = nil
+			for _, id := range findSpecForm(spec, change.Name).TicketFieldIDs {
+				targetID, ok := fieldIDs[specFieldTitles[id]]
+				if !ok {
+					c.logger.Printf("[zd_provisioning_service][ApplyProvisioningSpec] form %q references unknown field %d\n", form.Name, id)
+					continue
+				}
+				form.TicketFieldIDs = append(form.TicketFieldIDs, targetID)
+			}
+
+			var saved *TicketForm
+			if change.Action == ProvisioningCreate {
+				saved, err = c.CreateTicketForm(&form)
+			} else {
+				saved, err = c.UpdateTicketForm(change.ID, &form)
+			}
+			if err != nil {
+				return applied, err
+			}
+			change.ID = saved.ID
+
+		case ProvisioningTrigger:
+			trigger := *findSpecTrigger(spec, change.Name)
+			trigger.ID = 0
+			trigger.URL = ""
+			trigger.CreatedAt = nil
+			trigger.UpdatedAt = nil
+
+			var saved *Trigger
+			if change.Action == ProvisioningCreate {
+				saved, err = c.createTrigger(&trigger)
+			} else {
+				saved, err = c.updateTrigger(change.ID, &trigger)
+			}
+			if err != nil {
+				return applied, err
+			}
+			change.ID = saved.ID
+
+		default:
+			continue
+		}
+
+		applied.Changes = append(applied.Changes, change)
+	}
+
+	// Deletions run last and in reverse dependency order: triggers, forms, then fields.
+	for _, resource := range []string{ProvisioningTrigger, ProvisioningTicketForm, ProvisioningTicketField} {
+		for _, change := range plan.Changes {
+			if change.Action != ProvisioningDelete || change.Resource != resource {
+				continue
+			}
+
+			switch resource {
+			case ProvisioningTrigger:
+				err = c.deleteTrigger(change.ID)
+			case ProvisioningTicketForm:
+				err = c.DeleteTicketForm(change.ID)
+			case ProvisioningTicketField:
+				err = c.DeleteTicketField(change.ID)
+			}
+			if err != nil {
+				return applied, err
+			}
+
+			applied.Changes = append(applied.Changes, change)
+		}
+	}
+
+	c.logger.Printf("[zd_provisioning_service][ApplyProvisioningSpec] number of changes applied: %v\n", len(applied.Changes))
+	return applied, nil
+}
+
+func planProvisioning(spec, current *ProvisioningSpec, opts *ProvisioningOptions) *ProvisioningPlan {
+	prune := opts != nil && opts.Prune
+	plan := &ProvisioningPlan{}
+
+	specFieldTitles := make(map[int64]string)
+	for _, field := range spec.TicketFields {
+		specFieldTitles[field.ID] = field.Title
+	}
+	currentFieldTitles := make(map[int64]string)
+	for _, field := range current.TicketFields {
+		currentFieldTitles[field.ID] = field.Title
+	}
+
+	// Fields
+	currentFields := make(map[string]TicketField)
+	for _, field := range current.TicketFields {
+		currentFields[field.Title] = field
+	}
+	wantedFields := make(map[string]bool)
+	for _, field := range spec.TicketFields {
+		if isSystemFieldType(field.Type) {
+			continue
+		}
+		wantedFields[field.Title] = true
+
+		existing, ok := currentFields[field.Title]
+		if !ok {
+			plan.Changes = append(plan.Changes, ProvisioningChange{Action: ProvisioningCreate, Resource: ProvisioningTicketField, Name: field.Title})
+		} else if !reflect.DeepEqual(normalizeTicketField(field), normalizeTicketField(existing)) {
+			plan.Changes = append(plan.Changes, ProvisioningChange{Action: ProvisioningUpdate, Resource: ProvisioningTicketField, Name: field.Title, ID: existing.ID})
+		}
+	}
+
+	// Forms
+	currentForms := make(map[string]TicketForm)
+	for _, form := range current.TicketForms {
+		currentForms[form.Name] = form
+	}
+	wantedForms := make(map[string]bool)
+	for _, form := range spec.TicketForms {
+		wantedForms[form.Name] = true
+
+		existing, ok := currentForms[form.Name]
+		if !ok {
+			plan.Changes = append(plan.Changes, ProvisioningChange{Action: ProvisioningCreate, Resource: ProvisioningTicketForm, Name: form.Name})
+		} else if !reflect.DeepEqual(normalizeTicketForm(form, specFieldTitles), normalizeTicketForm(existing, currentFieldTitles)) {
+			plan.Changes = append(plan.Changes, ProvisioningChange{Action: ProvisioningUpdate, Resource: ProvisioningTicketForm, Name: form.Name, ID: existing.ID})
+		}
+	}
+
+	// Triggers
+	currentTriggers := make(map[string]Trigger)
+	for _, trigger := range current.Triggers {
+		currentTriggers[trigger.Title] = trigger
+	}
+	wantedTriggers := make(map[string]bool)
+	for _, trigger := range spec.Triggers {
+		wantedTriggers[trigger.Title] = true
+
+		existing, ok := currentTriggers[trigger.Title]
+		if !ok {
+			plan.Changes = append(plan.Changes, ProvisioningChange{Action: ProvisioningCreate, Resource: ProvisioningTrigger, Name: trigger.Title})
+		} else if !reflect.DeepEqual(normalizeTrigger(trigger), normalizeTrigger(existing)) {
+			plan.Changes = append(plan.Changes, ProvisioningChange{Action: ProvisioningUpdate, Resource: ProvisioningTrigger, Name: trigger.Title, ID: existing.ID})
+		}
+	}
+
+	if !prune {
+		return plan
+	}
+
+	for _, field := range current.TicketFields {
+		if !isSystemFieldType(field.Type) && !wantedFields[field.Title] {
+			plan.Changes = append(plan.Changes, ProvisioningChange{Action: ProvisioningDelete, Resource: ProvisioningTicketField, Name: field.Title, ID: field.ID})
+		}
+	}
+	for _, form := range current.TicketForms {
+		if !wantedForms[form.Name] {
+			plan.Changes = append(plan.Changes, ProvisioningChange{Action: ProvisioningDelete, Resource: ProvisioningTicketForm, Name: form.Name, ID: form.ID})
+		}
+	}
+	for _, trigger := range current.Triggers {
+		if !wantedTriggers[trigger.Title] {
+			plan.Changes = append(plan.Changes, ProvisioningChange{Action: ProvisioningDelete, Resource: ProvisioningTrigger, Name: trigger.Title, ID: trigger.ID})
+		}
+	}
+
+	return plan
+}
+
+// isSystemFieldType reports whether fields of the type are built into Zendesk
+// and thus cannot be created or deleted.
+func isSystemFieldType(t TicketFieldType) bool {
+	switch t {
+	case SubjectType, DescriptionType, StatusType, TicketType, PriorityType, GroupType, AssigneeType:
+		return true
+	}
+	return false
+}
+
+func normalizeTicketField(field TicketField) TicketField {
+	field.ID = 0
+	field.CreatedAt = nil
+	field.UpdatedAt = nil
+	// Removable is read-only: system fields cannot be removed.
+	field.Removable = false
+
+	options := make([]CustomFieldOption, len(field.CustomFieldOptions))
+	for i, option := range field.CustomFieldOptions {
+		option.ID = 0
+		options[i] = option
+	}
+	field.CustomFieldOptions = options
+
+	return field
+}
+
+func normalizeTicketForm(form TicketForm, fieldTitles map[int64]string) interface{} {
+	titles := make([]string, len(form.TicketFieldIDs))
+	for i, id := range form.TicketFieldIDs {
+		titles[i] = fieldTitles[id]
+	}
+
+	form.ID = 0
+	form.URL = ""
+	form.CreatedAt = nil
+	form.UpdatedAt = nil
+	form.TicketFieldIDs = nil
+
+	return struct {
+		Form   TicketForm
+		Fields []string
+	}{form, titles}
+}
+
+func normalizeTrigger(trigger Trigger) Trigger {
+	trigger.ID = 0
+	trigger.URL = ""
+	trigger.CreatedAt = nil
+	trigger.UpdatedAt = nil
+	return trigger
+}
+
+func findSpecField(spec *ProvisioningSpec, title string) *TicketField {
+	for i := range spec.TicketFields {
+		if spec.TicketFields[i].Title == title {
+			return &spec.TicketFields[i]
+		}
+	}
+	return nil
+}
+
+func findSpecForm(spec *ProvisioningSpec, name string) *TicketForm {
+	for i := range spec.TicketForms {
+		if spec.TicketForms[i].Name == name {
+			return &spec.TicketForms[i]
+		}
+	}
+	return nil
+}
+
+func findSpecTrigger(spec *ProvisioningSpec, title string) *Trigger {
+	for i := range spec.Triggers {
+		if spec.Triggers[i].Title == title {
+			return &spec.Triggers[i]
+		}
+	}
+	return nil
+}
+
+// listTriggers lists all the triggers of the account, following the pages.
+//
+// Zendesk Core API docs: https://developer.zendesk.com/api-reference/ticketing/business-rules/triggers/#list-triggers
+func (c *client) listTriggers() ([]Trigger, error) {
+	result := make([]Trigger, 0)
+	err := c.forEachPage("/api/v2/triggers.json", func(page *APIPayload) error {
+		result = append(result, page.Triggers...)
+		return nil
+	})
+	if err != nil {
+		return result, partialResult(err, result)
+	}
+
+	return result, nil
+}
+
+func (c *client) createTrigger(trigger *Trigger) (*Trigger, error) {
+	in := &APIPayload{Trigger: trigger}
+	out := new(APIPayload)
+	err := c.post("/api/v2/triggers.json", in, out)
+	return out.Trigger, err
+}
+
+func (c *client) updateTrigger(id int64, trigger *Trigger) (*Trigger, error) {
+	in := &APIPayload{Trigger: trigger}
+	out := new(APIPayload)
+	err := c.put(fmt.Sprintf("/api/v2/triggers/%d.json", id), in, out)
+	return out.Trigger, err
+}
+
+func (c *client) deleteTrigger(id int64) error {
+	return c.delete(fmt.Sprintf("/api/v2/triggers/%d.json", id), nil)
+}
@@ -0,0 +1,169 @@
+package zendesk
+
+import (
+	"fmt"
+	"time"
+)
+
+// RoutingAttribute is a skill type of the skills-based routing, such as a language or a
+// product, whose values are assigned to tickets and agents.
+//
+// Zendesk Core API docs: https://developer.zendesk.com/api-reference/ticketing/ticket-management/skill_based_routing/
+type RoutingAttribute struct {
+	ID        string                  `json:"id,omitempty"`
+	URL       string                  `json:"url,omitempty"`
+	Name      string                  `json:"name,omitempty"`
+	Values    []RoutingAttributeValue `json:"values,omitempty"`
+	CreatedAt *time.Time              `json:"created_at,omitempty"`
+	UpdatedAt *time.Time              `json:"updated_at,omitempty"`
+}
+
+// RoutingAttributeValue is a skill, such as "Spanish" for a language attribute.
+type RoutingAttributeValue struct {
+	ID        string     `json:"id,omitempty"`
+	URL       string     `json:"url,omitempty"`
+	Name      string     `json:"name,omitempty"`
+	CreatedAt *time.Time `json:"created_at,omitempty"`
+	UpdatedAt *time.Time `json:"updated_at,omitempty"`
+}
+
+type routingAttributePayload struct {
+	Attribute  *RoutingAttribute  `json:"attribute,omitempty"`
+	Attributes []RoutingAttribute `json:"attributes,omitempty"`
+}
+
+type routingAttributeValuePayload struct {
+	AttributeValue    *RoutingAttributeValue  `json:"attribute_value,omitempty"`
+	AttributeValues   []RoutingAttributeValue `json:"attribute_values,omitempty"`
+	AttributeValueIDs []string                `json:"attribute_value_ids,omitempty"`
+}
+
+// ListRoutingAttributes lists the routing attributes of the account.
+//
+// Zendesk Core API docs: https://developer.zendesk.com/api-reference/ticketing/ticket-management/skill_based_routing/#list-account-attributes
+func (c *client) ListRoutingAttributes() ([]RoutingAttribute, error) {
+	out := new(routingAttributePayload)
+	err := c.get("/api/v2/routing/attributes.json", out)
+	return out.Attributes, err
+}
+
+// ShowRoutingAttribute fetches a routing attribute by its ID.
+//
+// Zendesk Core API docs: https://developer.zendesk.com/api-reference/ticketing/ticket-management/skill_based_routing/#show-attribute
+func (c *client) ShowRoutingAttribute(id string) (*RoutingAttribute, error) {
+	out := new(routingAttributePayload)
+	err := c.get(fmt.Sprintf("/api/v2/routing/attributes/%s.json", id), out)
+	return out.Attribute, err
+}
+
+// CreateRoutingAttribute creates a routing attribute. Its values are added with
+// CreateRoutingAttributeValue.
+//
+// Zendesk Core API docs: https://developer.zendesk.com/api-reference/ticketing/ticket-management/skill_based_routing/#create-attribute
+func (c *client) CreateRoutingAttribute(attribute *RoutingAttribute) (*RoutingAttribute, error) {
+	in := &routingAttributePayload{Attribute: attribute}
+	out := new(routingAttributePayload)
+	err := c.post("/api/v2/routing/attributes.json", in, out)
+	return out.Attribute, err
+}
+
+// UpdateRoutingAttribute renames a routing attribute.
+//
+// Zendesk Core API docs: https://developer.zendesk.com/api-reference/ticketing/ticket-management/skill_based_routing/#update-attribute
+func (c *client) UpdateRoutingAttribute(id string, attribute *RoutingAttribute) (*RoutingAttribute, error) {
+	in := &routingAttributePayload{Attribute: attribute}
+	out := new(routingAttributePayload)
+	err := c.put(fmt.Sprintf("/api/v2/routing/attributes/%s.json", id), in, out)
+	return out.Attribute, err
+}
+
+// DeleteRoutingAttribute deletes a routing attribute and its values.
+//
+// Zendesk Core API docs: https://developer.zendesk.com/api-reference/ticketing/ticket-management/skill_based_routing/#delete-attribute
+func (c *client) DeleteRoutingAttribute(id string) error {
+	return c.delete(fmt.Sprintf("/api/v2/routing/attributes/%s.json", id), nil)
+}
+
+// ListRoutingAttributeValues lists the values of a routing attribute.
+//
+// Zendesk Core API docs: https://developer.zendesk.com/api-reference/ticketing/ticket-management/skill_based_routing/#list-attribute-values-for-an-attribute
+func (c *client) ListRoutingAttributeValues(attributeID string) ([]RoutingAttributeValue, error) {
+	out := new(routingAttributeValuePayload)
+	err := c.get(fmt.Sprintf("/api/v2/routing/attributes/%s/values.json", attributeID), out)
+	return out.AttributeValues, err
+}
+
+// ShowRoutingAttributeValue fetches a value of a routing attribute by its ID.
+//
+// Zendesk Core API docs: https://developer.zendesk.com/api-reference/ticketing/ticket-management/skill_based_routing/#show-attribute-value
+func (c *client) ShowRoutingAttributeValue(attributeID, id string) (*RoutingAttributeValue, error) {
+	out := new(routingAttributeValuePayload)
+	err := c.get(fmt.Sprintf("/api/v2/routing/attributes/%s/values/%s.json", attributeID, id), out)
+	return out.AttributeValue, err
+}
+
+// CreateRoutingAttributeValue adds a value to a routing attribute.
+//
+// Zendesk Core API docs: https://developer.zendesk.com/api-reference/ticketing/ticket-management/skill_based_routing/#create-attribute-value
+func (c *client) CreateRoutingAttributeValue(attributeID string, value *RoutingAttributeValue) (*RoutingAttributeValue, error) {
+	in := &routingAttributeValuePayload{AttributeValue: value}
+	out := new(routingAttributeValuePayload)
+	err := c.post(fmt.Sprintf("/api/v2/routing/attributes/%s/values.json", attributeID), in, out)
+	return out.AttributeValue, err
+}
+
+// UpdateRoutingAttributeValue renames a value of a routing attribute.
+//
+// Zendesk Core API docs: https://developer.zendesk.com/api-reference/ticketing/ticket-management/skill_based_routing/#update-attribute-value
+func (c *client) UpdateRoutingAttributeValue(attributeID, id string, value *RoutingAttributeValue) (*RoutingAttributeValue, error) {
+	in := &routingAttributeValuePayload{AttributeValue: value}
+	out := new(routingAttributeValuePayload)
+	err := c.put(fmt.Sprintf("/api/v2/routing/attributes/%s/values/%s.json", attributeID, id), in, out)
+	return out.AttributeValue, err
+}
+
+// DeleteRoutingAttributeValue deletes a value of a routing attribute, removing it from
+// the tickets and agents it is assigned to.
+//
+// Zendesk Core API docs: https://developer.zendesk.com/api-reference/ticketing/ticket-management/skill_based_routing/#delete-attribute-value
+func (c *client) DeleteRoutingAttributeValue(attributeID, id string) error {
+	return c.delete(fmt.Sprintf("/api/v2/routing/attributes/%s/values/%s.json", attributeID, id), nil)
+}
+
+// ListTicketAttributes lists the routing attribute values assigned to a ticket.
+//
+// Zendesk Core API docs: https://developer.zendesk.com/api-reference/ticketing/ticket-management/skill_based_routing/#list-tickets-attribute-values
+func (c *client) ListTicketAttributes(ticketID int64) ([]RoutingAttributeValue, error) {
+	out := new(routingAttributeValuePayload)
+	err := c.get(fmt.Sprintf("/api/v2/routing/tickets/%d/instance_values.json", ticketID), out)
+	return out.AttributeValues, err
+}
+
+// SetTicketAttributes replaces the routing attribute values of a ticket with the given ones.
+//
+// Zendesk Core API docs: https://developer.zendesk.com/api-reference/ticketing/ticket-management/skill_based_routing/#set-tickets-attribute-values
+func (c *client) SetTicketAttributes(ticketID int64, valueIDs []string) ([]RoutingAttributeValue, error) {
+	in := &routingAttributeValuePayload{AttributeValueIDs: valueIDs}
+	out := new(routingAttributeValuePayload)
+	err := c.post(fmt.Sprintf("/api/v2/routing/tickets/%d/instance_values.json", ticketID), in, out)
+	return out.AttributeValues, err
+}
+
+// ListAgentAttributes lists the routing attribute values, that is the skills, of an agent.
+//
+// Zendesk Core API docs: https://developer.zendesk.com/api-reference/ticketing/ticket-management/skill_based_routing/#list-agent-attribute-values
+func (c *client) ListAgentAttributes(userID int64) ([]RoutingAttributeValue, error) {
+	out := new(routingAttributeValuePayload)
+	err := c.get(fmt.Sprintf("/api/v2/routing/agents/%d/instance_values.json", userID), out)
+	return out.AttributeValues, err
+}
+
+// SetAgentAttributes replaces the skills of an agent with the given routing attribute values.
+//
+// Zendesk Core API docs: https://developer.zendesk.com/api-reference/ticketing/ticket-management/skill_based_routing/#set-agent-attribute-values
+func (c *client) SetAgentAttributes(userID int64, valueIDs []string) ([]RoutingAttributeValue, error) {
+	in := &routingAttributeValuePayload{AttributeValueIDs: valueIDs}
+	out := new(routingAttributeValuePayload)
+	err := c.post(fmt.Sprintf("/api/v2/routing/agents/%d/instance_values.json", userID), in, out)
+	return out.AttributeValues, err
+}
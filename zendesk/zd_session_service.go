@@ -0,0 +1,61 @@
+package zendesk
+
+import (
+	"fmt"
+	"time"
+)
+
+// Session is a signed in session of a user.
+//
+// Zendesk Core API docs: https://developer.zendesk.com/api-reference/ticketing/account-configuration/sessions/
+type Session struct {
+	ID              int64      `json:"id,omitempty"`
+	UserID          int64      `json:"user_id,omitempty"`
+	URL             string     `json:"url,omitempty"`
+	AuthenticatedAt *time.Time `json:"authenticated_at,omitempty"`
+	LastSeenAt      *time.Time `json:"last_seen_at,omitempty"`
+}
+
+// ListSessions lists the sessions of a user, following the pages until the last one.
+//
+// Zendesk Core API docs: https://developer.zendesk.com/api-reference/ticketing/account-configuration/sessions/#list-sessions
+func (c *client) ListSessions(userID int64) ([]Session, error) {
+	result := make([]Session, 0)
+	endpoint := fmt.Sprintf("/api/v2/users/%d/sessions.json", userID)
+	type page struct {
+		Sessions []Session `json:"sessions"`
+		NextPage string    `json:"next_page"`
+	}
+	err := c.forEachPageOf(endpoint, func() interface{} { return new(page) }, func(p interface{}) error {
+		result = append(result, p.(*page).Sessions...)
+		return nil
+	})
+	return result, partialResult(err, result)
+}
+
+// DeleteSession signs the user out of one session.
+//
+// Zendesk Core API docs: https://developer.zendesk.com/api-reference/ticketing/account-configuration/sessions/#delete-session
+func (c *client) DeleteSession(userID, sessionID int64) error {
+	return c.delete(fmt.Sprintf("/api/v2/users/%d/sessions/%d.json", userID, sessionID), nil)
+}
+
+// BulkDeleteSessionsByUserID signs the user out of all their sessions, for instance when
+// offboarding them.
+//
+// Zendesk Core API docs: https://developer.zendesk.com/api-reference/ticketing/account-configuration/sessions/#bulk-delete-sessions
+func (c *client) BulkDeleteSessionsByUserID(userID int64) error {
+	return c.delete(fmt.Sprintf("/api/v2/users/%d/sessions.json", userID), nil)
+}
+
+// ShowCurrentlyAuthenticatedSession fetches the session of the authenticated user. Requests
+// authenticated with an API token or an OAuth token have no session and get ErrNotFound.
+//
+// Zendesk Core API docs: https://developer.zendesk.com/api-reference/ticketing/account-configuration/sessions/#show-the-currently-authenticated-session
+func (c *client) ShowCurrentlyAuthenticatedSession() (*Session, error) {
+	out := struct {
+		Session *Session `json:"session"`
+	}{}
+	err := c.get("/api/v2/users/me/session.json", &out)
+	return out.Session, err
+}
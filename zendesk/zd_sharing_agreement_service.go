@@ -0,0 +1,79 @@
+package zendesk
+
+import (
+	"fmt"
+	"time"
+)
+
+// SharingAgreement allows tickets to be shared with another Zendesk account, or with a
+// partner such as Jira.
+//
+// Zendesk Core API docs: https://developer.zendesk.com/api-reference/ticketing/tickets/sharing_agreements/
+type SharingAgreement struct {
+	ID              int64      `json:"id,omitempty"`
+	URL             string     `json:"url,omitempty"`
+	Name            string     `json:"name,omitempty"`
+	Type            string     `json:"type,omitempty"`
+	Status          string     `json:"status,omitempty"`
+	PartnerName     string     `json:"partner_name,omitempty"`
+	RemoteSubdomain string     `json:"remote_subdomain,omitempty"`
+	CreatedAt       *time.Time `json:"created_at,omitempty"`
+	UpdatedAt       *time.Time `json:"updated_at,omitempty"`
+}
+
+// Types and statuses of a sharing agreement.
+const (
+	SharingAgreementInbound  = "inbound"
+	SharingAgreementOutbound = "outbound"
+
+	SharingAgreementPending  = "pending"
+	SharingAgreementAccepted = "accepted"
+	SharingAgreementDeclined = "declined"
+	SharingAgreementInactive = "inactive"
+)
+
+// ListSharingAgreements lists the sharing agreements of the account.
+//
+// Zendesk Core API docs: https://developer.zendesk.com/api-reference/ticketing/tickets/sharing_agreements/#list-sharing-agreements
+func (c *client) ListSharingAgreements() ([]SharingAgreement, error) {
+	out := new(APIPayload)
+	err := c.get("/api/v2/sharing_agreements.json", out)
+	return out.SharingAgreements, err
+}
+
+// ShowSharingAgreement fetches a sharing agreement by its ID.
+//
+// Zendesk Core API docs: https://developer.zendesk.com/api-reference/ticketing/tickets/sharing_agreements/#show-a-sharing-agreement
+func (c *client) ShowSharingAgreement(id int64) (*SharingAgreement, error) {
+	out := new(APIPayload)
+	err := c.get(fmt.Sprintf("/api/v2/sharing_agreements/%d.json", id), out)
+	return out.SharingAgreement, err
+}
+
+// CreateSharingAgreement invites the account of RemoteSubdomain to share tickets. The
+// agreement is pending until the other account accepts it.
+//
+// Zendesk Core API docs: https://developer.zendesk.com/api-reference/ticketing/tickets/sharing_agreements/#create-sharing-agreement
+func (c *client) CreateSharingAgreement(agreement *SharingAgreement) (*SharingAgreement, error) {
+	in := &APIPayload{SharingAgreement: agreement}
+	out := new(APIPayload)
+	err := c.post("/api/v2/sharing_agreements.json", in, out)
+	return out.SharingAgreement, err
+}
+
+// UpdateSharingAgreement updates a sharing agreement, of which only the status can change.
+//
+// Zendesk Core API docs: https://developer.zendesk.com/api-reference/ticketing/tickets/sharing_agreements/#update-a-sharing-agreement
+func (c *client) UpdateSharingAgreement(id int64, agreement *SharingAgreement) (*SharingAgreement, error) {
+	in := &APIPayload{SharingAgreement: agreement}
+	out := new(APIPayload)
+	err := c.put(fmt.Sprintf("/api/v2/sharing_agreements/%d.json", id), in, out)
+	return out.SharingAgreement, err
+}
+
+// DeleteSharingAgreement deletes a sharing agreement.
+//
+// Zendesk Core API docs: https://developer.zendesk.com/api-reference/ticketing/tickets/sharing_agreements/#delete-a-sharing-agreement
+func (c *client) DeleteSharingAgreement(id int64) error {
+	return c.delete(fmt.Sprintf("/api/v2/sharing_agreements/%d.json", id), nil)
+}
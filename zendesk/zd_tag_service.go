@@ -0,0 +1,40 @@
+package zendesk
+
+import (
+	"net/url"
+)
+
+// Tag is a tag used in the account, with the number of records it is applied to.
+type Tag struct {
+	Name  string `json:"name"`
+	Count int64  `json:"count"`
+}
+
+// ListTags lists the most popular tags of the account, following the pages until the
+// last one.
+//
+// Zendesk Core API docs: https://developer.zendesk.com/api-reference/ticketing/ticket-management/tags/#list-tags
+func (c *client) ListTags() ([]Tag, error) {
+	result := make([]Tag, 0)
+	endpoint := "/api/v2/tags.json"
+	// The account tags come with their counts under the key of the tag names of records.
+	type page struct {
+		Tags     []Tag  `json:"tags"`
+		NextPage string `json:"next_page"`
+	}
+	err := c.forEachPageOf(endpoint, func() interface{} { return new(page) }, func(p interface{}) error {
+		result = append(result, p.(*page).Tags...)
+		return nil
+	})
+	return result, partialResult(err, result)
+}
+
+// AutocompleteTags returns the tags of the account starting with the prefix, which needs
+// at least 2 characters.
+//
+// Zendesk Core API docs: https://developer.zendesk.com/api-reference/ticketing/ticket-management/tags/#search-tags
+func (c *client) AutocompleteTags(prefix string) ([]string, error) {
+	out := new(APIPayload)
+	err := c.get("/api/v2/autocomplete/tags.json?name="+url.QueryEscape(prefix), out)
+	return out.Tags, err
+}
@@ -0,0 +1,197 @@
+package zendesk
+
+import (
+	"fmt"
+	"time"
+)
+
+// Via IDs of the tickets created by Talk.
+const (
+	ViaVoicemail    = 45
+	ViaInboundCall  = 46
+	ViaOutboundCall = 47
+)
+
+// CallbackRequest asks Talk to call a customer back from one of the phone numbers of the account.
+//
+// Zendesk Talk Partner Edition API docs: https://developer.zendesk.com/api-reference/voice/talk-partner-edition-api/reference/#create-callback-request
+type CallbackRequest struct {
+	PhoneNumberID        int64   `json:"phone_number_id"`
+	RequesterPhoneNumber string  `json:"requester_phone_number"`
+	GroupIDs             []int64 `json:"group_ids,omitempty"`
+}
+
+// VoiceComment describes the call or voicemail of a voice ticket.
+type VoiceComment struct {
+	From              string     `json:"from,omitempty"`
+	To                string     `json:"to,omitempty"`
+	RecordingURL      string     `json:"recording_url,omitempty"`
+	StartedAt         *time.Time `json:"started_at,omitempty"`
+	CallDuration      int64      `json:"call_duration,omitempty"`
+	AnsweredByID      int64      `json:"answered_by_id,omitempty"`
+	TranscriptionText string     `json:"transcription_text,omitempty"`
+	Location          string     `json:"location,omitempty"`
+}
+
+// VoiceTicket is a ticket created for a call or a voicemail handled outside of Talk, such as by a CTI integration.
+type VoiceTicket struct {
+	Ticket
+	// ViaID is one of ViaVoicemail, ViaInboundCall or ViaOutboundCall.
+	ViaID        int           `json:"via_id"`
+	VoiceComment *VoiceComment `json:"voice_comment,omitempty"`
+}
+
+// CreateTalkCallbackRequest requests a callback to a customer.
+//
+// Zendesk Talk Partner Edition API docs: https://developer.zendesk.com/api-reference/voice/talk-partner-edition-api/reference/#create-callback-request
+func (c *client) CreateTalkCallbackRequest(request *CallbackRequest) error {
+	in := struct {
+		CallbackRequest *CallbackRequest `json:"callback_request"`
+	}{CallbackRequest: request}
+	return c.post("/api/v2/channels/voice/callback_requests.json", in, nil)
+}
+
+// CreateVoiceTicket creates a ticket for a call or a voicemail. When displayToAgentID is
+// not zero, the ticket is opened in the browser of that agent.
+//
+// Zendesk Talk Partner Edition API docs: https://developer.zendesk.com/api-reference/voice/talk-partner-edition-api/reference/#creating-tickets
+func (c *client) CreateVoiceTicket(ticket *VoiceTicket, displayToAgentID int64) (*Ticket, error) {
+	in := struct {
+		DisplayToAgent int64        `json:"display_to_agent,omitempty"`
+		Ticket         *VoiceTicket `json:"ticket"`
+	}{DisplayToAgent: displayToAgentID, Ticket: ticket}
+	out := new(APIPayload)
+	err := c.post("/api/v2/channels/voice/tickets.json", in, out)
+	return out.Ticket, err
+}
+
+// DisplayTicketToAgent opens a ticket in the browser of an agent, as a screen-pop for
+// an incoming call. The agent must be signed in to the agent workspace.
+//
+// Zendesk Talk Partner Edition API docs: https://developer.zendesk.com/api-reference/voice/talk-partner-edition-api/reference/#open-a-ticket-in-an-agents-browser
+func (c *client) DisplayTicketToAgent(agentID, ticketID int64) error {
+	return c.post(fmt.Sprintf("/api/v2/channels/voice/agents/%d/tickets/%d/display.json", agentID, ticketID), nil, nil)
+}
+
+// DisplayUserToAgent opens the profile of a user in the browser of an agent.
+//
+// Zendesk Talk Partner Edition API docs: https://developer.zendesk.com/api-reference/voice/talk-partner-edition-api/reference/#open-a-users-profile-in-an-agents-browser
+func (c *client) DisplayUserToAgent(agentID, userID int64) error {
+	return c.post(fmt.Sprintf("/api/v2/channels/voice/agents/%d/users/%d/display.json", agentID, userID), nil, nil)
+}
+
+// PhoneNumber is a phone number of the account, used to receive and place calls.
+//
+// Zendesk Talk API docs: https://developer.zendesk.com/api-reference/voice/talk-api/phone_numbers/
+type PhoneNumber struct {
+	ID                 int64                    `json:"id,omitempty"`
+	Number             string                   `json:"number,omitempty"`
+	DisplayNumber      string                   `json:"display_number,omitempty"`
+	Name               string                   `json:"name,omitempty"`
+	Nickname           string                   `json:"nickname,omitempty"`
+	CountryCode        string                   `json:"country_code,omitempty"`
+	Location           string                   `json:"location,omitempty"`
+	TollFree           bool                     `json:"toll_free,omitempty"`
+	External           bool                     `json:"external,omitempty"`
+	Recorded           bool                     `json:"recorded,omitempty"`
+	Transcription      bool                     `json:"transcription,omitempty"`
+	SMSEnabled         bool                     `json:"sms_enabled,omitempty"`
+	Capabilities       *PhoneNumberCapabilities `json:"capabilities,omitempty"`
+	GroupIDs           []int64                  `json:"group_ids,omitempty"`
+	GreetingIDs        []int64                  `json:"greeting_ids,omitempty"`
+	DefaultGreetingIDs []string                 `json:"default_greeting_ids,omitempty"`
+	CreatedAt          *time.Time               `json:"created_at,omitempty"`
+}
+
+// PhoneNumberCapabilities tells which kinds of communication a phone number supports.
+type PhoneNumberCapabilities struct {
+	SMS   bool `json:"sms"`
+	MMS   bool `json:"mms"`
+	Voice bool `json:"voice"`
+}
+
+// Line is a phone number or a digital line of the account.
+//
+// Zendesk Talk API docs: https://developer.zendesk.com/api-reference/voice/talk-api/lines/
+type Line struct {
+	ID        int64      `json:"id,omitempty"`
+	Nickname  string     `json:"nickname,omitempty"`
+	LineType  string     `json:"line_type,omitempty"`
+	Number    string     `json:"number,omitempty"`
+	Priority  int64      `json:"priority,omitempty"`
+	GroupIDs  []int64    `json:"group_ids,omitempty"`
+	CreatedAt *time.Time `json:"created_at,omitempty"`
+}
+
+// Greeting is a recorded message played to callers, such as a welcome or a voicemail message.
+//
+// Zendesk Talk API docs: https://developer.zendesk.com/api-reference/voice/talk-api/greetings/
+type Greeting struct {
+	ID             int64   `json:"id,omitempty"`
+	Name           string  `json:"name,omitempty"`
+	CategoryID     int64   `json:"category_id,omitempty"`
+	Active         bool    `json:"active,omitempty"`
+	Default        bool    `json:"default,omitempty"`
+	DefaultLang    bool    `json:"default_lang,omitempty"`
+	AudioName      string  `json:"audio_name,omitempty"`
+	AudioURL       string  `json:"audio_url,omitempty"`
+	PhoneNumberIDs []int64 `json:"phone_number_ids,omitempty"`
+	IVRIDs         []int64 `json:"ivr_ids,omitempty"`
+}
+
+// Availability is the state of an agent in Talk.
+//
+// Zendesk Talk API docs: https://developer.zendesk.com/api-reference/voice/talk-api/availabilities/
+type Availability struct {
+	// AgentState is one of "online", "offline", "away" or "transfers_only".
+	AgentState string `json:"agent_state,omitempty"`
+	// CallStatus is "on_call" or "wrap_up" while the agent handles a call, empty otherwise.
+	CallStatus   string   `json:"call_status,omitempty"`
+	Via          string   `json:"via,omitempty"`
+	AvailableVia []string `json:"available_via,omitempty"`
+}
+
+// ListPhoneNumbers lists the phone numbers of the account.
+//
+// Zendesk Talk API docs: https://developer.zendesk.com/api-reference/voice/talk-api/phone_numbers/#list-phone-numbers
+func (c *client) ListPhoneNumbers() ([]PhoneNumber, error) {
+	result := make([]PhoneNumber, 0)
+	err := c.forEachPage("/api/v2/channels/voice/phone_numbers.json", func(page *APIPayload) error {
+		result = append(result, page.PhoneNumbers...)
+		return nil
+	})
+	return result, partialResult(err, result)
+}
+
+// ListLines lists the phone numbers and digital lines of the account.
+//
+// Zendesk Talk API docs: https://developer.zendesk.com/api-reference/voice/talk-api/lines/#list-all-lines
+func (c *client) ListLines() ([]Line, error) {
+	result := make([]Line, 0)
+	err := c.forEachPage("/api/v2/channels/voice/lines.json", func(page *APIPayload) error {
+		result = append(result, page.Lines...)
+		return nil
+	})
+	return result, partialResult(err, result)
+}
+
+// ListGreetings lists the greetings of the account.
+//
+// Zendesk Talk API docs: https://developer.zendesk.com/api-reference/voice/talk-api/greetings/#list-greetings
+func (c *client) ListGreetings() ([]Greeting, error) {
+	result := make([]Greeting, 0)
+	err := c.forEachPage("/api/v2/channels/voice/greetings.json", func(page *APIPayload) error {
+		result = append(result, page.Greetings...)
+		return nil
+	})
+	return result, partialResult(err, result)
+}
+
+// ShowAgentAvailability fetches the current Talk availability of an agent.
+//
+// Zendesk Talk API docs: https://developer.zendesk.com/api-reference/voice/talk-api/availabilities/#show-availability
+func (c *client) ShowAgentAvailability(agentID int64) (*Availability, error) {
+	out := new(APIPayload)
+	err := c.get(fmt.Sprintf("/api/v2/channels/voice/availabilities/%d.json", agentID), out)
+	return out.Availability, err
+}
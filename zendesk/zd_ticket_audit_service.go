@@ -0,0 +1,230 @@
+package zendesk
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// TicketAudit represents an update of a ticket, as the list of events it caused.
+//
+// Zendesk Core API docs: https://developer.zendesk.com/api-reference/ticketing/tickets/ticket_audits/
+type TicketAudit struct {
+	ID        int64                  `json:"id,omitempty"`
+	TicketID  int64                  `json:"ticket_id,omitempty"`
+	AuthorID  int64                  `json:"author_id,omitempty"`
+	Via       *Via                   `json:"via,omitempty"`
+	Metadata  map[string]interface{} `json:"metadata,omitempty"`
+	Events    AuditEvents            `json:"events,omitempty"`
+	CreatedAt *time.Time             `json:"created_at,omitempty"`
+}
+
+// Audit event types.
+const (
+	AuditEventCreate             = "Create"
+	AuditEventChange             = "Change"
+	AuditEventComment            = "Comment"
+	AuditEventVoiceComment       = "VoiceComment"
+	AuditEventSatisfactionRating = "SatisfactionRating"
+	AuditEventNotification       = "Notification"
+	AuditEventExternal           = "External"
+)
+
+// AuditEvent is an event of a ticket audit. Its concrete type depends on the event type:
+// *CreateEvent, *ChangeEvent, *CommentEvent, *VoiceCommentEvent, *SatisfactionRatingEvent,
+// *NotificationEvent, *ExternalEvent, or *UnknownAuditEvent for the other types.
+type AuditEvent interface {
+	EventType() string
+}
+
+// CreateEvent records the value of a field when the ticket was created.
+// Value holds a string, a list of strings for tags, or nil.
+type CreateEvent struct {
+	ID        int64       `json:"id"`
+	Type      string      `json:"type"`
+	FieldName string      `json:"field_name"`
+	Value     interface{} `json:"value"`
+}
+
+// ChangeEvent records the change of a field. Value and PreviousValue hold a string,
+// a list of strings for tags, or nil.
+type ChangeEvent struct {
+	ID            int64       `json:"id"`
+	Type          string      `json:"type"`
+	FieldName     string      `json:"field_name"`
+	Value         interface{} `json:"value"`
+	PreviousValue interface{} `json:"previous_value"`
+}
+
+// CommentEvent records a comment added to the ticket.
+type CommentEvent struct {
+	ID          int64        `json:"id"`
+	Type        string       `json:"type"`
+	AuthorID    int64        `json:"author_id"`
+	Body        string       `json:"body"`
+	HTMLBody    string       `json:"html_body"`
+	PlainBody   string       `json:"plain_body"`
+	Public      bool         `json:"public"`
+	Attachments []Attachment `json:"attachments,omitempty"`
+	AuditID     int64        `json:"audit_id"`
+}
+
+// VoiceCommentData describes the call a voice comment was made from.
+type VoiceCommentData struct {
+	From              string     `json:"from"`
+	To                string     `json:"to"`
+	RecordingURL      string     `json:"recording_url"`
+	CallID            int64      `json:"call_id"`
+	CallDuration      int64      `json:"call_duration"`
+	AnsweredByID      int64      `json:"answered_by_id"`
+	TranscriptionText string     `json:"transcription_text"`
+	StartedAt         *time.Time `json:"started_at"`
+	Location          string     `json:"location"`
+}
+
+// VoiceCommentEvent records a comment created from a call or a voicemail.
+type VoiceCommentEvent struct {
+	ID                   int64             `json:"id"`
+	Type                 string            `json:"type"`
+	AuthorID             int64             `json:"author_id"`
+	Body                 string            `json:"body"`
+	HTMLBody             string            `json:"html_body"`
+	Public               bool              `json:"public"`
+	Data                 *VoiceCommentData `json:"data"`
+	FormattedFrom        string            `json:"formatted_from"`
+	FormattedTo          string            `json:"formatted_to"`
+	TranscriptionVisible bool              `json:"transcription_visible"`
+}
+
+// SatisfactionRatingEvent records the satisfaction rating of the ticket.
+type SatisfactionRatingEvent struct {
+	ID         int64  `json:"id"`
+	Type       string `json:"type"`
+	Score      string `json:"score"`
+	AssigneeID int64  `json:"assignee_id"`
+	Body       string `json:"body"`
+}
+
+// NotificationEvent records a notification sent by a trigger or an automation.
+type NotificationEvent struct {
+	ID         int64   `json:"id"`
+	Type       string  `json:"type"`
+	Subject    string  `json:"subject"`
+	Body       string  `json:"body"`
+	Recipients []int64 `json:"recipients"`
+	Via        *Via    `json:"via,omitempty"`
+}
+
+// ExternalEvent records a request sent to a target or a webhook.
+type ExternalEvent struct {
+	ID       int64  `json:"id"`
+	Type     string `json:"type"`
+	Resource string `json:"resource"`
+	Body     string `json:"body"`
+	Success  string `json:"success"`
+}
+
+// UnknownAuditEvent holds an event of a type without a concrete Go type.
+type UnknownAuditEvent struct {
+	Type string
+	Raw  json.RawMessage
+}
+
+func (e *CreateEvent) EventType() string             { return e.Type }
+func (e *ChangeEvent) EventType() string             { return e.Type }
+func (e *CommentEvent) EventType() string            { return e.Type }
+func (e *VoiceCommentEvent) EventType() string       { return e.Type }
+func (e *SatisfactionRatingEvent) EventType() string { return e.Type }
+func (e *NotificationEvent) EventType() string       { return e.Type }
+func (e *ExternalEvent) EventType() string           { return e.Type }
+func (e *UnknownAuditEvent) EventType() string       { return e.Type }
+
+// MarshalJSON returns the raw event.
+func (e *UnknownAuditEvent) MarshalJSON() ([]byte, error) {
+	return e.Raw, nil
+}
+
+// AuditEvents is the list of events of an audit, decoded to their concrete types.
+type AuditEvents []AuditEvent
+
+// UnmarshalJSON decodes each event according to its type.
+func (events *AuditEvents) UnmarshalJSON(data []byte) error {
+	raws := make([]json.RawMessage, 0)
+	if err := json.Unmarshal(data, &raws); err != nil {
+		return err
+	}
+
+	result := make(AuditEvents, 0, len(raws))
+	for _, raw := range raws {
+		event, err := decodeAuditEvent(raw)
+		if err != nil {
+			return err
+		}
+		result = append(result, event)
+	}
+
+	*events = result
+	return nil
+}
+
+func decodeAuditEvent(raw json.RawMessage) (AuditEvent, error) {
+	head := struct {
+		Type string `json:"type"`
+	}{}
+	if err := json.Unmarshal(raw, &head); err != nil {
+		return nil, err
+	}
+
+	var event AuditEvent
+	switch head.Type {
+	case AuditEventCreate:
+		event = new(CreateEvent)
+	case AuditEventChange:
+		event = new(ChangeEvent)
+	case AuditEventComment:
+		event = new(CommentEvent)
+	case AuditEventVoiceComment:
+		event = new(VoiceCommentEvent)
+	case AuditEventSatisfactionRating:
+		event = new(SatisfactionRatingEvent)
+	case AuditEventNotification:
+		event = new(NotificationEvent)
+	case AuditEventExternal:
+		event = new(ExternalEvent)
+	default:
+		return &UnknownAuditEvent{Type: head.Type, Raw: raw}, nil
+	}
+
+	if err := json.Unmarshal(raw, event); err != nil {
+		return nil, fmt.Errorf("audit event %s: %w", head.Type, err)
+	}
+	return event, nil
+}
+
+// ListTicketAudits lists the audits of a ticket, oldest first.
+//
+// Zendesk Core API docs: https://developer.zendesk.com/api-reference/ticketing/tickets/ticket_audits/#list-audits-for-a-ticket
+func (c *client) ListTicketAudits(ticketID int64) ([]TicketAudit, error) {
+	result := make([]TicketAudit, 0)
+	endpoint := fmt.Sprintf("/api/v2/tickets/%d/audits.json", ticketID)
+
+	err := c.forEachPage(endpoint, func(page *APIPayload) error {
+		result = append(result, page.Audits...)
+		return nil
+	})
+	if err != nil {
+		return result, partialResult(err, result)
+	}
+
+	c.logger.Printf("[zd_ticket_audit_service][ListTicketAudits] number of records pulled: %v\n", len(result))
+	return result, nil
+}
+
+// ShowTicketAudit fetches an audit of a ticket.
+//
+// Zendesk Core API docs: https://developer.zendesk.com/api-reference/ticketing/tickets/ticket_audits/#show-audit
+func (c *client) ShowTicketAudit(ticketID, auditID int64) (*TicketAudit, error) {
+	out := new(APIPayload)
+	err := c.get(fmt.Sprintf("/api/v2/tickets/%d/audits/%d.json", ticketID, auditID), out)
+	return out.Audit, err
+}
@@ -1,10 +1,10 @@
 package zendesk
 
 import (
-	"bytes"
+	"errors"
 	"fmt"
-	"log"
-	"strconv"
+	"net/url"
+	"sync"
 	"time"
 )
 
@@ -25,6 +25,9 @@ type TicketComment struct {
 	MetaData    interface{}  `json:"metadata,omitempty"`
 	CreatedAt   *time.Time   `json:"created_at,omitempty"`
 	Uploads     []string     `json:"uploads,omitempty"`
+
+	// Author is the author of the comment, set when the comments are listed with a UserCache.
+	Author *User `json:"author,omitempty"`
 }
 
 // Attachment represents a Zendesk attachment for tickets and forum posts.
@@ -73,27 +76,224 @@ type ToObject struct {
 	ToPhone    *string       `json:"phone,omitempty"`
 }
 
+// ListTicketComments lists the comments of a ticket, oldest first, following the pages
+// until the last one.
+//
+// Zendesk Core API docs: https://developer.zendesk.com/api-reference/ticketing/tickets/ticket_comments/#list-comments
 func (c *client) ListTicketComments(id int64) ([]TicketComment, error) {
+	return c.listTicketComments(id, nil)
+}
+
+func (c *client) listTicketComments(id int64, opts *ListTicketCommentsOptions) ([]TicketComment, error) {
+	params := url.Values{}
+	if opts != nil && opts.SortOrder != "" {
+		params.Set("sort_order", opts.SortOrder)
+	}
+	if opts != nil && opts.IncludeInlineImages {
+		params.Set("include_inline_images", "true")
+	}
+	endpoint := fmt.Sprintf("/api/v2/tickets/%d/comments.json", id)
+	if len(params) > 0 {
+		endpoint += "?" + params.Encode()
+	}
+
+	result := make([]TicketComment, 0)
+	err := c.forEachPage(endpoint, func(out *APIPayload) error {
+		result = append(result, out.Comments...)
+		return nil
+	})
+	return result, partialResult(err, result)
+}
+
+// CountTicketComments returns the number of comments of a ticket. Zendesk may return a
+// cached value for tickets with many comments.
+//
+// Zendesk Core API docs: https://developer.zendesk.com/api-reference/ticketing/tickets/ticket_comments/#count-ticket-comments
+func (c *client) CountTicketComments(id int64) (int64, error) {
+	out := struct {
+		Count struct {
+			Value int64 `json:"value"`
+		} `json:"count"`
+	}{}
+	err := c.get(fmt.Sprintf("/api/v2/tickets/%d/comments/count.json", id), &out)
+	return out.Count.Value, err
+}
+
+// AddTicketComment adds a comment to a ticket.
+//
+// Zendesk Core API docs: https://developer.zendesk.com/api-reference/ticketing/tickets/ticket_comments/#creating-ticket-comments
+func (c *client) AddTicketComment(ticketID int64, comment *TicketComment) (*Ticket, error) {
+	in := &APIPayload{Ticket: &Ticket{Comment: comment}}
+	out := new(APIPayload)
+	err := c.put(fmt.Sprintf("/api/v2/tickets/%d.json", ticketID), in, out)
+	return out.Ticket, err
+}
+
+// RedactCommentString permanently removes the given text from a ticket comment.
+//
+// Zendesk Core API docs: https://developer.zendesk.com/api-reference/ticketing/tickets/ticket_comments/#redact-string-in-comment
+func (c *client) RedactCommentString(ticketID, commentID int64, text string) (*TicketComment, error) {
+	in := map[string]string{"text": text}
+	out := new(APIPayload)
+	err := c.put(fmt.Sprintf("/api/v2/tickets/%d/comments/%d/redact.json", ticketID, commentID), in, out)
+	return out.Comment, err
+}
+
+// RedactTicketCommentAttachment permanently replaces an attachment of a ticket comment
+// with an empty file named redacted.txt.
+//
+// Zendesk Core API docs: https://developer.zendesk.com/api-reference/ticketing/tickets/ticket-attachments/#redact-comment-attachment
+func (c *client) RedactTicketCommentAttachment(ticketID, commentID, attachmentID int64) (*Attachment, error) {
+	out := new(APIPayload)
+	err := c.put(fmt.Sprintf("/api/v2/tickets/%d/comments/%d/attachments/%d/redact.json", ticketID, commentID, attachmentID), nil, out)
+	return out.Attachment, err
+}
+
+// CommentRedaction describes what RedactTicketComment removes from a comment.
+type CommentRedaction struct {
+	TicketID int64 `json:"ticket_id"`
+	// HTMLBody is the HTML body of the comment with the text to remove wrapped in
+	// <redact> tags. Inline images wrapped in them are removed too.
+	HTMLBody string `json:"html_body,omitempty"`
+	// ExternalAttachmentURLs lists the content URLs of the attachments to remove.
+	ExternalAttachmentURLs []string `json:"external_attachment_urls,omitempty"`
+}
+
+// RedactTicketComment permanently removes text, inline images and attachments from a
+// ticket comment, including the comments of the agent workspace channels, such as
+// messaging, that RedactCommentString does not support.
+//
+// Zendesk Core API docs: https://developer.zendesk.com/api-reference/ticketing/tickets/ticket_comments/#redact-ticket-comment-in-agent-workspace
+func (c *client) RedactTicketComment(commentID int64, redaction *CommentRedaction) (*TicketComment, error) {
 	out := new(APIPayload)
-	err := c.get(fmt.Sprintf("/api/v2/tickets/%d/comments.json", id), out)
-	return out.Comments, err
+	err := c.put(fmt.Sprintf("/api/v2/comment_redactions/%d.json", commentID), redaction, out)
+	return out.Comment, err
+}
+
+// MakeCommentPrivate makes a public ticket comment private.
+//
+// Zendesk Core API docs: https://developer.zendesk.com/api-reference/ticketing/tickets/ticket_comments/#make-comment-private
+func (c *client) MakeCommentPrivate(ticketID, commentID int64) error {
+	return c.put(fmt.Sprintf("/api/v2/tickets/%d/comments/%d/make_private.json", ticketID, commentID), nil, nil)
+}
+
+// ListTicketCommentsOptions specifies the optional parameters of the comment listing methods.
+type ListTicketCommentsOptions struct {
+	// Authors, if set, resolves the authors of the comments, which are set on the
+	// Author field. The cache can be shared by calls to avoid fetching the same users again.
+	Authors *UserCache
+	// SortOrder is "asc", the default, or "desc" for the newest comments first. It only
+	// applies to ListTicketCommentsWithOptions.
+	SortOrder string
+	// IncludeInlineImages lists the inline images of the comments among their attachments.
+	// It only applies to ListTicketCommentsWithOptions.
+	IncludeInlineImages bool
+}
+
+// ListTicketCommentsWithOptions is like ListTicketComments with options.
+func (c *client) ListTicketCommentsWithOptions(id int64, opts *ListTicketCommentsOptions) ([]TicketComment, error) {
+	comments, err := c.listTicketComments(id, opts)
+	if err != nil {
+		return nil, err
+	}
+	if opts != nil && opts.Authors != nil {
+		if err := opts.Authors.HydrateCommentAuthors(comments); err != nil {
+			return nil, err
+		}
+	}
+	return comments, nil
+}
+
+// GetAllTicketCommentsWithOptions is like GetAllTicketComments with options.
+func (c *client) GetAllTicketCommentsWithOptions(ticketIDs []int64, opts *ListTicketCommentsOptions) (map[int64][]TicketComment, error) {
+	comments, err := c.GetAllTicketComments(ticketIDs)
+	if err != nil {
+		return nil, err
+	}
+	if opts != nil && opts.Authors != nil {
+		all := make([]TicketComment, 0)
+		for _, ticketComments := range comments {
+			all = append(all, ticketComments...)
+		}
+		if _, err := opts.Authors.Users(commentAuthorIDs(all)); err != nil {
+			return nil, err
+		}
+		for _, ticketComments := range comments {
+			if err := opts.Authors.HydrateCommentAuthors(ticketComments); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return comments, nil
+}
+
+// HydrateCommentAuthors sets the Author of the comments.
+func (c *UserCache) HydrateCommentAuthors(comments []TicketComment) error {
+	users, err := c.Users(commentAuthorIDs(comments))
+	if err != nil {
+		return err
+	}
+	for i := range comments {
+		comments[i].Author = users[comments[i].AuthorID]
+	}
+	return nil
+}
+
+func commentAuthorIDs(comments []TicketComment) []int64 {
+	seen := make(map[int64]bool)
+	ids := make([]int64, 0)
+	for _, comment := range comments {
+		if !seen[comment.AuthorID] {
+			seen[comment.AuthorID] = true
+			ids = append(ids, comment.AuthorID)
+		}
+	}
+	return ids
 }
 
 func (c *client) GetAllTicketComments(ticketIDs []int64) (map[int64][]TicketComment, error) {
-	log.Printf("[zd_ticket_comments_service][GetAllTicketComments] Start GetAllTicketComments")
+	c.logger.Printf("[zd_ticket_comments_service][GetAllTicketComments] Start GetAllTicketComments")
 	ticketCommentsMap, err := c.getTicketCommentsOneByOne(nil, ticketIDs)
 	if err != nil {
 		return nil, err
 	}
-	log.Printf("[zd_ticket_comments_service][GetAllTicketComments] number of ticket comments: %v", len(ticketCommentsMap))
-	log.Printf("[zd_ticket_comments_service][GetAllTicketComments] End GetAllTicketComments")
+	c.logger.Printf("[zd_ticket_comments_service][GetAllTicketComments] number of ticket comments: %v", len(ticketCommentsMap))
+	c.logger.Printf("[zd_ticket_comments_service][GetAllTicketComments] End GetAllTicketComments")
 	return ticketCommentsMap, nil
 }
 
+// GetAllTicketCommentsWithHandler fetches the comments of the tickets and passes them to fn,
+// with the ID of their ticket, every ticketBatchSize tickets, so that they do not have to be
+// held in memory. It stops at the first error, returned by fn or not.
+//
+// Zendesk Core API docs: https://developer.zendesk.com/api-reference/ticketing/tickets/ticket_comments/#list-comments
+func (c *client) GetAllTicketCommentsWithHandler(ticketIDs []int64, fn func(int64, []TicketComment) error) error {
+	for start := 0; start < len(ticketIDs); start += ticketBatchSize {
+		end := start + ticketBatchSize
+		if end > len(ticketIDs) {
+			end = len(ticketIDs)
+		}
+
+		comments, err := c.getTicketCommentsOneByOne(nil, ticketIDs[start:end])
+		if err != nil {
+			return err
+		}
+		for _, ticketID := range ticketIDs[start:end] {
+			if batch, ok := comments[ticketID]; ok {
+				if err := fn(ticketID, batch); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
 // getTicketCommentOneByOne return a map with ticket id as the key and
-// an array of ticket comments as its value
+// an array of ticket comments as its value. The tickets are fetched from
+// as many concurrent requests as the client's concurrency.
 func (c *client) getTicketCommentsOneByOne(in interface{}, ticketIDs []int64) (map[int64][]TicketComment, error) {
-	log.Printf("[zd_ticket_comments_service][getAllTicketComments] Start getTicketCommentsOneByOne")
+	c.logger.Printf("[zd_ticket_comments_service][getAllTicketComments] Start getTicketCommentsOneByOne")
 	endpointPrefix := "/api/v2/tickets/"
 	endpointPostfix := "/comments.json"
 
@@ -107,51 +307,50 @@ func (c *client) getTicketCommentsOneByOne(in interface{}, ticketIDs []int64) (m
 	if in != nil {
 		headers["Content-Type"] = "application/json"
 	}
-	record := new(APIPayload)
 
 	numTickets := len(ticketIDs)
 	if numTickets == 0 {
 		return result, nil
 	}
-	log.Printf("[zd_ticket_comments_service][getAllTicketComments] numTickets: %v", numTickets)
+	c.logger.Printf("[zd_ticket_comments_service][getAllTicketComments] numTickets: %v", numTickets)
 
-	endpoint := fmt.Sprintf("%s%v%s", endpointPrefix, ticketIDs[0], endpointPostfix)
-	res, err := c.request("GET", endpoint, headers, bytes.NewReader(payload))
-	if err != nil {
-		return nil, err
-	}
-	defer res.Body.Close()
-
-	var totalWaitTime int64
-	log.Printf("[zd_ticket_comments_service][getAllTicketComments] Start for loop in getTicketCommentsOneByOne")
-	for ticketInd := 1; ticketInd < numTickets; ticketInd++ {
-		// handle page not found
-		if res.StatusCode == 404 {
-			log.Printf("[zd_ticket_comments_service][getAllTicketComments] 404 not found: %s\n", endpoint)
-			// handle too many requests (rate limit)
-		} else if res.StatusCode == 429 {
-			after, err := strconv.ParseInt(res.Header.Get("Retry-After"), 10, 64)
-			log.Printf("[zd_ticket_comments_service][getAllTicketComments] too many requests. Wait for %v seconds\n", after)
-			totalWaitTime += after
-			if err != nil {
-				return nil, err
-			}
-			time.Sleep(time.Duration(after) * time.Second)
-			continue
-		} else {
-			err = unmarshall(res, record)
-			if err != nil {
-				return nil, err
+	var mu sync.Mutex
+	err = c.fanOut(ticketIDs, func(i int, ticketID int64) error {
+		endpoint := fmt.Sprintf("%s%v%s", endpointPrefix, ticketID, endpointPostfix)
+		record := new(APIPayload)
+		found, err := c.getOne(endpoint, headers, payload, record)
+		if err != nil {
+			return &PartialResultError{PageURL: endpoint, Err: err}
+		}
+		if !found {
+			return nil
+		}
+
+		// Tickets with many comments have more than one page.
+		comments := record.Comments
+		for record.NextPage != "" && c.relativeURL(record.NextPage) != endpoint {
+			lastPage := endpoint
+			endpoint = c.relativeURL(record.NextPage)
+			record = new(APIPayload)
+			if _, err := c.getOne(endpoint, headers, payload, record); err != nil {
+				return &PartialResultError{PageURL: endpoint, LastPage: lastPage, Err: err}
 			}
-			result[ticketIDs[ticketInd-1]] = record.Comments
+			comments = append(comments, record.Comments...)
 		}
 
-		record = new(APIPayload)
-		endpoint = fmt.Sprintf("%s%v%s", endpointPrefix, ticketIDs[ticketInd], endpointPostfix)
-		res, _ = c.request("GET", endpoint, headers, bytes.NewReader(payload))
+		mu.Lock()
+		result[ticketID] = comments
+		mu.Unlock()
+		return nil
+	})
+	if err != nil {
+		var partial *PartialResultError
+		if len(result) == 0 && errors.As(err, &partial) {
+			return nil, errors.Unwrap(partial)
+		}
+		return nil, partialResult(err, result)
 	}
 
-	log.Printf("[zd_ticket_comments_service][getAllTicketComments] number of records pulled: %v\n", len(result))
-	log.Printf("[zd_ticket_comments_service][getAllTicketComments] total waiting time due to rate limit: %v\n", totalWaitTime)
+	c.logger.Printf("[zd_ticket_comments_service][getAllTicketComments] number of records pulled: %v\n", len(result))
 	return result, nil
 }
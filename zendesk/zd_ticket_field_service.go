@@ -0,0 +1,428 @@
+package zendesk
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+)
+
+// ShowTicketField fetches a ticket field by its ID.
+//
+// Zendesk Core API docs: https://developer.zendesk.com/api-reference/ticketing/tickets/ticket_fields/#show-ticket-field
+func (c *client) ShowTicketField(id int64) (*TicketField, error) {
+	out := new(APIPayload)
+	err := c.get(fmt.Sprintf("/api/v2/ticket_fields/%d.json", id), out)
+	return out.TicketField, err
+}
+
+// CreateTicketField creates a custom ticket field. It returns an ErrLimitExceeded without
+// creating the field when the account already has as many custom fields as
+// DefaultAccountLimits allows.
+//
+// Zendesk Core API docs: https://developer.zendesk.com/api-reference/ticketing/tickets/ticket_fields/#create-ticket-field
+func (c *client) CreateTicketField(field *TicketField) (*TicketField, error) {
+	if !isSystemFieldType(field.Type) {
+		fields, err := c.CachedTicketFields()
+		if err != nil {
+			return nil, err
+		}
+		usage := &AccountUsage{TicketFields: countCustomFields(fields)}
+		if warnings := CheckLimits(&DefaultAccountLimits, usage, &AccountUsage{TicketFields: 1}); len(warnings) > 0 {
+			return nil, &ErrLimitExceeded{Warnings: warnings}
+		}
+	}
+
+	defer c.InvalidateSchemas()
+	in := &APIPayload{TicketField: field}
+	out := new(APIPayload)
+	err := c.post("/api/v2/ticket_fields.json", in, out)
+	return out.TicketField, err
+}
+
+// UpdateTicketField updates a ticket field. The options of a drop-down field given in the
+// update replace its existing options.
+//
+// Zendesk Core API docs: https://developer.zendesk.com/api-reference/ticketing/tickets/ticket_fields/#update-ticket-field
+func (c *client) UpdateTicketField(id int64, field *TicketField) (*TicketField, error) {
+	defer c.InvalidateSchemas()
+	in := &APIPayload{TicketField: field}
+	out := new(APIPayload)
+	err := c.put(fmt.Sprintf("/api/v2/ticket_fields/%d.json", id), in, out)
+	return out.TicketField, err
+}
+
+// DeleteTicketField deletes a custom ticket field.
+//
+// Zendesk Core API docs: https://developer.zendesk.com/api-reference/ticketing/tickets/ticket_fields/#delete-ticket-field
+func (c *client) DeleteTicketField(id int64) error {
+	defer c.InvalidateSchemas()
+	return c.delete(fmt.Sprintf("/api/v2/ticket_fields/%d.json", id), nil)
+}
+
+// ListTicketFieldOptions lists the options of a drop-down or multi-select ticket field.
+//
+// Zendesk Core API docs: https://developer.zendesk.com/api-reference/ticketing/tickets/ticket_fields/#list-ticket-field-options
+func (c *client) ListTicketFieldOptions(fieldID int64) ([]CustomFieldOption, error) {
+	out := new(APIPayload)
+	err := c.get(fmt.Sprintf("/api/v2/ticket_fields/%d/options.json", fieldID), out)
+	return out.CustomFieldOptions, err
+}
+
+// CreateOrUpdateTicketFieldOption creates an option of a ticket field, or updates the option
+// with the ID of the given option.
+//
+// Zendesk Core API docs: https://developer.zendesk.com/api-reference/ticketing/tickets/ticket_fields/#create-or-update-ticket-field-option
+func (c *client) CreateOrUpdateTicketFieldOption(fieldID int64, option *CustomFieldOption) (*CustomFieldOption, error) {
+	defer c.InvalidateSchemas()
+	in := &APIPayload{CustomFieldOption: option}
+	out := new(APIPayload)
+	err := c.post(fmt.Sprintf("/api/v2/ticket_fields/%d/options.json", fieldID), in, out)
+	return out.CustomFieldOption, err
+}
+
+// DeleteTicketFieldOption deletes an option of a ticket field.
+//
+// Zendesk Core API docs: https://developer.zendesk.com/api-reference/ticketing/tickets/ticket_fields/#delete-ticket-field-option
+func (c *client) DeleteTicketFieldOption(fieldID, optionID int64) error {
+	defer c.InvalidateSchemas()
+	return c.delete(fmt.Sprintf("/api/v2/ticket_fields/%d/options/%d.json", fieldID, optionID), nil)
+}
+
+// CustomFieldsWritableByRole returns the custom field values a user with the given role can
+// set on a ticket. End users can only set the active fields editable in the portal, while
+// agents and admins can set all the active fields. Values of inactive fields or of fields
+// unknown to the account, which Zendesk rejects, are dropped.
+//
+// Only the role is considered: the permissions of custom agent roles, such as light agents
+// or roles not allowed to edit ticket properties, are not checked, so the values kept for an
+// agent may still be rejected.
+func CustomFieldsWritableByRole(fields []TicketField, role string, values []CustomField) []CustomField {
+	byID := make(map[int64]*TicketField, len(fields))
+	for i := range fields {
+		byID[fields[i].ID] = &fields[i]
+	}
+
+	writable := make([]CustomField, 0, len(values))
+	for _, value := range values {
+		field, ok := byID[value.ID]
+		if !ok || !field.Active {
+			continue
+		}
+		if role == "end-user" && (field.EditableInPortal == nil || !*field.EditableInPortal) {
+			continue
+		}
+		writable = append(writable, value)
+	}
+	return writable
+}
+
+// FilterWritableFields returns a copy of the ticket whose custom fields are restricted to
+// those the role of the authenticated user can set, as done by CustomFieldsWritableByRole,
+// so that an update is not rejected for a single field. The permissions of custom agent
+// roles are not checked. The ticket fields and the authenticated user are cached.
+func (c *client) FilterWritableFields(ticket *Ticket) (*Ticket, error) {
+	fields, err := c.CachedTicketFields()
+	if err != nil {
+		return nil, err
+	}
+	value, err := c.caches.currentUser.get(func() (interface{}, error) {
+		return c.ShowCurrentUser()
+	})
+	if err != nil {
+		return nil, err
+	}
+	role := value.(*User).Role
+
+	filtered := *ticket
+	filtered.CustomFields = CustomFieldsWritableByRole(fields, role, ticket.CustomFields)
+	if dropped := len(ticket.CustomFields) - len(filtered.CustomFields); dropped > 0 {
+		c.logger.Printf("[zd_ticket_field_service][FilterWritableFields] dropped %d custom fields not writable by %s\n", dropped, role)
+	}
+	return &filtered, nil
+}
+
+// ValidationError is a custom field value of a ticket that Zendesk would reject, as found
+// by ValidateTicketFields.
+type ValidationError struct {
+	FieldID     int64
+	Title       string
+	Value       interface{}
+	Description string
+}
+
+func (e ValidationError) Error() string {
+	return fmt.Sprintf("zendesk: ticket field %d (%s) %s", e.FieldID, e.Title, e.Description)
+}
+
+// ValidateTicketFields checks the custom field values of a ticket against the ticket
+// fields of the account, so that an invalid ticket fails before it is sent rather than
+// with a 422. Each value must belong to an active field and match its type, its
+// validation regexp or its options, as done by ValidateFieldValues for users. A ticket
+// being solved must also have a value for every required field. Nil values, which clear a
+// field, are valid. It returns the problems found, in the order of the values.
+func ValidateTicketFields(fields []TicketField, t *Ticket) []ValidationError {
+	byID := make(map[int64]*TicketField, len(fields))
+	for i := range fields {
+		byID[fields[i].ID] = &fields[i]
+	}
+
+	problems := make([]ValidationError, 0)
+	for _, value := range t.CustomFields {
+		if value.Value == nil {
+			continue
+		}
+
+		var problem string
+		field, ok := byID[value.ID]
+		switch {
+		case !ok:
+			problem = "is not a field of the account"
+		case !field.Active:
+			problem = "is inactive"
+		default:
+			problem = checkFieldValue(&FieldDefinition{
+				Type:                field.Type,
+				RegexpForValidation: field.RegexpForValidation,
+				CustomFieldOptions:  field.CustomFieldOptions,
+			}, value.Value)
+		}
+		if problem != "" {
+			problems = append(problems, ValidationError{FieldID: value.ID, Title: fieldTitle(field), Value: value.Value, Description: problem})
+		}
+	}
+
+	if t.Status == "solved" || t.Status == "closed" {
+		for i := range fields {
+			field := &fields[i]
+			if !field.Active || !field.Required || !isCustomFieldType(field.Type) {
+				continue
+			}
+			if value, _ := t.GetCustomField(field.ID); isEmptyFieldValue(value) {
+				problems = append(problems, ValidationError{FieldID: field.ID, Title: field.Title, Value: value, Description: "is required to solve the ticket"})
+			}
+		}
+	}
+	return problems
+}
+
+// ValidateTicket checks the custom field values of the ticket with ValidateTicketFields,
+// against the cached ticket fields of the account.
+func (c *client) ValidateTicket(t *Ticket) ([]ValidationError, error) {
+	fields, err := c.CachedTicketFields()
+	if err != nil {
+		return nil, err
+	}
+	return ValidateTicketFields(fields, t), nil
+}
+
+func fieldTitle(field *TicketField) string {
+	if field == nil {
+		return "unknown"
+	}
+	return field.Title
+}
+
+// isCustomFieldType tells whether fields of the type are custom fields, whose values are
+// set in the CustomFields of the tickets, rather than system fields.
+func isCustomFieldType(fieldType TicketFieldType) bool {
+	switch fieldType {
+	case TextType, TextAreaType, CheckBoxType, DateType, IntegerType, DecimalType, RegExpType, TaggerType, MultiSelectType, LookupType:
+		return true
+	}
+	return false
+}
+
+// isEmptyFieldValue tells whether a value leaves a required field unset, a checkbox
+// counting as set only when checked.
+func isEmptyFieldValue(value interface{}) bool {
+	switch v := value.(type) {
+	case nil:
+		return true
+	case string:
+		return v == ""
+	case bool:
+		return !v
+	}
+	if values, ok := fieldStrings(value); ok {
+		return len(values) == 0
+	}
+	return false
+}
+
+// GetCustomField returns the value of the custom field with the given ID, and whether the
+// ticket has a value for the field.
+func (t *Ticket) GetCustomField(id int64) (interface{}, bool) {
+	for _, field := range t.CustomFields {
+		if field.ID == id {
+			return field.Value, field.Value != nil
+		}
+	}
+	return nil, false
+}
+
+// GetCustomFieldString returns the value of a text, multi-line text or regexp custom field.
+// The boolean is false when the field has no value or a value of another type.
+func (t *Ticket) GetCustomFieldString(id int64) (string, bool) {
+	value, _ := t.GetCustomField(id)
+	s, ok := value.(string)
+	return s, ok
+}
+
+// GetCustomFieldBool returns the value of a checkbox custom field.
+// The boolean is false when the field has no value or a value of another type.
+func (t *Ticket) GetCustomFieldBool(id int64) (bool, bool) {
+	value, _ := t.GetCustomField(id)
+	b, ok := value.(bool)
+	return b, ok
+}
+
+// GetCustomFieldTagger returns the tag of the option selected in a drop-down custom field.
+// The boolean is false when no option is selected.
+func (t *Ticket) GetCustomFieldTagger(id int64) (string, bool) {
+	tag, ok := t.GetCustomFieldString(id)
+	return tag, ok && tag != ""
+}
+
+// GetCustomFieldMultiSelect returns the tags of the options selected in a multi-select
+// custom field.
+func (t *Ticket) GetCustomFieldMultiSelect(id int64) ([]string, bool) {
+	value, _ := t.GetCustomField(id)
+	return fieldStrings(value)
+}
+
+// GetCustomFieldInt64 returns the value of an integer custom field, which Zendesk returns
+// as a string.
+func (t *Ticket) GetCustomFieldInt64(id int64) (int64, bool) {
+	value, _ := t.GetCustomField(id)
+	f, ok := fieldNumber(value)
+	if !ok || f != math.Trunc(f) {
+		return 0, false
+	}
+	return int64(f), true
+}
+
+// GetCustomFieldFloat64 returns the value of a decimal custom field, which Zendesk returns
+// as a string.
+func (t *Ticket) GetCustomFieldFloat64(id int64) (float64, bool) {
+	value, _ := t.GetCustomField(id)
+	return fieldNumber(value)
+}
+
+// GetCustomFieldDate returns the value of a date custom field.
+func (t *Ticket) GetCustomFieldDate(id int64) (time.Time, bool) {
+	s, ok := t.GetCustomFieldString(id)
+	if !ok {
+		return time.Time{}, false
+	}
+	date, err := time.Parse(customFieldDateLayout, s)
+	return date, err == nil
+}
+
+// SetCustomField sets the value of the custom field with the given ID, replacing its
+// current value if any. A nil value clears the field when the ticket is updated.
+func (t *Ticket) SetCustomField(id int64, value interface{}) {
+	if date, ok := value.(time.Time); ok {
+		value = date.Format(customFieldDateLayout)
+	}
+	for i := range t.CustomFields {
+		if t.CustomFields[i].ID == id {
+			t.CustomFields[i].Value = value
+			return
+		}
+	}
+	t.CustomFields = append(t.CustomFields, CustomField{ID: id, Value: value})
+}
+
+// customFieldDateLayout is the format of the values of date custom fields.
+const customFieldDateLayout = "2006-01-02"
+
+// CustomFieldDecoder converts the values of ticket custom fields to the Go type matching
+// the type of their field: bool for checkboxes, int64 for integers, float64 for decimals,
+// time.Time for dates, []string for multi-selects and string otherwise.
+type CustomFieldDecoder struct {
+	fields map[int64]*TicketField
+}
+
+// NewCustomFieldDecoder creates a decoder for the given ticket fields, typically those
+// returned by CachedTicketFields.
+func NewCustomFieldDecoder(fields []TicketField) *CustomFieldDecoder {
+	d := &CustomFieldDecoder{fields: make(map[int64]*TicketField, len(fields))}
+	for i := range fields {
+		d.fields[fields[i].ID] = &fields[i]
+	}
+	return d
+}
+
+// Decode converts the value of a custom field. Nil values and the values of fields unknown
+// to the decoder are returned as is.
+func (d *CustomFieldDecoder) Decode(field CustomField) (interface{}, error) {
+	definition, ok := d.fields[field.ID]
+	if !ok || field.Value == nil {
+		return field.Value, nil
+	}
+
+	invalid := func() error {
+		return fmt.Errorf("zendesk: invalid value %v for the %s field %d", field.Value, definition.Type, field.ID)
+	}
+	switch definition.Type {
+	case CheckBoxType:
+		switch v := field.Value.(type) {
+		case bool:
+			return v, nil
+		case string:
+			b, err := strconv.ParseBool(v)
+			if err != nil {
+				return nil, invalid()
+			}
+			return b, nil
+		}
+		return nil, invalid()
+	case IntegerType:
+		f, ok := fieldNumber(field.Value)
+		if !ok || f != math.Trunc(f) {
+			return nil, invalid()
+		}
+		return int64(f), nil
+	case DecimalType:
+		f, ok := fieldNumber(field.Value)
+		if !ok {
+			return nil, invalid()
+		}
+		return f, nil
+	case DateType:
+		s, ok := field.Value.(string)
+		if !ok {
+			return nil, invalid()
+		}
+		date, err := time.Parse(customFieldDateLayout, s)
+		if err != nil {
+			return nil, invalid()
+		}
+		return date, nil
+	case MultiSelectType:
+		values, ok := fieldStrings(field.Value)
+		if !ok {
+			return nil, invalid()
+		}
+		return values, nil
+	default:
+		if s, ok := field.Value.(string); ok {
+			return s, nil
+		}
+		return fmt.Sprint(field.Value), nil
+	}
+}
+
+// DecodeTicket converts the values of the custom fields of a ticket, keyed by field ID.
+func (d *CustomFieldDecoder) DecodeTicket(t *Ticket) (map[int64]interface{}, error) {
+	values := make(map[int64]interface{}, len(t.CustomFields))
+	for _, field := range t.CustomFields {
+		value, err := d.Decode(field)
+		if err != nil {
+			return nil, err
+		}
+		values[field.ID] = value
+	}
+	return values, nil
+}
@@ -0,0 +1,96 @@
+package zendesk
+
+import (
+	"fmt"
+	"time"
+)
+
+// TicketForm is a set of ticket fields offered for a kind of request.
+//
+// Zendesk Core API docs: https://developer.zendesk.com/api-reference/ticketing/tickets/ticket_forms/
+type TicketForm struct {
+	URL                string     `json:"url,omitempty"`
+	ID                 int64      `json:"id,omitempty"`
+	Name               string     `json:"name,omitempty"`
+	RawName            string     `json:"raw_name,omitempty"`
+	DisplayName        string     `json:"display_name,omitempty"`
+	RawDisplayName     string     `json:"raw_display_name,omitempty"`
+	EndUserVisible     *bool      `json:"end_user_visible,omitempty"`
+	Position           int64      `json:"position,omitempty"`
+	TicketFieldIDs     []int64    `json:"ticket_field_ids,omitempty"`
+	Active             *bool      `json:"active,omitempty"`
+	Default            *bool      `json:"default,omitempty"`
+	CreatedAt          *time.Time `json:"created_at,omitempty"`
+	UpdatedAt          *time.Time `json:"updated_at,omitempty"`
+	InAllBrands        *bool      `json:"in_all_brands,omitempty"`
+	RestrictedBrandIDs []int64    `json:"restricted_brand_ids,omitempty"`
+}
+
+// ListTicketForms lists the ticket forms of the account.
+//
+// Zendesk Core API docs: https://developer.zendesk.com/api-reference/ticketing/tickets/ticket_forms/#list-ticket-forms
+func (c *client) ListTicketForms() ([]TicketForm, error) {
+	out := new(APIPayload)
+	err := c.get("/api/v2/ticket_forms.json", out)
+	return out.TicketForms, err
+}
+
+// ShowTicketForm fetches a ticket form by its ID.
+//
+// Zendesk Core API docs: https://developer.zendesk.com/api-reference/ticketing/tickets/ticket_forms/#show-ticket-form
+func (c *client) ShowTicketForm(id int64) (*TicketForm, error) {
+	out := new(APIPayload)
+	err := c.get(fmt.Sprintf("/api/v2/ticket_forms/%d.json", id), out)
+	return out.TicketForm, err
+}
+
+// CreateTicketForm creates a ticket form.
+//
+// Zendesk Core API docs: https://developer.zendesk.com/api-reference/ticketing/tickets/ticket_forms/#create-ticket-form
+func (c *client) CreateTicketForm(form *TicketForm) (*TicketForm, error) {
+	defer c.InvalidateSchemas()
+	in := &APIPayload{TicketForm: form}
+	out := new(APIPayload)
+	err := c.post("/api/v2/ticket_forms.json", in, out)
+	return out.TicketForm, err
+}
+
+// UpdateTicketForm updates a ticket form.
+//
+// Zendesk Core API docs: https://developer.zendesk.com/api-reference/ticketing/tickets/ticket_forms/#update-ticket-form
+func (c *client) UpdateTicketForm(id int64, form *TicketForm) (*TicketForm, error) {
+	defer c.InvalidateSchemas()
+	in := &APIPayload{TicketForm: form}
+	out := new(APIPayload)
+	err := c.put(fmt.Sprintf("/api/v2/ticket_forms/%d.json", id), in, out)
+	return out.TicketForm, err
+}
+
+// DeleteTicketForm deletes a ticket form. The default form cannot be deleted.
+//
+// Zendesk Core API docs: https://developer.zendesk.com/api-reference/ticketing/tickets/ticket_forms/#delete-ticket-form
+func (c *client) DeleteTicketForm(id int64) error {
+	defer c.InvalidateSchemas()
+	return c.delete(fmt.Sprintf("/api/v2/ticket_forms/%d.json", id), nil)
+}
+
+// ReorderTicketForms sets the position of the ticket forms to their order in ids.
+//
+// Zendesk Core API docs: https://developer.zendesk.com/api-reference/ticketing/tickets/ticket_forms/#reorder-ticket-forms
+func (c *client) ReorderTicketForms(ids []int64) error {
+	defer c.InvalidateSchemas()
+	in := struct {
+		IDs []int64 `json:"ticket_form_ids"`
+	}{IDs: ids}
+	return c.put("/api/v2/ticket_forms/reorder.json", in, nil)
+}
+
+// CloneTicketForm creates a copy of a ticket form, named after the original.
+//
+// Zendesk Core API docs: https://developer.zendesk.com/api-reference/ticketing/tickets/ticket_forms/#clone-an-already-existing-ticket-form
+func (c *client) CloneTicketForm(id int64) (*TicketForm, error) {
+	defer c.InvalidateSchemas()
+	out := new(APIPayload)
+	err := c.post(fmt.Sprintf("/api/v2/ticket_forms/%d/clone.json", id), nil, out)
+	return out.TicketForm, err
+}
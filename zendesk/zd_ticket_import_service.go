@@ -0,0 +1,186 @@
+package zendesk
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// ticketImportLimit is the maximum number of tickets of a bulk import.
+const ticketImportLimit = 100
+
+// ticketCommentLimit is the maximum number of comments sent with a ticket import.
+const ticketCommentLimit = 5000
+
+// TicketImport is a ticket imported with its history, such as one migrated from another
+// help desk. Its timestamps and comments are kept as they are, and no trigger runs.
+//
+// Zendesk Core API docs: https://developer.zendesk.com/api-reference/ticketing/tickets/ticket_import/
+type TicketImport struct {
+	Ticket
+	// Comments are the comments of the ticket, in chronological order, with their
+	// original authors and creation times.
+	Comments []TicketComment `json:"comments,omitempty"`
+	SolvedAt *time.Time      `json:"solved_at,omitempty"`
+}
+
+// ImportTicket imports a ticket with its comments.
+//
+// Zendesk Core API docs: https://developer.zendesk.com/api-reference/ticketing/tickets/ticket_import/#ticket-import
+func (c *client) ImportTicket(ticket *TicketImport) (*Ticket, error) {
+	in := struct {
+		Ticket *TicketImport `json:"ticket"`
+	}{Ticket: ticket}
+	out := new(APIPayload)
+	err := c.post("/api/v2/imports/tickets.json", in, out)
+	return out.Ticket, err
+}
+
+// BulkImportTickets imports up to 100 tickets with their comments. The import runs as
+// a background job whose status is returned.
+//
+// Zendesk Core API docs: https://developer.zendesk.com/api-reference/ticketing/tickets/ticket_import/#ticket-bulk-import
+func (c *client) BulkImportTickets(tickets []TicketImport) (*JobStatus, error) {
+	if len(tickets) > ticketImportLimit {
+		return nil, fmt.Errorf("zendesk: at most %d tickets can be imported at once, got %d", ticketImportLimit, len(tickets))
+	}
+
+	in := struct {
+		Tickets []TicketImport `json:"tickets"`
+	}{Tickets: tickets}
+	out := new(APIPayload)
+	err := c.post("/api/v2/imports/tickets/create_many.json", in, out)
+	return out.JobStatus, err
+}
+
+// TicketImportOptions configures how ImportTickets splits a migration into requests.
+type TicketImportOptions struct {
+	// BatchSize is the maximum number of tickets of a bulk import. It defaults to, and
+	// cannot exceed, 100.
+	BatchSize int
+	// MaxBatchComments is the maximum number of comments of a bulk import, which keeps
+	// the payloads of long conversations small. A ticket with more comments is imported
+	// on its own. It defaults to 1000.
+	MaxBatchComments int
+	// MaxTicketComments is the maximum number of comments imported with a ticket, which
+	// chunks very long threads. A ticket with more comments is imported with its first
+	// MaxTicketComments comments, then the following ones are added to it in order with
+	// AddTicketComment. Added comments keep their authors and public flags, but Zendesk
+	// gives them the time they are added and runs the triggers. It defaults to, and
+	// cannot exceed, 5000.
+	MaxTicketComments int
+}
+
+// TicketImportResult holds the outcome of ImportTickets: the jobs of the bulk imports,
+// whose results list the created tickets, and the tickets imported on their own.
+type TicketImportResult struct {
+	Jobs    []*JobStatus
+	Tickets []Ticket
+	// AddedComments is the number of comments added to the tickets imported on their
+	// own, past the MaxTicketComments imported with them.
+	AddedComments int
+}
+
+// ImportTickets imports tickets with their full comment history, such as during a help
+// desk migration. The comments of each ticket are put in chronological order, keeping
+// their authors, creation times and public flags. Tickets are sent in bulk imports of
+// bounded size, while tickets with very long conversations are imported one by one, with
+// their threads chunked as described by TicketImportOptions.MaxTicketComments.
+// On failure, the result of the requests already made is returned along with the error.
+//
+// Zendesk Core API docs: https://developer.zendesk.com/api-reference/ticketing/tickets/ticket_import/
+func (c *client) ImportTickets(tickets []TicketImport, opts *TicketImportOptions) (*TicketImportResult, error) {
+	result := new(TicketImportResult)
+	batches, err := ticketImportBatches(tickets, opts)
+	if err != nil {
+		return result, err
+	}
+
+	for _, batch := range batches {
+		if len(batch.tickets) == 1 {
+			ticket, err := c.ImportTicket(&batch.tickets[0])
+			if err != nil {
+				return result, err
+			}
+			result.Tickets = append(result.Tickets, *ticket)
+
+			for i := range batch.rest {
+				if _, err := c.AddTicketComment(ticket.ID, &batch.rest[i]); err != nil {
+					return result, fmt.Errorf("zendesk: adding comment %d of imported ticket %d: %w", len(batch.tickets[0].Comments)+i+1, ticket.ID, err)
+				}
+				result.AddedComments++
+			}
+			continue
+		}
+
+		job, err := c.BulkImportTickets(batch.tickets)
+		if err != nil {
+			return result, err
+		}
+		result.Jobs = append(result.Jobs, job)
+	}
+
+	c.logger.Printf("[zd_ticket_import_service][ImportTickets] %d tickets imported in %d jobs and %d single imports\n", len(tickets), len(result.Jobs), len(result.Tickets))
+	return result, nil
+}
+
+// ticketImportBatch is a request of ImportTickets: a bulk import, or the import of a
+// single ticket followed by the comments of its thread that did not fit in the import.
+type ticketImportBatch struct {
+	tickets []TicketImport
+	rest    []TicketComment
+}
+
+// ticketImportBatches sorts the comments of the tickets, oldest first and comments without
+// creation time last, and groups the tickets in batches within the limits of opts.
+// The tickets are copied, leaving the caller's unchanged.
+func ticketImportBatches(tickets []TicketImport, opts *TicketImportOptions) ([]ticketImportBatch, error) {
+	if opts == nil {
+		opts = new(TicketImportOptions)
+	}
+	batchSize := opts.BatchSize
+	if batchSize <= 0 || batchSize > ticketImportLimit {
+		batchSize = ticketImportLimit
+	}
+	maxComments := opts.MaxBatchComments
+	if maxComments <= 0 {
+		maxComments = 1000
+	}
+	maxTicketComments := opts.MaxTicketComments
+	if maxTicketComments <= 0 || maxTicketComments > ticketCommentLimit {
+		maxTicketComments = ticketCommentLimit
+	}
+
+	batches := make([]ticketImportBatch, 0)
+	batch := make([]TicketImport, 0, batchSize)
+	comments := 0
+	for i := range tickets {
+		ticket := tickets[i]
+		ticket.Comments = append([]TicketComment(nil), ticket.Comments...)
+		sort.SliceStable(ticket.Comments, func(a, b int) bool {
+			at, bt := ticket.Comments[a].CreatedAt, ticket.Comments[b].CreatedAt
+			return at != nil && (bt == nil || at.Before(*bt))
+		})
+
+		if len(ticket.Comments) >= maxComments || len(ticket.Comments) > maxTicketComments {
+			single := ticketImportBatch{tickets: []TicketImport{ticket}}
+			if len(ticket.Comments) > maxTicketComments {
+				single.tickets[0].Comments = ticket.Comments[:maxTicketComments]
+				single.rest = ticket.Comments[maxTicketComments:]
+			}
+			batches = append(batches, single)
+			continue
+		}
+		if len(batch) == batchSize || comments+len(ticket.Comments) > maxComments {
+			batches = append(batches, ticketImportBatch{tickets: batch})
+			batch = make([]TicketImport, 0, batchSize)
+			comments = 0
+		}
+		batch = append(batch, ticket)
+		comments += len(ticket.Comments)
+	}
+	if len(batch) > 0 {
+		batches = append(batches, ticketImportBatch{tickets: batch})
+	}
+	return batches, nil
+}
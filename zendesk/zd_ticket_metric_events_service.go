@@ -0,0 +1,206 @@
+package zendesk
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+// TicketMetricEvent represents a change in the state of a ticket metric, such as
+// an SLA being applied, paused, fulfilled or breached.
+//
+// Zendesk Core API docs: https://developer.zendesk.com/api-reference/ticketing/tickets/ticket_metric_events/
+type TicketMetricEvent struct {
+	ID         int64                 `json:"id,omitempty"`
+	TicketID   int64                 `json:"ticket_id,omitempty"`
+	Metric     string                `json:"metric,omitempty"`
+	InstanceID int64                 `json:"instance_id,omitempty"`
+	Type       string                `json:"type,omitempty"`
+	Time       *time.Time            `json:"time,omitempty"`
+	SLA        *TicketMetricEventSLA `json:"sla,omitempty"`
+	Deleted    bool                  `json:"deleted,omitempty"`
+}
+
+// TicketMetricEventSLA holds the SLA target applied by an apply_sla event.
+type TicketMetricEventSLA struct {
+	// Target is the SLA target in minutes.
+	Target        int64      `json:"target"`
+	BusinessHours bool       `json:"business_hours"`
+	Policy        *SLAPolicy `json:"policy,omitempty"`
+}
+
+// SLAPolicy identifies the SLA policy a target comes from.
+type SLAPolicy struct {
+	ID          int64  `json:"id"`
+	Title       string `json:"title"`
+	Description string `json:"description"`
+}
+
+// Ticket metric event types.
+const (
+	MetricEventActivate     = "activate"
+	MetricEventPause        = "pause"
+	MetricEventFulfill      = "fulfill"
+	MetricEventApplySLA     = "apply_sla"
+	MetricEventBreach       = "breach"
+	MetricEventUpdateStatus = "update_status"
+	MetricEventMeasure      = "measure"
+)
+
+// GetTicketMetricEventsIncrementally pulls the ticket metric events that occurred since a specific time point.
+//
+// Zendesk Core API docs: https://developer.zendesk.com/api-reference/ticketing/tickets/ticket_metric_events/#list-ticket-metric-events
+func (c *client) GetTicketMetricEventsIncrementally(unixTime int64) ([]TicketMetricEvent, error) {
+	result := make([]TicketMetricEvent, 0)
+	endpoint := fmt.Sprintf("/api/v2/incremental/ticket_metric_events.json?start_time=%d", unixTime)
+
+	err := c.forEachPage(endpoint, func(page *APIPayload) error {
+		result = append(result, page.TicketMetricEvents...)
+		return nil
+	})
+	if err != nil {
+		return result, partialResult(err, result)
+	}
+
+	c.logger.Printf("[zd_ticket_metric_events_service][GetTicketMetricEventsIncrementally] number of records pulled: %v\n", len(result))
+	return result, nil
+}
+
+// BreachAlert reports an SLA target missed by a ticket metric.
+type BreachAlert struct {
+	TicketID   int64
+	Metric     string
+	InstanceID int64
+	// Target is the SLA target in minutes.
+	Target        int64
+	BusinessHours bool
+	Policy        *SLAPolicy
+	BreachedAt    time.Time
+}
+
+type slaKey struct {
+	ticketID   int64
+	metric     string
+	instanceID int64
+}
+
+type slaState struct {
+	sla       *TicketMetricEventSLA
+	fulfilled bool
+	alerted   bool
+	breachAt  *time.Time
+}
+
+// SLABreachDetector turns a stream of ticket metric events into BreachAlerts.
+//
+// Zendesk records breach events ahead of time, at the time the SLA will be breached,
+// and marks them as deleted when the metric is fulfilled or the SLA removed. The detector
+// keeps track of the state of each metric instance so that an alert is emitted once
+// the breach time has passed, for metrics that were not fulfilled before it.
+// A detector is safe for concurrent use.
+type SLABreachDetector struct {
+	// Now returns the current time, used to decide whether a breach is due.
+	Now func() time.Time
+
+	mu       sync.Mutex
+	onBreach func(BreachAlert) error
+	states   map[slaKey]*slaState
+}
+
+// NewSLABreachDetector creates a detector calling onBreach for each breach.
+func NewSLABreachDetector(onBreach func(BreachAlert) error) *SLABreachDetector {
+	return &SLABreachDetector{
+		Now:      time.Now,
+		onBreach: onBreach,
+		states:   make(map[slaKey]*slaState),
+	}
+}
+
+// Consume feeds events to the detector, in any order, and emits the alerts that are due.
+// It stops at the first error returned by the callback, which is returned.
+func (d *SLABreachDetector) Consume(events []TicketMetricEvent) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	sorted := make([]TicketMetricEvent, len(events))
+	copy(sorted, events)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].Time == nil || sorted[j].Time == nil {
+			return sorted[j].Time != nil
+		}
+		return sorted[i].Time.Before(*sorted[j].Time)
+	})
+
+	for _, event := range sorted {
+		key := slaKey{event.TicketID, event.Metric, event.InstanceID}
+		state, ok := d.states[key]
+		if !ok {
+			state = &slaState{}
+			d.states[key] = state
+		}
+
+		switch event.Type {
+		case MetricEventApplySLA:
+			state.sla = event.SLA
+			state.alerted = false
+		case MetricEventFulfill:
+			state.fulfilled = true
+			state.breachAt = nil
+		case MetricEventActivate:
+			state.fulfilled = false
+		case MetricEventBreach:
+			if event.Deleted {
+				state.breachAt = nil
+			} else if !state.fulfilled && event.Time != nil {
+				state.breachAt = event.Time
+			}
+		}
+	}
+
+	return d.emit()
+}
+
+// Flush emits the alerts that became due since the last call, without new events.
+func (d *SLABreachDetector) Flush() error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	return d.emit()
+}
+
+func (d *SLABreachDetector) emit() error {
+	now := d.Now()
+
+	keys := make([]slaKey, 0)
+	for key, state := range d.states {
+		if state.breachAt != nil && !state.alerted && !state.fulfilled && !state.breachAt.After(now) {
+			keys = append(keys, key)
+		}
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return d.states[keys[i]].breachAt.Before(*d.states[keys[j]].breachAt)
+	})
+
+	for _, key := range keys {
+		state := d.states[key]
+		alert := BreachAlert{
+			TicketID:   key.ticketID,
+			Metric:     key.metric,
+			InstanceID: key.instanceID,
+			BreachedAt: *state.breachAt,
+		}
+		if state.sla != nil {
+			alert.Target = state.sla.Target
+			alert.BusinessHours = state.sla.BusinessHours
+			alert.Policy = state.sla.Policy
+		}
+
+		if err := d.onBreach(alert); err != nil {
+			return err
+		}
+		state.alerted = true
+	}
+
+	return nil
+}
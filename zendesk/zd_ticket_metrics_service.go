@@ -1,11 +1,8 @@
 package zendesk
 
 import (
-	"bytes"
+	"errors"
 	"fmt"
-	"log"
-	"strconv"
-	"strings"
 	"time"
 )
 
@@ -49,76 +46,35 @@ func (c *client) ShowTicketMetric(id int64) (*TicketMetric, error) {
 }
 
 func (c *client) GetAllTicketMetrics() ([]TicketMetric, error) {
-	log.Printf("[zd_ticket_metrics_service][GetAllTicketMetrics] Start GetAllTicketMetrics")
+	c.logger.Printf("[zd_ticket_metrics_service][GetAllTicketMetrics] Start GetAllTicketMetrics")
 	// []int64{} is a placeholder which should be replaced by the actual tickets IDs
 	// since we only pull the entire history of ticket metrics only once, this function
 	// may not be used anymore
 	ticketmetrics, err := c.getTicketMetricOneByOne(nil, []int64{})
-	log.Printf("[zd_ticket_metrics_service][GetAllTicketMetrics] number of ticketmetrics: %v", len(ticketmetrics))
+	c.logger.Printf("[zd_ticket_metrics_service][GetAllTicketMetrics] number of ticketmetrics: %v", len(ticketmetrics))
 	return ticketmetrics, err
 }
 
 // due to the archived tickets, this function cannot be used to extract all tickets metrics
 // use getTicketMetricOneByOne
-func (c *client) getAllTicketMetrics(endpoint string, in interface{}) ([]TicketMetric, error) {
+func (c *client) getAllTicketMetrics(endpoint string) ([]TicketMetric, error) {
 	result := make([]TicketMetric, 0)
-	payload, err := marshall(in)
+	err := c.forEachPage(endpoint, func(page *APIPayload) error {
+		result = append(result, page.TicketMetrics...)
+		return nil
+	})
 	if err != nil {
-		return nil, err
-	}
-
-	headers := map[string]string{}
-	if in != nil {
-		headers["Content-Type"] = "application/json"
-	}
-
-	res, err := c.request("GET", endpoint, headers, bytes.NewReader(payload))
-	dataPerPage := new(APIPayload)
-	if err != nil {
-		return nil, err
+		return nil, partialResult(err, result)
 	}
 
-	apiV2 := "/api/v2/"
-	fieldName := strings.Split(endpoint[len(apiV2):], ".")[0]
-	defer res.Body.Close()
-
-	err = unmarshall(res, dataPerPage)
-
-	apiStartIndex := strings.Index(dataPerPage.NextPage, apiV2)
-	currentPage := endpoint
-
-	var totalWaitTime int64
-	for currentPage != "" {
-		// if too many requests(res.StatusCode == 429), delay sending request
-		if res.StatusCode == 429 {
-			after, err := strconv.ParseInt(res.Header.Get("Retry-After"), 10, 64)
-			log.Printf("[zd_ticket_metrics_service][getAllTicketMetrics] too many requests. Wait for %v seconds\n", after)
-			totalWaitTime += after
-			if err != nil {
-				return nil, err
-			}
-			time.Sleep(time.Duration(after) * time.Second)
-		} else {
-			if fieldName == "ticket_metrics" {
-				result = append(result, dataPerPage.TicketMetrics...)
-			}
-			currentPage = dataPerPage.NextPage
-		}
-		res, _ = c.request("GET", dataPerPage.NextPage[apiStartIndex:], headers, bytes.NewReader(payload))
-		dataPerPage = new(APIPayload)
-		err = unmarshall(res, dataPerPage)
-		if err != nil {
-			return nil, err
-		}
-	}
-	log.Printf("[zd_ticket_metrics_service][getAllTicketMetrics] number of records pulled: %v\n", len(result))
-	log.Printf("[zd_ticket_metrics_service][getAllTicketMetrics] total waiting time due to rate limit: %v\n", totalWaitTime)
-
-	return result, err
+	c.logger.Printf("[zd_ticket_metrics_service][getAllTicketMetrics] number of records pulled: %v\n", len(result))
+	return result, nil
 }
 
+// getTicketMetricOneByOne fetches the metrics of each ticket, from as many concurrent
+// requests as the client's concurrency. The metrics are returned in the order of the tickets.
 func (c *client) getTicketMetricOneByOne(in interface{}, ticketIDs []int64) ([]TicketMetric, error) {
-	log.Printf("[zd_ticket_metrics_service][getTicketMetricOneByOne] Start getTicketMetricOneByOne")
+	c.logger.Printf("[zd_ticket_metrics_service][getTicketMetricOneByOne] Start getTicketMetricOneByOne")
 	endpointPrefix := "/api/v2/tickets/"
 	endpointPostfix := "/metrics.json"
 
@@ -132,66 +88,86 @@ func (c *client) getTicketMetricOneByOne(in interface{}, ticketIDs []int64) ([]T
 	if in != nil {
 		headers["Content-Type"] = "application/json"
 	}
-	record := new(APIPayload)
 
 	numTickets := len(ticketIDs)
 	if numTickets == 0 {
 		return result, nil
 	}
-	log.Printf("[zd_ticket_metrics_service][getTicketMetricOneByOne] numTickets: %v", numTickets)
+	c.logger.Printf("[zd_ticket_metrics_service][getTicketMetricOneByOne] numTickets: %v", numTickets)
 
-	endpoint := fmt.Sprintf("%s%v%s", endpointPrefix, ticketIDs[0], endpointPostfix)
-	res, err := c.request("GET", endpoint, headers, bytes.NewReader(payload))
-	if err != nil {
-		return nil, err
-	}
-	defer res.Body.Close()
-
-	var totalWaitTime int64
-	log.Printf("[zd_ticket_metrics_service][getTicketMetricOneByOne] Start for loop in getTicketMetricOneByOne")
-	for ticketInd := 1; ticketInd < numTickets; ticketInd++ {
-		// handle page not found
-		if res.StatusCode == 404 {
-			log.Printf("[zd_ticket_metrics_service][getTicketMetricOneByOne] 404 not found: %s\n", endpoint)
-			// handle too many requests (rate limit)
-		} else if res.StatusCode == 429 {
-			after, err := strconv.ParseInt(res.Header.Get("Retry-After"), 10, 64)
-			log.Printf("[zd_ticket_metrics_service][getTicketMetricOneByOne] too many requests. Wait for %v seconds\n", after)
-			totalWaitTime += after
-			if err != nil {
-				return nil, err
-			}
-			time.Sleep(time.Duration(after) * time.Second)
+	records := make([]*APIPayload, numTickets)
+	err = c.fanOut(ticketIDs, func(i int, ticketID int64) error {
+		endpoint := fmt.Sprintf("%s%v%s", endpointPrefix, ticketID, endpointPostfix)
+		record := new(APIPayload)
+		found, err := c.getOne(endpoint, headers, payload, record)
+		if err != nil {
+			return &PartialResultError{PageURL: endpoint, Err: err}
+		}
+		if found {
+			records[i] = record
+		}
+		return nil
+	})
+
+	for _, record := range records {
+		if record == nil {
 			continue
+		}
+		if record.TicketMetric != nil {
+			result = append(result, *record.TicketMetric)
 		} else {
-			err = unmarshall(res, record)
-			if err != nil {
-				return nil, err
-			}
-			if record.TicketMetric != nil {
-				result = append(result, *record.TicketMetric)
-			} else {
-				result = append(result, record.TicketMetrics...)
-			}
+			result = append(result, record.TicketMetrics...)
 		}
-
-		record = new(APIPayload)
-		endpoint = fmt.Sprintf("%s%v%s", endpointPrefix, ticketIDs[ticketInd], endpointPostfix)
-		res, _ = c.request("GET", endpoint, headers, bytes.NewReader(payload))
+	}
+	if err != nil {
+		var partial *PartialResultError
+		if len(result) == 0 && errors.As(err, &partial) {
+			return nil, errors.Unwrap(partial)
+		}
+		return nil, partialResult(err, result)
 	}
 
-	log.Printf("[zd_ticket_metrics_service][getTicketMetricOneByOne] number of records pulled: %v\n", len(result))
-	log.Printf("[zd_ticket_metrics_service][getTicketMetricOneByOne] total waiting time due to rate limit: %v\n", totalWaitTime)
+	c.logger.Printf("[zd_ticket_metrics_service][getTicketMetricOneByOne] number of records pulled: %v\n", len(result))
 	return result, nil
 }
 
+// ticketBatchSize is the number of tickets whose resources are fetched before being
+// passed to the handler of the WithHandler methods.
+const ticketBatchSize = 100
+
+// GetTicketMetricsWithHandler fetches the metrics of the tickets and passes them to fn
+// every ticketBatchSize tickets, so that they do not have to be held in memory.
+// It stops at the first error, returned by fn or not.
+//
+// Zendesk Core API docs: https://developer.zendesk.com/api-reference/ticketing/tickets/ticket_metrics/#show-ticket-metrics
+func (c *client) GetTicketMetricsWithHandler(ticketIDs []int64, fn func([]TicketMetric) error) error {
+	for start := 0; start < len(ticketIDs); start += ticketBatchSize {
+		end := start + ticketBatchSize
+		if end > len(ticketIDs) {
+			end = len(ticketIDs)
+		}
+
+		metrics, err := c.getTicketMetricOneByOne(nil, ticketIDs[start:end])
+		if err != nil {
+			return err
+		}
+		if len(metrics) == 0 {
+			continue
+		}
+		if err := fn(metrics); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (c *client) GetTicketMetricsIncrementally(ticketIDs []int64) ([]TicketMetric, error) {
-	log.Printf("[zd_ticket_metrics_service][GetTicketMetricsIncrementally] GetTicketMetricsIncrementally")
+	c.logger.Printf("[zd_ticket_metrics_service][GetTicketMetricsIncrementally] GetTicketMetricsIncrementally")
 	ticketMetrics, err := c.getTicketMetricOneByOne(nil, ticketIDs)
 	if err != nil {
-		log.Printf("[zd_ticket_metrics_service][GetTicketMetricsIncrementally] error pulling ticket metrics by ticketIDs: %s\n", err)
+		c.logger.Printf("[zd_ticket_metrics_service][GetTicketMetricsIncrementally] error pulling ticket metrics by ticketIDs: %s\n", err)
 		return nil, err
 	}
-	log.Printf("[zd_ticket_metrics_service][GetTicketMetricsIncrementally] number of ticketMetrics: %v", len(ticketMetrics))
+	c.logger.Printf("[zd_ticket_metrics_service][GetTicketMetricsIncrementally] number of ticketMetrics: %v", len(ticketMetrics))
 	return ticketMetrics, nil
 }
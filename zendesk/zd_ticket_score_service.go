@@ -1,169 +1,227 @@
 package zendesk
 
 import (
-	"bytes"
 	"fmt"
-	"log"
+	"net/url"
 	"strconv"
 	"time"
+
+	"github.com/google/go-querystring/query"
 )
 
-// TicketMetric represents a Zendesk ticket satisfaction rating.
+// Score represents a Zendesk ticket satisfaction rating.
 //
 // Zendesk Core API docs: https://developer.zendesk.com/rest_api/docs/support/satisfaction_ratings
 
 type Score struct {
 	ID          int64      `json:"id,omitempty"`
-	AssigneeID  int64      `json:"assignee_id,omitempty`
+	URL         string     `json:"url,omitempty"`
+	AssigneeID  int64      `json:"assignee_id,omitempty"`
 	GroupID     int64      `json:"group_id,omitempty"`
 	RequesterID int64      `json:"requester_id,omitempty"`
 	TicketID    int64      `json:"ticket_id,omitempty"`
 	Score       string     `json:"score,omitempty"`
+	Comment     string     `json:"comment,omitempty"`
+	Reason      string     `json:"reason,omitempty"`
+	ReasonID    int64      `json:"reason_id,omitempty"`
+	ReasonCode  int64      `json:"reason_code,omitempty"`
 	CreatedAt   *time.Time `json:"created_at,omitempty"`
 	UpdatedAt   *time.Time `json:"updated_at,omitempty"`
 }
 
+// Satisfaction rating scores.
+const (
+	ScoreOffered                = "offered"
+	ScoreUnoffered              = "unoffered"
+	ScoreGood                   = "good"
+	ScoreBad                    = "bad"
+	ScoreReceived               = "received"
+	ScoreReceivedWithComment    = "received_with_comment"
+	ScoreReceivedWithoutComment = "received_without_comment"
+	ScoreGoodWithComment        = "good_with_comment"
+	ScoreGoodWithoutComment     = "good_without_comment"
+	ScoreBadWithComment         = "bad_with_comment"
+	ScoreBadWithoutComment      = "bad_without_comment"
+)
+
+// SatisfactionReason represents a reason given by a customer for a bad satisfaction rating.
+//
+// Zendesk Core API docs: https://developer.zendesk.com/api-reference/ticketing/ticket-management/satisfaction_reasons/
+type SatisfactionReason struct {
+	ID         int64      `json:"id,omitempty"`
+	URL        string     `json:"url,omitempty"`
+	ReasonCode int64      `json:"reason_code,omitempty"`
+	Value      string     `json:"value,omitempty"`
+	RawValue   string     `json:"raw_value,omitempty"`
+	Deleted    bool       `json:"deleted,omitempty"`
+	CreatedAt  *time.Time `json:"created_at,omitempty"`
+	UpdatedAt  *time.Time `json:"updated_at,omitempty"`
+}
+
+// ListSatisfactionRatingsOptions specifies the filters of ListSatisfactionRatings.
+// Times are unix times.
+type ListSatisfactionRatingsOptions struct {
+	Score     string `url:"score,omitempty"`
+	StartTime int64  `url:"start_time,omitempty"`
+	EndTime   int64  `url:"end_time,omitempty"`
+}
+
 // GetSatisfactionScores pull the list of all the scores
 // due to memory limit, we need to pull by page
 //
 // Zendesk Core API docs: https://developer.zendesk.com/rest_api/docs/support/satisfaction_ratings
 
 func (c *client) GetSatisfactionScores() ([]Score, error) {
-	scores, err := c.getSatisfactionScores("/api/v2/satisfaction_ratings.json?page=", nil)
-	return scores, err
+	return c.ListSatisfactionRatings(nil)
 }
 
+// GetSatisfactionScoresIncrementally pulls the satisfaction ratings created since a specific time point.
+//
+// Zendesk Core API docs: https://developer.zendesk.com/api-reference/ticketing/ticket-management/satisfaction_ratings/#list-satisfaction-ratings
 func (c *client) GetSatisfactionScoresIncrementally(unixTime int64) ([]Score, error) {
-	endpoint := fmt.Sprintf("%s%v", "/api/v2/satisfaction_ratings.json?start_time=", unixTime)
-	scores, err := c.getSatisfactionScoresIncrementally(endpoint, nil)
-	return scores, err
-}
-
-func (c *client) getSatisfactionScores(endpoint string, in interface{}) ([]Score, error) {
-	// startingPageNumber will be adjusted while pulling
-	startingPageNumber := 1
-
 	result := make([]Score, 0)
-	payload, err := marshall(in)
+	checkpoint, err := c.ExportSatisfactionRatings(&IncrementalExportOptions{StartTime: unixTime}, SinkFunc(func(record interface{}) error {
+		result = append(result, *record.(*Score))
+		return nil
+	}))
 	if err != nil {
-		return nil, err
+		if len(result) == 0 {
+			return nil, err
+		}
+		return result, &PartialResultError{Records: result, Cursor: checkpoint.Cursor, Err: err}
 	}
 
-	headers := map[string]string{}
-	if in != nil {
-		headers["Content-Type"] = "applications/json"
-	}
+	c.logger.Printf("[zd_ticket_score_service][GetSatisfactionScoresIncrementally] number of records pulled: %v\n", len(result))
+	return result, nil
+}
 
-	currentPage := fmt.Sprintf("%s%v", endpoint, startingPageNumber)
-	res, err := c.request("GET", currentPage, headers, bytes.NewReader(payload))
-	if err != nil {
-		return nil, err
+// ExportSatisfactionRatings streams the satisfaction ratings created since the start time
+// to the sink, following the cursor based pagination of the ratings. The cursor of the
+// checkpoint resumes the export after the last fully written page, with the same start
+// time. On failure, the checkpoint of the records already written is returned along with
+// the error.
+//
+// Zendesk Core API docs: https://developer.zendesk.com/api-reference/ticketing/ticket-management/satisfaction_ratings/#list-satisfaction-ratings
+func (c *client) ExportSatisfactionRatings(opts *IncrementalExportOptions, sink RecordSink) (checkpoint *ExportCheckpoint, err error) {
+	checkpoint = new(ExportCheckpoint)
+	if opts == nil {
+		opts = new(IncrementalExportOptions)
 	}
-	defer res.Body.Close()
-
-	// numberOfPages will be customized when pulling data
-	numberOfPages := 50
-	count := 1
-
-	// APIPayload defined the fields received from Zendesk
-	dataPerPage := new(APIPayload)
-	err = unmarshall(res, dataPerPage)
-	if err != nil {
-		return nil, err
+	if opts, err = opts.resume("satisfaction_ratings"); err != nil {
+		return checkpoint, err
+	}
+	checkpoint.Cursor = opts.Cursor
+	report := newRunReport("satisfaction_ratings", opts.Cursor)
+	defer func() {
+		report.finish(checkpoint, err)
+		c.logger.Printf("[zd_ticket_score_service][ExportSatisfactionRatings] %s\n", report)
+	}()
+
+	perPage := opts.PerPage
+	if perPage <= 0 {
+		perPage = 100
+	}
+	params := url.Values{}
+	params.Set("page[size]", strconv.Itoa(perPage))
+	if opts.StartTime != 0 {
+		params.Set("start_time", strconv.FormatInt(opts.StartTime, 10))
+	}
+	if opts.Cursor != "" {
+		params.Set("page[after]", opts.Cursor)
 	}
+	endpoint := "/api/v2/satisfaction_ratings.json?" + params.Encode()
+
+	for {
+		out := struct {
+			APIPayload
+			Meta struct {
+				HasMore     bool   `json:"has_more"`
+				AfterCursor string `json:"after_cursor"`
+			} `json:"meta"`
+			Links struct {
+				Next string `json:"next"`
+			} `json:"links"`
+		}{}
+		trace, err := c.getTraced(endpoint, &out)
+		report.addPage(trace)
+		if err != nil {
+			return checkpoint, err
+		}
 
-	var totalWaitTime int64
-	for count < numberOfPages && currentPage != "" {
-		// if too many requests(res.StatusCode == 429), delay sending request
-		if res.StatusCode == 429 {
-			after, err := strconv.ParseInt(res.Header.Get("Retry-After"), 10, 64)
-			if err != nil {
-				return nil, err
+		for i := range out.SatisfactionRatings {
+			if err := sink.WriteRecord(&out.SatisfactionRatings[i]); err != nil {
+				return checkpoint, err
+			}
+			checkpoint.Records++
+		}
+		if out.Meta.AfterCursor != "" {
+			checkpoint.Cursor = out.Meta.AfterCursor
+			if err := opts.save("satisfaction_ratings", checkpoint.Cursor); err != nil {
+				return checkpoint, err
 			}
-
-			log.Printf("[zd_ticket_score_service][getSatisfactionScores] too many requests. Wait for %v seconds\n", after)
-			totalWaitTime += after
-			time.Sleep(time.Duration(after) * time.Second)
-		} else {
-			result = append(result, dataPerPage.SatisfactionRatings...)
-			currentPage = dataPerPage.NextPage
 		}
 
-		currentPage = fmt.Sprintf("%s%v", endpoint, startingPageNumber+count)
-		count++
-		res, _ = c.request("GET", currentPage, headers, bytes.NewReader(payload))
-		dataPerPage = new(APIPayload)
-		err = unmarshall(res, dataPerPage)
-		if err != nil {
-			return nil, err
+		if !out.Meta.HasMore || out.Links.Next == "" {
+			break
 		}
+		if stopped(opts.Stop) {
+			return checkpoint, ErrExportStopped
+		}
+		endpoint = c.relativeURL(out.Links.Next)
 	}
 
-	log.Printf("[zd_ticket_score_service][getSatisfactionScores] number of records pulled: %v\n", len(result))
-	log.Printf("[zd_ticket_score_service][getSatisfactionScores] total waiting time due to rate limit: %v\n", totalWaitTime)
-
-	return result, err
+	return checkpoint, nil
 }
 
-func (c *client) getSatisfactionScoresIncrementally(currentPage string, in interface{}) ([]Score, error) {
-	result := make([]Score, 0)
-	payload, err := marshall(in)
-	if err != nil {
-		return nil, err
-	}
-
-	headers := map[string]string{}
-	if in != nil {
-		headers["Content-Type"] = "applications/json"
-	}
-
-	res, err := c.request("GET", currentPage, headers, bytes.NewReader(payload))
+// ListSatisfactionRatings lists the satisfaction ratings matching the filters, following
+// the pages until the last one.
+//
+// Zendesk Core API docs: https://developer.zendesk.com/api-reference/ticketing/ticket-management/satisfaction_ratings/#list-satisfaction-ratings
+func (c *client) ListSatisfactionRatings(opts *ListSatisfactionRatingsOptions) ([]Score, error) {
+	params, err := query.Values(opts)
 	if err != nil {
 		return nil, err
 	}
-	defer res.Body.Close()
 
-	// APIPayload defined the fields received from Zendesk
-	dataPerPage := new(APIPayload)
-	err = unmarshall(res, dataPerPage)
+	result := make([]Score, 0)
+	endpoint := "/api/v2/satisfaction_ratings.json?" + params.Encode()
+	err = c.forEachPage(endpoint, func(page *APIPayload) error {
+		result = append(result, page.SatisfactionRatings...)
+		return nil
+	})
 	if err != nil {
-		return nil, err
+		return result, partialResult(err, result)
 	}
-	count := 1
-	var totalWaitTime int64
-	for count < 10 {
-		// if too many requests(res.StatusCode == 429), delay sending request
-		if res.StatusCode == 429 {
-			after, err := strconv.ParseInt(res.Header.Get("Retry-After"), 10, 64)
-			if err != nil {
-				return nil, err
-			}
 
-			log.Printf("[zd_ticket_score_service][getSatisfactionScores] too many requests. Wait for %v seconds\n", after)
-			totalWaitTime += after
-			time.Sleep(time.Duration(after) * time.Second)
-		} else {
-			result = append(result, dataPerPage.SatisfactionRatings...)
-			if currentPage == dataPerPage.NextPage {
-				break
-			}
-			currentPage = dataPerPage.NextPage
-			if currentPage == "" {
-				break
-			}
-		}
+	c.logger.Printf("[zd_ticket_score_service][ListSatisfactionRatings] number of records pulled: %v\n", len(result))
+	return result, nil
+}
 
-		res, _ = c.request("GET", currentPage, headers, bytes.NewReader(payload))
-		dataPerPage = new(APIPayload)
-		err = unmarshall(res, dataPerPage)
-		if err != nil {
-			return nil, err
-		}
-		count++
-	}
+// ShowSatisfactionRating fetches a satisfaction rating by its ID.
+//
+// Zendesk Core API docs: https://developer.zendesk.com/api-reference/ticketing/ticket-management/satisfaction_ratings/#show-satisfaction-rating
+func (c *client) ShowSatisfactionRating(id int64) (*Score, error) {
+	out := new(APIPayload)
+	err := c.get(fmt.Sprintf("/api/v2/satisfaction_ratings/%d.json", id), out)
+	return out.SatisfactionRating, err
+}
 
-	log.Printf("[zd_ticket_score_service][getSatisfactionScores] number of records pulled: %v\n", len(result))
-	log.Printf("[zd_ticket_score_service][getSatisfactionScores] total waiting time due to rate limit: %v\n", totalWaitTime)
+// CreateSatisfactionRating rates a solved ticket on behalf of its requester.
+// Only the score, comment and reason code of the rating are used.
+//
+// Zendesk Core API docs: https://developer.zendesk.com/api-reference/ticketing/ticket-management/satisfaction_ratings/#create-a-satisfaction-rating
+func (c *client) CreateSatisfactionRating(ticketID int64, rating *Score) (*Score, error) {
+	in := &APIPayload{SatisfactionRating: rating}
+	out := new(APIPayload)
+	err := c.post(fmt.Sprintf("/api/v2/tickets/%d/satisfaction_rating.json", ticketID), in, out)
+	return out.SatisfactionRating, err
+}
 
-	return result, err
+// ListSatisfactionRatingReasons lists the reasons customers can give for bad ratings.
+//
+// Zendesk Core API docs: https://developer.zendesk.com/api-reference/ticketing/ticket-management/satisfaction_reasons/#list-reasons-for-satisfaction-rating
+func (c *client) ListSatisfactionRatingReasons() ([]SatisfactionReason, error) {
+	out := new(APIPayload)
+	err := c.get("/api/v2/satisfaction_reasons.json", out)
+	return out.SatisfactionReasons, err
 }
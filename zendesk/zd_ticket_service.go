@@ -1,10 +1,9 @@
 package zendesk
 
 import (
-	"bytes"
+	"errors"
 	"fmt"
 	"io"
-	"log"
 	"net/url"
 	"strconv"
 	"strings"
@@ -27,6 +26,7 @@ type Ticket struct {
 	Priority           string         `json:"priority,omitempty"`
 	Comment            *TicketComment `json:"comment,omitempty"`
 	Status             string         `json:"status,omitempty"`
+	CustomStatusID     int64          `json:"custom_status_id,omitempty"`
 	Recipient          string         `json:"recipient,omitempty"`
 	RequesterID        int64          `json:"requester_id,omitempty"`
 	Requester          *User          `json:"requester,omitempty"`
@@ -53,6 +53,19 @@ type Ticket struct {
 	IsPublic           bool           `json:"is_public"`
 	AdditionalTags     []string       `json:"additional_tags,omitempty"`
 	RemoveTags         []string       `json:"remove_tags,omitempty"`
+
+	// EmailCCs and Followers add or remove email CCs and followers in an update, leaving
+	// the others in place, unlike EmailCCIDs and FollowerIDs.
+	EmailCCs  []TicketCCUpdate `json:"email_ccs,omitempty"`
+	Followers []TicketCCUpdate `json:"followers,omitempty"`
+	// SafeUpdate, with UpdatedStamp set to the UpdatedAt of the ticket as last read, makes
+	// an update fail with ErrConflict when the ticket was changed since, instead of
+	// overwriting the changes.
+	SafeUpdate   bool       `json:"safe_update,omitempty"`
+	UpdatedStamp *time.Time `json:"updated_stamp,omitempty"`
+
+	// Sideloads holds the records requested with Include options.
+	Sideloads *Sideloads `json:"-"`
 }
 
 type SAT struct {
@@ -61,17 +74,66 @@ type SAT struct {
 	Comment string `json:"comment"`
 }
 
+// The actions of a TicketCCUpdate.
+const (
+	CCActionPut    = "put"
+	CCActionDelete = "delete"
+)
+
+// TicketCCUpdate adds a user, identified by ID or email, to the email CCs or followers of
+// a ticket, or removes it with CCActionDelete.
+//
+// Zendesk Core API docs: https://developer.zendesk.com/api-reference/ticketing/tickets/tickets/#setting-email-ccs
+type TicketCCUpdate struct {
+	UserID    int64  `json:"user_id,omitempty"`
+	UserEmail string `json:"user_email,omitempty"`
+	UserName  string `json:"user_name,omitempty"`
+	Action    string `json:"action,omitempty"`
+}
+
 type CustomField struct {
 	ID    int64       `json:"id"`
 	Value interface{} `json:"value"`
 }
 
-func (c *client) ShowTicket(id int64) (*Ticket, error) {
+// ShowTicket fetches a ticket by its ID, along with the requested sideloads.
+//
+// Zendesk Core API docs: https://developer.zendesk.com/rest_api/docs/core/tickets#show-ticket
+func (c *client) ShowTicket(id int64, includes ...Include) (*Ticket, error) {
 	out := new(APIPayload)
-	err := c.get(fmt.Sprintf("/api/v2/tickets/%d.json", id), out)
+	err := c.get(withIncludes(fmt.Sprintf("/api/v2/tickets/%d.json", id), includes), out)
+	if out.Ticket != nil {
+		out.Ticket.Sideloads = sideloads(out, includes)
+	}
 	return out.Ticket, err
 }
 
+// withTicketSideloads attaches the sideloads of a response to its tickets.
+func withTicketSideloads(out *APIPayload, includes []Include) []Ticket {
+	if s := sideloads(out, includes); s != nil {
+		for i := range out.Tickets {
+			out.Tickets[i].Sideloads = s
+		}
+	}
+	return out.Tickets
+}
+
+// ShowManyTickets fetches the tickets with the given IDs, along with the requested sideloads,
+// 100 tickets per request. Missing tickets are skipped.
+//
+// Zendesk Core API docs: https://developer.zendesk.com/api-reference/ticketing/tickets/tickets/#show-multiple-tickets
+func (c *client) ShowManyTickets(ids []int64, includes ...Include) ([]Ticket, error) {
+	result := make([]Ticket, 0, len(ids))
+	for _, chunk := range chunkIDs(ids, manyTicketsLimit) {
+		out := new(APIPayload)
+		if err := c.get(withIncludes("/api/v2/tickets/show_many.json?ids="+joinIDs(chunk), includes), out); err != nil {
+			return result, err
+		}
+		result = append(result, withTicketSideloads(out, includes)...)
+	}
+	return result, nil
+}
+
 /*  The implementation below only works for no pagination case.
 
 func (c *client) GetAllTickets() ([]Ticket, error) {
@@ -81,84 +143,237 @@ func (c *client) GetAllTickets() ([]Ticket, error) {
 }
 */
 
+// TicketProgress reports the progress of GetAllTicketsWithOptions and GetTicketsInRange.
+type TicketProgress struct {
+	// TicketID is the ID of the last ticket checked.
+	TicketID int64
+	// Checked is the number of IDs checked so far, out of Total.
+	Checked int
+	Total   int
+	// Found is the number of tickets found so far.
+	Found int
+}
+
+// GetAllTicketsOptions specifies the optional parameters of GetAllTicketsWithOptions.
+type GetAllTicketsOptions struct {
+	// Progress, when set, is called after each ticket ID is checked.
+	Progress func(TicketProgress)
+}
+
+// GetAllTickets fetches the tickets one by one, from the first ticket ID to the highest one.
+// Unlike listing the tickets, this includes the archived tickets.
 func (c *client) GetAllTickets() ([]Ticket, error) {
-	tickets, err := c.getOneByOne(nil)
-	return tickets, err
+	return c.GetAllTicketsWithOptions(nil)
 }
 
-// GetTicketsIncrementally pull the list of tickets modified from a specific time point
+// GetAllTicketsWithOptions is like GetAllTickets, reporting its progress to opts.Progress.
 //
-// https://developer.zendesk.com/rest_api/docs/support/incremental_export
-func (c *client) GetTicketsIncrementally(unixTime int64) ([]Ticket, error) {
-	log.Printf("[zd_ticket_service][GetTicketsIncrementally] Start GetTicketsIncrementally")
-	tickets, err := c.getTicketsIncrementally(unixTime, nil)
-	log.Printf("[zd_ticket_service][GetTicketsIncrementally] Number of tickets: %v", len(tickets))
-	return tickets, err
-}
+// Zendesk Core API docs: https://developer.zendesk.com/api-reference/ticketing/tickets/tickets/#count-tickets
+func (c *client) GetAllTicketsWithOptions(opts *GetAllTicketsOptions) ([]Ticket, error) {
+	if opts == nil {
+		opts = new(GetAllTicketsOptions)
+	}
 
-func (c *client) getTicketsIncrementally(unixTime int64, in interface{}) ([]Ticket, error) {
-	log.Printf("[zd_ticket_service][getTicketsIncrementally] Start getTicketsIncrementally")
-	result := make([]Ticket, 0)
-	payload, err := marshall(in)
+	count, err := c.countTickets()
 	if err != nil {
 		return nil, err
 	}
-
-	headers := map[string]string{}
-	if in != nil {
-		headers["Content-Type"] = "application/json"
+	if count == 0 {
+		return make([]Ticket, 0), nil
 	}
 
-	apiV2 := "/api/v2/incremental/tickets.json?start_time="
-	rel, err := url.Parse(apiV2)
+	maxID, err := c.maxTicketID()
 	if err != nil {
 		return nil, err
 	}
-	url := c.baseURL.ResolveReference(rel)
-	apiStartIndex := strings.Index(url.String(), apiV2)
-	endpoint := fmt.Sprintf("%s%v", apiV2, unixTime)
+	c.logger.Printf("[zd_ticket_service][GetAllTicketsWithOptions] %v tickets, highest ID: %v\n", count, maxID)
 
-	res, err := c.request("GET", endpoint, headers, bytes.NewReader(payload))
-	if err != nil {
-		return nil, err
+	return c.getOneByOne(nil, 1, maxID, opts.Progress)
+}
+
+// GetTicketsInRange fetches the tickets with an ID between startID and endID, both included.
+// Missing and deleted tickets are skipped.
+func (c *client) GetTicketsInRange(startID, endID int64) ([]Ticket, error) {
+	return c.getOneByOne(nil, startID, endID, nil)
+}
+
+// countTickets returns the approximate number of tickets of the account.
+func (c *client) countTickets() (int64, error) {
+	out := struct {
+		Count struct {
+			Value int64 `json:"value"`
+		} `json:"count"`
+	}{}
+	err := c.get("/api/v2/tickets/count.json", &out)
+	return out.Count.Value, err
+}
+
+// maxTicketID returns the highest ID of the tickets that are not archived, which the
+// archived tickets, being older, cannot exceed.
+func (c *client) maxTicketID() (int64, error) {
+	out := new(APIPayload)
+	if err := c.get("/api/v2/tickets.json?sort_by=id&sort_order=desc&per_page=1", out); err != nil {
+		return 0, err
 	}
-	defer res.Body.Close()
+	if len(out.Tickets) == 0 {
+		return 0, nil
+	}
+	return out.Tickets[0].ID, nil
+}
 
-	dataPerPage := new(APIPayload)
-	currentPage := "emptypage"
-	var totalWaitTime int64
-	log.Printf("[zd_ticket_service][getTicketsIncrementally] Start for loop in getTicketsIncrementally")
-	for currentPage != dataPerPage.NextPage {
-		// if too many requests(res.StatusCode == 429), delay sending request
-		if res.StatusCode == 429 {
-			after, err := strconv.ParseInt(res.Header.Get("Retry-After"), 10, 64)
-			log.Printf("[zd_ticket_service][getTicketsIncrementally] too many requests. Wait for %v seconds\n", after)
-			totalWaitTime += after
-			if err != nil {
-				return nil, err
+// GetTicketsIncrementally pull the list of tickets modified from a specific time point
+//
+// https://developer.zendesk.com/rest_api/docs/support/incremental_export
+func (c *client) GetTicketsIncrementally(unixTime int64) ([]Ticket, error) {
+	c.logger.Printf("[zd_ticket_service][GetTicketsIncrementally] Start GetTicketsIncrementally")
+	tickets, err := c.getTicketsIncrementally(unixTime)
+	c.logger.Printf("[zd_ticket_service][GetTicketsIncrementally] Number of tickets: %v", len(tickets))
+	return tickets, err
+}
+
+// GetTicketsIncrementallyWithHandler passes the tickets modified since a specific time point
+// to fn, one page at a time, so that they do not have to be held in memory. The duplicates
+// of consecutive pages are removed. It stops at the first error, returned by fn or not;
+// the pages already handled are not passed again.
+//
+// Zendesk Core API docs: https://developer.zendesk.com/api-reference/ticketing/ticket-management/incremental_exports/#incremental-ticket-export-time-based
+func (c *client) GetTicketsIncrementallyWithHandler(unixTime int64, fn func([]Ticket) error) error {
+	endpoint := fmt.Sprintf("/api/v2/incremental/tickets.json?start_time=%d", unixTime)
+	previous := make(map[string]bool)
+	total := 0
+
+	err := c.forEachPage(endpoint, func(out *APIPayload) error {
+		batch := make([]Ticket, 0, len(out.Tickets))
+		seen := make(map[string]bool)
+		for _, ticket := range out.Tickets {
+			key := fmt.Sprintf("%v %v", ticket.ID, ticket.UpdatedAt)
+			seen[key] = true
+			if !previous[key] {
+				batch = append(batch, ticket)
 			}
-			time.Sleep(time.Duration(after) * time.Second)
-			dataPerPage.NextPage = currentPage
-		} else {
-			err = unmarshall(res, dataPerPage)
-			if err != nil {
+		}
+		previous = seen
+
+		if len(batch) == 0 {
+			return nil
+		}
+		total += len(batch)
+		return fn(batch)
+	})
+
+	c.logger.Printf("[zd_ticket_service][GetTicketsIncrementallyWithHandler] number of records handled: %v\n", total)
+	return err
+}
+
+// TicketExport is the result of GetTicketsIncrementallyWithOptions.
+type TicketExport struct {
+	Tickets []Ticket
+	// AfterCursor resumes a cursor based export later on, as the Cursor of the next one.
+	// When the export ended at EndTime, the last page is exported again.
+	AfterCursor string
+	// EndTime resumes a time based export later on, as the StartTime of the next one.
+	EndTime int64
+}
+
+// GetTicketsIncrementallyWithOptions pulls the tickets modified since the start time or
+// cursor, until the end of the stream or the end time of the options. The duplicates of
+// consecutive pages are removed. When a page after the first one fails, the tickets pulled
+// so far are returned along with a *PartialResultError, and the AfterCursor and EndTime of
+// the export resume it from the failed page.
+//
+// Zendesk Core API docs: https://developer.zendesk.com/api-reference/ticketing/ticket-management/incremental_exports/#incremental-ticket-export-time-based
+func (c *client) GetTicketsIncrementallyWithOptions(opts *IncrementalExportOptions) (*TicketExport, error) {
+	if opts == nil {
+		opts = new(IncrementalExportOptions)
+	}
+	includes := includesOf(opts.Include)
+	cursorBased := opts.CursorBased || opts.Cursor != ""
+
+	params := url.Values{}
+	if opts.PerPage > 0 {
+		params.Set("per_page", strconv.Itoa(opts.PerPage))
+	}
+	if opts.Cursor != "" {
+		params.Set("cursor", opts.Cursor)
+	} else {
+		params.Set("start_time", strconv.FormatInt(opts.StartTime, 10))
+	}
+	path := "/api/v2/incremental/tickets.json"
+	if cursorBased {
+		path = "/api/v2/incremental/tickets/cursor.json"
+	}
+	endpoint := withIncludes(path+"?"+params.Encode(), includes)
+
+	result := &TicketExport{AfterCursor: opts.Cursor, EndTime: opts.StartTime}
+	lastPage := ""
+	for page := 1; ; page++ {
+		out := new(APIPayload)
+		if err := c.get(endpoint, out); err != nil {
+			if page == 1 {
 				return nil, err
 			}
-			result = append(result, dataPerPage.Tickets...)
-			if currentPage == dataPerPage.NextPage {
-				break
+			result.Tickets = getUniqTickets(result.Tickets)
+			partial := &PartialResultError{Records: result.Tickets, PageURL: endpoint, LastPage: lastPage, Err: err}
+			if cursorBased {
+				partial.Cursor = result.AfterCursor
+			}
+			return result, partial
+		}
+
+		reachedEnd := false
+		for _, ticket := range withTicketSideloads(out, includes) {
+			if opts.EndTime != 0 && ticket.UpdatedAt != nil && ticket.UpdatedAt.Unix() >= opts.EndTime {
+				reachedEnd = true
+				continue
 			}
-			currentPage = dataPerPage.NextPage
+			result.Tickets = append(result.Tickets, ticket)
+		}
+		if opts.EndTime != 0 && out.EndTime >= opts.EndTime {
+			reachedEnd = true
 		}
 
-		res, _ = c.request("GET", dataPerPage.NextPage[apiStartIndex:], headers, bytes.NewReader(payload))
+		if reachedEnd {
+			result.EndTime = opts.EndTime
+			break
+		}
+		if out.AfterCursor != "" {
+			result.AfterCursor = out.AfterCursor
+		}
+		if out.EndTime != 0 {
+			result.EndTime = out.EndTime
+		}
+
+		next := out.NextPage
+		if cursorBased {
+			next = out.AfterURL
+		}
+		if out.EndOfStream || next == "" || c.relativeURL(next) == endpoint {
+			break
+		}
+		lastPage = endpoint
+		endpoint = c.relativeURL(next)
+	}
+
+	result.Tickets = getUniqTickets(result.Tickets)
+
+	c.logger.Printf("[zd_ticket_service][GetTicketsIncrementallyWithOptions] number of records pulled: %v\n", len(result.Tickets))
+	return result, nil
+}
 
-		dataPerPage = new(APIPayload)
+func (c *client) getTicketsIncrementally(unixTime int64) ([]Ticket, error) {
+	result := make([]Ticket, 0)
+	endpoint := fmt.Sprintf("/api/v2/incremental/tickets.json?start_time=%d", unixTime)
+	err := c.forEachPage(endpoint, func(page *APIPayload) error {
+		result = append(result, page.Tickets...)
+		return nil
+	})
+	if err != nil {
+		result = getUniqTickets(result)
+		return result, partialResult(err, result)
 	}
-	log.Printf("[zd_ticket_service][getTicketsIncrementally] number of records pulled: %v\n", len(result))
-	log.Printf("[zd_ticket_service][getTicketsIncrementally] total waiting time due to rate limit: %v\n", totalWaitTime)
 
-	return getUniqTickets(result), err
+	c.logger.Printf("[zd_ticket_service][getTicketsIncrementally] number of records pulled: %v\n", len(result))
+	return getUniqTickets(result), nil
 }
 
 // getUniqTickets is to remove the duplicate records due to pagination
@@ -184,9 +399,47 @@ func (c *client) CreateTicket(ticket *Ticket) (*Ticket, error) {
 	in := &APIPayload{Ticket: ticket}
 	out := new(APIPayload)
 	err := c.post("/api/v2/tickets.json", in, out)
+	if err == nil {
+		c.recent.addTicket(out.Ticket)
+	}
 	return out.Ticket, err
 }
 
+// CreateFollowupTicket creates a followup of a closed ticket. The requester and the custom
+// fields of the closed ticket are copied to the followup, unless they are set on ticket.
+//
+// Zendesk Core API docs: https://developer.zendesk.com/api-reference/ticketing/tickets/tickets/#creating-follow-up-tickets
+func (c *client) CreateFollowupTicket(closedTicketID int64, ticket *Ticket) (*Ticket, error) {
+	// The custom fields are copied to the followup, so they are read unsanitized.
+	source, err := c.unsanitized().ShowTicket(closedTicketID)
+	if err != nil {
+		return nil, err
+	}
+	if source.Status != "closed" {
+		return nil, fmt.Errorf("zendesk: ticket %d is %s, only closed tickets can be followed up", closedTicketID, source.Status)
+	}
+
+	followup := *ticket
+	followup.FollowupSourceID = source.ID
+	if followup.RequesterID == 0 && followup.Requester == nil {
+		followup.RequesterID = source.RequesterID
+	}
+
+	set := make(map[int64]bool)
+	for _, field := range followup.CustomFields {
+		set[field.ID] = true
+	}
+	fields := append([]CustomField(nil), followup.CustomFields...)
+	for _, field := range source.CustomFields {
+		if !set[field.ID] && field.Value != nil {
+			fields = append(fields, field)
+		}
+	}
+	followup.CustomFields = fields
+
+	return c.CreateTicket(&followup)
+}
+
 func (c *client) UpdateTicket(id int64, ticket *Ticket) (*Ticket, error) {
 	ticket.AssigneeID = 0 // fixed the error of assignee_id required
 	in := &APIPayload{Ticket: ticket}
@@ -195,14 +448,55 @@ func (c *client) UpdateTicket(id int64, ticket *Ticket) (*Ticket, error) {
 	return out.Ticket, err
 }
 
-func (c *client) BatchUpdateManyTickets(tickets []Ticket) error {
+// UpdateTicketSafe updates a ticket unless it was updated after lastUpdatedAt, typically
+// the UpdatedAt of the ticket as last read, in which case it fails with an
+// *UpdateConflictError, so that concurrent updates do not silently overwrite each other.
+//
+// Zendesk Core API docs: https://developer.zendesk.com/api-reference/ticketing/tickets/tickets/#protecting-against-ticket-update-collisions
+func (c *client) UpdateTicketSafe(id int64, ticket *Ticket, lastUpdatedAt time.Time) (*Ticket, error) {
+	update := *ticket
+	update.SafeUpdate = true
+	update.UpdatedStamp = &lastUpdatedAt
+	updated, err := c.UpdateTicket(id, &update)
+	if errors.Is(err, ErrConflict) {
+		return nil, &UpdateConflictError{TicketID: id, UpdatedStamp: lastUpdatedAt, Err: err}
+	}
+	return updated, err
+}
+
+// UpdateConflictError is returned by UpdateTicketSafe when the ticket was updated by someone
+// else since it was read. It wraps the API error, so that errors.Is(err, ErrConflict) holds.
+type UpdateConflictError struct {
+	TicketID     int64
+	UpdatedStamp time.Time
+	Err          error
+}
+
+func (e *UpdateConflictError) Error() string {
+	return fmt.Sprintf("zendesk: ticket %d updated since %s", e.TicketID, e.UpdatedStamp.Format(time.RFC3339))
+}
+
+// Unwrap returns the API error of the conflict.
+func (e *UpdateConflictError) Unwrap() error {
+	return e.Err
+}
+
+// BatchUpdateManyTickets updates each of the given tickets with its own changes.
+// The update runs as a background job whose status is returned.
+//
+// Zendesk Core API docs: https://developer.zendesk.com/api-reference/ticketing/tickets/tickets/#update-many-tickets
+func (c *client) BatchUpdateManyTickets(tickets []Ticket) (*JobStatus, error) {
 	in := &APIPayload{Tickets: tickets}
 	out := new(APIPayload)
 	err := c.put("/api/v2/tickets/update_many.json", in, out)
-	return err
+	return out.JobStatus, err
 }
 
-func (c *client) BulkUpdateManyTickets(ids []int64, ticket *Ticket) error {
+// BulkUpdateManyTickets applies the same changes to all the given tickets.
+// The update runs as a background job whose status is returned.
+//
+// Zendesk Core API docs: https://developer.zendesk.com/api-reference/ticketing/tickets/tickets/#update-many-tickets
+func (c *client) BulkUpdateManyTickets(ids []int64, ticket *Ticket) (*JobStatus, error) {
 	parsed := []string{}
 	for _, id := range ids {
 		parsed = append(parsed, strconv.FormatInt(id, 10))
@@ -211,21 +505,91 @@ func (c *client) BulkUpdateManyTickets(ids []int64, ticket *Ticket) error {
 	in := &APIPayload{Ticket: ticket}
 	out := new(APIPayload)
 	err := c.put(fmt.Sprintf("/api/v2/tickets/update_many.json?ids=%s", strings.Join(parsed, ",")), in, out)
-	return err
+	return out.JobStatus, err
+}
+
+// MergeTickets merges the source tickets into the target ticket, adding the comments to the
+// target and to each source, which are closed. Empty comments are left to the defaults of
+// Zendesk. The merge runs as a background job whose status is returned.
+//
+// Zendesk Core API docs: https://developer.zendesk.com/api-reference/ticketing/tickets/tickets/#merge-tickets-into-target-ticket
+func (c *client) MergeTickets(targetID int64, sourceIDs []int64, targetComment, sourceComment string) (*JobStatus, error) {
+	in := struct {
+		IDs           []int64 `json:"ids"`
+		TargetComment string  `json:"target_comment,omitempty"`
+		SourceComment string  `json:"source_comment,omitempty"`
+	}{IDs: sourceIDs, TargetComment: targetComment, SourceComment: sourceComment}
+	out := new(APIPayload)
+	err := c.post(fmt.Sprintf("/api/v2/tickets/%d/merge.json", targetID), in, out)
+	return out.JobStatus, err
 }
 
-func (c *client) ListRequestedTickets(userID int64) ([]Ticket, error) {
+func (c *client) ListRequestedTickets(userID int64, includes ...Include) ([]Ticket, error) {
 	out := new(APIPayload)
-	err := c.get(fmt.Sprintf("/api/v2/users/%d/tickets/requested.json", userID), out)
-	return out.Tickets, err
+	err := c.get(withIncludes(fmt.Sprintf("/api/v2/users/%d/tickets/requested.json", userID), includes), out)
+	return withTicketSideloads(out, includes), err
+}
+
+// ListAssignedTickets lists the tickets assigned to a user.
+//
+// Zendesk Core API docs: https://developer.zendesk.com/api-reference/ticketing/tickets/tickets/#list-tickets
+func (c *client) ListAssignedTickets(userID int64, includes ...Include) ([]Ticket, error) {
+	out := new(APIPayload)
+	err := c.get(withIncludes(fmt.Sprintf("/api/v2/users/%d/tickets/assigned.json", userID), includes), out)
+	return withTicketSideloads(out, includes), err
+}
+
+// ListCCdTickets lists the tickets a user is copied on.
+//
+// Zendesk Core API docs: https://developer.zendesk.com/api-reference/ticketing/tickets/tickets/#list-tickets
+func (c *client) ListCCdTickets(userID int64, includes ...Include) ([]Ticket, error) {
+	out := new(APIPayload)
+	err := c.get(withIncludes(fmt.Sprintf("/api/v2/users/%d/tickets/ccd.json", userID), includes), out)
+	return withTicketSideloads(out, includes), err
 }
 
 // ListTicketIncidents list all incidents related to the problem
-func (c *client) ListTicketIncidents(problemID int64) ([]Ticket, error) {
+func (c *client) ListTicketIncidents(problemID int64, includes ...Include) ([]Ticket, error) {
 	out := new(APIPayload)
-	err := c.get(fmt.Sprintf("/api/v2/tickets/%d/incidents.json", problemID), out)
+	err := c.get(withIncludes(fmt.Sprintf("/api/v2/tickets/%d/incidents.json", problemID), includes), out)
 
-	return out.Tickets, err
+	return withTicketSideloads(out, includes), err
+}
+
+// ListTicketsOptions specifies the optional parameters of GetTicketsByOrganization.
+type ListTicketsOptions struct {
+	// PerPage is the number of tickets per page, up to 100.
+	PerPage int `url:"per_page,omitempty"`
+	// SortBy is one of assignee, created_at, id, priority, requester, status, subject or updated_at.
+	SortBy    string `url:"sort_by,omitempty"`
+	SortOrder string `url:"sort_order,omitempty"`
+}
+
+// GetTicketsByOrganization fetches all the tickets of an organization, following the pages.
+//
+// Zendesk Core API docs: https://developer.zendesk.com/api-reference/ticketing/tickets/tickets/#list-tickets
+func (c *client) GetTicketsByOrganization(orgID int64, opts *ListTicketsOptions, includes ...Include) ([]Ticket, error) {
+	params, err := query.Values(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]Ticket, 0)
+	endpoint := fmt.Sprintf("/api/v2/organizations/%d/tickets.json", orgID)
+	if len(params) > 0 {
+		endpoint += "?" + params.Encode()
+	}
+
+	err = c.forEachPage(withIncludes(endpoint, includes), func(page *APIPayload) error {
+		result = append(result, withTicketSideloads(page, includes)...)
+		return nil
+	})
+	if err != nil {
+		return result, partialResult(err, result)
+	}
+
+	c.logger.Printf("[zd_ticket_service][GetTicketsByOrganization] number of records pulled: %v\n", len(result))
+	return result, nil
 }
 
 // DeleteTickets deletes a Ticket.
@@ -235,6 +599,60 @@ func (c *client) DeleteTicket(id int64) error {
 	return c.delete(fmt.Sprintf("/api/v2/tickets/%d.json", id), nil)
 }
 
+// manyTicketsLimit is the maximum number of tickets of the show many and bulk deletion endpoints.
+const manyTicketsLimit = 100
+
+// DeleteManyTickets deletes up to 100 tickets. The deletion runs as a background job whose
+// status is returned.
+//
+// Zendesk Core API docs: https://developer.zendesk.com/api-reference/ticketing/tickets/tickets/#bulk-delete-tickets
+func (c *client) DeleteManyTickets(ids []int64) (*JobStatus, error) {
+	if len(ids) > manyTicketsLimit {
+		return nil, fmt.Errorf("zendesk: at most %d tickets can be deleted at once, got %d", manyTicketsLimit, len(ids))
+	}
+
+	out := new(APIPayload)
+	err := c.delete("/api/v2/tickets/destroy_many.json?ids="+joinIDs(ids), out)
+	return out.JobStatus, err
+}
+
+// DeleteTickets deletes any number of tickets, in bulk deletions of 100 tickets whose job
+// statuses are returned. On failure, the jobs already started are returned along with the error.
+//
+// Zendesk Core API docs: https://developer.zendesk.com/api-reference/ticketing/tickets/tickets/#bulk-delete-tickets
+func (c *client) DeleteTickets(ids []int64) ([]*JobStatus, error) {
+	jobs, err := deleteTicketChunks(ids, c.DeleteManyTickets, nil)
+	if err != nil {
+		return jobs, err
+	}
+
+	c.logger.Printf("[zd_ticket_service][DeleteTickets] %d tickets deleted in %d jobs\n", len(ids), len(jobs))
+	return jobs, nil
+}
+
+// deleteTicketChunks deletes tickets with deleteMany in bulk deletions of up to 100 tickets,
+// and returns the job statuses of the deletions started. If prepare is not nil, it is called
+// with the tickets of each bulk deletion before it is started, and stops the deletions when
+// it fails.
+func deleteTicketChunks(ids []int64, deleteMany func([]int64) (*JobStatus, error), prepare func([]int64) error) ([]*JobStatus, error) {
+	chunks := chunkIDs(ids, manyTicketsLimit)
+	jobs := make([]*JobStatus, 0, len(chunks))
+	for _, chunk := range chunks {
+		if prepare != nil {
+			if err := prepare(chunk); err != nil {
+				return jobs, err
+			}
+		}
+
+		job, err := deleteMany(chunk)
+		if err != nil {
+			return jobs, err
+		}
+		jobs = append(jobs, job)
+	}
+	return jobs, nil
+}
+
 // Upload represents a Zendesk file upload.
 type Upload struct {
 	Token       string       `json:"token"`
@@ -271,36 +689,13 @@ func (c *client) UploadFile(filename string, token string, filecontent io.Reader
 	if err != nil {
 		return nil, err
 	}
+	defer res.Body.Close()
 
 	out := new(APIPayload)
-	err = unmarshall(res, out)
+	err = c.decode(res, out)
 	return out.Upload, err
 }
 
-type TicketForm struct {
-	URL                string     `json:"url,omitempty"`
-	ID                 int64      `json:"id,omitempty"`
-	Name               string     `json:"name,omitempty"`
-	RawName            string     `json:"raw_name,omitempty"`
-	DisplayName        string     `json:"display_name,omitempty"`
-	RawDisplayName     string     `json:"raw_display_name,omitempty"`
-	EndUserVisible     bool       `json:"end_user_visible,omitempty"`
-	Position           int64      `json:"position,omitempty"`
-	TicketFieldIDs     []int64    `json:"ticket_field_ids,omitempty"`
-	Active             bool       `json:"active,omitempty"`
-	Default            bool       `json:"default,omitempty"`
-	CreatedAt          *time.Time `json:"created_at,omitempty"`
-	UpdatedAt          *time.Time `json:"updated_at,omitempty"`
-	InAllBrands        bool       `json:"in_all_brands,omitempty"`
-	RestrictedBrandIDs []int64    `json:"restricted_brand_ids,omitempty"`
-}
-
-func (c *client) ListTicketForms() ([]TicketForm, error) {
-	out := new(APIPayload)
-	err := c.get(fmt.Sprintf("/api/v2/ticket_forms.json"), out)
-	return out.TicketForms, err
-}
-
 type TicketField struct {
 	ID                  int64               `json:"id,omitempty"`
 	Type                TicketFieldType     `json:"type,omitempty"`
@@ -310,9 +705,13 @@ type TicketField struct {
 	Active              bool                `json:"active,omitempty"`
 	Required            bool                `json:"required,omitempty"`
 	RegexpForValidation string              `json:"regexp_for_validation,omitempty"`
-	VisibleInPortal     bool                `json:"visible_in_portal,omitempty"`
-	EditableInPortal    bool                `json:"editable_in_portal,omitempty"`
-	RequiredInPortal    bool                `json:"required_in_portal,omitempty"`
+	VisibleInPortal     *bool               `json:"visible_in_portal,omitempty"`
+	EditableInPortal    *bool               `json:"editable_in_portal,omitempty"`
+	RequiredInPortal    *bool               `json:"required_in_portal,omitempty"`
+	TitleInPortal       string              `json:"title_in_portal,omitempty"`
+	AgentDescription    string              `json:"agent_description,omitempty"`
+	CollapsedForAgents  bool                `json:"collapsed_for_agents,omitempty"`
+	Removable           bool                `json:"removable,omitempty"`
 	CreatedAt           *time.Time          `json:"created_at,omitempty"`
 	UpdatedAt           *time.Time          `json:"updated_at,omitempty"`
 	SystemFieldOptions  []SystemFieldOption `json:"system_field_options,omitempty"`
@@ -359,6 +758,11 @@ const (
 	DecimalType  TicketFieldType = "decimal"
 	RegExpType   TicketFieldType = "regexp"
 	TaggerType   TicketFieldType = "tagger"
+
+	// Custom field types of user and organization fields
+	DropdownType    TicketFieldType = "dropdown"
+	MultiSelectType TicketFieldType = "multiselect"
+	LookupType      TicketFieldType = "lookup"
 )
 
 func (c *client) AddTicketTags(id int64, tags []string) ([]string, error) {
@@ -368,3 +772,63 @@ func (c *client) AddTicketTags(id int64, tags []string) ([]string, error) {
 
 	return out.Tags, err
 }
+
+// setTagsPayload is the body of the set tags endpoints. Unlike APIPayload, it sends an
+// empty list of tags, which clears them.
+type setTagsPayload struct {
+	Tags []string `json:"tags"`
+}
+
+func newSetTagsPayload(tags []string) *setTagsPayload {
+	if tags == nil {
+		tags = []string{}
+	}
+	return &setTagsPayload{Tags: tags}
+}
+
+// SetTicketTags replaces the tags of a ticket. Setting no tags clears them.
+//
+// Zendesk Core API docs: https://developer.zendesk.com/api-reference/ticketing/ticket-management/tags/#set-tags
+func (c *client) SetTicketTags(id int64, tags []string) ([]string, error) {
+	in := newSetTagsPayload(tags)
+	out := new(APIPayload)
+	err := c.post(fmt.Sprintf("/api/v2/tickets/%d/tags.json", id), in, out)
+	return out.Tags, err
+}
+
+// RemoveTicketTags removes tags from a ticket.
+//
+// Zendesk Core API docs: https://developer.zendesk.com/api-reference/ticketing/ticket-management/tags/#remove-tags
+func (c *client) RemoveTicketTags(id int64, tags []string) error {
+	in := &APIPayload{Tags: tags}
+	return c.do("DELETE", fmt.Sprintf("/api/v2/tickets/%d/tags.json", id), in, nil)
+}
+
+// safeTagUpdate is the payload of the tag changes failing when the ticket was updated
+// after updatedStamp.
+type safeTagUpdate struct {
+	Tags         []string  `json:"tags"`
+	SafeUpdate   bool      `json:"safe_update"`
+	UpdatedStamp time.Time `json:"updated_stamp"`
+}
+
+// AddTicketTagsSafely adds tags to a ticket unless it was updated after updatedStamp,
+// typically the UpdatedAt of the ticket as last read, in which case it fails with
+// ErrConflict rather than overwriting a concurrent change of the tags.
+//
+// Zendesk Core API docs: https://developer.zendesk.com/api-reference/ticketing/ticket-management/tags/#add-tags
+func (c *client) AddTicketTagsSafely(id int64, tags []string, updatedStamp time.Time) ([]string, error) {
+	in := &safeTagUpdate{Tags: tags, SafeUpdate: true, UpdatedStamp: updatedStamp}
+	out := new(APIPayload)
+	err := c.put(fmt.Sprintf("/api/v2/tickets/%d/tags.json", id), in, out)
+	return out.Tags, err
+}
+
+// RemoveTicketTagsSafely removes tags from a ticket unless it was updated after
+// updatedStamp, in which case it fails with ErrConflict.
+//
+// Zendesk Core API docs: https://developer.zendesk.com/api-reference/ticketing/ticket-management/tags/#remove-tags
+func (c *client) RemoveTicketTagsSafely(id int64, tags []string, updatedStamp time.Time) error {
+	in := &safeTagUpdate{Tags: tags, SafeUpdate: true, UpdatedStamp: updatedStamp}
+	return c.do("DELETE", fmt.Sprintf("/api/v2/tickets/%d/tags.json", id), in, nil)
+}
@@ -0,0 +1,62 @@
+package zendesk
+
+import (
+	"fmt"
+	"time"
+)
+
+// TicketSkip records an agent skipping a ticket served by guided mode, with the reason
+// they gave.
+//
+// Zendesk Core API docs: https://developer.zendesk.com/api-reference/ticketing/tickets/ticket_skips/
+type TicketSkip struct {
+	ID        int64      `json:"id,omitempty"`
+	TicketID  int64      `json:"ticket_id,omitempty"`
+	UserID    int64      `json:"user_id,omitempty"`
+	Reason    string     `json:"reason,omitempty"`
+	CreatedAt *time.Time `json:"created_at,omitempty"`
+	UpdatedAt *time.Time `json:"updated_at,omitempty"`
+	// Ticket is the skipped ticket, returned when listing the skips.
+	Ticket *Ticket `json:"ticket,omitempty"`
+}
+
+// ListTicketSkips lists the skips of a ticket by all agents.
+//
+// Zendesk Core API docs: https://developer.zendesk.com/api-reference/ticketing/tickets/ticket_skips/#list-ticket-skips
+func (c *client) ListTicketSkips(ticketID int64) ([]TicketSkip, error) {
+	return c.listSkips(fmt.Sprintf("/api/v2/tickets/%d/skips.json", ticketID))
+}
+
+// ListUserSkips lists the tickets skipped by an agent.
+//
+// Zendesk Core API docs: https://developer.zendesk.com/api-reference/ticketing/tickets/ticket_skips/#list-ticket-skips
+func (c *client) ListUserSkips(userID int64) ([]TicketSkip, error) {
+	return c.listSkips(fmt.Sprintf("/api/v2/users/%d/skips.json", userID))
+}
+
+func (c *client) listSkips(endpoint string) ([]TicketSkip, error) {
+	result := make([]TicketSkip, 0)
+	type page struct {
+		Skips    []TicketSkip `json:"skips"`
+		NextPage string       `json:"next_page"`
+	}
+	err := c.forEachPageOf(endpoint, func() interface{} { return new(page) }, func(p interface{}) error {
+		result = append(result, p.(*page).Skips...)
+		return nil
+	})
+	return result, partialResult(err, result)
+}
+
+// CreateTicketSkip records that the authenticated agent skipped the ticket.
+//
+// Zendesk Core API docs: https://developer.zendesk.com/api-reference/ticketing/tickets/ticket_skips/#record-a-new-skip-for-the-current-user
+func (c *client) CreateTicketSkip(skip *TicketSkip) (*TicketSkip, error) {
+	in := struct {
+		Skip *TicketSkip `json:"skip"`
+	}{Skip: skip}
+	out := struct {
+		Skip *TicketSkip `json:"skip"`
+	}{}
+	err := c.post("/api/v2/skips.json", in, &out)
+	return out.Skip, err
+}
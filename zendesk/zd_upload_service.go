@@ -0,0 +1,132 @@
+package zendesk
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/url"
+)
+
+// maxUploadSize is the maximum size of a file uploaded to Zendesk.
+const maxUploadSize = 50 << 20
+
+// ResumableUploadOptions configures UploadFileWithResume.
+type ResumableUploadOptions struct {
+	// Resume continues an interrupted upload, returned by UploadFileWithResume along with
+	// its error. The file is added to the upload token of Resume, with the files uploaded
+	// before it, unless Resume records it as uploaded already.
+	Resume *ResumableUpload
+}
+
+// ResumableUpload records the progress of an upload made by UploadFileWithResume, whose
+// token may hold several files.
+type ResumableUpload struct {
+	FileName string
+	// Token is the upload token to give to a comment, once the upload is complete.
+	Token string
+	// Uploaded tells whether Zendesk confirmed the upload of the file.
+	Uploaded bool
+	// Upload is the last upload response, listing the attachments of the token.
+	Upload *Upload
+}
+
+// UploadFileWithResume uploads a file of up to 50 MB, such as a call recording or a log,
+// as a single attachment named after it. The file is read in memory and sent whole:
+// Zendesk has no chunked upload API, so larger files are refused before anything is sent.
+//
+// The upload is retried by the retry policy of the client only, which does not send it
+// again after a network failure, since the failed attempt may have reached Zendesk.
+// The progress of the upload is then returned along with the error, to be passed back in
+// the Resume option, which adds the file to the same upload token unless it is recorded
+// as uploaded. Resuming sends the whole file again, so when the failed attempt did reach
+// Zendesk, the token ends up with the file attached twice; check the attachments of the
+// token first when that matters.
+//
+// Zendesk Core API docs: https://developer.zendesk.com/api-reference/ticketing/tickets/ticket-attachments/#upload-files
+func (c *client) UploadFileWithResume(filename string, content io.Reader, opts *ResumableUploadOptions) (*ResumableUpload, error) {
+	progress := &ResumableUpload{FileName: filename}
+	if opts != nil && opts.Resume != nil {
+		*progress = *opts.Resume
+		if progress.Uploaded && progress.FileName == filename {
+			return progress, nil
+		}
+		progress.FileName = filename
+		progress.Uploaded = false
+	}
+
+	data, err := ioutil.ReadAll(io.LimitReader(content, maxUploadSize+1))
+	if err != nil {
+		return progress, err
+	}
+	if len(data) > maxUploadSize {
+		return progress, fmt.Errorf("zendesk: %s is over the %d MB limit of Zendesk attachments", filename, maxUploadSize>>20)
+	}
+
+	upload, err := c.UploadFile(filename, progress.Token, bytes.NewReader(data))
+	if err != nil {
+		c.logger.Printf("[zd_upload_service][UploadFileWithResume] %s failed, resume it once known missing: %s\n", filename, err)
+		return progress, err
+	}
+
+	progress.Token = upload.Token
+	progress.Upload = upload
+	progress.Uploaded = true
+	c.logger.Printf("[zd_upload_service][UploadFileWithResume] %s uploaded, %d bytes\n", filename, len(data))
+	return progress, nil
+}
+
+// DeleteUpload deletes an upload, and its attachments, before it is added to a comment.
+//
+// Zendesk Core API docs: https://developer.zendesk.com/api-reference/ticketing/tickets/ticket-attachments/#delete-upload
+func (c *client) DeleteUpload(token string) error {
+	return c.delete(fmt.Sprintf("/api/v2/uploads/%s.json", url.PathEscape(token)), nil)
+}
+
+// AttachedFile is a file to attach to a comment with AttachFilesToTicketComment.
+type AttachedFile struct {
+	Name    string
+	Content io.Reader
+}
+
+// AttachFilesToTicketComment uploads the files under a single upload token and adds the
+// comment to the ticket with them attached. The files are uploaded with
+// UploadFileWithResume, one attachment each, up to the 50 MB limit of Zendesk uploads.
+// When an upload or the comment fails, the files already uploaded are deleted.
+//
+// Zendesk Core API docs: https://developer.zendesk.com/api-reference/ticketing/tickets/ticket-attachments/#attaching-files
+func (c *client) AttachFilesToTicketComment(ticketID int64, comment *TicketComment, files ...AttachedFile) (*Ticket, error) {
+	token := ""
+	for _, file := range files {
+		opts := &ResumableUploadOptions{Resume: &ResumableUpload{FileName: file.Name, Token: token}}
+		progress, err := c.UploadFileWithResume(file.Name, file.Content, opts)
+		if progress != nil && progress.Token != "" {
+			token = progress.Token
+		}
+		if err != nil {
+			c.discardUpload(token)
+			return nil, err
+		}
+	}
+
+	cm := *comment
+	if token != "" {
+		cm.Uploads = append(cm.Uploads, token)
+	}
+	ticket, err := c.AddTicketComment(ticketID, &cm)
+	if err != nil {
+		c.discardUpload(token)
+		return nil, err
+	}
+	return ticket, nil
+}
+
+// discardUpload deletes an upload that will not be attached, logging the failures.
+func (c *client) discardUpload(token string) {
+	if token == "" {
+		return
+	}
+	if err := c.DeleteUpload(token); err != nil {
+		c.logger.Printf("[zd_upload_service][discardUpload] failed to delete upload %s: %s\n", token, err)
+	}
+}
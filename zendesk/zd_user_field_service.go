@@ -0,0 +1,241 @@
+package zendesk
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// FieldDefinition represents a custom field of users or organizations, whose values are
+// stored in the UserFields or OrganizationFields maps under the field key.
+//
+// Zendesk Core API docs: https://developer.zendesk.com/api-reference/ticketing/users/user_fields/
+type FieldDefinition struct {
+	ID                     int64               `json:"id,omitempty"`
+	URL                    string              `json:"url,omitempty"`
+	Key                    string              `json:"key,omitempty"`
+	Type                   TicketFieldType     `json:"type,omitempty"`
+	Title                  string              `json:"title,omitempty"`
+	RawTitle               string              `json:"raw_title,omitempty"`
+	Description            string              `json:"description,omitempty"`
+	RawDescription         string              `json:"raw_description,omitempty"`
+	Position               int64               `json:"position,omitempty"`
+	Active                 bool                `json:"active,omitempty"`
+	System                 bool                `json:"system,omitempty"`
+	RegexpForValidation    string              `json:"regexp_for_validation,omitempty"`
+	Tag                    string              `json:"tag,omitempty"`
+	CustomFieldOptions     []CustomFieldOption `json:"custom_field_options,omitempty"`
+	RelationshipTargetType string              `json:"relationship_target_type,omitempty"`
+	CreatedAt              *time.Time          `json:"created_at,omitempty"`
+	UpdatedAt              *time.Time          `json:"updated_at,omitempty"`
+}
+
+// ListUserFields lists the custom user fields of the account.
+//
+// Zendesk Core API docs: https://developer.zendesk.com/api-reference/ticketing/users/user_fields/#list-user-fields
+func (c *client) ListUserFields() ([]FieldDefinition, error) {
+	out := new(APIPayload)
+	err := c.get("/api/v2/user_fields.json", out)
+	return out.UserFields, err
+}
+
+// ShowUserField fetches a custom user field by its ID.
+//
+// Zendesk Core API docs: https://developer.zendesk.com/api-reference/ticketing/users/user_fields/#show-user-field
+func (c *client) ShowUserField(id int64) (*FieldDefinition, error) {
+	out := new(APIPayload)
+	err := c.get(fmt.Sprintf("/api/v2/user_fields/%d.json", id), out)
+	return out.UserField, err
+}
+
+// CreateUserField creates a custom user field.
+//
+// Zendesk Core API docs: https://developer.zendesk.com/api-reference/ticketing/users/user_fields/#create-user-field
+func (c *client) CreateUserField(field *FieldDefinition) (*FieldDefinition, error) {
+	in := &APIPayload{UserField: field}
+	out := new(APIPayload)
+	err := c.post("/api/v2/user_fields.json", in, out)
+	return out.UserField, err
+}
+
+// UpdateUserField updates a custom user field.
+//
+// Zendesk Core API docs: https://developer.zendesk.com/api-reference/ticketing/users/user_fields/#update-user-field
+func (c *client) UpdateUserField(id int64, field *FieldDefinition) (*FieldDefinition, error) {
+	in := &APIPayload{UserField: field}
+	out := new(APIPayload)
+	err := c.put(fmt.Sprintf("/api/v2/user_fields/%d.json", id), in, out)
+	return out.UserField, err
+}
+
+// DeleteUserField deletes a custom user field.
+//
+// Zendesk Core API docs: https://developer.zendesk.com/api-reference/ticketing/users/user_fields/#delete-user-field
+func (c *client) DeleteUserField(id int64) error {
+	return c.delete(fmt.Sprintf("/api/v2/user_fields/%d.json", id), nil)
+}
+
+// ReorderUserFields sets the position of the custom user fields to their order in ids.
+//
+// Zendesk Core API docs: https://developer.zendesk.com/api-reference/ticketing/users/user_fields/#reorder-user-field
+func (c *client) ReorderUserFields(ids []int64) error {
+	in := struct {
+		IDs []int64 `json:"user_field_ids"`
+	}{IDs: ids}
+	return c.put("/api/v2/user_fields/reorder.json", in, nil)
+}
+
+// ValidateFieldValues checks values, such as the UserFields of a user or the
+// OrganizationFields of an organization, against the definitions of the fields, so that
+// a write is not rejected by Zendesk. Each value must belong to an active field and match
+// its type; nil values, which clear a field, are always valid. The problems found are
+// returned as an *ErrValidation whose details are keyed by field key.
+func ValidateFieldValues(fields []FieldDefinition, values map[string]interface{}) error {
+	byKey := make(map[string]*FieldDefinition, len(fields))
+	for i := range fields {
+		byKey[fields[i].Key] = &fields[i]
+	}
+
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	details := make(map[string][]*APIErrorDetail)
+	for _, key := range keys {
+		value := values[key]
+		if value == nil {
+			continue
+		}
+
+		var problem string
+		field, ok := byKey[key]
+		switch {
+		case !ok:
+			problem = "is not a field of the account"
+		case !field.Active:
+			problem = "is inactive"
+		default:
+			problem = checkFieldValue(field, value)
+		}
+		if problem != "" {
+			details[key] = []*APIErrorDetail{{Type: "InvalidValue", Description: fmt.Sprintf("%s %s", key, problem)}}
+		}
+	}
+
+	if len(details) > 0 {
+		return &ErrValidation{Type: "RecordInvalid", Description: fmt.Sprintf("%d invalid field values", len(details)), Details: details}
+	}
+	return nil
+}
+
+// checkFieldValue returns why value is not valid for field, or an empty string.
+func checkFieldValue(field *FieldDefinition, value interface{}) string {
+	switch field.Type {
+	case TextType, TextAreaType:
+		if _, ok := value.(string); !ok {
+			return "must be a string"
+		}
+	case CheckBoxType:
+		if _, ok := value.(bool); !ok {
+			return "must be a boolean"
+		}
+	case DateType:
+		s, ok := value.(string)
+		if !ok {
+			return "must be a date string"
+		}
+		if _, err := time.Parse("2006-01-02", s); err != nil {
+			return "must be a date formatted as YYYY-MM-DD"
+		}
+	case IntegerType:
+		if f, ok := fieldNumber(value); !ok || f != math.Trunc(f) {
+			return "must be an integer"
+		}
+	case DecimalType:
+		if _, ok := fieldNumber(value); !ok {
+			return "must be a number"
+		}
+	case LookupType:
+		if f, ok := fieldNumber(value); !ok || f != math.Trunc(f) {
+			return "must be a record ID"
+		}
+	case RegExpType:
+		s, ok := value.(string)
+		if !ok {
+			return "must be a string"
+		}
+		re, err := regexp.Compile(field.RegexpForValidation)
+		if err == nil && !re.MatchString(s) {
+			return fmt.Sprintf("must match %s", field.RegexpForValidation)
+		}
+	case DropdownType, TaggerType:
+		s, ok := value.(string)
+		if !ok || !hasFieldOption(field, s) {
+			return "must be the value of an option of the field"
+		}
+	case MultiSelectType:
+		values, ok := fieldStrings(value)
+		if !ok {
+			return "must be a list of option values"
+		}
+		for _, s := range values {
+			if !hasFieldOption(field, s) {
+				return fmt.Sprintf("has %q, which is not the value of an option of the field", s)
+			}
+		}
+	}
+	return ""
+}
+
+// fieldNumber converts the numeric values and numeric strings to float64.
+func fieldNumber(value interface{}) (float64, bool) {
+	switch v := value.(type) {
+	case int:
+		return float64(v), true
+	case int32:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case float32:
+		return float64(v), true
+	case float64:
+		return v, true
+	case string:
+		f, err := strconv.ParseFloat(v, 64)
+		return f, err == nil
+	}
+	return 0, false
+}
+
+// fieldStrings converts a []string, or a []interface{} holding strings as decoded from JSON.
+func fieldStrings(value interface{}) ([]string, bool) {
+	switch v := value.(type) {
+	case []string:
+		return v, true
+	case []interface{}:
+		values := make([]string, 0, len(v))
+		for _, item := range v {
+			s, ok := item.(string)
+			if !ok {
+				return nil, false
+			}
+			values = append(values, s)
+		}
+		return values, true
+	}
+	return nil, false
+}
+
+func hasFieldOption(field *FieldDefinition, value string) bool {
+	for _, option := range field.CustomFieldOptions {
+		if option.Value == value {
+			return true
+		}
+	}
+	return false
+}
@@ -1,11 +1,10 @@
 package zendesk
 
 import (
-	"bytes"
+	"errors"
 	"fmt"
-	"log"
-	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/google/go-querystring/query"
@@ -45,26 +44,59 @@ type User struct {
 	RestrictedAgent     bool                   `json:"restricted_agent,omitempty"`
 	Suspended           bool                   `json:"suspended,omitempty"`
 	UserFields          map[string]interface{} `json:"user_fields,omitempty"`
+
+	// Sideloads holds the records requested with Include options.
+	Sideloads *Sideloads `json:"-"`
 }
 
 // ShowUser fetches a user by its ID.
 //
 // Zendesk Core API docs: https://developer.zendesk.com/rest_api/docs/core/users#show-user
-func (c *client) ShowUser(id int64) (*User, error) {
+func (c *client) ShowUser(id int64, includes ...Include) (*User, error) {
 	out := new(APIPayload)
-	err := c.get(fmt.Sprintf("/api/v2/users/%d.json", id), out)
+	err := c.get(withIncludes(fmt.Sprintf("/api/v2/users/%d.json", id), includes), out)
+	if out.User != nil {
+		out.User.Sideloads = sideloads(out, includes)
+	}
 	return out.User, err
 }
 
-func (c *client) ShowManyUsers(ids []int64) ([]User, error) {
-	sids := []string{}
-	for _, id := range ids {
-		sids = append(sids, strconv.FormatInt(id, 10))
+// ShowCurrentUser fetches the authenticated user.
+//
+// Zendesk Core API docs: https://developer.zendesk.com/api-reference/ticketing/users/users/#show-the-currently-authenticated-user
+func (c *client) ShowCurrentUser() (*User, error) {
+	out := new(APIPayload)
+	err := c.get("/api/v2/users/me.json", out)
+	return out.User, err
+}
+
+// withUserSideloads attaches the sideloads of a response to its users.
+func withUserSideloads(out *APIPayload, includes []Include) []User {
+	if s := sideloads(out, includes); s != nil {
+		for i := range out.Users {
+			out.Users[i].Sideloads = s
+		}
 	}
+	return out.Users
+}
 
-	out := new(APIPayload)
-	err := c.get(fmt.Sprintf("/api/v2/users/show_many.json?ids=%s", strings.Join(sids, ",")), out)
-	return out.Users, err
+// showManyUsersLimit is the maximum number of IDs accepted by the show many users endpoint.
+const showManyUsersLimit = 100
+
+// ShowManyUsers fetches the users with the given IDs, along with the requested sideloads,
+// 100 users per request. Missing users are skipped.
+//
+// Zendesk Core API docs: https://developer.zendesk.com/api-reference/ticketing/users/users/#show-many-users
+func (c *client) ShowManyUsers(ids []int64, includes ...Include) ([]User, error) {
+	result := make([]User, 0, len(ids))
+	for _, chunk := range chunkIDs(ids, showManyUsersLimit) {
+		out := new(APIPayload)
+		if err := c.get(withIncludes("/api/v2/users/show_many.json?ids="+joinIDs(chunk), includes), out); err != nil {
+			return result, err
+		}
+		result = append(result, withUserSideloads(out, includes)...)
+	}
+	return result, nil
 }
 
 // CreateUser creates a user.
@@ -74,6 +106,9 @@ func (c *client) CreateUser(user *User) (*User, error) {
 	in := &APIPayload{User: user}
 	out := new(APIPayload)
 	err := c.post("/api/v2/users.json", in, out)
+	if err == nil {
+		c.recent.addUser(out.User)
+	}
 	return out.User, err
 }
 
@@ -84,6 +119,9 @@ func (c *client) CreateOrUpdateUser(user *User) (*User, error) {
 	in := &APIPayload{User: user}
 	out := new(APIPayload)
 	err := c.post("/api/v2/users/create_or_update.json", in, out)
+	if err == nil {
+		c.recent.addUser(out.User)
+	}
 	return out.User, err
 }
 
@@ -106,6 +144,106 @@ func (c *client) DeleteUser(id int64) (*User, error) {
 	return out.User, err
 }
 
+// UserRelated holds the number of records related to a user.
+//
+// Zendesk Core API docs: https://developer.zendesk.com/api-reference/ticketing/users/users/#show-user-related-information
+type UserRelated struct {
+	AssignedTickets           int64 `json:"assigned_tickets"`
+	RequestedTickets          int64 `json:"requested_tickets"`
+	CCdTickets                int64 `json:"ccd_tickets"`
+	OrganizationSubscriptions int64 `json:"organization_subscriptions"`
+}
+
+// ShowUserRelated fetches the number of tickets assigned to, requested by and copying a user.
+//
+// Zendesk Core API docs: https://developer.zendesk.com/api-reference/ticketing/users/users/#show-user-related-information
+func (c *client) ShowUserRelated(id int64) (*UserRelated, error) {
+	out := new(APIPayload)
+	err := c.get(fmt.Sprintf("/api/v2/users/%d/related.json", id), out)
+	return out.UserRelated, err
+}
+
+// MergeUsers merges the end user sourceID into the end user targetID, which keeps the
+// tickets, identities and memberships of both. The source user is deleted and the
+// merged user is returned.
+//
+// Zendesk Core API docs: https://developer.zendesk.com/api-reference/ticketing/users/users/#merge-end-users
+func (c *client) MergeUsers(sourceID, targetID int64) (*User, error) {
+	in := &APIPayload{User: &User{ID: targetID}}
+	out := new(APIPayload)
+	err := c.put(fmt.Sprintf("/api/v2/users/%d/merge.json", sourceID), in, out)
+	return out.User, err
+}
+
+// MergeSelfWithUser merges the authenticated user into the existing user with the given
+// email and password, and returns the merged user.
+//
+// Zendesk Core API docs: https://developer.zendesk.com/api-reference/ticketing/users/users/#merge-self-with-another-user
+func (c *client) MergeSelfWithUser(email, password string) (*User, error) {
+	in := struct {
+		User struct {
+			Email    string `json:"email"`
+			Password string `json:"password"`
+		} `json:"user"`
+	}{}
+	in.User.Email, in.User.Password = email, password
+	out := new(APIPayload)
+	err := c.put("/api/v2/users/me/merge.json", in, out)
+	return out.User, err
+}
+
+// manyUsersLimit is the maximum number of users of a bulk job.
+const manyUsersLimit = 100
+
+// CreateManyUsers creates up to 100 users. The creation runs as a background job whose
+// status is returned.
+//
+// Zendesk Core API docs: https://developer.zendesk.com/api-reference/ticketing/users/users/#create-many-users
+func (c *client) CreateManyUsers(users []User) (*JobStatus, error) {
+	return c.manyUsers("POST", "/api/v2/users/create_many.json", users)
+}
+
+// UpdateManyUsers updates up to 100 users, each with its own changes. The users are
+// identified by their ID. The update runs as a background job whose status is returned.
+//
+// Zendesk Core API docs: https://developer.zendesk.com/api-reference/ticketing/users/users/#update-many-users
+func (c *client) UpdateManyUsers(users []User) (*JobStatus, error) {
+	return c.manyUsers("PUT", "/api/v2/users/update_many.json", users)
+}
+
+// CreateOrUpdateManyUsers creates or updates up to 100 users, matched by external ID or
+// email. The job runs in the background and its status is returned.
+//
+// Zendesk Core API docs: https://developer.zendesk.com/api-reference/ticketing/users/users/#create-or-update-many-users
+func (c *client) CreateOrUpdateManyUsers(users []User) (*JobStatus, error) {
+	return c.manyUsers("POST", "/api/v2/users/create_or_update_many.json", users)
+}
+
+// DestroyManyUsers deletes up to 100 users. The deletion runs as a background job whose
+// status is returned.
+//
+// Zendesk Core API docs: https://developer.zendesk.com/api-reference/ticketing/users/users/#bulk-delete-users
+func (c *client) DestroyManyUsers(ids []int64) (*JobStatus, error) {
+	if len(ids) > manyUsersLimit {
+		return nil, fmt.Errorf("zendesk: at most %d users can be deleted at once, got %d", manyUsersLimit, len(ids))
+	}
+
+	out := new(APIPayload)
+	err := c.delete("/api/v2/users/destroy_many.json?ids="+joinIDs(ids), out)
+	return out.JobStatus, err
+}
+
+func (c *client) manyUsers(method, endpoint string, users []User) (*JobStatus, error) {
+	if len(users) > manyUsersLimit {
+		return nil, fmt.Errorf("zendesk: at most %d users can be sent at once, got %d", manyUsersLimit, len(users))
+	}
+
+	in := &APIPayload{Users: users}
+	out := new(APIPayload)
+	err := c.do(method, endpoint, in, out)
+	return out.JobStatus, err
+}
+
 // ListUsersOptions specifies the optional parameters for the list users methods.
 type ListUsersOptions struct {
 	ListOptions
@@ -117,38 +255,38 @@ type ListUsersOptions struct {
 // ListOrganizationUsers list the users associated to an organization.
 //
 // Zendesk Core API docs: https://developer.zendesk.com/rest_api/docs/core/users#list-users
-func (c *client) ListOrganizationUsers(id int64, opts *ListUsersOptions) ([]User, error) {
+func (c *client) ListOrganizationUsers(id int64, opts *ListUsersOptions, includes ...Include) ([]User, error) {
 	params, err := query.Values(opts)
 	if err != nil {
 		return nil, err
 	}
 
 	out := new(APIPayload)
-	err = c.get(fmt.Sprintf("/api/v2/organizations/%d/users.json?%s", id, params.Encode()), out)
-	return out.Users, err
+	err = c.get(withIncludes(fmt.Sprintf("/api/v2/organizations/%d/users.json?%s", id, params.Encode()), includes), out)
+	return withUserSideloads(out, includes), err
 }
 
 // ListUsers list of all users.
 //
 // Zendesk Core API docs: https://developer.zendesk.com/rest_api/docs/core/users#list-users
-func (c *client) ListUsers(opts *ListUsersOptions) ([]User, error) {
+func (c *client) ListUsers(opts *ListUsersOptions, includes ...Include) ([]User, error) {
 	params, err := query.Values(opts)
 	if err != nil {
 		return nil, err
 	}
 
 	out := new(APIPayload)
-	err = c.get(fmt.Sprintf("/api/v2/users.json?%s", params.Encode()), out)
-	return out.Users, err
+	err = c.get(withIncludes(fmt.Sprintf("/api/v2/users.json?%s", params.Encode()), includes), out)
+	return withUserSideloads(out, includes), err
 }
 
 // SearchUsers searches users by name or email address.
 //
 // Zendesk Core API docs: https://developer.zendesk.com/rest_api/docs/core/users#search-users
-func (c *client) SearchUsers(query string) ([]User, error) {
+func (c *client) SearchUsers(query string, includes ...Include) ([]User, error) {
 	out := new(APIPayload)
-	err := c.get("/api/v2/users/search.json?query="+query, out)
-	return out.Users, err
+	err := c.get(withIncludes("/api/v2/users/search.json?query="+query, includes), out)
+	return withUserSideloads(out, includes), err
 }
 
 // AddUserTags adds a tag to a user
@@ -161,76 +299,82 @@ func (c *client) AddUserTags(id int64, tags []string) ([]string, error) {
 	return out.Tags, err
 }
 
+// SetUserTags replaces the tags of a user. Setting no tags clears them.
+//
+// Zendesk Core API docs: https://developer.zendesk.com/api-reference/ticketing/ticket-management/tags/#set-tags
+func (c *client) SetUserTags(id int64, tags []string) ([]string, error) {
+	in := newSetTagsPayload(tags)
+	out := new(APIPayload)
+	err := c.post(fmt.Sprintf("/api/v2/users/%d/tags.json", id), in, out)
+	return out.Tags, err
+}
+
+// RemoveUserTags removes tags from a user.
+//
+// Zendesk Core API docs: https://developer.zendesk.com/api-reference/ticketing/ticket-management/tags/#remove-tags
+func (c *client) RemoveUserTags(id int64, tags []string) error {
+	in := &APIPayload{Tags: tags}
+	return c.do("DELETE", fmt.Sprintf("/api/v2/users/%d/tags.json", id), in, nil)
+}
+
 // GetUsersIncrementally pull the list of users modified from a specific time point
 //
 // https://developer.zendesk.com/rest_api/docs/support/incremental_export#incremental-user-export
 func (c *client) GetUsersIncrementally(unixTime int64) ([]User, error) {
-	log.Printf("[zd_user_service][GetUsersIncrementally] Start GetUsersIncrementally")
-	users, err := c.getUsersIncrementally(unixTime, nil)
-	log.Printf("[zd_user_service][GetUsersIncrementally] Number of Users: %v", len(users))
+	c.logger.Printf("[zd_user_service][GetUsersIncrementally] Start GetUsersIncrementally")
+	users, err := c.getUsersIncrementally(unixTime)
+	c.logger.Printf("[zd_user_service][GetUsersIncrementally] Number of Users: %v", len(users))
 	return users, err
 }
 
-func (c *client) getUsersIncrementally(unixTime int64, in interface{}) ([]User, error) {
-	log.Printf("[zd_user_service][getUsersIncrementally] Start getUsersIncrementally")
+func (c *client) getUsersIncrementally(unixTime int64) ([]User, error) {
 	result := make([]User, 0)
-	payload, err := marshall(in)
+	endpoint := fmt.Sprintf("/api/v2/incremental/users.json?start_time=%d", unixTime)
+	err := c.forEachPage(endpoint, func(page *APIPayload) error {
+		result = append(result, page.Users...)
+		return nil
+	})
 	if err != nil {
-		return nil, err
-	}
-
-	headers := map[string]string{}
-	if in != nil {
-		headers["Content-Type"] = "application/json"
+		result = getUniqUsers(result)
+		return result, partialResult(err, result)
 	}
 
-	apiV2 := "/api/v2/incremental/users.json?start_time="
-	url := "https://philhelp.zendesk.com" + apiV2
-	apiStartIndex := strings.Index(url, apiV2)
-	endpoint := fmt.Sprintf("%s%v", apiV2, unixTime)
+	c.logger.Printf("[zd_user_service][getUsersIncrementally] number of records pulled: %v\n", len(result))
+	return getUniqUsers(result), nil
+}
 
-	res, err := c.request("GET", endpoint, headers, bytes.NewReader(payload))
-	if err != nil {
-		return nil, err
-	}
-	defer res.Body.Close()
-
-	dataPerPage := new(APIPayload)
-	currentPage := "emptypage"
-	var totalWaitTime int64
-	log.Printf("[zd_user_service][getUsersIncrementally] Start for loop in getUsersIncrementally")
-	for currentPage != dataPerPage.NextPage {
-
-		// if too many requests(res.StatusCode == 429), delay sending request
-		if res.StatusCode == 429 {
-			after, err := strconv.ParseInt(res.Header.Get("Retry-After"), 10, 64)
-			log.Printf("[zd_user_service][getUsersIncrementally] too many requests. Wait for %v seconds\n", after)
-			totalWaitTime += after
-			if err != nil {
-				return nil, err
-			}
-			time.Sleep(time.Duration(after) * time.Second)
-			dataPerPage.NextPage = currentPage
-		} else {
-			err = unmarshall(res, dataPerPage)
-			if err != nil {
-				return nil, err
-			}
-			result = append(result, dataPerPage.Users...)
-			if currentPage == dataPerPage.NextPage {
-				break
+// GetUsersIncrementallyWithHandler passes the users modified since a specific time point
+// to fn, one page at a time, so that they do not have to be held in memory. The duplicates
+// of consecutive pages are removed. It stops at the first error, returned by fn or not;
+// the pages already handled are not passed again.
+//
+// Zendesk Core API docs: https://developer.zendesk.com/api-reference/ticketing/ticket-management/incremental_exports/#incremental-user-export-time-based
+func (c *client) GetUsersIncrementallyWithHandler(unixTime int64, fn func([]User) error) error {
+	endpoint := fmt.Sprintf("/api/v2/incremental/users.json?start_time=%d", unixTime)
+	previous := make(map[string]bool)
+	total := 0
+
+	err := c.forEachPage(endpoint, func(out *APIPayload) error {
+		batch := make([]User, 0, len(out.Users))
+		seen := make(map[string]bool)
+		for _, user := range out.Users {
+			key := fmt.Sprintf("%v %v", user.ID, user.UpdatedAt)
+			seen[key] = true
+			if !previous[key] {
+				batch = append(batch, user)
 			}
-			currentPage = dataPerPage.NextPage
 		}
+		previous = seen
 
-		res, _ = c.request("GET", dataPerPage.NextPage[apiStartIndex:], headers, bytes.NewReader(payload))
-
-		dataPerPage = new(APIPayload)
-	}
-	log.Printf("[zd_user_service][getUsersIncrementally] number of records pulled: %v\n", len(result))
-	log.Printf("[zd_user_service][getUsersIncrementally] total waiting time due to rate limit: %v\n", totalWaitTime)
+		if len(batch) == 0 {
+			return nil
+		}
+		total += len(batch)
+		return fn(batch)
+	})
 
-	return getUniqUsers(result), err
+	c.logger.Printf("[zd_user_service][GetUsersIncrementallyWithHandler] number of records handled: %v\n", total)
+	return err
 }
 
 // getUniqUsers is to remove the duplicate records due to pagination
@@ -258,70 +402,71 @@ func getUniqUsers(users []User) []User {
 // Zendesk Core API docs: https://developer.zendesk.com/rest_api/docs/core/users#list-users
 
 func (c *client) GetAllUsers() ([]User, error) {
-	users, err := c.getAllUsers("/api/v2/users.json", nil)
+	users, err := c.getAllUsers("/api/v2/users.json")
 	return users, err
 }
 
-func (c *client) getAllUsers(endpoint string, in interface{}) ([]User, error) {
-	result := make([]User, 0)
-	payload, err := marshall(in)
-	if err != nil {
-		return nil, err
-	}
-
-	headers := map[string]string{}
-	if in != nil {
-		headers["Content-Type"] = "application/json"
-	}
+// GetAllUsersOptions specifies how GetAllUsersWithOptions pulls the users.
+type GetAllUsersOptions struct {
+	// UseIncrementalExport pulls the users with the incremental export from time zero
+	// instead of the offset pagination of the users list, which is faster and is not
+	// subject to the paging limits of large accounts.
+	UseIncrementalExport bool
+}
 
-	res, err := c.request("GET", endpoint, headers, bytes.NewReader(payload))
-	if err != nil {
-		return nil, err
+// GetAllUsersWithOptions is like GetAllUsers but lets the caller choose how users are pulled.
+//
+// Zendesk Core API docs: https://developer.zendesk.com/rest_api/docs/support/incremental_export#incremental-user-export
+func (c *client) GetAllUsersWithOptions(opts *GetAllUsersOptions) ([]User, error) {
+	if opts == nil || !opts.UseIncrementalExport {
+		return c.GetAllUsers()
 	}
 
-	apiV2 := "/api/v2/"
-	fieldName := strings.Split(endpoint[len(apiV2):], ".")[0]
-	defer res.Body.Close()
-
-	// APIPayload defined the fields received from Zendesk
-	dataPerPage := new(APIPayload)
-	err = unmarshall(res, dataPerPage)
+	users, err := c.getUsersIncrementally(0)
 	if err != nil {
+		var partial *PartialResultError
+		if errors.As(err, &partial) {
+			partial.Records = getLatestUsers(partial.Records.([]User))
+		}
 		return nil, err
 	}
 
-	apiStartIndex := strings.Index(dataPerPage.NextPage, apiV2)
-	currentPage := endpoint
-
-	var totalWaitTime int64
-	for currentPage != "" {
-		// if too many requests(res.StatusCode == 429), delay sending request
-		if res.StatusCode == 429 {
-			after, err := strconv.ParseInt(res.Header.Get("Retry-After"), 10, 64)
-			if err != nil {
-				return nil, err
-			}
+	users = getLatestUsers(users)
+	c.logger.Printf("[zd_user_service][GetAllUsersWithOptions] number of users pulled: %v\n", len(users))
+	return users, nil
+}
 
-			log.Printf("[zd_user_service][getAllUsers] too many requests. Wait for %v seconds\n", after)
-			totalWaitTime += after
-			time.Sleep(time.Duration(after) * time.Second)
-		} else {
-			if fieldName == "users" {
-				result = append(result, dataPerPage.Users...)
-			}
-			currentPage = dataPerPage.NextPage
+// getLatestUsers keeps the latest version of each user. A user updated while the
+// export runs is returned again in a later page.
+func getLatestUsers(users []User) []User {
+	index := make(map[int64]int)
+	result := make([]User, 0, len(users))
+	for _, user := range users {
+		i, ok := index[user.ID]
+		if !ok {
+			index[user.ID] = len(result)
+			result = append(result, user)
+			continue
 		}
-		res, _ = c.request("GET", dataPerPage.NextPage[apiStartIndex:], headers, bytes.NewReader(payload))
-		dataPerPage = new(APIPayload)
-		err = unmarshall(res, dataPerPage)
-		if err != nil {
-			return nil, err
+		if existing := result[i].UpdatedAt; existing == nil || (user.UpdatedAt != nil && !user.UpdatedAt.Before(*existing)) {
+			result[i] = user
 		}
 	}
-	log.Printf("[zd_user_service][getAllUsers] number of records pulled: %v\n", len(result))
-	log.Printf("[zd_user_service][getAllUsers] total waiting time due to rate limit: %v\n", totalWaitTime)
+	return result
+}
+
+func (c *client) getAllUsers(endpoint string) ([]User, error) {
+	result := make([]User, 0)
+	err := c.forEachPage(endpoint, func(page *APIPayload) error {
+		result = append(result, page.Users...)
+		return nil
+	})
+	if err != nil {
+		return nil, partialResult(err, result)
+	}
 
-	return result, err
+	c.logger.Printf("[zd_user_service][getAllUsers] number of records pulled: %v\n", len(result))
+	return result, nil
 }
 
 //UpdateEndUser updates the info of one end user
@@ -401,3 +546,162 @@ func (c *client) MakeIdentityPrimary(userID, id int64) ([]UserIdentity, error) {
 	err := c.put(fmt.Sprintf("/api/v2/users/%d/identities/%d/make_primary.json", userID, id), nil, out)
 	return out.Identities, err
 }
+
+// VerifyIdentity marks a user identity as verified, without asking the user.
+//
+// Zendesk Core API docs: https://developer.zendesk.com/api-reference/ticketing/users/user_identities/#verify-identity
+func (c *client) VerifyIdentity(userID, id int64) (*UserIdentity, error) {
+	out := new(APIPayload)
+	err := c.put(fmt.Sprintf("/api/v2/users/%d/identities/%d/verify.json", userID, id), nil, out)
+	return out.Identity, err
+}
+
+// RequestIdentityVerification sends the user an email to verify the identity.
+//
+// Zendesk Core API docs: https://developer.zendesk.com/api-reference/ticketing/users/user_identities/#request-user-verification
+func (c *client) RequestIdentityVerification(userID, id int64) error {
+	return c.put(fmt.Sprintf("/api/v2/users/%d/identities/%d/request_verification.json", userID, id), nil, nil)
+}
+
+// ChangeEmailOptions specifies the optional parameters of ChangeUserPrimaryEmail.
+type ChangeEmailOptions struct {
+	// Verify marks the new email as verified, without sending a verification email.
+	Verify bool
+	// KeepOldEmail keeps the previous primary email as a secondary identity.
+	KeepOldEmail bool
+}
+
+// ChangeUserPrimaryEmail replaces the primary email of a user. The email identity is
+// created if needed, optionally verified, then made primary, and the previous primary
+// email is removed. The steps already done are rolled back when one of them fails,
+// so that the user keeps a working primary email.
+//
+// Zendesk Core API docs: https://developer.zendesk.com/rest_api/docs/core/user_identities#make-identity-primary
+func (c *client) ChangeUserPrimaryEmail(userID int64, newEmail string, opts *ChangeEmailOptions) (*UserIdentity, error) {
+	if opts == nil {
+		opts = new(ChangeEmailOptions)
+	}
+
+	identities, err := c.ListIdentities(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	var previous, identity *UserIdentity
+	for i := range identities {
+		if identities[i].Type != "email" {
+			continue
+		}
+		if identities[i].Primary {
+			previous = &identities[i]
+		}
+		if strings.EqualFold(identities[i].Value, newEmail) {
+			identity = &identities[i]
+		}
+	}
+
+	if identity != nil && identity.Primary && (identity.Verified || !opts.Verify) {
+		return identity, nil
+	}
+
+	created := false
+	if identity == nil {
+		identity, err = c.CreateIdentity(userID, &UserIdentity{Type: "email", Value: newEmail, Verified: opts.Verify})
+		if err != nil {
+			return nil, err
+		}
+		created = true
+	}
+
+	rollback := func(step string, err error) (*UserIdentity, error) {
+		if previous != nil && previous.ID != identity.ID && identity.Primary {
+			if _, rerr := c.MakeIdentityPrimary(userID, previous.ID); rerr != nil {
+				c.logger.Printf("[zd_user_service][ChangeUserPrimaryEmail] failed to restore primary identity %d: %s\n", previous.ID, rerr)
+			}
+		}
+		if created {
+			if rerr := c.DeleteIdentity(userID, identity.ID); rerr != nil {
+				c.logger.Printf("[zd_user_service][ChangeUserPrimaryEmail] failed to roll back identity %d: %s\n", identity.ID, rerr)
+			}
+		}
+		return nil, fmt.Errorf("%s: %w", step, err)
+	}
+
+	if opts.Verify && !identity.Verified {
+		verified, err := c.UpdateIdentity(userID, identity.ID, &UserIdentity{Verified: true})
+		if err != nil {
+			return rollback("verifying identity", err)
+		}
+		identity = verified
+	}
+
+	if !identity.Primary {
+		identities, err := c.MakeIdentityPrimary(userID, identity.ID)
+		if err != nil {
+			return rollback("making identity primary", err)
+		}
+		identity.Primary = true
+		for i := range identities {
+			if identities[i].ID == identity.ID {
+				identity = &identities[i]
+			}
+		}
+	}
+
+	if previous != nil && previous.ID != identity.ID && !opts.KeepOldEmail {
+		if err := c.DeleteIdentity(userID, previous.ID); err != nil {
+			return rollback("removing previous identity", err)
+		}
+	}
+
+	return identity, nil
+}
+
+// UserCache resolves user IDs to users, fetching the unknown ones with ShowManyUsers and
+// keeping them for later lookups. It is safe for concurrent use.
+type UserCache struct {
+	client Client
+	mu     sync.Mutex
+	users  map[int64]*User
+}
+
+// NewUserCache creates an empty cache fetching users with the client.
+func NewUserCache(client Client) *UserCache {
+	return &UserCache{client: client, users: make(map[int64]*User)}
+}
+
+// Users returns the users with the given IDs. Users that do not exist, such as deleted
+// ones, are missing from the result.
+func (c *UserCache) Users(ids []int64) (map[int64]*User, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	missing := make([]int64, 0)
+	for _, id := range ids {
+		if _, ok := c.users[id]; !ok && id != 0 {
+			c.users[id] = nil
+			missing = append(missing, id)
+		}
+	}
+
+	if len(missing) > 0 {
+		users, err := c.client.ShowManyUsers(missing)
+		if err != nil {
+			for _, id := range missing {
+				delete(c.users, id)
+			}
+			return nil, err
+		}
+		for i := range users {
+			c.users[users[i].ID] = &users[i]
+		}
+	}
+
+	result := make(map[int64]*User)
+	for _, id := range ids {
+		if user := c.users[id]; user != nil {
+			result[id] = user
+		}
+	}
+	return result, nil
+}
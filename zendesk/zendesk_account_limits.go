@@ -0,0 +1,225 @@
+package zendesk
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// Resources with an account limit.
+const (
+	LimitAgents       = "agents"
+	LimitTicketFields = "ticket_fields"
+	LimitTicketForms  = "ticket_forms"
+	LimitTriggers     = "triggers"
+	LimitWebhooks     = "webhooks"
+)
+
+// AccountLimits holds the maximum number of resources of an account. A zero limit is unknown
+// and never enforced.
+type AccountLimits struct {
+	Agents       int `json:"agents,omitempty"`
+	TicketFields int `json:"ticket_fields,omitempty"`
+	TicketForms  int `json:"ticket_forms,omitempty"`
+	Triggers     int `json:"triggers,omitempty"`
+	Webhooks     int `json:"webhooks,omitempty"`
+}
+
+// DefaultAccountLimits are the limits documented by Zendesk for all plans.
+// The number of agents depends on the subscription of the account.
+var DefaultAccountLimits = AccountLimits{
+	TicketFields: 400,
+	TicketForms:  300,
+	Triggers:     7000,
+	Webhooks:     1000,
+}
+
+// AccountUsage holds the number of resources used in an account. Ticket fields only
+// count the custom fields.
+type AccountUsage struct {
+	Agents       int `json:"agents"`
+	TicketFields int `json:"ticket_fields"`
+	TicketForms  int `json:"ticket_forms"`
+	Triggers     int `json:"triggers"`
+	Webhooks     int `json:"webhooks"`
+}
+
+// LimitWarning reports an operation that would take a resource past its limit.
+type LimitWarning struct {
+	Resource string
+	Limit    int
+	Usage    int
+	// Added is the number of resources the operation would add.
+	Added int
+}
+
+func (w LimitWarning) String() string {
+	return fmt.Sprintf("%d %s would exceed the limit of %d, %d are used", w.Added, w.Resource, w.Limit, w.Usage)
+}
+
+// ErrLimitExceeded is returned by operations refused because they would exceed account limits.
+type ErrLimitExceeded struct {
+	Warnings []LimitWarning
+}
+
+func (e *ErrLimitExceeded) Error() string {
+	msgs := make([]string, 0, len(e.Warnings))
+	for _, w := range e.Warnings {
+		msgs = append(msgs, w.String())
+	}
+	return "zendesk: account limit exceeded: " + strings.Join(msgs, ", ")
+}
+
+// CheckLimits returns a warning for each resource whose usage would exceed its limit once
+// the added resources are created. Removed resources are expected to be deducted from added.
+func CheckLimits(limits *AccountLimits, usage, added *AccountUsage) []LimitWarning {
+	warnings := make([]LimitWarning, 0)
+	check := func(resource string, limit, used, add int) {
+		if limit > 0 && add > 0 && used+add > limit {
+			warnings = append(warnings, LimitWarning{Resource: resource, Limit: limit, Usage: used, Added: add})
+		}
+	}
+
+	check(LimitAgents, limits.Agents, usage.Agents, added.Agents)
+	check(LimitTicketFields, limits.TicketFields, usage.TicketFields, added.TicketFields)
+	check(LimitTicketForms, limits.TicketForms, usage.TicketForms, added.TicketForms)
+	check(LimitTriggers, limits.Triggers, usage.Triggers, added.Triggers)
+	check(LimitWebhooks, limits.Webhooks, usage.Webhooks, added.Webhooks)
+	return warnings
+}
+
+// GetAccountLimits returns DefaultAccountLimits, completed with the maximum number of
+// agents of the subscription when the credentials are allowed to read it.
+//
+// Zendesk Core API docs: https://developer.zendesk.com/api-reference/ticketing/account-configuration/account_settings/
+func (c *client) GetAccountLimits() (*AccountLimits, error) {
+	limits := DefaultAccountLimits
+
+	out := struct {
+		Subscription struct {
+			MaxAgents int `json:"max_agents"`
+		} `json:"subscription"`
+	}{}
+	err := c.get("/api/v2/account/subscription.json", &out)
+	switch {
+	case err == nil:
+		limits.Agents = out.Subscription.MaxAgents
+	case errors.Is(err, ErrForbidden), errors.Is(err, ErrNotFound):
+		c.logger.Printf("[zendesk_account_limits][GetAccountLimits] subscription not readable: %s\n", err)
+	default:
+		return nil, err
+	}
+
+	return &limits, nil
+}
+
+// GetAccountUsage counts the agents, custom ticket fields, ticket forms, triggers and webhooks of the account.
+//
+// Zendesk Core API docs: https://developer.zendesk.com/api-reference/ticketing/users/users/#count-users
+func (c *client) GetAccountUsage() (*AccountUsage, error) {
+	usage := new(AccountUsage)
+
+	count := struct {
+		Count struct {
+			Value int `json:"value"`
+		} `json:"count"`
+	}{}
+	params := url.Values{"role[]": []string{"agent", "admin"}}
+	if err := c.get("/api/v2/users/count.json?"+params.Encode(), &count); err != nil {
+		return nil, err
+	}
+	usage.Agents = count.Count.Value
+
+	spec, err := c.ExportProvisioningSpec()
+	if err != nil {
+		return nil, err
+	}
+	usage.TicketFields = countCustomFields(spec.TicketFields)
+	usage.TicketForms = len(spec.TicketForms)
+	usage.Triggers = len(spec.Triggers)
+
+	newPage := func() interface{} { return new(webhookPage) }
+	nextPage := func(page interface{}) string {
+		if p := page.(*webhookPage); p.Meta.HasMore {
+			return p.Links.Next
+		}
+		return ""
+	}
+	err = c.paginate("/api/v2/webhooks?page[size]=100", map[string]string{}, newPage, nextPage, func(page interface{}) error {
+		usage.Webhooks += len(page.(*webhookPage).Webhooks)
+		return nil
+	})
+	if err != nil {
+		return usage, partialResult(err, usage)
+	}
+
+	return usage, nil
+}
+
+// webhookPage is a page of the cursor paginated webhook listing, of which only the IDs
+// are decoded.
+type webhookPage struct {
+	Webhooks []struct {
+		ID string `json:"id"`
+	} `json:"webhooks"`
+	Meta struct {
+		HasMore bool `json:"has_more"`
+	} `json:"meta"`
+	Links struct {
+		Next string `json:"next"`
+	} `json:"links"`
+}
+
+func countCustomFields(fields []TicketField) int {
+	n := 0
+	for _, field := range fields {
+		if !isSystemFieldType(field.Type) {
+			n++
+		}
+	}
+	return n
+}
+
+// provisioningGrowth returns the number of resources a plan adds, net of its deletions.
+func provisioningGrowth(plan *ProvisioningPlan) *AccountUsage {
+	growth := new(AccountUsage)
+	for _, change := range plan.Changes {
+		delta := 0
+		switch change.Action {
+		case ProvisioningCreate:
+			delta = 1
+		case ProvisioningDelete:
+			delta = -1
+		}
+
+		switch change.Resource {
+		case ProvisioningTicketField:
+			growth.TicketFields += delta
+		case ProvisioningTicketForm:
+			growth.TicketForms += delta
+		case ProvisioningTrigger:
+			growth.Triggers += delta
+		}
+	}
+	return growth
+}
+
+// checkProvisioningLimits logs a warning for each limit the plan would exceed, and
+// returns them as an ErrLimitExceeded.
+func checkProvisioningLimits(logger Logger, limits *AccountLimits, current *ProvisioningSpec, plan *ProvisioningPlan) error {
+	usage := &AccountUsage{
+		TicketFields: countCustomFields(current.TicketFields),
+		TicketForms:  len(current.TicketForms),
+		Triggers:     len(current.Triggers),
+	}
+
+	warnings := CheckLimits(limits, usage, provisioningGrowth(plan))
+	if len(warnings) == 0 {
+		return nil
+	}
+	for _, w := range warnings {
+		logger.Printf("[zendesk_account_limits][checkProvisioningLimits] %s\n", w)
+	}
+	return &ErrLimitExceeded{Warnings: warnings}
+}
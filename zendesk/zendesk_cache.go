@@ -0,0 +1,97 @@
+package zendesk
+
+import (
+	"sync"
+)
+
+// cachedList holds a list fetched on first use. Concurrent callers wait for a single fetch.
+type cachedList struct {
+	mu     sync.Mutex
+	loaded bool
+	value  interface{}
+}
+
+func (l *cachedList) get(load func() (interface{}, error)) (interface{}, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.loaded {
+		return l.value, nil
+	}
+	value, err := load()
+	if err != nil {
+		return nil, err
+	}
+	l.value, l.loaded = value, true
+	return value, nil
+}
+
+func (l *cachedList) invalidate() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.value, l.loaded = nil, false
+}
+
+// clientCaches holds the rarely changing account configuration, shared by the clients
+// derived from the same client.
+type clientCaches struct {
+	ticketFields cachedList
+	ticketForms  cachedList
+	brands       cachedList
+	currentUser  cachedList
+	capabilities cachedList
+}
+
+func newClientCaches() *clientCaches {
+	return new(clientCaches)
+}
+
+// CachedTicketFields returns the ticket fields of the account, fetched on first use then
+// cached until InvalidateSchemas is called or the fields are changed through the client.
+func (c *client) CachedTicketFields() ([]TicketField, error) {
+	value, err := c.caches.ticketFields.get(func() (interface{}, error) {
+		return c.ListTicketFields()
+	})
+	if err != nil {
+		return nil, err
+	}
+	return append([]TicketField(nil), value.([]TicketField)...), nil
+}
+
+// CachedTicketForms returns the ticket forms of the account, fetched on first use then
+// cached until InvalidateSchemas is called or the forms are changed through the client.
+func (c *client) CachedTicketForms() ([]TicketForm, error) {
+	value, err := c.caches.ticketForms.get(func() (interface{}, error) {
+		return c.ListTicketForms()
+	})
+	if err != nil {
+		return nil, err
+	}
+	return append([]TicketForm(nil), value.([]TicketForm)...), nil
+}
+
+// CachedBrands returns the brands of the account, fetched on first use then cached
+// until InvalidateBrands is called or the brands are changed through the client.
+func (c *client) CachedBrands() ([]Brand, error) {
+	value, err := c.caches.brands.get(func() (interface{}, error) {
+		return c.ListBrands()
+	})
+	if err != nil {
+		return nil, err
+	}
+	return append([]Brand(nil), value.([]Brand)...), nil
+}
+
+// InvalidateSchemas drops the cached ticket fields and forms, which are fetched again on
+// next use. Changes made through the client invalidate them already; this is for changes
+// made by admins or other integrations.
+func (c *client) InvalidateSchemas() {
+	c.caches.ticketFields.invalidate()
+	c.caches.ticketForms.invalidate()
+}
+
+// InvalidateBrands drops the cached brands, which are fetched again on next use.
+func (c *client) InvalidateBrands() {
+	c.caches.brands.invalidate()
+}
@@ -0,0 +1,84 @@
+package zendesk
+
+import (
+	"errors"
+)
+
+// Capabilities describes the features available to an account, which vary with its plan
+// and how it was provisioned, so that shared code can adapt to each account.
+type Capabilities struct {
+	// CursorPagination is true when the list endpoints support cursor based pagination.
+	CursorPagination bool
+	// CustomStatuses is true when custom ticket statuses are enabled.
+	CustomStatuses bool
+	// Webhooks is true when webhooks can be managed.
+	Webhooks bool
+	// Targets is true when the legacy targets, replaced by webhooks, are still available.
+	Targets bool
+}
+
+// Capabilities detects the features available to the account by probing the API, then
+// caches them for the lifetime of the client. A feature whose endpoint answers with a
+// 403 or 404 status code is reported as unavailable; other failures are returned.
+func (c *client) Capabilities() (*Capabilities, error) {
+	value, err := c.caches.capabilities.get(func() (interface{}, error) {
+		return c.detectCapabilities()
+	})
+	if err != nil {
+		return nil, err
+	}
+	capabilities := *value.(*Capabilities)
+	return &capabilities, nil
+}
+
+func (c *client) detectCapabilities() (*Capabilities, error) {
+	capabilities := new(Capabilities)
+
+	tickets := struct {
+		Meta *struct {
+			HasMore bool `json:"has_more"`
+		} `json:"meta"`
+	}{}
+	ok, err := c.probe("/api/v2/tickets.json?page[size]=1", &tickets)
+	if err != nil {
+		return nil, err
+	}
+	capabilities.CursorPagination = ok && tickets.Meta != nil
+
+	settings := struct {
+		Settings struct {
+			Tickets struct {
+				CustomStatusesEnabled bool `json:"custom_statuses_enabled"`
+			} `json:"tickets"`
+		} `json:"settings"`
+	}{}
+	ok, err = c.probe("/api/v2/account/settings.json", &settings)
+	if err != nil {
+		return nil, err
+	}
+	capabilities.CustomStatuses = ok && settings.Settings.Tickets.CustomStatusesEnabled
+
+	if capabilities.Webhooks, err = c.probe("/api/v2/webhooks?page[size]=1", nil); err != nil {
+		return nil, err
+	}
+	if capabilities.Targets, err = c.probe("/api/v2/targets.json", nil); err != nil {
+		return nil, err
+	}
+
+	c.logger.Printf("[zendesk_capabilities][Capabilities] detected %+v\n", *capabilities)
+	return capabilities, nil
+}
+
+// probe fetches endpoint into out, reporting whether it is available to the account.
+func (c *client) probe(endpoint string, out interface{}) (bool, error) {
+	err := c.get(endpoint, out)
+	switch {
+	case err == nil:
+		return true, nil
+	case errors.Is(err, ErrForbidden), errors.Is(err, ErrNotFound):
+		c.logger.Printf("[zendesk_capabilities][probe] %s not available: %s\n", endpoint, err)
+		return false, nil
+	default:
+		return false, err
+	}
+}
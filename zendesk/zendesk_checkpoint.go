@@ -0,0 +1,121 @@
+package zendesk
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// Checkpoint persists the cursors of incremental exports. Given in the export options, it
+// provides the cursor to start from when the options have none, and is saved the cursor
+// of each page once its records are written to the sink, so an interrupted export resumes
+// after the last page written. Sinks buffering records, such as JSONLSink, may lose the
+// records of the last pages unless they are flushed before the process exits.
+type Checkpoint interface {
+	// Save stores the cursor of the stream.
+	Save(stream string, cursor string) error
+	// Load returns the cursor stored for the stream, or an empty string when none is.
+	Load(stream string) (string, error)
+}
+
+// FileCheckpoint is a Checkpoint storing the cursors of the streams in a JSON file. The
+// file is replaced atomically on each save. It is safe for concurrent use.
+type FileCheckpoint struct {
+	mu   sync.Mutex
+	path string
+}
+
+// NewFileCheckpoint creates a FileCheckpoint stored at path. The file is created on the
+// first save.
+func NewFileCheckpoint(path string) *FileCheckpoint {
+	return &FileCheckpoint{path: path}
+}
+
+// Load returns the cursor saved for the stream.
+func (f *FileCheckpoint) Load(stream string) (string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	cursors, err := f.read()
+	return cursors[stream], err
+}
+
+// Save saves the cursor of the stream, keeping the cursors of the other streams.
+func (f *FileCheckpoint) Save(stream string, cursor string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	cursors, err := f.read()
+	if err != nil {
+		return err
+	}
+	cursors[stream] = cursor
+
+	data, err := json.MarshalIndent(cursors, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp, err := ioutil.TempFile(filepath.Dir(f.path), filepath.Base(f.path)+".*")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), f.path)
+}
+
+func (f *FileCheckpoint) read() (map[string]string, error) {
+	cursors := make(map[string]string)
+	data, err := ioutil.ReadFile(f.path)
+	if os.IsNotExist(err) {
+		return cursors, nil
+	}
+	if err != nil {
+		return cursors, err
+	}
+	if err := json.Unmarshal(data, &cursors); err != nil {
+		return cursors, err
+	}
+	return cursors, nil
+}
+
+// stream returns the name the cursor of an export of the resource is saved under.
+func (opts *IncrementalExportOptions) stream(resource string) string {
+	if opts.Stream != "" {
+		return opts.Stream
+	}
+	return resource
+}
+
+// resume returns a copy of the options starting from the cursor of their checkpoint when
+// they have none.
+func (opts *IncrementalExportOptions) resume(resource string) (*IncrementalExportOptions, error) {
+	o := *opts
+	if o.Checkpoint == nil || o.Cursor != "" {
+		return &o, nil
+	}
+
+	cursor, err := o.Checkpoint.Load(o.stream(resource))
+	if err != nil {
+		return &o, err
+	}
+	o.Cursor = cursor
+	return &o, nil
+}
+
+// save saves the cursor of a page to the checkpoint of the options.
+func (opts *IncrementalExportOptions) save(resource, cursor string) error {
+	if opts.Checkpoint == nil || cursor == "" {
+		return nil
+	}
+	return opts.Checkpoint.Save(opts.stream(resource), cursor)
+}
@@ -2,72 +2,306 @@ package zendesk
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
-	"log"
+	"io/ioutil"
 	"net/http"
 	"net/url"
+	"reflect"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 )
 
 // Client describes a client for the Zendesk Core API.
 type Client interface {
 	WithHeader(name, value string) Client
-
+	WithMarketplaceApp(name string, organizationID, appID int64) Client
+	WithRetryPolicy(RetryPolicy) Client
+	WithEndpointPolicy(EndpointFamily, EndpointPolicy) Client
+	WithConcurrency(int) Client
+	WithHTTPClient(*http.Client) Client
+	WithTimeout(time.Duration) Client
+	WithMiddlewareReplaced(string, MiddlewareFunction) Client
+	WithoutMiddleware(string) Client
+
+	AddOrganizationTags(int64, []string) ([]string, error)
 	AddUserTags(int64, []string) ([]string, error)
+	AddTicketComment(int64, *TicketComment) (*Ticket, error)
 	AddTicketTags(int64, []string) ([]string, error)
-	BatchUpdateManyTickets([]Ticket) error
-	BulkUpdateManyTickets([]int64, *Ticket) error
+	AddTicketTagsSafely(int64, []string, time.Time) ([]string, error)
+	ApplyProvisioningSpec(*ProvisioningSpec, *ProvisioningOptions) (*ProvisioningPlan, error)
+	AttachFilesToTicketComment(int64, *TicketComment, ...AttachedFile) (*Ticket, error)
+	AutocompleteOrganizations(string) ([]Organization, error)
+	AutocompleteProblems(string) ([]Ticket, error)
+	AutocompleteTags(string) ([]string, error)
+	BatchUpdateManyTickets([]Ticket) (*JobStatus, error)
+	BulkDeleteSessionsByUserID(int64) error
+	BulkImportTickets([]TicketImport) (*JobStatus, error)
+	BulkUpdateManyTickets([]int64, *Ticket) (*JobStatus, error)
+	CachedBrands() ([]Brand, error)
+	CachedTicketFields() ([]TicketField, error)
+	CachedTicketForms() ([]TicketForm, error)
+	Capabilities() (*Capabilities, error)
+	CheckHostMapping(string, string) (*HostMappingCheck, error)
+	ChangeUserPrimaryEmail(int64, string, *ChangeEmailOptions) (*UserIdentity, error)
+	CloneTicketForm(int64) (*TicketForm, error)
+	CountTicketComments(int64) (int64, error)
+	CreateArticle(int64, *Article) (*Article, error)
+	CreateArticleAttachment(int64, string, io.Reader, bool) (*ArticleAttachment, error)
+	CreateArticleTranslation(int64, *Translation) (*Translation, error)
+	CreateBrand(*Brand) (*Brand, error)
+	CreateCategory(*Category) (*Category, error)
+	CreateCustomRole(*CustomRole) (*CustomRole, error)
+	CreateCustomStatus(*CustomStatus) (*CustomStatus, error)
+	CreateDynamicContentItem(*DynamicContentItem) (*DynamicContentItem, error)
+	CreateDynamicContentVariant(int64, *DynamicContentVariant) (*DynamicContentVariant, error)
+	CreateFollowupTicket(int64, *Ticket) (*Ticket, error)
 	CreateIdentity(int64, *UserIdentity) (*UserIdentity, error)
+	CreateManyOrganizationMemberships([]OrganizationMembership) (*JobStatus, error)
 	CreateOrganization(*Organization) (*Organization, error)
 	CreateOrganizationMembership(*OrganizationMembership) (*OrganizationMembership, error)
+	CreateOrganizationField(*FieldDefinition) (*FieldDefinition, error)
+	CreateManyUsers([]User) (*JobStatus, error)
+	CreateOrUpdateManyUsers([]User) (*JobStatus, error)
+	CreateOrUpdateTicketFieldOption(int64, *CustomFieldOption) (*CustomFieldOption, error)
 	CreateOrUpdateUser(*User) (*User, error)
+	CreateRoutingAttribute(*RoutingAttribute) (*RoutingAttribute, error)
+	CreateRoutingAttributeValue(string, *RoutingAttributeValue) (*RoutingAttributeValue, error)
+	CreateSatisfactionRating(int64, *Score) (*Score, error)
+	CreateSection(int64, *Section) (*Section, error)
+	CreateSharingAgreement(*SharingAgreement) (*SharingAgreement, error)
+	CreateTalkCallbackRequest(*CallbackRequest) error
 	CreateTicket(*Ticket) (*Ticket, error)
+	CreateTicketForm(*TicketForm) (*TicketForm, error)
+	CreateTicketIfNotExists(*Ticket, *SearchOptions) (*Ticket, bool, error)
+	CreateTicketField(*TicketField) (*TicketField, error)
+	CreateTicketSkip(*TicketSkip) (*TicketSkip, error)
 	CreateUser(*User) (*User, error)
+	CreateUserField(*FieldDefinition) (*FieldDefinition, error)
+	CreateVoiceTicket(*VoiceTicket, int64) (*Ticket, error)
+	DeleteArticle(int64) error
+	DeleteBrand(int64) error
+	DeleteCategory(int64) error
+	DeleteCustomRole(int64) error
+	DeleteDynamicContentItem(int64) error
+	DeleteDynamicContentVariant(int64, int64) error
 	DeleteIdentity(int64, int64) error
+	DeleteManyTickets([]int64) (*JobStatus, error)
 	DeleteOrganization(int64) error
+	DeleteOrganizationField(int64) error
+	DeleteRoutingAttribute(string) error
+	DeleteRoutingAttributeValue(string, string) error
+	DeleteSection(int64) error
+	DeleteSession(int64, int64) error
+	DeleteSharingAgreement(int64) error
 	DeleteTicket(int64) error
+	DeleteTicketField(int64) error
+	DeleteTicketFieldOption(int64, int64) error
+	DeleteTicketForm(int64) error
+	DeleteTickets([]int64) ([]*JobStatus, error)
+	DeleteUpload(string) error
 	DeleteUser(int64) (*User, error)
+	DeleteUserField(int64) error
 	DeleteOrganizationMembershipByID(int64) error
+	DetectBestLocale([]string) (*Locale, error)
+	DestroyManyUsers([]int64) (*JobStatus, error)
+	DisplayTicketToAgent(int64, int64) error
+	DisplayUserToAgent(int64, int64) error
+	EnsureDefaultOrganization(int64, int64) (*OrganizationMembership, error)
+	FilterWritableFields(*Ticket) (*Ticket, error)
+	FindUserByEmail(string, *SearchOptions) (*User, error)
+	GetAccountLimits() (*AccountLimits, error)
+	GetAccountUsage() (*AccountUsage, error)
+	ImportTicket(*TicketImport) (*Ticket, error)
+	ImportTickets([]TicketImport, *TicketImportOptions) (*TicketImportResult, error)
+	InvalidateBrands()
+	InvalidateSchemas()
+	LastResponse() *ResponseMeta
+	LinkIncidentToProblem(int64, int64) (*Ticket, error)
+	ListAgentAttributes(int64) ([]RoutingAttributeValue, error)
+	ListArticleAttachments(int64) ([]ArticleAttachment, error)
+	ListArticleComments(int64) ([]ArticleComment, error)
+	ListArticleTranslations(int64) ([]Translation, error)
+	ListArticles(int64) ([]Article, error)
+	ListAssignedTickets(int64, ...Include) ([]Ticket, error)
+	ListAvailablePublicLocales() ([]Locale, error)
+	ListBrands() ([]Brand, error)
+	ListCCdTickets(int64, ...Include) ([]Ticket, error)
+	ListCategories() ([]Category, error)
+	ListCustomRoles() ([]CustomRole, error)
+	ListCustomTicketStatuses(*CustomStatusListOptions) ([]CustomStatus, error)
+	ListDeletedTickets() ([]DeletedTicket, error)
+	ListDeletedUsers() ([]User, error)
+	ListDynamicContentItems() ([]DynamicContentItem, error)
+	ListDynamicContentVariants(int64) ([]DynamicContentVariant, error)
+	ListGreetings() ([]Greeting, error)
 	ListIdentities(int64) ([]UserIdentity, error)
+	ListLines() ([]Line, error)
 	ListLocales() ([]Locale, error)
+	ListLocalesForAgent() ([]Locale, error)
+	ListOrganizationMemberships() ([]OrganizationMembership, error)
+	ListOrganizationMembershipsByOrgID(int64) ([]OrganizationMembership, error)
 	ListOrganizationMembershipsByUserID(id int64) ([]OrganizationMembership, error)
-	ListOrganizations(*ListOptions) ([]Organization, error)
-	ListOrganizationUsers(int64, *ListUsersOptions) ([]User, error)
-	ListRequestedTickets(int64) ([]Ticket, error)
+	ListOrganizationFields() ([]FieldDefinition, error)
+	ListOrganizations(*ListOptions, ...Include) ([]Organization, error)
+	ListOrganizationsForUser(int64) ([]Organization, error)
+	ListOrganizationUsers(int64, *ListUsersOptions, ...Include) ([]User, error)
+	ListPhoneNumbers() ([]PhoneNumber, error)
+	ListProblemTickets(...Include) ([]Ticket, error)
+	ListRequestedTickets(int64, ...Include) ([]Ticket, error)
+	ListRoutingAttributeValues(string) ([]RoutingAttributeValue, error)
+	ListRoutingAttributes() ([]RoutingAttribute, error)
+	ListSatisfactionRatingReasons() ([]SatisfactionReason, error)
+	ListSatisfactionRatings(*ListSatisfactionRatingsOptions) ([]Score, error)
+	ListSections(int64) ([]Section, error)
+	ListSessions(int64) ([]Session, error)
+	ListSharingAgreements() ([]SharingAgreement, error)
+	ListTags() ([]Tag, error)
+	ListTicketAttributes(int64) ([]RoutingAttributeValue, error)
+	ListTicketAudits(int64) ([]TicketAudit, error)
 	ListTicketComments(int64) ([]TicketComment, error)
+	ListTicketCommentsWithOptions(int64, *ListTicketCommentsOptions) ([]TicketComment, error)
 	ListTicketFields() ([]TicketField, error)
+	ListTicketFieldOptions(int64) ([]CustomFieldOption, error)
 	ListTicketForms() ([]TicketForm, error)
-	ListTicketIncidents(int64) ([]Ticket, error)
-	ListUsers(*ListUsersOptions) ([]User, error)
+	ListTicketIncidents(int64, ...Include) ([]Ticket, error)
+	ListTicketSkips(int64) ([]TicketSkip, error)
+	ListUserSkips(int64) ([]TicketSkip, error)
+	ListUsers(*ListUsersOptions, ...Include) ([]User, error)
+	ListUserFields() ([]FieldDefinition, error)
+	Logger() Logger
+	ExportOrganizations(*IncrementalExportOptions, RecordSink) (*ExportCheckpoint, error)
+	ExportProvisioningSpec() (*ProvisioningSpec, error)
+	ExportSatisfactionRatings(*IncrementalExportOptions, RecordSink) (*ExportCheckpoint, error)
+	ExportTickets(*IncrementalExportOptions, RecordSink) (*ExportCheckpoint, error)
+	ExportUsers(*IncrementalExportOptions, RecordSink) (*ExportCheckpoint, error)
+	MakeCommentPrivate(int64, int64) error
 	MakeIdentityPrimary(int64, int64) ([]UserIdentity, error)
-	SearchUsers(string) ([]User, error)
+	MergeSelfWithUser(string, string) (*User, error)
+	MergeTickets(int64, []int64, string, string) (*JobStatus, error)
+	MergeUsers(int64, int64) (*User, error)
+	Middleware() []string
+	PlanProvisioning(*ProvisioningSpec, *ProvisioningOptions) (*ProvisioningPlan, error)
+	RedactCommentString(int64, int64, string) (*TicketComment, error)
+	RedactTicketComment(int64, *CommentRedaction) (*TicketComment, error)
+	RedactTicketCommentAttachment(int64, int64, int64) (*Attachment, error)
+	RemoveOrganizationTags(int64, []string) error
+	RemoveTicketTags(int64, []string) error
+	RemoveTicketTagsSafely(int64, []string, time.Time) error
+	RemoveUserTags(int64, []string) error
+	ReorderOrganizationFields([]int64) error
+	ReorderTicketForms([]int64) error
+	ReorderUserFields([]int64) error
+	RequestIdentityVerification(int64, int64) error
+	SearchArticles(string, string) ([]Article, error)
+	SearchOrganizations(string) ([]Organization, error)
+	SearchUsers(string, ...Include) ([]User, error)
+	SetAgentAttributes(int64, []string) ([]RoutingAttributeValue, error)
+	SetDefaultOrganizationMembership(int64, int64) ([]OrganizationMembership, error)
+	SetOrganizationTags(int64, []string) ([]string, error)
+	SetTicketAttributes(int64, []string) ([]RoutingAttributeValue, error)
+	SetTicketTags(int64, []string) ([]string, error)
+	SetUserTags(int64, []string) ([]string, error)
+	ShowAccountSettings() (*AccountSettings, error)
+	ShowAgentAvailability(int64) (*Availability, error)
+	ShowArticle(int64) (*Article, error)
+	ShowArticleTranslation(int64, string) (*Translation, error)
+	ShowBrand(int64) (*Brand, error)
+	ShowCategory(int64) (*Category, error)
+	ShowCurrentUser() (*User, error)
+	ShowCurrentlyAuthenticatedSession() (*Session, error)
+	ShowCustomRole(int64) (*CustomRole, error)
+	ShowCustomStatus(int64) (*CustomStatus, error)
+	ShowDynamicContentItem(int64) (*DynamicContentItem, error)
+	ShowDynamicContentVariant(int64, int64) (*DynamicContentVariant, error)
+	ShowDeletedUser(int64) (*User, error)
 	ShowIdentity(int64, int64) (*UserIdentity, error)
+	ShowJobStatus(string) (*JobStatus, error)
+	PermanentlyDeleteUser(int64) (*User, error)
+	PurgeManyTickets([]int64) (*JobStatus, error)
+	PurgeTicket(int64) (*JobStatus, error)
+	RestoreManyTickets([]int64) error
+	RestoreTicket(int64) error
 	ShowLocale(int64) (*Locale, error)
 	ShowLocaleByCode(string) (*Locale, error)
-	ShowManyUsers([]int64) ([]User, error)
-	ShowOrganization(int64) (*Organization, error)
-	ShowTicket(int64) (*Ticket, error)
-	ShowUser(int64) (*User, error)
+	ShowManyOrganizations([]int64) ([]Organization, error)
+	ShowManyTickets([]int64, ...Include) ([]Ticket, error)
+	ShowManyUsers([]int64, ...Include) ([]User, error)
+	ShowOrganization(int64, ...Include) (*Organization, error)
+	ShowOrganizationField(int64) (*FieldDefinition, error)
+	ShowOrganizationMembership(int64) (*OrganizationMembership, error)
+	ShowRoutingAttribute(string) (*RoutingAttribute, error)
+	ShowRoutingAttributeValue(string, string) (*RoutingAttributeValue, error)
+	ShowSatisfactionRating(int64) (*Score, error)
+	ShowSection(int64) (*Section, error)
+	ShowSharingAgreement(int64) (*SharingAgreement, error)
+	ShowTicket(int64, ...Include) (*Ticket, error)
+	ShowTicketAudit(int64, int64) (*TicketAudit, error)
+	ShowTicketField(int64) (*TicketField, error)
+	ShowTicketForm(int64) (*TicketForm, error)
+	ShowUser(int64, ...Include) (*User, error)
+	ShowUserField(int64) (*FieldDefinition, error)
+	ShowUserRelated(int64) (*UserRelated, error)
+	UpdateAccountSettings(*AccountSettings) (*AccountSettings, error)
+	UpdateArticle(int64, *Article) (*Article, error)
+	UpdateArticleTranslation(int64, string, *Translation) (*Translation, error)
+	UpdateBrand(int64, *Brand) (*Brand, error)
+	UpdateCategory(int64, *Category) (*Category, error)
+	UpdateCustomRole(int64, *CustomRole) (*CustomRole, error)
+	UpdateCustomStatus(int64, *CustomStatus) (*CustomStatus, error)
+	UpdateDynamicContentItem(int64, *DynamicContentItem) (*DynamicContentItem, error)
+	UpdateDynamicContentVariant(int64, int64, *DynamicContentVariant) (*DynamicContentVariant, error)
 	UpdateIdentity(int64, int64, *UserIdentity) (*UserIdentity, error)
+	UpdateManyUsers([]User) (*JobStatus, error)
 	UpdateOrganization(int64, *Organization) (*Organization, error)
+	UpdateOrganizationField(int64, *FieldDefinition) (*FieldDefinition, error)
+	UpdateRoutingAttribute(string, *RoutingAttribute) (*RoutingAttribute, error)
+	UpdateRoutingAttributeValue(string, string, *RoutingAttributeValue) (*RoutingAttributeValue, error)
+	UpdateSection(int64, *Section) (*Section, error)
+	UpdateSharingAgreement(int64, *SharingAgreement) (*SharingAgreement, error)
 	UpdateTicket(int64, *Ticket) (*Ticket, error)
+	UpdateTicketField(int64, *TicketField) (*TicketField, error)
+	UpdateTicketForm(int64, *TicketForm) (*TicketForm, error)
+	UpdateTicketSafe(int64, *Ticket, time.Time) (*Ticket, error)
 	UpdateUser(int64, *User) (*User, error)
+	UpdateUserField(int64, *FieldDefinition) (*FieldDefinition, error)
 	UploadFile(string, string, io.Reader) (*Upload, error)
+	UploadFileWithResume(string, io.Reader, *ResumableUploadOptions) (*ResumableUpload, error)
+	ValidateTicket(*Ticket) ([]ValidationError, error)
+	VerifyIdentity(int64, int64) (*UserIdentity, error)
+	VoteArticleDown(int64) (*Vote, error)
+	VoteArticleUp(int64) (*Vote, error)
+	WaitForJobCompletion(context.Context, string, time.Duration) (*JobStatus, error)
 	GetAllTickets() ([]Ticket, error)
+	GetAllTicketsWithOptions(*GetAllTicketsOptions) ([]Ticket, error)
+	GetTicketsInRange(int64, int64) ([]Ticket, error)
+	GetTicketsByOrganization(int64, *ListTicketsOptions, ...Include) ([]Ticket, error)
 	GetTicketsIncrementally(int64) ([]Ticket, error)
+	GetTicketsIncrementallyWithHandler(int64, func([]Ticket) error) error
 	GetAllUsers() ([]User, error)
+	GetAllUsersWithOptions(*GetAllUsersOptions) ([]User, error)
 	GetAllTicketMetrics() ([]TicketMetric, error)
 	GetTicketMetricsIncrementally([]int64) ([]TicketMetric, error)
+	GetTicketMetricsWithHandler([]int64, func([]TicketMetric) error) error
 	ShowTicketMetric(int64) (*TicketMetric, error)
+	GetTicketMetricEventsIncrementally(int64) ([]TicketMetricEvent, error)
 	GetAllTicketComments([]int64) (map[int64][]TicketComment, error)
+	GetAllTicketCommentsWithOptions([]int64, *ListTicketCommentsOptions) (map[int64][]TicketComment, error)
+	GetAllTicketCommentsWithHandler([]int64, func(int64, []TicketComment) error) error
+	GetTicketsIncrementallyWithOptions(*IncrementalExportOptions) (*TicketExport, error)
 	GetUsersIncrementally(int64) ([]User, error)
+	GetUsersIncrementallyWithHandler(int64, func([]User) error) error
+	GetOrganizationsIncrementally(int64) ([]Organization, error)
 	GetSatisfactionScores() ([]Score, error)
 	GetSatisfactionScoresIncrementally(int64) ([]Score, error)
 	GetCallLegIncrementally(int64) ([]CallLeg, error)
+	GetCallsIncrementally(int64) ([]Call, error)
+	GetCallLegsIncrementallyWithOptions(*IncrementalCallExportOptions) (*CallLegExport, error)
 }
 
 type RequestFunction func(*http.Request) (*http.Response, error)
@@ -78,43 +312,45 @@ type client struct {
 	username string
 	password string
 
-	client    *http.Client
-	baseURL   *url.URL
-	userAgent string
-	reqFunc   RequestFunction
-	headers   map[string]string
+	client     *http.Client
+	baseURL    *url.URL
+	userAgent  string
+	reqFunc    RequestFunction
+	middleware []namedMiddleware
+	headers    map[string]string
+	endpoints  map[EndpointFamily]*endpointState
+	logger     Logger
+
+	concurrency int
+	recent      *recentWrites
+	caches      *clientCaches
+	responses   *responses
+
+	maxResponseSize int64
+	compression     bool
+	sanitizer       Sanitizer
 }
 
 // NewClient creates a new Client.
 // You can use either a user email/password combination or an API token.
 // For the latter, append /token to the email and use the API token as a password
+//
+// It is kept for compatibility: New with WithBasicAuth and WithMiddleware is equivalent.
 func NewClient(domain, username, password string, middleware ...MiddlewareFunction) (Client, error) {
-	return NewURLClient(fmt.Sprintf("https://%s.zendesk.com", domain), username, password, middleware...)
+	return New(domain, WithBasicAuth(username, password), WithMiddleware(middleware...))
 }
 
 // NewURLClient is like NewClient but accepts an explicit end point instead of a Zendesk domain.
 func NewURLClient(endpoint, username, password string, middleware ...MiddlewareFunction) (Client, error) {
-	baseURL, err := url.Parse(endpoint)
-	if err != nil {
-		return nil, err
-	}
-
-	c := &client{
-		baseURL:   baseURL,
-		userAgent: "PHIL-Zendesk",
-		username:  username,
-		password:  password,
-		reqFunc:   http.DefaultClient.Do,
-		headers:   make(map[string]string),
-	}
+	return New("", WithBaseURL(endpoint), WithBasicAuth(username, password), WithMiddleware(middleware...))
+}
 
-	if middleware != nil {
-		for i := len(middleware) - 1; i >= 0; i-- {
-			c.reqFunc = middleware[i](c.reqFunc)
-		}
+// chain wraps reqFunc in the middleware of the client, the first middleware being the outermost.
+func (c *client) chain(reqFunc RequestFunction) RequestFunction {
+	for i := len(c.middleware) - 1; i >= 0; i-- {
+		reqFunc = c.middleware[i].fn(reqFunc)
 	}
-
-	return c, nil
+	return reqFunc
 }
 
 // WithHeader returns an updated client that sends the provided header
@@ -132,30 +368,138 @@ func (c *client) WithHeader(name, value string) Client {
 	return &newClient
 }
 
+// WithMarketplaceApp returns an updated client that identifies each subsequent
+// request as coming from the given Zendesk Marketplace app, as required for
+// the backends of published apps.
+//
+// Zendesk Core API docs: https://developer.zendesk.com/api-reference/ticketing/introduction/#marketplace-headers
+func (c *client) WithMarketplaceApp(name string, organizationID, appID int64) Client {
+	return c.WithHeader("X-Zendesk-Marketplace-Name", name).
+		WithHeader("X-Zendesk-Marketplace-Organization-Id", strconv.FormatInt(organizationID, 10)).
+		WithHeader("X-Zendesk-Marketplace-App-Id", strconv.FormatInt(appID, 10))
+}
+
+// WithRetryPolicy returns an updated client that retries failed requests
+// according to the provided policy, whatever their endpoint family.
+func (c *client) WithRetryPolicy(policy RetryPolicy) Client {
+	newClient := *c
+	newClient.endpoints = make(map[EndpointFamily]*endpointState)
+
+	for k, v := range c.endpoints {
+		state := *v
+		state.policy.Retry = policy
+		newClient.endpoints[k] = &state
+	}
+
+	return &newClient
+}
+
+// WithHTTPClient returns an updated client that sends its requests with the provided
+// HTTP client, to configure timeouts, proxies, connection pooling or TLS settings.
+// The middleware of the client still wraps each request.
+func (c *client) WithHTTPClient(httpClient *http.Client) Client {
+	newClient := *c
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	newClient.client = httpClient
+	newClient.reqFunc = newClient.chain(httpClient.Do)
+
+	return &newClient
+}
+
+// WithTimeout returns an updated client whose requests time out after d, each attempt
+// of a retried request having its own timeout. A zero duration means no timeout.
+func (c *client) WithTimeout(d time.Duration) Client {
+	httpClient := *c.client
+	httpClient.Timeout = d
+
+	return c.WithHTTPClient(&httpClient)
+}
+
 func (c *client) request(method, endpoint string, headers map[string]string, body io.Reader) (*http.Response, error) {
+	trace := &CallTrace{Method: method, Start: time.Now()}
+	res, err := c.send(trace, method, endpoint, headers, body)
+	trace.finish(res, err)
+	c.responses.record(trace, res)
+	return res, err
+}
+
+// send makes the attempts of a request, recording their timing in trace.
+func (c *client) send(trace *CallTrace, method, endpoint string, headers map[string]string, body io.Reader) (*http.Response, error) {
 	rel, err := url.Parse(endpoint)
 	if err != nil {
 		return nil, err
 	}
 
 	url := c.baseURL.ResolveReference(rel)
-	req, err := http.NewRequest(method, url.String(), body)
-	if err != nil {
-		return nil, err
+	state := c.endpoint(url.Path)
+	retry := state.policy.Retry
+	trace.URL = url.String()
+	trace.Family = endpointFamily(url.Path)
+
+	// The body is buffered so that it can be sent again when the request is retried.
+	var payload []byte
+	if body != nil {
+		payload, err = ioutil.ReadAll(body)
+		if err != nil {
+			return nil, err
+		}
 	}
 
-	req.SetBasicAuth(c.username, c.password)
-	req.Header.Set("User-Agent", c.userAgent)
+	for attempt := 1; ; attempt++ {
+		req, err := http.NewRequest(method, url.String(), bytes.NewReader(payload))
+		if err != nil {
+			return nil, err
+		}
 
-	for key, value := range c.headers {
-		req.Header.Set(key, value)
-	}
+		req.SetBasicAuth(c.username, c.password)
+		req.Header.Set("User-Agent", c.userAgent)
+		if c.compression {
+			req.Header.Set("Accept-Encoding", "gzip")
+		}
 
-	for key, value := range headers {
-		req.Header.Set(key, value)
-	}
+		for key, value := range c.headers {
+			req.Header.Set(key, value)
+		}
+
+		for key, value := range headers {
+			req.Header.Set(key, value)
+		}
+
+		queued := time.Now()
+		state.limiter.wait()
+		trace.QueueWait += time.Since(queued)
+		trace.Attempts = attempt
+
+		sent := time.Now()
+		req = trace.attach(req)
+		res, err := c.reqFunc(req)
+		trace.roundTrip(time.Since(sent), res)
+		if res != nil && res.StatusCode == http.StatusTooManyRequests {
+			trace.RateLimited++
+		}
+		if !retry.shouldRetry(req, res, err, attempt) {
+			if err == nil {
+				if err = c.prepareBody(res); err != nil {
+					return nil, err
+				}
+			}
+			return res, err
+		}
 
-	return c.reqFunc(req)
+		wait := retry.delay(attempt, res)
+		if err != nil {
+			c.logger.Printf("[zendesk_client_service][request] %s %s failed: %s. Retrying in %v\n", method, url, err, wait)
+		} else {
+			c.logger.Printf("[zendesk_client_service][request] %s %s returned %d. Retrying in %v\n", method, url, res.StatusCode, wait)
+			io.Copy(ioutil.Discard, res.Body)
+			res.Body.Close()
+		}
+
+		time.Sleep(wait)
+		trace.RetrySleep += wait
+	}
 }
 
 func (c *client) do(method, endpoint string, in, out interface{}) error {
@@ -169,6 +513,7 @@ func (c *client) do(method, endpoint string, in, out interface{}) error {
 		headers["Content-Type"] = "application/json"
 	}
 
+	// Failed requests are retried by request according to the client's retry policy.
 	res, err := c.request(method, endpoint, headers, bytes.NewReader(payload))
 	if err != nil {
 		return err
@@ -176,88 +521,196 @@ func (c *client) do(method, endpoint string, in, out interface{}) error {
 
 	defer res.Body.Close()
 
-	// Retry the request if the retry after header is present. This can happen when we are
-	// being rate limited or we failed with a retriable error.
-	if res.Header.Get("Retry-After") != "" {
-		after, err := strconv.ParseInt(res.Header.Get("Retry-After"), 10, 64)
-		if err != nil || after == 0 {
-			return unmarshall(res, out)
+	return c.decode(res, out)
+}
+
+// relativeURL returns the path and query of a next page URL returned by Zendesk, so that
+// the page is requested from the base URL of the client rather than the host of the URL.
+// URLs that cannot be parsed are returned as is, for the request to report the error.
+func (c *client) relativeURL(next string) string {
+	u, err := url.Parse(next)
+	if err != nil {
+		return next
+	}
+	return u.RequestURI()
+}
+
+// chunkIDs splits ids into chunks of at most size IDs, for the bulk endpoints limited to
+// a number of IDs per request.
+func chunkIDs(ids []int64, size int) [][]int64 {
+	chunks := make([][]int64, 0, (len(ids)+size-1)/size)
+	for start := 0; start < len(ids); start += size {
+		end := start + size
+		if end > len(ids) {
+			end = len(ids)
 		}
+		chunks = append(chunks, ids[start:end])
+	}
+	return chunks
+}
 
-		time.Sleep(time.Duration(after) * time.Second)
+// joinIDs formats IDs for the ids parameter of the bulk endpoints.
+func joinIDs(ids []int64) string {
+	parsed := make([]string, 0, len(ids))
+	for _, id := range ids {
+		parsed = append(parsed, strconv.FormatInt(id, 10))
+	}
+	return strings.Join(parsed, ",")
+}
 
-		res, err = c.request(method, endpoint, headers, bytes.NewReader(payload))
-		if err != nil {
+// forEachPage passes each page of a list or of a time based incremental export to handle,
+// until the last page: a page without a next page, the end of an incremental export, or a
+// next page pointing back to the page itself. Rate limited requests are retried by request
+// according to the client's retry policy. The errors of handle are returned as is, as are
+// the failures to fetch the first page; a failure on a later page is returned as a
+// *PartialResultError naming the failed and the last fetched pages, to which the caller
+// adds its records with partialResult.
+func (c *client) forEachPage(endpoint string, handle func(*APIPayload) error) error {
+	return c.forEachPageWithHeaders(endpoint, map[string]string{}, handle)
+}
+
+// forEachPageWithHeaders is forEachPage sending the headers with each request, for the
+// endpoints requiring some.
+func (c *client) forEachPageWithHeaders(endpoint string, headers map[string]string, handle func(*APIPayload) error) error {
+	newPage := func() interface{} { return new(APIPayload) }
+	return c.paginate(endpoint, headers, newPage, nextPageOf, func(page interface{}) error {
+		return handle(page.(*APIPayload))
+	})
+}
+
+// forEachCursorPage is forEachPageWithHeaders for the cursor based exports, following the
+// after_url of each page until the end of the stream.
+func (c *client) forEachCursorPage(endpoint string, headers map[string]string, handle func(*APIPayload) error) error {
+	newPage := func() interface{} { return new(APIPayload) }
+	return c.paginate(endpoint, headers, newPage, afterURLOf, func(page interface{}) error {
+		return handle(page.(*APIPayload))
+	})
+}
+
+// forEachPageOf is forEachPage for the listings whose records come under a key that
+// APIPayload does not hold, or holds with another type. Each page is decoded into the
+// struct returned by newPage, whose next page is read from its field tagged "next_page".
+func (c *client) forEachPageOf(endpoint string, newPage func() interface{}, handle func(interface{}) error) error {
+	return c.paginate(endpoint, map[string]string{}, newPage, nextPageOf, handle)
+}
+
+func (c *client) paginate(endpoint string, headers map[string]string, newPage func() interface{}, nextPageOf func(interface{}) string, handle func(interface{}) error) error {
+	lastPage := ""
+	for page := 1; ; page++ {
+		out := newPage()
+		if err := c.getPage(endpoint, headers, out); err != nil {
+			if page == 1 {
+				return err
+			}
+			return &PartialResultError{PageURL: endpoint, LastPage: lastPage, Err: err}
+		}
+		if err := handle(out); err != nil {
 			return err
 		}
-		defer res.Body.Close()
+
+		nextPage := nextPageOf(out)
+		if nextPage == "" {
+			return nil
+		}
+		next := c.relativeURL(nextPage)
+		if next == endpoint {
+			return nil
+		}
+		lastPage = endpoint
+		endpoint = next
+	}
+}
+
+// nextPageOf returns the URL of the page following a decoded page, or an empty string for
+// the last page.
+func nextPageOf(page interface{}) string {
+	if payload, ok := page.(*APIPayload); ok {
+		if payload.EndOfStream {
+			return ""
+		}
+		return payload.NextPage
 	}
 
-	return unmarshall(res, out)
+	v := reflect.Indirect(reflect.ValueOf(page))
+	if v.Kind() != reflect.Struct {
+		return ""
+	}
+	for i := 0; i < v.NumField(); i++ {
+		if jsonName(v.Type().Field(i)) == "next_page" && v.Field(i).Kind() == reflect.String {
+			return v.Field(i).String()
+		}
+	}
+	return ""
 }
 
-func (c *client) get(endpoint string, out interface{}) error {
-	return c.do("GET", endpoint, nil, out)
+// afterURLOf returns the URL of the page following a page of a cursor based export, or an
+// empty string at the end of the stream.
+func afterURLOf(page interface{}) string {
+	payload := page.(*APIPayload)
+	if payload.EndOfStream {
+		return ""
+	}
+	return payload.AfterURL
 }
 
-func (c *client) getAll(endpoint string, in interface{}) ([]Ticket, error) {
-	result := make([]Ticket, 0)
-	payload, err := marshall(in)
+func (c *client) getPage(endpoint string, headers map[string]string, out interface{}) error {
+	res, err := c.request("GET", endpoint, headers, nil)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
-	headers := map[string]string{}
-	if in != nil {
-		headers["Content-Type"] = "application/json"
+	defer res.Body.Close()
+
+	return c.decode(res, out)
+}
+
+// partialResult sets the records pulled before a pagination failure on err, when it is a
+// *PartialResultError, and returns err.
+func partialResult(err error, records interface{}) error {
+	var partial *PartialResultError
+	if errors.As(err, &partial) {
+		partial.Records = records
 	}
+	return err
+}
+
+func (c *client) get(endpoint string, out interface{}) error {
+	return c.do("GET", endpoint, nil, out)
+}
 
-	res, err := c.request("GET", endpoint, headers, bytes.NewReader(payload))
-	dataPerPage := new(APIPayload)
+// getTraced is get returning the trace of the call, for the callers reporting on their requests.
+func (c *client) getTraced(endpoint string, out interface{}) (*CallTrace, error) {
+	trace := &CallTrace{Method: "GET", Start: time.Now()}
+	res, err := c.send(trace, "GET", endpoint, map[string]string{}, nil)
+	trace.finish(res, err)
+	c.responses.record(trace, res)
 	if err != nil {
-		return nil, err
+		return trace, err
 	}
 
-	apiV2 := "/api/v2/"
-	fieldName := strings.Split(endpoint[len(apiV2):], ".")[0]
 	defer res.Body.Close()
 
-	err = unmarshall(res, dataPerPage)
-
-	apiStartIndex := strings.Index(dataPerPage.NextPage, apiV2)
-	currentPage := endpoint
+	err = c.decode(res, out)
+	return trace, err
+}
 
-	var totalWaitTime int64
-	for currentPage != "" {
-		// if too many requests(res.StatusCode == 429), delay sending request
-		if res.StatusCode == 429 {
-			after, err := strconv.ParseInt(res.Header.Get("Retry-After"), 10, 64)
-			log.Printf("[zendesk_client_service][getAll] too many requests. Wait for %v seconds\n", after)
-			totalWaitTime += after
-			if err != nil {
-				return nil, err
-			}
-			time.Sleep(time.Duration(after) * time.Second)
-		} else {
-			if fieldName == "tickets" {
-				result = append(result, dataPerPage.Tickets...)
-			}
-			currentPage = dataPerPage.NextPage
-		}
-		res, _ = c.request("GET", dataPerPage.NextPage[apiStartIndex:], headers, bytes.NewReader(payload))
-		dataPerPage = new(APIPayload)
-		err = unmarshall(res, dataPerPage)
-		if err != nil {
-			return nil, err
-		}
+func (c *client) getAll(endpoint string) ([]Ticket, error) {
+	result := make([]Ticket, 0)
+	err := c.forEachPage(endpoint, func(page *APIPayload) error {
+		result = append(result, page.Tickets...)
+		return nil
+	})
+	if err != nil {
+		return nil, partialResult(err, result)
 	}
-	log.Printf("[zendesk_client_service][getAll] number of records pulled: %v\n", len(result))
-	log.Printf("[zendesk_client_service][getAll] total waiting time due to rate limit: %v\n", totalWaitTime)
 
-	return result, err
+	c.logger.Printf("[zendesk_client_service][getAll] number of records pulled: %v\n", len(result))
+	return result, nil
 }
 
-func (c *client) getOneByOne(in interface{}) ([]Ticket, error) {
+// getOneByOne fetches the tickets with an ID between startID and endID, both included,
+// from as many concurrent requests as the client's concurrency. Missing tickets are skipped.
+func (c *client) getOneByOne(in interface{}, startID, endID int64, progress func(TicketProgress)) ([]Ticket, error) {
 	endpointPrefix := "/api/v2/tickets/"
 	endpointPostfix := ".json"
 	result := make([]Ticket, 0)
@@ -270,55 +723,56 @@ func (c *client) getOneByOne(in interface{}) ([]Ticket, error) {
 	if in != nil {
 		headers["Content-Type"] = "application/json"
 	}
-	record := new(APIPayload)
-
-	// currently we can manually set the starting and ending IDs for data pulling
-	// because memory may reach its limit if the dataset is too large
-	// ideally, we want to load data to database in batches on the fly
-	// instead of loading the entire chunk
-	startID := 1
-	endID := 10000
-	ticketID := startID // start
-	endpoint := fmt.Sprintf("%s%v%s", endpointPrefix, ticketID, endpointPostfix)
-	res, err := c.request("GET", endpoint, headers, bytes.NewReader(payload))
-	if err != nil {
-		return nil, err
+
+	if endID < startID {
+		return result, nil
+	}
+	ticketIDs := make([]int64, 0, endID-startID+1)
+	for id := startID; id <= endID; id++ {
+		ticketIDs = append(ticketIDs, id)
 	}
-	defer res.Body.Close()
 
-	var totalWaitTime int64
-	for ticketID < endID {
-
-		// handle page not found
-		if res.StatusCode == 404 {
-			log.Printf("[zendesk_client_service][getOneByOne] 404 not found: %s\n", endpoint)
-			// handle too many requests (rate limit)
-		} else if res.StatusCode == 429 {
-			after, err := strconv.ParseInt(res.Header.Get("Retry-After"), 10, 64)
-			log.Printf("[zendesk_client_service][getOneByOne] too many requests. Wait for %v seconds\n", after)
-			totalWaitTime += after
-			if err != nil {
-				return nil, err
-			}
-			time.Sleep(time.Duration(after) * time.Second)
-			continue
-		} else {
-			err = unmarshall(res, record)
-			if err != nil {
-				return nil, err
-			}
+	var mu sync.Mutex
+	status := TicketProgress{Total: len(ticketIDs)}
+	records := make([]*Ticket, len(ticketIDs))
+	err = c.fanOut(ticketIDs, func(i int, ticketID int64) error {
+		endpoint := fmt.Sprintf("%s%v%s", endpointPrefix, ticketID, endpointPostfix)
+		record := new(APIPayload)
+		found, err := c.getOne(endpoint, headers, payload, record)
+		if err != nil {
+			return &PartialResultError{PageURL: endpoint, Err: err}
+		}
+		if found {
+			records[i] = record.Ticket
+		}
 
-			result = append(result, *record.Ticket)
+		if progress != nil {
+			mu.Lock()
+			defer mu.Unlock()
+			status.TicketID = ticketID
+			status.Checked++
+			if found {
+				status.Found++
+			}
+			progress(status)
 		}
+		return nil
+	})
 
-		record = new(APIPayload)
-		ticketID++
-		endpoint = fmt.Sprintf("%s%v%s", endpointPrefix, ticketID, endpointPostfix)
-		res, _ = c.request("GET", endpoint, headers, bytes.NewReader(payload))
+	for _, record := range records {
+		if record != nil {
+			result = append(result, *record)
+		}
+	}
+	if err != nil {
+		var partial *PartialResultError
+		if len(result) == 0 && errors.As(err, &partial) {
+			return nil, errors.Unwrap(partial)
+		}
+		return nil, partialResult(err, result)
 	}
 
-	log.Printf("[zendesk_client_service][getOneByOne] number of records pulled: %v\n", len(result))
-	log.Printf("[zendesk_client_service][getOneByOne] total waiting time due to rate limit: %v\n", totalWaitTime)
+	c.logger.Printf("[zendesk_client_service][getOneByOne] number of records pulled: %v\n", len(result))
 	return result, nil
 }
 
@@ -342,10 +796,10 @@ func marshall(in interface{}) ([]byte, error) {
 	return json.Marshal(in)
 }
 
-func unmarshall(res *http.Response, out interface{}) error {
+func (c *client) unmarshall(res *http.Response, out interface{}) error {
 	if res.StatusCode < 200 || res.StatusCode >= 300 {
 		if res.StatusCode >= 500 {
-			log.Printf("[EXTERNAL][FATAL][ZENDESK] %d response code with Zendesk", res.StatusCode)
+			c.logger.Printf("[EXTERNAL][FATAL][ZENDESK] %d response code with Zendesk", res.StatusCode)
 		}
 		apierr := new(APIError)
 		apierr.Response = res
@@ -367,16 +821,36 @@ func unmarshall(res *http.Response, out interface{}) error {
 type APIPayload struct {
 	Attachment              *Attachment              `json:"attachment"`
 	Attachments             []Attachment             `json:"attachments"`
+	Audit                   *TicketAudit             `json:"audit,omitempty"`
+	Audits                  []TicketAudit            `json:"audits,omitempty"`
+	Brand                   *Brand                   `json:"brand,omitempty"`
+	Brands                  []Brand                  `json:"brands,omitempty"`
 	Comment                 *TicketComment           `json:"comment,omitempty"`
 	Comments                []TicketComment          `json:"comments,omitempty"`
+	CustomFieldOption       *CustomFieldOption       `json:"custom_field_option,omitempty"`
+	CustomFieldOptions      []CustomFieldOption      `json:"custom_field_options,omitempty"`
+	CustomRole              *CustomRole              `json:"custom_role,omitempty"`
+	CustomRoles             []CustomRole             `json:"custom_roles,omitempty"`
+	DeletedTickets          []DeletedTicket          `json:"deleted_tickets,omitempty"`
+	DeletedUser             *User                    `json:"deleted_user,omitempty"`
+	DeletedUsers            []User                   `json:"deleted_users,omitempty"`
 	Identity                *UserIdentity            `json:"identity,omitempty"`
 	Identities              []UserIdentity           `json:"identities,omitempty"`
+	JobStatus               *JobStatus               `json:"job_status,omitempty"`
 	Locale                  *Locale                  `json:"locale,omitempty"`
 	Locales                 []Locale                 `json:"locales,omitempty"`
+	Groups                  []Group                  `json:"groups,omitempty"`
+	MetricSets              []TicketMetric           `json:"metric_sets,omitempty"`
 	Organization            *Organization            `json:"organization,omitempty"`
+	OrganizationField       *FieldDefinition         `json:"organization_field,omitempty"`
+	OrganizationFields      []FieldDefinition        `json:"organization_fields,omitempty"`
 	OrganizationMembership  *OrganizationMembership  `json:"organization_membership,omitempty"`
 	OrganizationMemberships []OrganizationMembership `json:"organization_memberships,omitempty"`
 	Organizations           []Organization           `json:"organizations,omitempty"`
+	CustomStatus            *CustomStatus            `json:"custom_status,omitempty"`
+	CustomStatuses          []CustomStatus           `json:"custom_statuses,omitempty"`
+	SharingAgreement        *SharingAgreement        `json:"sharing_agreement,omitempty"`
+	SharingAgreements       []SharingAgreement       `json:"sharing_agreements,omitempty"`
 	Tags                    []string                 `json:"tags,omitempty"`
 	Ticket                  *Ticket                  `json:"ticket,omitempty"`
 	TicketField             *TicketField             `json:"ticket_field,omitempty"`
@@ -384,18 +858,55 @@ type APIPayload struct {
 	Tickets                 []Ticket                 `json:"tickets,omitempty"`
 	Upload                  *Upload                  `json:"upload,omitempty"`
 	User                    *User                    `json:"user,omitempty"`
+	UserField               *FieldDefinition         `json:"user_field,omitempty"`
+	UserFields              []FieldDefinition        `json:"user_fields,omitempty"`
 	Users                   []User                   `json:"users,omitempty"`
+	UserRelated             *UserRelated             `json:"user_related,omitempty"`
 	TicketForm              *TicketForm              `json:"ticket_form,omitempty"`
 	TicketForms             []TicketForm             `json:"ticket_forms,omitempty"`
 	TicketMetric            *TicketMetric            `json:"ticket_metric,omitempty"`
 	TicketMetrics           []TicketMetric           `json:"ticket_metrics,omitempty"`
+	TicketMetricEvents      []TicketMetricEvent      `json:"ticket_metric_events,omitempty"`
+	Trigger                 *Trigger                 `json:"trigger,omitempty"`
+	Triggers                []Trigger                `json:"triggers,omitempty"`
 	NextPage                string                   `json:"next_page,omitempty"`
-	SatisfactionRating      Score                    `json:"satisfaction_rating,omitempty"`
+	Count                   *int64                   `json:"count,omitempty"`
+	AfterCursor             string                   `json:"after_cursor,omitempty"`
+	AfterURL                string                   `json:"after_url,omitempty"`
+	EndOfStream             bool                     `json:"end_of_stream,omitempty"`
+	EndTime                 int64                    `json:"end_time,omitempty"`
+	Agents                  []User                   `json:"agents,omitempty"`
+	SatisfactionRating      *Score                   `json:"satisfaction_rating,omitempty"`
 	SatisfactionRatings     []Score                  `json:"satisfaction_ratings,omitempty"`
+	SatisfactionReasons     []SatisfactionReason     `json:"reasons,omitempty"`
 	CallLegs                []CallLeg                `json:"legs,omitempty"`
+	Calls                   []Call                   `json:"calls,omitempty"`
+	PhoneNumbers            []PhoneNumber            `json:"phone_numbers,omitempty"`
+	Lines                   []Line                   `json:"lines,omitempty"`
+	Greetings               []Greeting               `json:"greetings,omitempty"`
+	Availability            *Availability            `json:"availability,omitempty"`
+	Article                 *Article                 `json:"article,omitempty"`
+	Articles                []Article                `json:"articles,omitempty"`
+	Section                 *Section                 `json:"section,omitempty"`
+	Sections                []Section                `json:"sections,omitempty"`
+	Category                *Category                `json:"category,omitempty"`
+	Categories              []Category               `json:"categories,omitempty"`
+	Translation             *Translation             `json:"translation,omitempty"`
+	Translations            []Translation            `json:"translations,omitempty"`
+	ArticleAttachment       *ArticleAttachment       `json:"article_attachment,omitempty"`
+	ArticleAttachments      []ArticleAttachment      `json:"article_attachments,omitempty"`
+	Vote                    *Vote                    `json:"vote,omitempty"`
 }
 
 // APIError represents an error response returnted by the API.
+//
+// An APIError wraps one of the errors below depending on the response status code,
+// so that callers can branch on failures with errors.Is and errors.As:
+//
+//	if errors.Is(err, zendesk.ErrNotFound) { ... }
+//
+//	var limited *zendesk.ErrRateLimited
+//	if errors.As(err, &limited) { time.Sleep(limited.RetryAfter) }
 type APIError struct {
 	Response *http.Response
 
@@ -422,6 +933,121 @@ func (e *APIError) Error() string {
 	return msg
 }
 
+// Unwrap returns the error matching the response status code, if any.
+func (e *APIError) Unwrap() error {
+	if e.Response == nil {
+		return nil
+	}
+
+	switch code := e.Response.StatusCode; {
+	case code == http.StatusUnauthorized:
+		return ErrUnauthorized
+	case code == http.StatusForbidden:
+		return ErrForbidden
+	case code == http.StatusNotFound:
+		return ErrNotFound
+	case code == http.StatusConflict:
+		return ErrConflict
+	case code == http.StatusTooManyRequests:
+		limited := &ErrRateLimited{}
+		if after, err := strconv.ParseInt(e.Response.Header.Get("Retry-After"), 10, 64); err == nil {
+			limited.RetryAfter = time.Duration(after) * time.Second
+		}
+		return limited
+	case code == http.StatusUnprocessableEntity, code == http.StatusBadRequest && e.Details != nil:
+		return &ErrValidation{Type: e.Type, Description: e.Description, Details: e.Details}
+	case code >= 500:
+		return ErrServerError
+	}
+
+	return nil
+}
+
+var (
+	// ErrUnauthorized is wrapped by API errors with a 401 status code.
+	ErrUnauthorized = errors.New("zendesk: unauthorized")
+	// ErrForbidden is wrapped by API errors with a 403 status code.
+	ErrForbidden = errors.New("zendesk: forbidden")
+	// ErrNotFound is wrapped by API errors with a 404 status code.
+	ErrNotFound = errors.New("zendesk: not found")
+	// ErrConflict is wrapped by API errors with a 409 status code.
+	ErrConflict = errors.New("zendesk: conflict")
+	// ErrServerError is wrapped by API errors with a 5xx status code.
+	ErrServerError = errors.New("zendesk: server error")
+)
+
+// ErrRateLimited is wrapped by API errors with a 429 status code.
+type ErrRateLimited struct {
+	// RetryAfter is the wait time requested by Zendesk, zero when unknown.
+	RetryAfter time.Duration
+}
+
+func (e *ErrRateLimited) Error() string {
+	if e.RetryAfter == 0 {
+		return "zendesk: rate limited"
+	}
+	return fmt.Sprintf("zendesk: rate limited, retry after %v", e.RetryAfter)
+}
+
+// Is makes errors.Is(err, &ErrRateLimited{}) match any rate limit error.
+func (e *ErrRateLimited) Is(target error) bool {
+	_, ok := target.(*ErrRateLimited)
+	return ok
+}
+
+// ErrValidation is wrapped by API errors rejecting invalid records, usually with a 422 status code.
+type ErrValidation struct {
+	Type        string
+	Description string
+	Details     map[string][]*APIErrorDetail
+}
+
+func (e *ErrValidation) Error() string {
+	msg := "zendesk: validation failed"
+
+	if e.Description != "" {
+		msg = fmt.Sprintf("%s: %v", msg, e.Description)
+	}
+
+	if e.Details != nil {
+		msg = fmt.Sprintf("%s: %+v", msg, e.Details)
+	}
+
+	return msg
+}
+
+// Is makes errors.Is(err, &ErrValidation{}) match any validation error.
+func (e *ErrValidation) Is(target error) bool {
+	_, ok := target.(*ErrValidation)
+	return ok
+}
+
+// PartialResultError is returned when a paginated pull stops before reaching its last page.
+// It carries the records fetched before the failure, as a slice of the pulled type
+// (e.g. []Ticket), so callers can decide whether to keep or discard them, and the last
+// page fetched successfully, from which a caller can resume the pull. The incremental
+// exports also return these records along with the error, so their progress can be saved.
+type PartialResultError struct {
+	Records interface{}
+	// PageURL is the page that failed.
+	PageURL string
+	// LastPage is the last page fetched successfully, empty when unknown.
+	LastPage string
+	// Cursor resumes an interrupted cursor based export after the pulled records, as the
+	// cursor of the next export. It is empty for the other pulls.
+	Cursor string
+	Err    error
+}
+
+func (e *PartialResultError) Error() string {
+	return fmt.Sprintf("pagination stopped at %s: %v", e.PageURL, e.Err)
+}
+
+// Unwrap returns the error that stopped the pagination.
+func (e *PartialResultError) Unwrap() error {
+	return e.Err
+}
+
 // APIErrorDetail represents a detail about an APIError.
 type APIErrorDetail struct {
 	Type        string `json:"error,omitempty"`
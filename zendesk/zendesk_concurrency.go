@@ -0,0 +1,85 @@
+package zendesk
+
+import (
+	"bytes"
+	"net/http"
+	"sync"
+	"sync/atomic"
+)
+
+// WithConcurrency returns an updated client that fetches per-ticket resources, such as
+// the comments or the metrics of a list of tickets, from n concurrent requests.
+// The requests still share the rate limit of their endpoint family. The default is 1.
+func (c *client) WithConcurrency(n int) Client {
+	newClient := *c
+	if n < 1 {
+		n = 1
+	}
+	newClient.concurrency = n
+
+	return &newClient
+}
+
+// fanOut calls fetch for each ticket ID from up to c.concurrency goroutines.
+// No new fetch is started after a failure, and the first error is returned.
+func (c *client) fanOut(ticketIDs []int64, fetch func(i int, ticketID int64) error) error {
+	workers := c.concurrency
+	if workers < 1 {
+		workers = 1
+	}
+	if workers > len(ticketIDs) {
+		workers = len(ticketIDs)
+	}
+
+	var (
+		wg       sync.WaitGroup
+		once     sync.Once
+		firstErr error
+		failed   int32
+	)
+	indexes := make(chan int)
+
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				if err := fetch(i, ticketIDs[i]); err != nil {
+					once.Do(func() { firstErr = err })
+					atomic.StoreInt32(&failed, 1)
+				}
+			}
+		}()
+	}
+
+	for i := range ticketIDs {
+		if atomic.LoadInt32(&failed) == 1 {
+			break
+		}
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+
+	return firstErr
+}
+
+// getOne fetches the resource at endpoint into out. Rate limited requests are retried
+// according to the client's retry policy. It returns false when the resource is not found.
+func (c *client) getOne(endpoint string, headers map[string]string, payload []byte, out *APIPayload) (bool, error) {
+	res, err := c.request("GET", endpoint, headers, bytes.NewReader(payload))
+	if err != nil {
+		return false, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode == http.StatusNotFound {
+		c.logger.Printf("[zendesk_concurrency][getOne] 404 not found: %s\n", endpoint)
+		return false, nil
+	}
+
+	if err := c.decode(res, out); err != nil {
+		return false, err
+	}
+	return true, nil
+}
@@ -0,0 +1,155 @@
+package zendesk
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// debugBodyLimit is the number of bytes of the bodies written by the debug dumps.
+const debugBodyLimit = 4096
+
+// debugRedactedHeaders are the headers whose values are left out of the debug dumps.
+var debugRedactedHeaders = map[string]bool{
+	"Authorization":       true,
+	"Proxy-Authorization": true,
+	"Cookie":              true,
+	"Set-Cookie":          true,
+}
+
+// WithDebug dumps the requests of the client and their responses to w, to troubleshoot
+// calls that fail or do not unmarshall as expected. The credentials are redacted, from the
+// headers and from the JSON keys such as "password" or "token" of the bodies, and only the
+// start of the bodies is read and written, decompressed when the response is gzip encoded.
+// The dumps come from a middleware named "debug" installed inside the middleware installed
+// so far, so the option is best given last; WithoutMiddleware("debug") turns them off for
+// a copy of the client.
+func WithDebug(w io.Writer) Option {
+	return func(c *client) error {
+		return c.insertMiddleware(len(c.middleware), "debug", debugMiddleware(w))
+	}
+}
+
+func debugMiddleware(w io.Writer) MiddlewareFunction {
+	var mu sync.Mutex
+
+	return func(next RequestFunction) RequestFunction {
+		return func(req *http.Request) (*http.Response, error) {
+			var reqBody []byte
+			if req.Body != nil {
+				var err error
+				if reqBody, req.Body, err = peekBody(req.Body); err != nil {
+					return nil, err
+				}
+			}
+
+			start := time.Now()
+			res, err := next(req)
+			elapsed := time.Since(start)
+
+			var resBody []byte
+			if res != nil && res.Body != nil {
+				var readErr error
+				resBody, res.Body, readErr = peekBody(res.Body)
+				if readErr != nil && err == nil {
+					err = readErr
+				}
+			}
+
+			var dump strings.Builder
+			fmt.Fprintf(&dump, "--> %s %s\n", req.Method, req.URL)
+			writeDebugHeaders(&dump, req.Header)
+			writeDebugBody(&dump, reqBody, false)
+			if err != nil {
+				fmt.Fprintf(&dump, "<-- error after %v: %s\n\n", elapsed, err)
+			} else {
+				fmt.Fprintf(&dump, "<-- %s (%v)\n", res.Status, elapsed)
+				writeDebugHeaders(&dump, res.Header)
+				if res.Header.Get("Content-Encoding") == "gzip" {
+					writeDebugBody(&dump, gunzipHead(resBody), len(resBody) > debugBodyLimit)
+				} else {
+					writeDebugBody(&dump, resBody, false)
+				}
+			}
+
+			mu.Lock()
+			io.WriteString(w, dump.String())
+			mu.Unlock()
+
+			return res, err
+		}
+	}
+}
+
+// peekBody reads the start of body, up to one byte past debugBodyLimit to tell whether it
+// is truncated, and returns it along with a body reading the whole content again, so that
+// large exports and attachments are not buffered.
+func peekBody(body io.ReadCloser) ([]byte, io.ReadCloser, error) {
+	head, err := ioutil.ReadAll(io.LimitReader(body, debugBodyLimit+1))
+	rest := struct {
+		io.Reader
+		io.Closer
+	}{io.MultiReader(bytes.NewReader(head), body), body}
+	return head, rest, err
+}
+
+// gunzipHead decompresses the start of a gzip encoded body read by peekBody, as far as it
+// goes, for the dumps. The body itself is left compressed, to be decoded by the client.
+func gunzipHead(head []byte) []byte {
+	gz, err := gzip.NewReader(bytes.NewReader(head))
+	if err != nil {
+		return head
+	}
+	content, _ := ioutil.ReadAll(io.LimitReader(gz, debugBodyLimit+1))
+	return content
+}
+
+// debugRedactedKeys matches the JSON string values of the keys naming credentials, such as
+// "password", "api_token" or "client_secret", up to the end of the dumped body when it is
+// truncated within the value.
+var debugRedactedKeys = regexp.MustCompile(`(?i)("[^"]*(?:password|token|secret)[^"]*"\s*:\s*)"(?:[^"\\]|\\.)*(?:"|$)`)
+
+func writeDebugHeaders(dump *strings.Builder, header http.Header) {
+	names := make([]string, 0, len(header))
+	for name := range header {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		value := strings.Join(header[name], ", ")
+		if debugRedactedHeaders[http.CanonicalHeaderKey(name)] {
+			value = "[REDACTED]"
+		}
+		fmt.Fprintf(dump, "%s: %s\n", name, value)
+	}
+}
+
+// writeDebugBody writes the start of a body, noting it truncated when it is longer than
+// debugBodyLimit or when more tells that the content goes on.
+func writeDebugBody(dump *strings.Builder, body []byte, more bool) {
+	dump.WriteString("\n")
+	body = bytes.TrimRight(body, "\r\n")
+	if len(body) == 0 {
+		return
+	}
+	truncated := more || len(body) > debugBodyLimit
+	if truncated {
+		body = body[:debugBodyLimit]
+	}
+	body = debugRedactedKeys.ReplaceAll(body, []byte(`$1"[REDACTED]"`))
+	if truncated {
+		fmt.Fprintf(dump, "%s... (truncated)\n\n", body)
+		return
+	}
+	dump.Write(body)
+	dump.WriteString("\n\n")
+}
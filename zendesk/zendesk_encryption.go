@@ -0,0 +1,59 @@
+package zendesk
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"errors"
+	"io"
+)
+
+// Encryptor encrypts exported records before they are written, so that the personal
+// data of an export never leaves the process in clear text.
+type Encryptor interface {
+	Encrypt(plaintext []byte) ([]byte, error)
+}
+
+// EncryptorFunc adapts a function to an Encryptor.
+type EncryptorFunc func(plaintext []byte) ([]byte, error)
+
+// Encrypt calls f(plaintext).
+func (f EncryptorFunc) Encrypt(plaintext []byte) ([]byte, error) {
+	return f(plaintext)
+}
+
+// AESGCMEncryptor encrypts with AES-GCM. Each ciphertext is prefixed with its random nonce.
+type AESGCMEncryptor struct {
+	aead cipher.AEAD
+}
+
+// NewAESGCMEncryptor creates an AESGCMEncryptor from a 16, 24 or 32 bytes key.
+func NewAESGCMEncryptor(key []byte) (*AESGCMEncryptor, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	return &AESGCMEncryptor{aead: aead}, nil
+}
+
+// Encrypt seals plaintext with a new random nonce.
+func (e *AESGCMEncryptor) Encrypt(plaintext []byte) ([]byte, error) {
+	nonce := make([]byte, e.aead.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, err
+	}
+	return e.aead.Seal(nonce, nonce, plaintext, nil), nil
+}
+
+// Decrypt opens a ciphertext produced by Encrypt.
+func (e *AESGCMEncryptor) Decrypt(ciphertext []byte) ([]byte, error) {
+	size := e.aead.NonceSize()
+	if len(ciphertext) < size {
+		return nil, errors.New("ciphertext too short")
+	}
+	return e.aead.Open(nil, ciphertext[:size], ciphertext[size:], nil)
+}
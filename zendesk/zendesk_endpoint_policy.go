@@ -0,0 +1,156 @@
+package zendesk
+
+import (
+	"strings"
+	"sync"
+	"time"
+)
+
+// EndpointFamily groups the endpoints to which Zendesk applies the same rate limits.
+type EndpointFamily string
+
+// Endpoint families.
+const (
+	// FamilyInteractive covers the regular endpoints, limited per account plan.
+	FamilyInteractive EndpointFamily = "interactive"
+	// FamilyExport covers the incremental export endpoints, limited to 10 requests
+	// per minute by default.
+	FamilyExport EndpointFamily = "export"
+	// FamilyUpload covers the file upload endpoints.
+	FamilyUpload EndpointFamily = "upload"
+)
+
+// RateLimit limits the number of requests sent over a period of time. Requests are
+// allowed in bursts of up to Requests, then spread evenly over the period.
+// The zero value sets no limit.
+type RateLimit struct {
+	Requests int
+	Period   time.Duration
+}
+
+// EndpointPolicy describes how the client sends the requests of an endpoint family.
+type EndpointPolicy struct {
+	Retry     RetryPolicy
+	RateLimit RateLimit
+}
+
+// DefaultEndpointPolicies are the endpoint policies used by new clients. They follow the
+// limits documented by Zendesk: exports are limited to 10 requests per minute and are
+// retried with longer delays, while uploads, which are not idempotent, are retried on
+// rate limiting and unavailability only.
+//
+// Zendesk Core API docs: https://developer.zendesk.com/api-reference/introduction/rate-limits/
+var DefaultEndpointPolicies = map[EndpointFamily]EndpointPolicy{
+	FamilyInteractive: {
+		Retry: DefaultRetryPolicy,
+	},
+	FamilyExport: {
+		Retry: RetryPolicy{
+			MaxAttempts:          5,
+			Backoff:              ExponentialBackoff(5*time.Second, 2*time.Minute),
+			RetryableStatusCodes: []int{429, 500, 502, 503, 504},
+			Jitter:               0.2,
+		},
+		RateLimit: RateLimit{Requests: 10, Period: time.Minute},
+	},
+	FamilyUpload: {
+		Retry: RetryPolicy{
+			MaxAttempts:          3,
+			Backoff:              ExponentialBackoff(2*time.Second, 30*time.Second),
+			RetryableStatusCodes: []int{429, 503},
+			Jitter:               0.2,
+		},
+	},
+}
+
+// endpointFamily returns the family of the endpoint with the given path.
+func endpointFamily(path string) EndpointFamily {
+	switch {
+	case strings.Contains(path, "/incremental/"):
+		return FamilyExport
+	case strings.HasPrefix(path, "/api/v2/uploads"):
+		return FamilyUpload
+	}
+	return FamilyInteractive
+}
+
+// endpointState holds the policy of an endpoint family along with its rate limiter,
+// which is shared by the clients derived from the same client.
+type endpointState struct {
+	policy  EndpointPolicy
+	limiter *rateLimiter
+}
+
+func newEndpointStates(policies map[EndpointFamily]EndpointPolicy) map[EndpointFamily]*endpointState {
+	states := make(map[EndpointFamily]*endpointState)
+	for family, policy := range policies {
+		states[family] = &endpointState{policy: policy, limiter: newRateLimiter(policy.RateLimit)}
+	}
+	return states
+}
+
+// endpoint returns the state of the family of the endpoint with the given path.
+func (c *client) endpoint(path string) *endpointState {
+	if state, ok := c.endpoints[endpointFamily(path)]; ok {
+		return state
+	}
+	return c.endpoints[FamilyInteractive]
+}
+
+// WithEndpointPolicy returns an updated client that sends the requests of the
+// given endpoint family according to the provided policy.
+func (c *client) WithEndpointPolicy(family EndpointFamily, policy EndpointPolicy) Client {
+	newClient := *c
+	newClient.endpoints = make(map[EndpointFamily]*endpointState)
+
+	for k, v := range c.endpoints {
+		newClient.endpoints[k] = v
+	}
+
+	newClient.endpoints[family] = &endpointState{policy: policy, limiter: newRateLimiter(policy.RateLimit)}
+
+	return &newClient
+}
+
+// rateLimiter is a token bucket limiting the rate of requests.
+type rateLimiter struct {
+	mu     sync.Mutex
+	limit  RateLimit
+	tokens float64
+	last   time.Time
+}
+
+func newRateLimiter(limit RateLimit) *rateLimiter {
+	if limit.Requests <= 0 || limit.Period <= 0 {
+		return nil
+	}
+	return &rateLimiter{limit: limit, tokens: float64(limit.Requests), last: time.Now()}
+}
+
+// wait blocks until a request can be sent.
+func (l *rateLimiter) wait() {
+	if l == nil {
+		return
+	}
+
+	interval := l.limit.Period / time.Duration(l.limit.Requests)
+	for {
+		l.mu.Lock()
+		now := time.Now()
+		l.tokens += float64(now.Sub(l.last)) / float64(interval)
+		if max := float64(l.limit.Requests); l.tokens > max {
+			l.tokens = max
+		}
+		l.last = now
+
+		if l.tokens >= 1 {
+			l.tokens--
+			l.mu.Unlock()
+			return
+		}
+
+		wait := time.Duration((1 - l.tokens) * float64(interval))
+		l.mu.Unlock()
+		time.Sleep(wait)
+	}
+}
@@ -0,0 +1,93 @@
+package zendesk
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+)
+
+// RunReport summarizes an export run, whether it completed or failed midway.
+type RunReport struct {
+	// Resource is the type of the exported records, such as "tickets".
+	Resource string `json:"resource"`
+	Records  int    `json:"records"`
+	Pages    int    `json:"pages"`
+	// RateLimitWaits is the number of requests answered with a 429 status code.
+	RateLimitWaits int `json:"rate_limit_waits"`
+	// RetryWait is the time spent backing off between the attempts of the requests.
+	RetryWait   time.Duration `json:"retry_wait"`
+	StartedAt   time.Time     `json:"started_at"`
+	Duration    time.Duration `json:"duration"`
+	StartCursor string        `json:"start_cursor,omitempty"`
+	EndCursor   string        `json:"end_cursor,omitempty"`
+	Errors      []string      `json:"errors,omitempty"`
+}
+
+func newRunReport(resource, cursor string) *RunReport {
+	return &RunReport{Resource: resource, StartedAt: time.Now(), StartCursor: cursor}
+}
+
+// addPage records a page fetched by the call of trace.
+func (r *RunReport) addPage(trace *CallTrace) {
+	if trace.Err == nil {
+		r.Pages++
+	}
+	r.RateLimitWaits += trace.RateLimited
+	r.RetryWait += trace.RetrySleep
+}
+
+// finish records the outcome of the run in the report of checkpoint.
+func (r *RunReport) finish(checkpoint *ExportCheckpoint, err error) {
+	r.Records = checkpoint.Records
+	r.EndCursor = checkpoint.Cursor
+	r.Duration = time.Since(r.StartedAt)
+	if err != nil {
+		r.Errors = append(r.Errors, err.Error())
+	}
+	checkpoint.Report = r
+}
+
+func (r *RunReport) String() string {
+	msg := fmt.Sprintf("%d %s exported in %d pages and %v", r.Records, r.Resource, r.Pages, r.Duration.Round(time.Millisecond))
+	if r.RateLimitWaits > 0 {
+		msg = fmt.Sprintf("%s, %d rate limited requests", msg, r.RateLimitWaits)
+	}
+	msg = fmt.Sprintf("%s, cursor %q to %q", msg, r.StartCursor, r.EndCursor)
+	if len(r.Errors) > 0 {
+		msg = fmt.Sprintf("%s, failed: %s", msg, r.Errors[len(r.Errors)-1])
+	}
+	return msg
+}
+
+// ExportManifest gathers the reports of the export runs writing to the same destination,
+// to be written alongside the exported data.
+type ExportManifest struct {
+	Reports []*RunReport `json:"reports"`
+}
+
+// Add adds the report of a run to the manifest. Nil reports are ignored.
+func (m *ExportManifest) Add(report *RunReport) {
+	if report != nil {
+		m.Reports = append(m.Reports, report)
+	}
+}
+
+// Counts returns the number of exported records per resource.
+func (m *ExportManifest) Counts() map[string]int {
+	counts := make(map[string]int)
+	for _, report := range m.Reports {
+		counts[report.Resource] += report.Records
+	}
+	return counts
+}
+
+// WriteJSON writes the manifest and the record counts as indented JSON.
+func (m *ExportManifest) WriteJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(struct {
+		Counts  map[string]int `json:"counts"`
+		Reports []*RunReport   `json:"reports"`
+	}{Counts: m.Counts(), Reports: m.Reports})
+}
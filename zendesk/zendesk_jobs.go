@@ -0,0 +1,143 @@
+package zendesk
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+)
+
+// ExportFunc runs an export to a sink, like ExportTickets or UserOrganizationSync.Run.
+type ExportFunc func(opts *IncrementalExportOptions, sink RecordSink) (*ExportCheckpoint, error)
+
+// SaveCheckpointFunc persists the checkpoint of a finished export job, so that the next
+// run resumes from its cursor. err is the error the export ended with, ErrExportStopped
+// when the job was drained.
+type SaveCheckpointFunc func(name string, checkpoint *ExportCheckpoint, err error) error
+
+// ErrDraining is returned when starting a job on a drained Jobs.
+var ErrDraining = errors.New("zendesk: jobs are draining")
+
+// Jobs tracks the exports running in the background, so that a process can stop them
+// cleanly on shutdown. Drain ends every export after the page being written and saves the
+// checkpoints, so no cursor is lost when, for instance, a pod receives a SIGTERM:
+//
+//	jobs := zendesk.NewJobs(save)
+//	jobs.Go("tickets", client.ExportTickets, &zendesk.IncrementalExportOptions{Cursor: cursor}, sink)
+//	...
+//	<-sigterm
+//	ctx, cancel := context.WithTimeout(context.Background(), 25*time.Second)
+//	defer cancel()
+//	err := jobs.Drain(ctx)
+//
+// It is safe for concurrent use.
+type Jobs struct {
+	save SaveCheckpointFunc
+
+	mu       sync.Mutex
+	wg       sync.WaitGroup
+	stop     chan struct{}
+	draining bool
+	running  map[string]int
+	errs     []error
+}
+
+// NewJobs creates a job manager saving the checkpoints of the finished jobs with save,
+// which may be nil.
+func NewJobs(save SaveCheckpointFunc) *Jobs {
+	return &Jobs{save: save, stop: make(chan struct{}), running: make(map[string]int)}
+}
+
+// Go runs the export in a new goroutine. The options are copied and their Stop channel is
+// replaced by the one closed on Drain.
+func (j *Jobs) Go(name string, export ExportFunc, opts *IncrementalExportOptions, sink RecordSink) error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	if j.draining {
+		return ErrDraining
+	}
+
+	o := IncrementalExportOptions{}
+	if opts != nil {
+		o = *opts
+	}
+	o.Stop = j.stop
+
+	j.running[name]++
+	j.wg.Add(1)
+	go func() {
+		defer j.wg.Done()
+
+		checkpoint, err := export(&o, sink)
+		j.finish(name, checkpoint, err)
+	}()
+	return nil
+}
+
+func (j *Jobs) finish(name string, checkpoint *ExportCheckpoint, err error) {
+	var saveErr error
+	if j.save != nil && checkpoint != nil {
+		saveErr = j.save(name, checkpoint, err)
+	}
+
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	j.running[name]--
+	if j.running[name] == 0 {
+		delete(j.running, name)
+	}
+	if saveErr != nil {
+		j.errs = append(j.errs, saveErr)
+	}
+}
+
+// Running returns the names of the jobs still running, sorted.
+func (j *Jobs) Running() []string {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	names := make([]string, 0, len(j.running))
+	for name := range j.running {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Wait blocks until every job has finished.
+func (j *Jobs) Wait() {
+	j.wg.Wait()
+}
+
+// Drain stops the jobs after the pages being written and waits for their checkpoints to
+// be saved. No job can be started afterwards. It returns the context error when the
+// context is done first, or the first error of the saves of the checkpoints.
+func (j *Jobs) Drain(ctx context.Context) error {
+	j.mu.Lock()
+	if !j.draining {
+		j.draining = true
+		close(j.stop)
+	}
+	j.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		j.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	if len(j.errs) > 0 {
+		return j.errs[0]
+	}
+	return nil
+}
@@ -0,0 +1,130 @@
+package zendesk
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// RateLimitSimulation configures the synthetic rate limiting injected by SimulateRateLimit.
+type RateLimitSimulation struct {
+	// Rate is the fraction of requests, between 0 and 1, answered with a 429.
+	Rate float64
+	// RetryAfter is the number of seconds sent in the Retry-After header.
+	RetryAfter int64
+	// Seed seeds the random source deciding which requests are limited,
+	// so that a given sequence of requests is always limited the same way.
+	Seed int64
+}
+
+// SimulateRateLimit returns a middleware that answers a share of the requests with a
+// synthetic 429 Too Many Requests response instead of sending them to Zendesk.
+// It is meant for tests exercising the backoff behavior of code built on this client.
+func SimulateRateLimit(sim RateLimitSimulation) MiddlewareFunction {
+	var mu sync.Mutex
+	rnd := rand.New(rand.NewSource(sim.Seed))
+
+	return func(next RequestFunction) RequestFunction {
+		return func(req *http.Request) (*http.Response, error) {
+			mu.Lock()
+			limited := rnd.Float64() < sim.Rate
+			mu.Unlock()
+
+			if !limited {
+				return next(req)
+			}
+
+			body := `{"error":"RateLimited","description":"Simulated rate limit exceeded"}`
+			header := make(http.Header)
+			header.Set("Content-Type", "application/json")
+			header.Set("Retry-After", strconv.FormatInt(sim.RetryAfter, 10))
+
+			return &http.Response{
+				Status:        "429 Too Many Requests",
+				StatusCode:    http.StatusTooManyRequests,
+				Proto:         "HTTP/1.1",
+				ProtoMajor:    1,
+				ProtoMinor:    1,
+				Header:        header,
+				Body:          ioutil.NopCloser(strings.NewReader(body)),
+				ContentLength: int64(len(body)),
+				Request:       req,
+			}, nil
+		}
+	}
+}
+
+// namedMiddleware is a middleware installed on a client under a name.
+type namedMiddleware struct {
+	name string
+	fn   MiddlewareFunction
+}
+
+// Middleware lists the names of the middleware of the client, from the outermost to the
+// innermost one.
+func (c *client) Middleware() []string {
+	names := make([]string, 0, len(c.middleware))
+	for _, m := range c.middleware {
+		names = append(names, m.name)
+	}
+	return names
+}
+
+// WithMiddlewareReplaced returns an updated client whose named middleware is replaced,
+// keeping its position. The middleware is installed innermost when the client has none by
+// that name.
+func (c *client) WithMiddlewareReplaced(name string, middleware MiddlewareFunction) Client {
+	newClient := *c
+	newClient.middleware = append([]namedMiddleware(nil), c.middleware...)
+	if i := newClient.middlewareIndex(name); i >= 0 {
+		newClient.middleware[i].fn = middleware
+	} else {
+		newClient.middleware = append(newClient.middleware, namedMiddleware{name: name, fn: middleware})
+	}
+	newClient.reqFunc = newClient.chain(newClient.client.Do)
+
+	return &newClient
+}
+
+// WithoutMiddleware returns an updated client without the named middleware, such as to
+// bypass a cache for some calls.
+func (c *client) WithoutMiddleware(name string) Client {
+	newClient := *c
+	newClient.middleware = make([]namedMiddleware, 0, len(c.middleware))
+	for _, m := range c.middleware {
+		if m.name != name {
+			newClient.middleware = append(newClient.middleware, m)
+		}
+	}
+	newClient.reqFunc = newClient.chain(newClient.client.Do)
+
+	return &newClient
+}
+
+func (c *client) middlewareIndex(name string) int {
+	for i, m := range c.middleware {
+		if m.name == name {
+			return i
+		}
+	}
+	return -1
+}
+
+func (c *client) insertMiddleware(i int, name string, middleware MiddlewareFunction) error {
+	if name == "" {
+		return errors.New("zendesk: middleware name is required")
+	}
+	if c.middlewareIndex(name) >= 0 {
+		return fmt.Errorf("zendesk: a middleware named %q is already installed", name)
+	}
+
+	c.middleware = append(c.middleware, namedMiddleware{})
+	copy(c.middleware[i+1:], c.middleware[i:])
+	c.middleware[i] = namedMiddleware{name: name, fn: middleware}
+	return nil
+}
@@ -0,0 +1,222 @@
+package zendesk
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+)
+
+// Logger is the interface of the loggers the client writes to. *log.Logger implements it.
+type Logger interface {
+	Printf(format string, v ...interface{})
+}
+
+// stdLogger writes to the standard logger of the log package.
+type stdLogger struct{}
+
+func (stdLogger) Printf(format string, v ...interface{}) {
+	log.Printf(format, v...)
+}
+
+// Option configures a client created by New.
+type Option func(*client) error
+
+// New creates a new Client for the given Zendesk domain, configured by the provided options.
+// The domain may be empty when the base URL is set with WithBaseURL.
+func New(domain string, opts ...Option) (Client, error) {
+	c := &client{
+		userAgent: "PHIL-Zendesk",
+		client:    http.DefaultClient,
+		headers:   make(map[string]string),
+		endpoints: newEndpointStates(DefaultEndpointPolicies),
+		logger:    stdLogger{},
+
+		concurrency: 1,
+		recent:      newRecentWrites(),
+		caches:      newClientCaches(),
+		responses:   newResponses(),
+	}
+
+	if domain != "" {
+		baseURL, err := url.Parse(fmt.Sprintf("https://%s.zendesk.com", domain))
+		if err != nil {
+			return nil, err
+		}
+		c.baseURL = baseURL
+	}
+
+	for _, opt := range opts {
+		if err := opt(c); err != nil {
+			return nil, err
+		}
+	}
+
+	if c.baseURL == nil {
+		return nil, errors.New("zendesk: a domain or a base URL is required")
+	}
+	c.reqFunc = c.chain(c.client.Do)
+
+	return c, nil
+}
+
+// WithBasicAuth authenticates the requests with a user email and password.
+func WithBasicAuth(username, password string) Option {
+	return func(c *client) error {
+		c.username = username
+		c.password = password
+		return nil
+	}
+}
+
+// WithToken authenticates the requests with the API token of the user with the given email.
+//
+// Zendesk Core API docs: https://developer.zendesk.com/api-reference/introduction/security-and-auth/#api-token
+func WithToken(email, token string) Option {
+	return WithBasicAuth(email+"/token", token)
+}
+
+// WithUserAgent sets the User-Agent header of the requests.
+func WithUserAgent(userAgent string) Option {
+	return func(c *client) error {
+		c.userAgent = userAgent
+		return nil
+	}
+}
+
+// WithRetry retries failed requests according to the provided policy, whatever their
+// endpoint family.
+func WithRetry(policy RetryPolicy) Option {
+	return func(c *client) error {
+		for family, state := range c.endpoints {
+			c.endpoints[family] = &endpointState{
+				policy:  EndpointPolicy{Retry: policy, RateLimit: state.policy.RateLimit},
+				limiter: state.limiter,
+			}
+		}
+		return nil
+	}
+}
+
+// WithRateLimit limits the rate of the requests to the interactive endpoints, typically
+// to leave room for other integrations of the account. Exports keep their own limit,
+// which can be changed with WithEndpointPolicy.
+func WithRateLimit(limit RateLimit) Option {
+	return func(c *client) error {
+		policy := c.endpoints[FamilyInteractive].policy
+		policy.RateLimit = limit
+		c.endpoints[FamilyInteractive] = &endpointState{policy: policy, limiter: newRateLimiter(limit)}
+		return nil
+	}
+}
+
+// WithLogger sets the logger of the client. It defaults to the standard logger of the log package.
+func WithLogger(logger Logger) Option {
+	return func(c *client) error {
+		if logger == nil {
+			return errors.New("zendesk: nil logger")
+		}
+		c.logger = logger
+		return nil
+	}
+}
+
+// Logger returns the logger of the client, for the helpers built on it to write to.
+func (c *client) Logger() Logger {
+	return c.logger
+}
+
+// WithMaxResponseSize limits the size of the response bodies read by the client, so that
+// an unexpectedly large page does not exhaust the memory. Reading past the limit fails
+// with ErrResponseTooLarge. The size of decompressed bodies is limited, not the size
+// transferred. A zero size means no limit.
+func WithMaxResponseSize(size int64) Option {
+	return func(c *client) error {
+		if size < 0 {
+			return fmt.Errorf("zendesk: invalid max response size %d", size)
+		}
+		c.maxResponseSize = size
+		return nil
+	}
+}
+
+// WithCompression asks Zendesk for gzip compressed responses and decompresses them, to
+// reduce the bandwidth of large exports whatever the transport of the HTTP client. The
+// middleware of the client see the compressed bodies.
+func WithCompression() Option {
+	return func(c *client) error {
+		c.compression = true
+		return nil
+	}
+}
+
+// WithSanitizer passes the tickets, users and comments fetched by the client to the
+// sanitizer before returning them, so that sensitive data is masked for all callers.
+// The records sent to Zendesk are not sanitized: a masked record written back stores the
+// masks in Zendesk. The helpers of the client that read records to write them, such as
+// CreateFollowupTicket, read them unsanitized.
+func WithSanitizer(sanitizer Sanitizer) Option {
+	return func(c *client) error {
+		c.sanitizer = sanitizer
+		return nil
+	}
+}
+
+// WithBaseURL sets the base URL of the API instead of the one derived from the domain,
+// for proxies or test servers.
+func WithBaseURL(endpoint string) Option {
+	return func(c *client) error {
+		baseURL, err := url.Parse(endpoint)
+		if err != nil {
+			return err
+		}
+		c.baseURL = baseURL
+		return nil
+	}
+}
+
+// WithMiddleware wraps the requests of the client in the provided middleware, the first
+// middleware being the outermost. The middleware are named "middleware-1", "middleware-2"
+// and so on in the order they are installed; WithNamedMiddleware gives them a chosen name.
+func WithMiddleware(middleware ...MiddlewareFunction) Option {
+	return func(c *client) error {
+		for _, fn := range middleware {
+			c.middleware = append(c.middleware, namedMiddleware{name: fmt.Sprintf("middleware-%d", len(c.middleware)+1), fn: fn})
+		}
+		return nil
+	}
+}
+
+// WithNamedMiddleware wraps the requests of the client in the middleware, inside the
+// middleware installed so far. The name identifies it for the Middleware, WithoutMiddleware
+// and WithMiddlewareReplaced methods of the client, and must be unique.
+func WithNamedMiddleware(name string, middleware MiddlewareFunction) Option {
+	return func(c *client) error {
+		return c.insertMiddleware(len(c.middleware), name, middleware)
+	}
+}
+
+// WithMiddlewareBefore installs the named middleware right outside the existing one, so
+// that it sees the requests before it.
+func WithMiddlewareBefore(existing, name string, middleware MiddlewareFunction) Option {
+	return func(c *client) error {
+		i := c.middlewareIndex(existing)
+		if i < 0 {
+			return fmt.Errorf("zendesk: no middleware named %q", existing)
+		}
+		return c.insertMiddleware(i, name, middleware)
+	}
+}
+
+// WithMiddlewareAfter installs the named middleware right inside the existing one, so
+// that it sees the requests after it.
+func WithMiddlewareAfter(existing, name string, middleware MiddlewareFunction) Option {
+	return func(c *client) error {
+		i := c.middlewareIndex(existing)
+		if i < 0 {
+			return fmt.Errorf("zendesk: no middleware named %q", existing)
+		}
+		return c.insertMiddleware(i+1, name, middleware)
+	}
+}
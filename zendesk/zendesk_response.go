@@ -0,0 +1,173 @@
+package zendesk
+
+import (
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ResponseMeta describes a response received from Zendesk.
+type ResponseMeta struct {
+	Method     string
+	URL        string
+	StatusCode int
+	// RequestID identifies the request at Zendesk, to quote when escalating to their support.
+	RequestID string
+	// RateLimit and RateLimitRemaining are the rate limit headers of the response, -1 when
+	// they are missing.
+	RateLimit          int
+	RateLimitRemaining int
+	// RetryAfter is the delay asked by a rate limited response.
+	RetryAfter time.Duration
+	// Attempts is the number of requests sent for the call, retries included.
+	Attempts int
+	Duration time.Duration
+
+	// Count is the number of records reported by a listing, -1 when the response has none.
+	Count int64
+	// HasMore reports whether a listing has a page after this one.
+	HasMore bool
+
+	Header http.Header
+}
+
+// responses keeps the metadata of the last response received by a client and its copies.
+type responses struct {
+	mu   sync.Mutex
+	last *ResponseMeta
+}
+
+func newResponses() *responses {
+	return new(responses)
+}
+
+// record keeps the metadata of the response of a call.
+func (r *responses) record(trace *CallTrace, res *http.Response) {
+	if res == nil {
+		return
+	}
+
+	meta := &ResponseMeta{
+		Method:             trace.Method,
+		URL:                trace.URL,
+		StatusCode:         res.StatusCode,
+		RequestID:          res.Header.Get("X-Request-Id"),
+		RateLimit:          headerInt(res.Header, "X-Rate-Limit", "Ratelimit-Limit"),
+		RateLimitRemaining: headerInt(res.Header, "X-Rate-Limit-Remaining", "Ratelimit-Remaining"),
+		Attempts:           trace.Attempts,
+		Duration:           trace.Total,
+		Count:              -1,
+		Header:             res.Header.Clone(),
+	}
+	if meta.RequestID == "" {
+		meta.RequestID = res.Header.Get("X-Zendesk-Request-Id")
+	}
+	if after, err := strconv.ParseInt(res.Header.Get("Retry-After"), 10, 64); err == nil {
+		meta.RetryAfter = time.Duration(after) * time.Second
+	}
+
+	r.mu.Lock()
+	r.last = meta
+	trace.meta = meta
+	r.mu.Unlock()
+}
+
+// recordPage adds the page information of a listing, read from the payload it was
+// decoded into, to the metadata of its response.
+func (r *responses) recordPage(meta *ResponseMeta, out interface{}) {
+	count, hasMore, ok := pageInfo(out)
+	if !ok {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if count != nil {
+		meta.Count = *count
+	}
+	meta.HasMore = hasMore
+}
+
+// pageInfo reads the page information of a listing from the fields of a payload tagged
+// "count", "next_page" and "meta", the latter holding a "has_more" field. The bool is
+// false when the payload has none of them.
+func pageInfo(out interface{}) (count *int64, hasMore bool, ok bool) {
+	v := reflect.Indirect(reflect.ValueOf(out))
+	if v.Kind() != reflect.Struct {
+		return nil, false, false
+	}
+
+	for i := 0; i < v.NumField(); i++ {
+		field := reflect.Indirect(v.Field(i))
+		switch jsonName(v.Type().Field(i)) {
+		case "count":
+			switch field.Kind() {
+			case reflect.Int, reflect.Int32, reflect.Int64:
+				n := field.Int()
+				count, ok = &n, true
+			}
+		case "next_page":
+			if field.Kind() == reflect.String {
+				hasMore = hasMore || field.String() != ""
+				ok = true
+			}
+		case "meta":
+			if field.Kind() != reflect.Struct {
+				continue
+			}
+			for j := 0; j < field.NumField(); j++ {
+				if jsonName(field.Type().Field(j)) == "has_more" && field.Field(j).Kind() == reflect.Bool {
+					hasMore = hasMore || field.Field(j).Bool()
+					ok = true
+				}
+			}
+		}
+	}
+	return count, hasMore, ok
+}
+
+func jsonName(field reflect.StructField) string {
+	return strings.Split(field.Tag.Get("json"), ",")[0]
+}
+
+func headerInt(header http.Header, names ...string) int {
+	for _, name := range names {
+		if value, err := strconv.Atoi(header.Get(name)); err == nil {
+			return value
+		}
+	}
+	return -1
+}
+
+// decode unmarshalls the response of a call into out, sanitizes it and records its page
+// information.
+func (c *client) decode(res *http.Response, out interface{}) error {
+	if err := c.unmarshall(res, out); err != nil {
+		return err
+	}
+	c.sanitize(out)
+	if out != nil && res.Request != nil {
+		if trace := CallTraceFromRequest(res.Request); trace != nil && trace.meta != nil {
+			c.responses.recordPage(trace.meta, out)
+		}
+	}
+	return nil
+}
+
+// LastResponse returns the metadata of the last response received by the client, or by
+// the clients derived from it with the With methods, or nil before the first one. When
+// calls are made concurrently, TraceMiddleware gives the metadata of each of them.
+func (c *client) LastResponse() *ResponseMeta {
+	c.responses.mu.Lock()
+	defer c.responses.mu.Unlock()
+
+	if c.responses.last == nil {
+		return nil
+	}
+	meta := *c.responses.last
+	meta.Header = meta.Header.Clone()
+	return &meta
+}
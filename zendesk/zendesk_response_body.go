@@ -0,0 +1,71 @@
+package zendesk
+
+import (
+	"compress/gzip"
+	"errors"
+	"io"
+	"net/http"
+)
+
+// ErrResponseTooLarge is returned when reading a response body larger than the limit set
+// with WithMaxResponseSize.
+var ErrResponseTooLarge = errors.New("zendesk: response body too large")
+
+// prepareBody decompresses the body of res when the client asked for compressed responses,
+// and limits it to the maximum response size of the client.
+func (c *client) prepareBody(res *http.Response) error {
+	if c.compression && res.Header.Get("Content-Encoding") == "gzip" {
+		gz, err := gzip.NewReader(res.Body)
+		if err != nil {
+			res.Body.Close()
+			return err
+		}
+		res.Body = &gzipBody{Reader: gz, body: res.Body}
+		res.Header.Del("Content-Encoding")
+		res.Header.Del("Content-Length")
+		res.ContentLength = -1
+		res.Uncompressed = true
+	}
+
+	if c.maxResponseSize > 0 {
+		res.Body = &limitedBody{ReadCloser: res.Body, remaining: c.maxResponseSize}
+	}
+	return nil
+}
+
+// gzipBody reads the decompressed content of a gzip encoded body.
+type gzipBody struct {
+	*gzip.Reader
+	body io.ReadCloser
+}
+
+func (b *gzipBody) Close() error {
+	b.Reader.Close()
+	return b.body.Close()
+}
+
+// limitedBody fails with ErrResponseTooLarge once more than remaining bytes are read.
+type limitedBody struct {
+	io.ReadCloser
+	remaining int64
+	exceeded  bool
+}
+
+func (b *limitedBody) Read(p []byte) (int, error) {
+	if b.exceeded {
+		return 0, ErrResponseTooLarge
+	}
+	if int64(len(p)) > b.remaining+1 {
+		p = p[:b.remaining+1]
+	}
+
+	n, err := b.ReadCloser.Read(p)
+	if int64(n) > b.remaining {
+		n = int(b.remaining)
+		b.remaining = 0
+		b.exceeded = true
+		return n, ErrResponseTooLarge
+	}
+	b.remaining -= int64(n)
+	return n, err
+}
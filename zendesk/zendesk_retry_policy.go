@@ -0,0 +1,136 @@
+package zendesk
+
+import (
+	"math"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// BackoffStrategy returns how long to wait before the given retry attempt.
+// The first retry is attempt 1.
+type BackoffStrategy func(attempt int) time.Duration
+
+// ExponentialBackoff returns a strategy doubling the wait time from base on each
+// attempt, without ever exceeding max.
+func ExponentialBackoff(base, max time.Duration) BackoffStrategy {
+	return func(attempt int) time.Duration {
+		wait := float64(base) * math.Pow(2, float64(attempt-1))
+		if wait > float64(max) {
+			return max
+		}
+		return time.Duration(wait)
+	}
+}
+
+// ConstantBackoff returns a strategy waiting the same amount of time on each attempt.
+func ConstantBackoff(wait time.Duration) BackoffStrategy {
+	return func(int) time.Duration {
+		return wait
+	}
+}
+
+// RetryPolicy describes how the client retries failed requests.
+//
+// A response whose status code is retryable is retried after the delay given in its
+// Retry-After header or, when the header is missing, after the backoff delay.
+//
+// Only the idempotent methods, GET, HEAD, OPTIONS and DELETE, are retried on any
+// retryable status code and on network errors. A POST, PUT or PATCH may have been applied
+// by Zendesk despite the failure: retrying it could create a ticket twice, append a
+// comment twice or fail a safe update with a conflict. These requests are retried only
+// when Zendesk rejected them before processing, with a 429 or a 503 carrying a
+// Retry-After header, unless they are sent with an Idempotency-Key header, which makes
+// Zendesk ignore the replays.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts made for a request, including the
+	// first one. A value of 1 or less disables retries.
+	MaxAttempts int
+	// Backoff computes the wait time when the response has no Retry-After header.
+	Backoff BackoffStrategy
+	// RetryableStatusCodes lists the response status codes that are retried.
+	RetryableStatusCodes []int
+	// Jitter randomizes the backoff delay by up to the given fraction, between 0 and 1,
+	// so that concurrent clients do not retry in lockstep.
+	Jitter float64
+}
+
+// DefaultRetryPolicy is the retry policy used by new clients.
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts:          3,
+	Backoff:              ExponentialBackoff(time.Second, 30*time.Second),
+	RetryableStatusCodes: []int{429, 500, 502, 503, 504},
+	Jitter:               0.2,
+}
+
+// NoRetryPolicy disables retries.
+var NoRetryPolicy = RetryPolicy{MaxAttempts: 1}
+
+// IdempotencyKeyHeader is the header making the replays of a request creating a record,
+// such as a ticket, return the first response rather than create the record again. The
+// client retries the requests carrying it like the idempotent ones.
+//
+// Zendesk Core API docs: https://developer.zendesk.com/api-reference/ticketing/introduction/#idempotency
+const IdempotencyKeyHeader = "Idempotency-Key"
+
+func (p RetryPolicy) shouldRetry(req *http.Request, res *http.Response, err error, attempt int) bool {
+	if attempt >= p.MaxAttempts {
+		return false
+	}
+
+	idempotent := isIdempotent(req)
+	if err != nil {
+		return idempotent
+	}
+
+	retryable := false
+	for _, code := range p.RetryableStatusCodes {
+		if res.StatusCode == code {
+			retryable = true
+			break
+		}
+	}
+	if !retryable || idempotent {
+		return retryable
+	}
+
+	// The request was rejected before being processed.
+	switch res.StatusCode {
+	case http.StatusTooManyRequests:
+		return true
+	case http.StatusServiceUnavailable:
+		return res.Header.Get("Retry-After") != ""
+	}
+	return false
+}
+
+// isIdempotent tells whether sending the request again cannot apply it twice.
+func isIdempotent(req *http.Request) bool {
+	switch req.Method {
+	case "GET", "HEAD", "OPTIONS", "DELETE":
+		return true
+	}
+	return req.Header.Get(IdempotencyKeyHeader) != ""
+}
+
+func (p RetryPolicy) delay(attempt int, res *http.Response) time.Duration {
+	if res != nil {
+		if after, err := strconv.ParseInt(res.Header.Get("Retry-After"), 10, 64); err == nil && after > 0 {
+			return time.Duration(after) * time.Second
+		}
+	}
+
+	if p.Backoff == nil {
+		return 0
+	}
+
+	wait := p.Backoff(attempt)
+	if p.Jitter > 0 {
+		wait += time.Duration(p.Jitter * (2*rand.Float64() - 1) * float64(wait))
+	}
+	if wait < 0 {
+		return 0
+	}
+	return wait
+}
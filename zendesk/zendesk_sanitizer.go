@@ -0,0 +1,193 @@
+package zendesk
+
+import (
+	"reflect"
+	"regexp"
+)
+
+// Sanitizer masks sensitive data, such as PHI, in the records fetched by a client set up
+// with WithSanitizer, before they are returned to the caller. The tickets, users and
+// comments are passed wherever they appear in a response, sideloads included. The comment
+// events of the ticket audits are passed to SanitizeComment as comments.
+type Sanitizer interface {
+	SanitizeTicket(*Ticket)
+	SanitizeUser(*User)
+	SanitizeComment(*TicketComment)
+}
+
+// TextSanitizer is implemented by the sanitizers that can mask any text. The values of the
+// create and change events of the ticket audits, and the bodies of their notification,
+// satisfaction rating and external events, are only masked by such sanitizers, since their
+// fields cannot be told apart.
+type TextSanitizer interface {
+	SanitizeText(string) string
+}
+
+// Patterns of common personal data, for PatternSanitizer.
+var (
+	SSNPattern   = regexp.MustCompile(`\b\d{3}-\d{2}-\d{4}\b`)
+	PhonePattern = regexp.MustCompile(`(?:\+?1[ .-]?)?(?:\(\d{3}\)|\b\d{3})[ .-]?\d{3}[ .-]?\d{4}\b`)
+	EmailPattern = regexp.MustCompile(`[A-Za-z0-9._%+-]+@[A-Za-z0-9.-]+\.[A-Za-z]{2,}`)
+)
+
+// PatternSanitizer replaces the matches of its patterns with a mask in the free text of
+// the records: the subject, description and text custom fields of the tickets, the phone,
+// details and notes of the users and the bodies of the comments.
+type PatternSanitizer struct {
+	Patterns []*regexp.Regexp
+	// Mask replaces the matches. It defaults to "[REDACTED]".
+	Mask string
+}
+
+// NewPatternSanitizer creates a PatternSanitizer masking the matches of the patterns.
+func NewPatternSanitizer(patterns ...*regexp.Regexp) *PatternSanitizer {
+	return &PatternSanitizer{Patterns: patterns}
+}
+
+func (s *PatternSanitizer) mask(text string) string {
+	mask := s.Mask
+	if mask == "" {
+		mask = "[REDACTED]"
+	}
+	for _, pattern := range s.Patterns {
+		text = pattern.ReplaceAllLiteralString(text, mask)
+	}
+	return text
+}
+
+// SanitizeText masks the text.
+func (s *PatternSanitizer) SanitizeText(text string) string {
+	return s.mask(text)
+}
+
+// SanitizeTicket masks the subject, description and text custom fields of the ticket.
+func (s *PatternSanitizer) SanitizeTicket(ticket *Ticket) {
+	ticket.Subject = s.mask(ticket.Subject)
+	ticket.RawSubject = s.mask(ticket.RawSubject)
+	ticket.Description = s.mask(ticket.Description)
+	for i, field := range ticket.CustomFields {
+		if value, ok := field.Value.(string); ok {
+			ticket.CustomFields[i].Value = s.mask(value)
+		}
+	}
+}
+
+// SanitizeUser masks the phone, details and notes of the user.
+func (s *PatternSanitizer) SanitizeUser(user *User) {
+	user.Phone = s.mask(user.Phone)
+	user.Details = s.mask(user.Details)
+	user.Notes = s.mask(user.Notes)
+}
+
+// SanitizeComment masks the bodies of the comment.
+func (s *PatternSanitizer) SanitizeComment(comment *TicketComment) {
+	comment.Body = s.mask(comment.Body)
+	comment.HTMLBody = s.mask(comment.HTMLBody)
+	comment.PlainBody = s.mask(comment.PlainBody)
+}
+
+// unsanitized returns a copy of the client whose fetches are not sanitized, for the reads
+// of the client's own helpers that write the fetched data back to Zendesk or compare it.
+// Their results are sanitized when returned to the caller.
+func (c *client) unsanitized() *client {
+	raw := *c
+	raw.sanitizer = nil
+	return &raw
+}
+
+// sanitize passes the tickets, users and comments held by out to the sanitizer of the client.
+func (c *client) sanitize(out interface{}) {
+	if c.sanitizer == nil || out == nil {
+		return
+	}
+	sanitizeValue(c.sanitizer, reflect.ValueOf(out))
+}
+
+// sanitizeEvent masks the free text of the audit events other than comments.
+func sanitizeEvent(s TextSanitizer, event interface{}) {
+	switch event := event.(type) {
+	case *CreateEvent:
+		event.Value = sanitizeEventValue(s, event.Value)
+	case *ChangeEvent:
+		event.Value = sanitizeEventValue(s, event.Value)
+		event.PreviousValue = sanitizeEventValue(s, event.PreviousValue)
+	case *NotificationEvent:
+		event.Subject = s.SanitizeText(event.Subject)
+		event.Body = s.SanitizeText(event.Body)
+	case *SatisfactionRatingEvent:
+		event.Body = s.SanitizeText(event.Body)
+	case *ExternalEvent:
+		event.Body = s.SanitizeText(event.Body)
+	}
+}
+
+// sanitizeEventValue masks the value of a create or change event, a string or a list of
+// strings.
+func sanitizeEventValue(s TextSanitizer, value interface{}) interface{} {
+	switch v := value.(type) {
+	case string:
+		return s.SanitizeText(v)
+	case []interface{}:
+		masked := make([]interface{}, len(v))
+		for i, item := range v {
+			masked[i] = sanitizeEventValue(s, item)
+		}
+		return masked
+	}
+	return value
+}
+
+func sanitizeValue(s Sanitizer, v reflect.Value) {
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if !v.IsNil() {
+			sanitizeValue(s, v.Elem())
+		}
+	case reflect.Struct:
+		if v.CanAddr() {
+			switch record := v.Addr().Interface().(type) {
+			case *Ticket:
+				s.SanitizeTicket(record)
+			case *User:
+				s.SanitizeUser(record)
+			case *TicketComment:
+				s.SanitizeComment(record)
+			case *CommentEvent:
+				comment := TicketComment{Body: record.Body, HTMLBody: record.HTMLBody, PlainBody: record.PlainBody}
+				s.SanitizeComment(&comment)
+				record.Body, record.HTMLBody, record.PlainBody = comment.Body, comment.HTMLBody, comment.PlainBody
+			case *VoiceCommentEvent:
+				comment := TicketComment{Body: record.Body, HTMLBody: record.HTMLBody}
+				if record.Data != nil {
+					comment.PlainBody = record.Data.TranscriptionText
+				}
+				s.SanitizeComment(&comment)
+				record.Body, record.HTMLBody = comment.Body, comment.HTMLBody
+				if record.Data != nil {
+					record.Data.TranscriptionText = comment.PlainBody
+				}
+			default:
+				if text, ok := s.(TextSanitizer); ok {
+					sanitizeEvent(text, record)
+				}
+			}
+		}
+		for i := 0; i < v.NumField(); i++ {
+			if v.Type().Field(i).PkgPath == "" {
+				sanitizeValue(s, v.Field(i))
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			sanitizeValue(s, v.Index(i))
+		}
+	case reflect.Map:
+		// Map values cannot be addressed, so they are sanitized in a copy put back in the map.
+		for _, key := range v.MapKeys() {
+			value := reflect.New(v.Type().Elem()).Elem()
+			value.Set(v.MapIndex(key))
+			sanitizeValue(s, value)
+			v.SetMapIndex(key, value)
+		}
+	}
+}
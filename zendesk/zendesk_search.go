@@ -0,0 +1,238 @@
+package zendesk
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// SearchOptions configures how the search based helpers cope with the delay between a
+// write and its indexing by search, during which searches miss the written record.
+type SearchOptions struct {
+	// DirectLookup falls back, when the search finds nothing, to lookups that do not go
+	// through the search index: the records recently created through this client, and
+	// tickets listed by external ID.
+	DirectLookup bool
+	// Retries is the number of times a search that found nothing is retried, waiting
+	// for the record to be indexed.
+	Retries int
+	// Backoff computes the wait time before each retry. It defaults to an exponential
+	// backoff from 1 second up to 10 seconds.
+	Backoff BackoffStrategy
+}
+
+// recentWriteTTL is how long records created through the client are remembered, well
+// over the usual indexing delay of search.
+const recentWriteTTL = 10 * time.Minute
+
+// recentWrites remembers the records created through a client, and the clients derived
+// from it, for read-your-writes lookups.
+type recentWrites struct {
+	mu      sync.Mutex
+	users   map[string]recentWrite
+	tickets map[string]recentWrite
+}
+
+type recentWrite struct {
+	id int64
+	at time.Time
+}
+
+func newRecentWrites() *recentWrites {
+	return &recentWrites{users: make(map[string]recentWrite), tickets: make(map[string]recentWrite)}
+}
+
+func (r *recentWrites) add(records map[string]recentWrite, key string, id int64) {
+	if r == nil || key == "" || id == 0 {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+	for k, w := range records {
+		if now.Sub(w.at) > recentWriteTTL {
+			delete(records, k)
+		}
+	}
+	records[key] = recentWrite{id: id, at: now}
+}
+
+func (r *recentWrites) find(records map[string]recentWrite, key string) (int64, bool) {
+	if r == nil {
+		return 0, false
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	w, ok := records[key]
+	if !ok || time.Since(w.at) > recentWriteTTL {
+		return 0, false
+	}
+	return w.id, true
+}
+
+func (r *recentWrites) addUser(user *User) {
+	if r != nil && user != nil {
+		r.add(r.users, strings.ToLower(user.Email), user.ID)
+	}
+}
+
+func (r *recentWrites) addTicket(ticket *Ticket) {
+	if r != nil && ticket != nil {
+		r.add(r.tickets, ticket.ExternalID, ticket.ID)
+	}
+}
+
+// searchAttempts calls search until it finds a record, retrying according to opts.
+// lookup, when not nil and DirectLookup is set, is tried after each unsuccessful search.
+func searchAttempts(opts *SearchOptions, search, lookup func() (bool, error)) (bool, error) {
+	if opts == nil {
+		opts = new(SearchOptions)
+	}
+	backoff := opts.Backoff
+	if backoff == nil {
+		backoff = ExponentialBackoff(time.Second, 10*time.Second)
+	}
+
+	for attempt := 0; ; attempt++ {
+		if found, err := search(); found || err != nil {
+			return found, err
+		}
+		if opts.DirectLookup && lookup != nil {
+			if found, err := lookup(); found || err != nil {
+				return found, err
+			}
+		}
+		if attempt >= opts.Retries {
+			return false, nil
+		}
+		time.Sleep(backoff(attempt + 1))
+	}
+}
+
+// FindUserByEmail searches the user with the given email address. When no user is found,
+// the returned error wraps ErrNotFound.
+//
+// Zendesk Core API docs: https://developer.zendesk.com/api-reference/ticketing/users/users/#search-users
+func (c *client) FindUserByEmail(email string, opts *SearchOptions) (*User, error) {
+	var user *User
+	// The emails are compared to the one searched, before sanitization.
+	raw := c.unsanitized()
+
+	search := func() (bool, error) {
+		params := url.Values{}
+		params.Set("query", fmt.Sprintf("email:%q", email))
+		out := new(APIPayload)
+		if err := raw.get("/api/v2/users/search.json?"+params.Encode(), out); err != nil {
+			return false, err
+		}
+		for i := range out.Users {
+			if strings.EqualFold(out.Users[i].Email, email) {
+				user = &out.Users[i]
+				return true, nil
+			}
+		}
+		return false, nil
+	}
+
+	lookup := func() (bool, error) {
+		id, ok := c.recent.find(c.recent.users, strings.ToLower(email))
+		if !ok {
+			return false, nil
+		}
+		found, err := raw.ShowUser(id)
+		if errors.Is(err, ErrNotFound) {
+			return false, nil
+		}
+		user = found
+		return err == nil, err
+	}
+
+	found, err := searchAttempts(opts, search, lookup)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, fmt.Errorf("user %s: %w", email, ErrNotFound)
+	}
+	c.sanitize(user)
+	return user, nil
+}
+
+// CreateTicketIfNotExists creates the ticket unless a ticket with the same external ID
+// exists, in which case the existing ticket is returned. The returned bool reports whether
+// the ticket was created. The ticket must have an external ID.
+//
+// Zendesk Core API docs: https://developer.zendesk.com/api-reference/ticketing/ticket-management/search/
+func (c *client) CreateTicketIfNotExists(ticket *Ticket, opts *SearchOptions) (*Ticket, bool, error) {
+	if ticket.ExternalID == "" {
+		return nil, false, errors.New("zendesk: CreateTicketIfNotExists requires an external ID")
+	}
+
+	var existing *Ticket
+	// The external IDs are compared to the ticket's one, before sanitization.
+	raw := c.unsanitized()
+
+	search := func() (bool, error) {
+		params := url.Values{}
+		params.Set("query", fmt.Sprintf("type:ticket external_id:%q", ticket.ExternalID))
+		out := struct {
+			Results []Ticket `json:"results"`
+		}{}
+		if err := raw.get("/api/v2/search.json?"+params.Encode(), &out); err != nil {
+			return false, err
+		}
+		for i := range out.Results {
+			if out.Results[i].ExternalID == ticket.ExternalID {
+				existing = &out.Results[i]
+				return true, nil
+			}
+		}
+		return false, nil
+	}
+
+	lookup := func() (bool, error) {
+		if id, ok := c.recent.find(c.recent.tickets, ticket.ExternalID); ok {
+			found, err := raw.ShowTicket(id)
+			if err == nil {
+				existing = found
+				return true, nil
+			}
+			if !errors.Is(err, ErrNotFound) {
+				return false, err
+			}
+		}
+
+		params := url.Values{}
+		params.Set("external_id", ticket.ExternalID)
+		out := new(APIPayload)
+		if err := raw.get("/api/v2/tickets.json?"+params.Encode(), out); err != nil {
+			return false, err
+		}
+		if len(out.Tickets) > 0 {
+			existing = &out.Tickets[0]
+			return true, nil
+		}
+		return false, nil
+	}
+
+	found, err := searchAttempts(opts, search, lookup)
+	if err != nil {
+		return nil, false, err
+	}
+	if found {
+		c.logger.Printf("[zendesk_search][CreateTicketIfNotExists] ticket with external ID %s already exists: %d\n", ticket.ExternalID, existing.ID)
+		c.sanitize(existing)
+		return existing, false, nil
+	}
+
+	created, err := c.CreateTicket(ticket)
+	if err != nil {
+		return nil, false, err
+	}
+	return created, true, nil
+}
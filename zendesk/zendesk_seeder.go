@@ -0,0 +1,215 @@
+package zendesk
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultSeedRateLimit is the rate limit of a Seeder without an explicit one, well under
+// the limits of the smallest Zendesk plans so that seeding does not starve other integrations.
+var DefaultSeedRateLimit = RateLimit{Requests: 100, Period: time.Minute}
+
+// SeedOptions configures the records generated by a Seeder.
+type SeedOptions struct {
+	Organizations int
+	Users         int
+	Tickets       int
+	// CommentsPerTicket is the number of comments added to each ticket after its description.
+	CommentsPerTicket int
+
+	// Prefix identifies the records of a seeding run. It is used in organization
+	// external IDs and user emails, which must be unique, and defaults to a value
+	// derived from the current time.
+	Prefix string
+	// Tag is added to the seeded users and tickets. It defaults to "seeded".
+	Tag string
+	// EmailDomain is the domain of the user emails. It defaults to example.com.
+	EmailDomain string
+	// RandSeed seeds the random source, so that a run can be reproduced.
+	RandSeed int64
+	// RateLimit caps the requests of the seeder, on top of the policy of the client.
+	// It defaults to DefaultSeedRateLimit.
+	RateLimit RateLimit
+}
+
+// SeedResult holds the IDs of the seeded records.
+type SeedResult struct {
+	OrganizationIDs []int64
+	UserIDs         []int64
+	TicketIDs       []int64
+}
+
+// Seeder generates fake organizations, users and tickets in a sandbox account, for
+// load-testing integrations built on this client. It must not be used on a production account.
+type Seeder struct {
+	client  Client
+	opts    SeedOptions
+	rnd     *rand.Rand
+	limiter *rateLimiter
+}
+
+// NewSeeder creates a Seeder creating records with client.
+func NewSeeder(client Client, opts SeedOptions) *Seeder {
+	if opts.Prefix == "" {
+		opts.Prefix = strconv.FormatInt(time.Now().Unix(), 36)
+	}
+	if opts.Tag == "" {
+		opts.Tag = "seeded"
+	}
+	if opts.EmailDomain == "" {
+		opts.EmailDomain = "example.com"
+	}
+	if opts.RateLimit.Requests <= 0 || opts.RateLimit.Period <= 0 {
+		opts.RateLimit = DefaultSeedRateLimit
+	}
+
+	return &Seeder{
+		client:  client,
+		opts:    opts,
+		rnd:     rand.New(rand.NewSource(opts.RandSeed)),
+		limiter: newRateLimiter(opts.RateLimit),
+	}
+}
+
+var (
+	seedFirstNames = []string{"Ada", "Alan", "Grace", "Linus", "Margaret", "Dennis", "Barbara", "Ken", "Frances", "Edsger"}
+	seedLastNames  = []string{"Lovelace", "Turing", "Hopper", "Torvalds", "Hamilton", "Ritchie", "Liskov", "Thompson", "Allen", "Dijkstra"}
+	seedCompanies  = []string{"Acme", "Globex", "Initech", "Umbrella", "Hooli", "Stark", "Wayne", "Wonka", "Tyrell", "Cyberdyne"}
+	seedSubjects   = []string{"Cannot log in", "Refund request", "Order not delivered", "Billing question", "Feature request", "App crashes on start", "Password reset", "Update my address"}
+	seedWords      = []string{"please", "help", "order", "account", "since", "yesterday", "again", "thanks", "issue", "urgent", "invoice", "shipping"}
+	seedPriorities = []string{"low", "normal", "high", "urgent"}
+	seedStatuses   = []string{"new", "open", "pending", "solved"}
+)
+
+// Seed creates the organizations, then the users, spread across the organizations, then
+// the tickets, requested by random users. It stops at the first error, or when ctx is done,
+// and returns the records created so far along with the error.
+func (s *Seeder) Seed(ctx context.Context) (*SeedResult, error) {
+	result := new(SeedResult)
+
+	for i := 0; i < s.opts.Organizations; i++ {
+		if err := s.wait(ctx); err != nil {
+			return result, err
+		}
+		org, err := s.client.CreateOrganization(&Organization{
+			Name:       fmt.Sprintf("%s %s %d", s.pick(seedCompanies), s.opts.Prefix, i+1),
+			ExternalID: fmt.Sprintf("seed-%s-org-%d", s.opts.Prefix, i+1),
+		})
+		if err != nil {
+			return result, fmt.Errorf("seeding organization %d: %w", i+1, err)
+		}
+		result.OrganizationIDs = append(result.OrganizationIDs, org.ID)
+	}
+
+	for i := 0; i < s.opts.Users; i++ {
+		if err := s.wait(ctx); err != nil {
+			return result, err
+		}
+		user := &User{
+			Name:  s.pick(seedFirstNames) + " " + s.pick(seedLastNames),
+			Email: fmt.Sprintf("seed-%s-user-%d@%s", s.opts.Prefix, i+1, s.opts.EmailDomain),
+			Role:  "end-user",
+			Tags:  []string{s.opts.Tag},
+		}
+		if len(result.OrganizationIDs) > 0 {
+			user.OrganizationID = result.OrganizationIDs[i%len(result.OrganizationIDs)]
+		}
+		created, err := s.client.CreateUser(user)
+		if err != nil {
+			return result, fmt.Errorf("seeding user %d: %w", i+1, err)
+		}
+		result.UserIDs = append(result.UserIDs, created.ID)
+	}
+
+	for i := 0; i < s.opts.Tickets; i++ {
+		if err := s.wait(ctx); err != nil {
+			return result, err
+		}
+		ticket := &Ticket{
+			Subject:  s.pick(seedSubjects),
+			Comment:  &TicketComment{Body: s.sentence(), Public: true},
+			Priority: s.pick(seedPriorities),
+			Status:   s.pick(seedStatuses),
+			Tags:     []string{s.opts.Tag},
+		}
+		if len(result.UserIDs) > 0 {
+			ticket.RequesterID = result.UserIDs[s.rnd.Intn(len(result.UserIDs))]
+		}
+		created, err := s.client.CreateTicket(ticket)
+		if err != nil {
+			return result, fmt.Errorf("seeding ticket %d: %w", i+1, err)
+		}
+		result.TicketIDs = append(result.TicketIDs, created.ID)
+
+		for j := 0; j < s.opts.CommentsPerTicket; j++ {
+			if err := s.wait(ctx); err != nil {
+				return result, err
+			}
+			comment := &TicketComment{Body: s.sentence(), Public: s.rnd.Intn(4) > 0}
+			if _, err := s.client.AddTicketComment(created.ID, comment); err != nil {
+				return result, fmt.Errorf("seeding comment %d of ticket %d: %w", j+1, created.ID, err)
+			}
+		}
+	}
+
+	s.client.Logger().Printf("[zendesk_seeder][Seed] seeded %d organizations, %d users and %d tickets\n", len(result.OrganizationIDs), len(result.UserIDs), len(result.TicketIDs))
+	return result, nil
+}
+
+// Cleanup deletes the seeded records, tickets first. It keeps going after a failure
+// and returns the first error.
+func (s *Seeder) Cleanup(ctx context.Context, result *SeedResult) error {
+	var firstErr error
+	record := func(err error) {
+		if err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+
+	for _, id := range result.TicketIDs {
+		if err := s.wait(ctx); err != nil {
+			return err
+		}
+		record(s.client.DeleteTicket(id))
+	}
+	for _, id := range result.UserIDs {
+		if err := s.wait(ctx); err != nil {
+			return err
+		}
+		_, err := s.client.DeleteUser(id)
+		record(err)
+	}
+	for _, id := range result.OrganizationIDs {
+		if err := s.wait(ctx); err != nil {
+			return err
+		}
+		record(s.client.DeleteOrganization(id))
+	}
+
+	return firstErr
+}
+
+func (s *Seeder) wait(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	s.limiter.wait()
+	return nil
+}
+
+func (s *Seeder) pick(values []string) string {
+	return values[s.rnd.Intn(len(values))]
+}
+
+func (s *Seeder) sentence() string {
+	words := make([]string, 5+s.rnd.Intn(10))
+	for i := range words {
+		words[i] = s.pick(seedWords)
+	}
+	sentence := strings.Join(words, " ")
+	return strings.ToUpper(sentence[:1]) + sentence[1:] + "."
+}
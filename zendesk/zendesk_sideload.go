@@ -0,0 +1,170 @@
+package zendesk
+
+import (
+	"strings"
+	"time"
+)
+
+// Include names records to sideload with the records returned by a show or list method,
+// saving the requests otherwise needed to fetch them one by one. The methods taking
+// includes are the ones whose endpoints support sideloading: ShowTicket, ShowManyTickets,
+// ListAssignedTickets, ListCCdTickets, ListTicketIncidents, ListProblemTickets and
+// GetTicketsByOrganization for tickets, ShowUser, ShowManyUsers, ListUsers,
+// ListOrganizationUsers and SearchUsers for users, and ShowOrganization and
+// ListOrganizations for organizations. The incremental exports and the other methods
+// return no sideloads.
+//
+// Zendesk Core API docs: https://developer.zendesk.com/documentation/ticketing/using-the-zendesk-api/side_loading/
+type Include string
+
+// Sideloadable records. Each endpoint supports only some of them.
+const (
+	IncludeUsers         Include = "users"
+	IncludeGroups        Include = "groups"
+	IncludeOrganizations Include = "organizations"
+	IncludeMetricSets    Include = "metric_sets"
+	IncludeIdentities    Include = "identities"
+)
+
+// Group represents a Zendesk agent group.
+//
+// Zendesk Core API docs: https://developer.zendesk.com/api-reference/ticketing/groups/groups/
+type Group struct {
+	ID          int64      `json:"id,omitempty"`
+	URL         string     `json:"url,omitempty"`
+	Name        string     `json:"name,omitempty"`
+	Description string     `json:"description,omitempty"`
+	Default     bool       `json:"default,omitempty"`
+	Deleted     bool       `json:"deleted,omitempty"`
+	CreatedAt   *time.Time `json:"created_at,omitempty"`
+	UpdatedAt   *time.Time `json:"updated_at,omitempty"`
+}
+
+// Sideloads holds the records sideloaded with a response. Records returned by the same
+// response share the same Sideloads.
+type Sideloads struct {
+	Users         []User
+	Organizations []Organization
+	Groups        []Group
+	MetricSets    []TicketMetric
+	Identities    []UserIdentity
+}
+
+// User returns the sideloaded user with the given ID, or nil.
+func (s *Sideloads) User(id int64) *User {
+	if s == nil {
+		return nil
+	}
+	for i := range s.Users {
+		if s.Users[i].ID == id {
+			return &s.Users[i]
+		}
+	}
+	return nil
+}
+
+// Organization returns the sideloaded organization with the given ID, or nil.
+func (s *Sideloads) Organization(id int64) *Organization {
+	if s == nil {
+		return nil
+	}
+	for i := range s.Organizations {
+		if s.Organizations[i].ID == id {
+			return &s.Organizations[i]
+		}
+	}
+	return nil
+}
+
+// Group returns the sideloaded group with the given ID, or nil.
+func (s *Sideloads) Group(id int64) *Group {
+	if s == nil {
+		return nil
+	}
+	for i := range s.Groups {
+		if s.Groups[i].ID == id {
+			return &s.Groups[i]
+		}
+	}
+	return nil
+}
+
+// MetricSet returns the sideloaded metric set of the ticket with the given ID, or nil.
+func (s *Sideloads) MetricSet(ticketID int64) *TicketMetric {
+	if s == nil {
+		return nil
+	}
+	for i := range s.MetricSets {
+		if s.MetricSets[i].TicketID == ticketID {
+			return &s.MetricSets[i]
+		}
+	}
+	return nil
+}
+
+// UserIdentities returns the sideloaded identities of the user with the given ID.
+func (s *Sideloads) UserIdentities(userID int64) []UserIdentity {
+	if s == nil {
+		return nil
+	}
+	result := make([]UserIdentity, 0)
+	for _, identity := range s.Identities {
+		if identity.UserID == userID {
+			result = append(result, identity)
+		}
+	}
+	return result
+}
+
+// withIncludes appends the include query parameter to the endpoint.
+func withIncludes(endpoint string, includes []Include) string {
+	if len(includes) == 0 {
+		return endpoint
+	}
+
+	names := make([]string, 0, len(includes))
+	for _, include := range includes {
+		names = append(names, string(include))
+	}
+
+	sep := "?"
+	if strings.Contains(endpoint, "?") {
+		sep = "&"
+	}
+	return endpoint + sep + "include=" + strings.Join(names, ",")
+}
+
+// includesOf converts the include names of the export options.
+func includesOf(names []string) []Include {
+	includes := make([]Include, 0, len(names))
+	for _, name := range names {
+		includes = append(includes, Include(name))
+	}
+	return includes
+}
+
+// sideloads collects the requested sideloaded records of a response. Only the requested
+// records are picked, so that the primary records of a response are not mistaken for
+// sideloaded ones.
+func sideloads(out *APIPayload, includes []Include) *Sideloads {
+	if len(includes) == 0 {
+		return nil
+	}
+
+	s := new(Sideloads)
+	for _, include := range includes {
+		switch include {
+		case IncludeUsers:
+			s.Users = out.Users
+		case IncludeOrganizations:
+			s.Organizations = out.Organizations
+		case IncludeGroups:
+			s.Groups = out.Groups
+		case IncludeMetricSets:
+			s.MetricSets = out.MetricSets
+		case IncludeIdentities:
+			s.Identities = out.Identities
+		}
+	}
+	return s
+}
@@ -0,0 +1,303 @@
+package zendesk
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Snapshot is a local copy of the tickets and users of an account, kept up to date with
+// the incremental exports, which small tools can query without calling the API. It is a
+// RecordSink, so it can also be fed by exports run elsewhere, and it can be loaded from
+// the JSON lines files written by a JSONLSink. It is safe for concurrent use.
+type Snapshot struct {
+	mu      sync.RWMutex
+	tickets map[int64]*Ticket
+	users   map[int64]*User
+
+	// TicketCursor and UserCursor are the checkpoints of the last Sync.
+	TicketCursor string
+	UserCursor   string
+}
+
+// NewSnapshot creates an empty snapshot.
+func NewSnapshot() *Snapshot {
+	return &Snapshot{tickets: make(map[int64]*Ticket), users: make(map[int64]*User)}
+}
+
+// WriteRecord adds a copy of a *Ticket or a *User to the snapshot, replacing the copy of
+// the record with the same ID. A ticket with the "deleted" status, as listed by the
+// incremental exports, is removed instead.
+func (s *Snapshot) WriteRecord(record interface{}) error {
+	switch r := record.(type) {
+	case *Ticket:
+		if r.Status == deletedTicketStatus {
+			s.mu.Lock()
+			delete(s.tickets, r.ID)
+			s.mu.Unlock()
+			return nil
+		}
+		t := new(Ticket)
+		if err := deepCopy(t, r); err != nil {
+			return err
+		}
+		s.mu.Lock()
+		s.tickets[t.ID] = t
+		s.mu.Unlock()
+	case *User:
+		u := new(User)
+		if err := deepCopy(u, r); err != nil {
+			return err
+		}
+		s.mu.Lock()
+		s.users[u.ID] = u
+		s.mu.Unlock()
+	default:
+		return fmt.Errorf("zendesk: a snapshot only holds tickets and users, got %T", record)
+	}
+	return nil
+}
+
+// deletedTicketStatus is the status of the deleted tickets listed by the incremental exports.
+const deletedTicketStatus = "deleted"
+
+// deepCopy copies src into dst through their JSON encoding, so that they share no slice,
+// map or pointer. Sideloads, which are not encoded, are left out.
+func deepCopy(dst, src interface{}) error {
+	data, err := json.Marshal(src)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, dst)
+}
+
+// ticketCopy returns a copy of a ticket of the snapshot, which the caller may modify.
+func ticketCopy(t *Ticket) Ticket {
+	var ticket Ticket
+	// The ticket was copied the same way when it was added, so this cannot fail.
+	deepCopy(&ticket, t)
+	return ticket
+}
+
+// userCopy returns a copy of a user of the snapshot, which the caller may modify.
+func userCopy(u *User) User {
+	var user User
+	// The user was copied the same way when it was added, so this cannot fail.
+	deepCopy(&user, u)
+	return user
+}
+
+// Sync pulls the tickets and users updated since the previous Sync, or all of them on the
+// first one.
+func (s *Snapshot) Sync(client Client) error {
+	s.mu.RLock()
+	ticketCursor, userCursor := s.TicketCursor, s.UserCursor
+	s.mu.RUnlock()
+
+	checkpoint, err := client.ExportTickets(&IncrementalExportOptions{Cursor: ticketCursor}, s)
+	if checkpoint != nil {
+		s.mu.Lock()
+		s.TicketCursor = checkpoint.Cursor
+		s.mu.Unlock()
+	}
+	if err != nil {
+		return err
+	}
+
+	checkpoint, err = client.ExportUsers(&IncrementalExportOptions{Cursor: userCursor}, s)
+	if checkpoint != nil {
+		s.mu.Lock()
+		s.UserCursor = checkpoint.Cursor
+		s.mu.Unlock()
+	}
+	return err
+}
+
+// LoadTickets adds the tickets of a JSON lines file, such as one written by ExportTickets
+// to a JSONLSink.
+func (s *Snapshot) LoadTickets(r io.Reader) error {
+	return s.load(r, func() interface{} { return new(Ticket) })
+}
+
+// LoadUsers adds the users of a JSON lines file.
+func (s *Snapshot) LoadUsers(r io.Reader) error {
+	return s.load(r, func() interface{} { return new(User) })
+}
+
+func (s *Snapshot) load(r io.Reader, record func() interface{}) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		rec := record()
+		if err := json.Unmarshal(scanner.Bytes(), rec); err != nil {
+			return fmt.Errorf("zendesk: line %d: %w", line, err)
+		}
+		if err := s.WriteRecord(rec); err != nil {
+			return err
+		}
+	}
+	return scanner.Err()
+}
+
+// Ticket returns the ticket with the given ID.
+func (s *Snapshot) Ticket(id int64) (*Ticket, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	t, ok := s.tickets[id]
+	if !ok {
+		return nil, false
+	}
+	ticket := ticketCopy(t)
+	return &ticket, true
+}
+
+// User returns the user with the given ID.
+func (s *Snapshot) User(id int64) (*User, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	u, ok := s.users[id]
+	if !ok {
+		return nil, false
+	}
+	user := userCopy(u)
+	return &user, true
+}
+
+// TimeRange matches the times from From, included, to To, excluded. A zero bound leaves
+// the range open on that side.
+type TimeRange struct {
+	From time.Time
+	To   time.Time
+}
+
+// Contains reports whether the time is in the range. A nil time is only in an unbounded range.
+func (r TimeRange) Contains(t *time.Time) bool {
+	if r.From.IsZero() && r.To.IsZero() {
+		return true
+	}
+	if t == nil {
+		return false
+	}
+	return (r.From.IsZero() || !t.Before(r.From)) && (r.To.IsZero() || t.Before(r.To))
+}
+
+// TicketQuery selects tickets of a snapshot. Its zero value matches every ticket, and each
+// field set narrows the selection.
+type TicketQuery struct {
+	// Statuses matches the tickets with any of the statuses.
+	Statuses []string
+	// Tags matches the tickets with all of the tags.
+	Tags           []string
+	AssigneeID     int64
+	RequesterID    int64
+	OrganizationID int64
+	GroupID        int64
+	Created        TimeRange
+	Updated        TimeRange
+}
+
+func (q *TicketQuery) matches(t *Ticket) bool {
+	return (len(q.Statuses) == 0 || containsString(q.Statuses, t.Status)) &&
+		hasTags(t.Tags, q.Tags) &&
+		(q.AssigneeID == 0 || t.AssigneeID == q.AssigneeID) &&
+		(q.RequesterID == 0 || t.RequesterID == q.RequesterID) &&
+		(q.OrganizationID == 0 || t.OrganizationID == q.OrganizationID) &&
+		(q.GroupID == 0 || t.GroupID == q.GroupID) &&
+		q.Created.Contains(t.CreatedAt) &&
+		q.Updated.Contains(t.UpdatedAt)
+}
+
+// Tickets returns the tickets matching the query, ordered by ID.
+func (s *Snapshot) Tickets(q TicketQuery) []Ticket {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	result := make([]Ticket, 0)
+	for _, t := range s.tickets {
+		if q.matches(t) {
+			result = append(result, ticketCopy(t))
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].ID < result[j].ID })
+	return result
+}
+
+// CountTicketsByStatus counts the tickets matching the query per status.
+func (s *Snapshot) CountTicketsByStatus(q TicketQuery) map[string]int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	counts := make(map[string]int)
+	for _, t := range s.tickets {
+		if q.matches(t) {
+			counts[t.Status]++
+		}
+	}
+	return counts
+}
+
+// UserQuery selects users of a snapshot. Its zero value matches every user, and each field
+// set narrows the selection.
+type UserQuery struct {
+	// Roles matches the users with any of the roles.
+	Roles []string
+	// Tags matches the users with all of the tags.
+	Tags           []string
+	OrganizationID int64
+	// Active, when set, matches the active or the deleted users.
+	Active  *bool
+	Created TimeRange
+	Updated TimeRange
+}
+
+func (q *UserQuery) matches(u *User) bool {
+	return (len(q.Roles) == 0 || containsString(q.Roles, u.Role)) &&
+		hasTags(u.Tags, q.Tags) &&
+		(q.OrganizationID == 0 || u.OrganizationID == q.OrganizationID) &&
+		(q.Active == nil || u.Active == *q.Active) &&
+		q.Created.Contains(u.CreatedAt) &&
+		q.Updated.Contains(u.UpdatedAt)
+}
+
+// Users returns the users matching the query, ordered by ID.
+func (s *Snapshot) Users(q UserQuery) []User {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	result := make([]User, 0)
+	for _, u := range s.users {
+		if q.matches(u) {
+			result = append(result, userCopy(u))
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].ID < result[j].ID })
+	return result
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// hasTags reports whether tags holds all of the wanted tags.
+func hasTags(tags, wanted []string) bool {
+	for _, w := range wanted {
+		if !containsString(tags, w) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,86 @@
+package zendesk
+
+import (
+	"fmt"
+)
+
+// TicketArchive is the full content of a ticket saved before its deletion: the ticket,
+// its comments with the metadata of their attachments, and its audits.
+type TicketArchive struct {
+	Ticket   Ticket          `json:"ticket"`
+	Comments []TicketComment `json:"comments"`
+	Audits   []TicketAudit   `json:"audits"`
+}
+
+// ArchivingDeleter deletes tickets after writing their full content to a sink, so that
+// deleted tickets can be inspected or recreated later on. A ticket whose archive cannot
+// be fetched or written is not deleted.
+type ArchivingDeleter struct {
+	client Client
+	sink   RecordSink
+}
+
+// NewArchivingDeleter creates an ArchivingDeleter writing a *TicketArchive to sink for
+// each ticket deleted with client.
+func NewArchivingDeleter(client Client, sink RecordSink) *ArchivingDeleter {
+	return &ArchivingDeleter{client: client, sink: sink}
+}
+
+// Archive fetches the full content of a ticket, following the pages of its comments and
+// audits. It fails if fewer comments were listed than the ticket counts, since a partial
+// archive must not lead to a deletion.
+func (d *ArchivingDeleter) Archive(id int64) (*TicketArchive, error) {
+	ticket, err := d.client.ShowTicket(id)
+	if err != nil {
+		return nil, err
+	}
+	comments, err := d.client.ListTicketComments(id)
+	if err != nil {
+		return nil, err
+	}
+	count, err := d.client.CountTicketComments(id)
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(comments)) < count {
+		return nil, fmt.Errorf("zendesk: %d comments listed out of %d", len(comments), count)
+	}
+	audits, err := d.client.ListTicketAudits(id)
+	if err != nil {
+		return nil, err
+	}
+	return &TicketArchive{Ticket: *ticket, Comments: comments, Audits: audits}, nil
+}
+
+// DeleteTicket archives a ticket, then deletes it.
+func (d *ArchivingDeleter) DeleteTicket(id int64) error {
+	if err := d.archive(id); err != nil {
+		return err
+	}
+	return d.client.DeleteTicket(id)
+}
+
+// DeleteTickets archives tickets, then deletes them in bulk deletions of up to 100 tickets
+// whose job statuses are returned. The tickets of a bulk deletion are all archived before
+// it is started, and none of them is deleted if one fails to be archived.
+func (d *ArchivingDeleter) DeleteTickets(ids []int64) ([]*JobStatus, error) {
+	return deleteTicketChunks(ids, d.client.DeleteManyTickets, func(chunk []int64) error {
+		for _, id := range chunk {
+			if err := d.archive(id); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (d *ArchivingDeleter) archive(id int64) error {
+	archive, err := d.Archive(id)
+	if err != nil {
+		return fmt.Errorf("zendesk: ticket %d not deleted, archive failed: %w", id, err)
+	}
+	if err := d.sink.WriteRecord(archive); err != nil {
+		return fmt.Errorf("zendesk: ticket %d not deleted, archive failed: %w", id, err)
+	}
+	return nil
+}
@@ -0,0 +1,61 @@
+package zendesk
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// timestampLayouts lists the timestamp formats found in Zendesk responses. Most endpoints
+// use RFC 3339, while some less common ones, such as the Talk stats, use other layouts
+// or omit the time zone, in which case UTC is assumed.
+var timestampLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05Z0700",
+	"2006-01-02 15:04:05 -0700",
+	"2006-01-02 15:04:05 MST",
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05",
+	"2006/01/02 15:04:05 -0700",
+}
+
+// timestamp is a time decoded from any of the timestamp formats used by Zendesk and
+// normalized to UTC. The types whose timestamps use less common formats decode them
+// with it in their UnmarshalJSON method, keeping *time.Time fields.
+type timestamp struct {
+	time.Time
+}
+
+// UnmarshalJSON decodes a timestamp string. Empty strings leave the zero time.
+func (t *timestamp) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(data, []byte("null")) {
+		return nil
+	}
+
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	if s == "" {
+		t.Time = time.Time{}
+		return nil
+	}
+
+	for _, layout := range timestampLayouts {
+		if parsed, err := time.Parse(layout, s); err == nil {
+			t.Time = parsed.UTC()
+			return nil
+		}
+	}
+
+	return fmt.Errorf("zendesk: cannot parse timestamp %q", s)
+}
+
+// ptr returns the time, or nil for a null, empty or missing timestamp.
+func (t *timestamp) ptr() *time.Time {
+	if t == nil || t.IsZero() {
+		return nil
+	}
+	return &t.Time
+}
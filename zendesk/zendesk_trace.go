@@ -0,0 +1,159 @@
+package zendesk
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// CallTrace breaks down the time spent by a call to the API, from the first attempt
+// until the response headers of the last attempt are received, retries included.
+type CallTrace struct {
+	Method   string
+	URL      string
+	Family   EndpointFamily
+	Start    time.Time
+	Attempts int
+	// StatusCode is the status of the last response, 0 when no response was received.
+	StatusCode int
+	Err        error
+
+	// QueueWait is the time spent waiting for the rate limiter of the endpoint family.
+	QueueWait time.Duration
+	// Server is the processing time reported by Zendesk in the X-Runtime header.
+	Server time.Duration
+	// Network is the round trip time of the attempts, minus the server time.
+	Network time.Duration
+	// RetrySleep is the time spent backing off between attempts.
+	RetrySleep time.Duration
+	// RateLimited is the number of attempts answered with a 429 status code.
+	RateLimited int
+	// Total is the duration of the whole call.
+	Total time.Duration
+
+	observers []func(*CallTrace)
+	// meta is the metadata of the response of the call, completed by its decoding.
+	meta *ResponseMeta
+}
+
+type traceKey struct{}
+
+// CallTraceFromRequest returns the trace of the call a request is an attempt of, or nil.
+// Middleware can use it to attach observers called once the call is done.
+func CallTraceFromRequest(req *http.Request) *CallTrace {
+	trace, _ := req.Context().Value(traceKey{}).(*CallTrace)
+	return trace
+}
+
+// Observe registers a function called with the trace once the call is done.
+func (t *CallTrace) Observe(observe func(*CallTrace)) {
+	t.observers = append(t.observers, observe)
+}
+
+func (t *CallTrace) attach(req *http.Request) *http.Request {
+	return req.WithContext(context.WithValue(req.Context(), traceKey{}, t))
+}
+
+// roundTrip records the timing of an attempt that took elapsed until its response.
+func (t *CallTrace) roundTrip(elapsed time.Duration, res *http.Response) {
+	var server time.Duration
+	if res != nil {
+		if seconds, err := strconv.ParseFloat(res.Header.Get("X-Runtime"), 64); err == nil {
+			server = time.Duration(seconds * float64(time.Second))
+		}
+	}
+	if server > elapsed {
+		server = elapsed
+	}
+	t.Server += server
+	t.Network += elapsed - server
+}
+
+func (t *CallTrace) finish(res *http.Response, err error) {
+	t.Total = time.Since(t.Start)
+	t.Err = err
+	if res != nil {
+		t.StatusCode = res.StatusCode
+	}
+	for _, observe := range t.observers {
+		observe(t)
+	}
+}
+
+// TraceMiddleware returns a middleware calling observe with the trace of each call
+// made by the client, once the call is done.
+func TraceMiddleware(observe func(*CallTrace)) MiddlewareFunction {
+	return func(next RequestFunction) RequestFunction {
+		return func(req *http.Request) (*http.Response, error) {
+			if trace := CallTraceFromRequest(req); trace != nil && trace.Attempts == 1 {
+				trace.Observe(observe)
+			}
+			return next(req)
+		}
+	}
+}
+
+// CallStats sums the call traces of an endpoint family.
+type CallStats struct {
+	Calls      int
+	Attempts   int
+	Errors     int
+	QueueWait  time.Duration
+	Server     time.Duration
+	Network    time.Duration
+	RetrySleep time.Duration
+	Total      time.Duration
+}
+
+// Metrics aggregates the call traces of a client per endpoint family.
+// It is safe for concurrent use.
+type Metrics struct {
+	mu    sync.Mutex
+	stats map[EndpointFamily]*CallStats
+}
+
+// NewMetrics creates an empty Metrics. Its Middleware must be given to the client.
+func NewMetrics() *Metrics {
+	return &Metrics{stats: make(map[EndpointFamily]*CallStats)}
+}
+
+// Middleware returns the middleware feeding the metrics.
+func (m *Metrics) Middleware() MiddlewareFunction {
+	return TraceMiddleware(m.Observe)
+}
+
+// Observe adds a call trace to the metrics.
+func (m *Metrics) Observe(t *CallTrace) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	stats, ok := m.stats[t.Family]
+	if !ok {
+		stats = new(CallStats)
+		m.stats[t.Family] = stats
+	}
+	stats.Calls++
+	stats.Attempts += t.Attempts
+	if t.Err != nil || t.StatusCode >= http.StatusBadRequest {
+		stats.Errors++
+	}
+	stats.QueueWait += t.QueueWait
+	stats.Server += t.Server
+	stats.Network += t.Network
+	stats.RetrySleep += t.RetrySleep
+	stats.Total += t.Total
+}
+
+// Stats returns a copy of the metrics of each endpoint family.
+func (m *Metrics) Stats() map[EndpointFamily]CallStats {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	result := make(map[EndpointFamily]CallStats)
+	for family, stats := range m.stats {
+		result[family] = *stats
+	}
+	return result
+}
@@ -0,0 +1,95 @@
+package zendesk
+
+// UserOrganizations is a user joined with its organization memberships and the
+// organizations they refer to, as written by UserOrganizationSync.
+type UserOrganizations struct {
+	User        *User                    `json:"user"`
+	Memberships []OrganizationMembership `json:"organization_memberships"`
+	// Organizations are in the order of the memberships. Organizations that could not
+	// be found, such as deleted ones, are missing.
+	Organizations []Organization `json:"organizations"`
+	// DefaultOrganization is the default organization of the user, if any.
+	DefaultOrganization *Organization `json:"default_organization,omitempty"`
+}
+
+// UserOrganizationSync pulls users incrementally and joins each of them with its
+// organizations, which most reports need. Memberships are listed for the updated users
+// only, and organizations are fetched in batches and cached across runs.
+type UserOrganizationSync struct {
+	client Client
+	orgs   *OrganizationCache
+}
+
+// NewUserOrganizationSync creates a UserOrganizationSync pulling records with client.
+func NewUserOrganizationSync(client Client) *UserOrganizationSync {
+	return &UserOrganizationSync{client: client, orgs: NewOrganizationCache(client)}
+}
+
+// Organizations returns the cache of the organizations, for instance to forget the ones
+// known to have changed since the last run.
+func (s *UserOrganizationSync) Organizations() *OrganizationCache {
+	return s.orgs
+}
+
+// Run writes a *UserOrganizations to the sink for each user updated since the start point
+// of opts. The returned checkpoint resumes the user export, as with ExportUsers.
+func (s *UserOrganizationSync) Run(opts *IncrementalExportOptions, sink RecordSink) (*ExportCheckpoint, error) {
+	return s.client.ExportUsers(opts, SinkFunc(func(record interface{}) error {
+		user := record.(*User)
+		memberships, err := s.memberships(user)
+		if err != nil {
+			return err
+		}
+		joined, err := s.join(user, memberships)
+		if err != nil {
+			return err
+		}
+		return sink.WriteRecord(joined)
+	}))
+}
+
+// memberships lists the organization memberships of a user. Users without an
+// organization have no memberships, so they are not listed.
+func (s *UserOrganizationSync) memberships(user *User) ([]OrganizationMembership, error) {
+	if user.OrganizationID == 0 {
+		return nil, nil
+	}
+	return s.client.ListOrganizationMembershipsByUserID(user.ID)
+}
+
+// join resolves the organizations of a user. The organization of the user is added as
+// its default membership when it is missing from the memberships listed, such as when
+// the user joined it during the export.
+func (s *UserOrganizationSync) join(user *User, memberships []OrganizationMembership) (*UserOrganizations, error) {
+	if user.OrganizationID != 0 {
+		found := false
+		for _, membership := range memberships {
+			found = found || membership.OrganizationID == user.OrganizationID
+		}
+		if !found {
+			memberships = append(memberships, OrganizationMembership{UserID: user.ID, OrganizationID: user.OrganizationID, Default: true})
+		}
+	}
+
+	ids := make([]int64, 0, len(memberships))
+	for _, membership := range memberships {
+		ids = append(ids, membership.OrganizationID)
+	}
+	orgs, err := s.orgs.Organizations(ids)
+	if err != nil {
+		return nil, err
+	}
+
+	joined := &UserOrganizations{User: user, Memberships: memberships, Organizations: make([]Organization, 0, len(memberships))}
+	for _, membership := range memberships {
+		org, ok := orgs[membership.OrganizationID]
+		if !ok {
+			continue
+		}
+		joined.Organizations = append(joined.Organizations, *org)
+		if membership.OrganizationID == user.OrganizationID || (user.OrganizationID == 0 && membership.Default) {
+			joined.DefaultOrganization = org
+		}
+	}
+	return joined, nil
+}
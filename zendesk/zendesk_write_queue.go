@@ -0,0 +1,404 @@
+package zendesk
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// Queued write operations.
+const (
+	QueueCreateTicket = "create_ticket"
+	QueueUpdateTicket = "update_ticket"
+)
+
+// QueuedWrite is a ticket write waiting in a WriteQueue.
+type QueuedWrite struct {
+	// Key is the idempotency key of the write. Writes with a key already queued are ignored,
+	// and ticket creations send it in the Idempotency-Key header so that a creation
+	// retried after a lost response does not create a second ticket.
+	Key        string    `json:"key"`
+	Operation  string    `json:"operation"`
+	TicketID   int64     `json:"ticket_id,omitempty"`
+	Ticket     *Ticket   `json:"ticket"`
+	EnqueuedAt time.Time `json:"enqueued_at"`
+	Attempts   int       `json:"attempts"`
+	LastError  string    `json:"last_error,omitempty"`
+	// WrittenAt is set once Zendesk confirmed the write. Written writes stay in the
+	// storage for the KeepWritten period of the queue, so that their key still
+	// deduplicates the same write enqueued again.
+	WrittenAt *time.Time `json:"written_at,omitempty"`
+}
+
+// QueueStorage persists the writes of a WriteQueue.
+type QueueStorage interface {
+	// Put adds or replaces the write with the same key.
+	Put(QueuedWrite) error
+	// Get returns the write with the given key, or false if there is none.
+	Get(key string) (QueuedWrite, bool, error)
+	// List returns the writes in the order they were first added.
+	List() ([]QueuedWrite, error)
+	// Delete removes the write with the given key.
+	Delete(key string) error
+}
+
+// MemoryQueueStorage keeps queued writes in memory. It is safe for concurrent use.
+type MemoryQueueStorage struct {
+	mu     sync.Mutex
+	writes []QueuedWrite
+}
+
+// NewMemoryQueueStorage creates an empty MemoryQueueStorage.
+func NewMemoryQueueStorage() *MemoryQueueStorage {
+	return &MemoryQueueStorage{}
+}
+
+func (s *MemoryQueueStorage) Put(w QueuedWrite) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.writes = putWrite(s.writes, w)
+	return nil
+}
+
+func (s *MemoryQueueStorage) Get(key string) (QueuedWrite, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	w, ok := getWrite(s.writes, key)
+	return w, ok, nil
+}
+
+func (s *MemoryQueueStorage) List() ([]QueuedWrite, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]QueuedWrite(nil), s.writes...), nil
+}
+
+func (s *MemoryQueueStorage) Delete(key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.writes = deleteWrite(s.writes, key)
+	return nil
+}
+
+// FileQueueStorage keeps queued writes in a JSON file, so that they survive restarts.
+// The file is replaced atomically on each change. It is safe for concurrent use
+// within a process.
+type FileQueueStorage struct {
+	mu   sync.Mutex
+	path string
+}
+
+// NewFileQueueStorage creates a FileQueueStorage using the file at path, which is
+// created on the first write.
+func NewFileQueueStorage(path string) *FileQueueStorage {
+	return &FileQueueStorage{path: path}
+}
+
+func (s *FileQueueStorage) Put(w QueuedWrite) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	writes, err := s.load()
+	if err != nil {
+		return err
+	}
+	return s.save(putWrite(writes, w))
+}
+
+func (s *FileQueueStorage) Get(key string) (QueuedWrite, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	writes, err := s.load()
+	if err != nil {
+		return QueuedWrite{}, false, err
+	}
+	w, ok := getWrite(writes, key)
+	return w, ok, nil
+}
+
+func (s *FileQueueStorage) List() ([]QueuedWrite, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.load()
+}
+
+func (s *FileQueueStorage) Delete(key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	writes, err := s.load()
+	if err != nil {
+		return err
+	}
+	return s.save(deleteWrite(writes, key))
+}
+
+func (s *FileQueueStorage) load() ([]QueuedWrite, error) {
+	data, err := ioutil.ReadFile(s.path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var writes []QueuedWrite
+	if err := json.Unmarshal(data, &writes); err != nil {
+		return nil, err
+	}
+	return writes, nil
+}
+
+func (s *FileQueueStorage) save(writes []QueuedWrite) error {
+	data, err := json.Marshal(writes)
+	if err != nil {
+		return err
+	}
+
+	tmp, err := ioutil.TempFile(filepath.Dir(s.path), filepath.Base(s.path)+".*")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), s.path)
+}
+
+func putWrite(writes []QueuedWrite, w QueuedWrite) []QueuedWrite {
+	for i := range writes {
+		if writes[i].Key == w.Key {
+			writes[i] = w
+			return writes
+		}
+	}
+	return append(writes, w)
+}
+
+func getWrite(writes []QueuedWrite, key string) (QueuedWrite, bool) {
+	for _, w := range writes {
+		if w.Key == key {
+			return w, true
+		}
+	}
+	return QueuedWrite{}, false
+}
+
+func deleteWrite(writes []QueuedWrite, key string) []QueuedWrite {
+	result := writes[:0]
+	for _, w := range writes {
+		if w.Key != key {
+			result = append(result, w)
+		}
+	}
+	return result
+}
+
+// WriteQueue is a write-behind queue for ticket creations and updates. Writes are
+// accepted even while Zendesk is unavailable, and sent in order when the queue is drained.
+type WriteQueue struct {
+	// OnWritten, if set, is called with each write sent successfully and the resulting ticket.
+	OnWritten func(QueuedWrite, *Ticket)
+	// OnFailed, if set, is called with each write rejected by Zendesk. Rejected writes,
+	// for instance invalid tickets, are removed from the queue since retrying them
+	// would fail again.
+	OnFailed func(QueuedWrite, error)
+	// KeepWritten is how long the keys of the writes sent are kept, so that a producer
+	// enqueuing a write again, for instance after a crash, does not write twice.
+	KeepWritten time.Duration
+
+	mu      sync.Mutex
+	client  Client
+	storage QueueStorage
+}
+
+// defaultKeepWritten is the default KeepWritten period of a WriteQueue.
+const defaultKeepWritten = 24 * time.Hour
+
+// NewWriteQueue creates a queue sending writes with the client and keeping them in storage.
+// The keys of the writes sent are kept for 24 hours.
+func NewWriteQueue(client Client, storage QueueStorage) *WriteQueue {
+	return &WriteQueue{KeepWritten: defaultKeepWritten, client: client, storage: storage}
+}
+
+// EnqueueCreateTicket queues the creation of a ticket. It returns false if a write with
+// the same key is already queued or was written within the KeepWritten period.
+func (q *WriteQueue) EnqueueCreateTicket(key string, ticket *Ticket) (bool, error) {
+	return q.enqueue(QueuedWrite{Key: key, Operation: QueueCreateTicket, Ticket: ticket})
+}
+
+// EnqueueUpdateTicket queues the update of a ticket. It returns false if a write with
+// the same key is already queued or was written within the KeepWritten period.
+func (q *WriteQueue) EnqueueUpdateTicket(key string, id int64, ticket *Ticket) (bool, error) {
+	return q.enqueue(QueuedWrite{Key: key, Operation: QueueUpdateTicket, TicketID: id, Ticket: ticket})
+}
+
+func (q *WriteQueue) enqueue(w QueuedWrite) (bool, error) {
+	if w.Key == "" {
+		return false, errors.New("zendesk: queued write without idempotency key")
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	existing, ok, err := q.storage.Get(w.Key)
+	if err != nil {
+		return false, err
+	}
+	if ok && !q.expired(existing, time.Now()) {
+		return false, nil
+	}
+
+	w.EnqueuedAt = time.Now()
+	return true, q.storage.Put(w)
+}
+
+// expired tells whether a written write is past its KeepWritten period.
+func (q *WriteQueue) expired(w QueuedWrite, now time.Time) bool {
+	return w.WrittenAt != nil && now.Sub(*w.WrittenAt) >= q.KeepWritten
+}
+
+// Len returns the number of writes waiting to be sent.
+func (q *WriteQueue) Len() (int, error) {
+	writes, err := q.storage.List()
+	if err != nil {
+		return 0, err
+	}
+
+	pending := 0
+	for _, w := range writes {
+		if w.WrittenAt == nil {
+			pending++
+		}
+	}
+	return pending, nil
+}
+
+// queueStorageError is an error of the storage of a WriteQueue, as opposed to an error
+// of Zendesk.
+type queueStorageError struct {
+	err error
+}
+
+func (e *queueStorageError) Error() string {
+	return "zendesk: write queue storage: " + e.err.Error()
+}
+
+func (e *queueStorageError) Unwrap() error {
+	return e.err
+}
+
+// Drain sends the queued writes in order. It stops at the first write failing because
+// Zendesk is unavailable or rate limiting, leaving it and the following writes queued,
+// and returns its error. Written writes are marked as such, and removed once past their
+// KeepWritten period.
+func (q *WriteQueue) Drain() error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	writes, err := q.storage.List()
+	if err != nil {
+		return &queueStorageError{err}
+	}
+
+	for _, w := range writes {
+		if w.WrittenAt != nil {
+			if q.expired(w, time.Now()) {
+				if err := q.storage.Delete(w.Key); err != nil {
+					return &queueStorageError{err}
+				}
+			}
+			continue
+		}
+
+		ticket, err := q.send(w)
+		if err != nil && isOutage(err) {
+			w.Attempts++
+			w.LastError = err.Error()
+			if perr := q.storage.Put(w); perr != nil {
+				return &queueStorageError{perr}
+			}
+			q.client.Logger().Printf("[zendesk_write_queue][Drain] %s %s postponed: %s\n", w.Operation, w.Key, err)
+			return err
+		}
+
+		if err != nil {
+			if err := q.storage.Delete(w.Key); err != nil {
+				return &queueStorageError{err}
+			}
+			q.client.Logger().Printf("[zendesk_write_queue][Drain] %s %s rejected: %s\n", w.Operation, w.Key, err)
+			if q.OnFailed != nil {
+				q.OnFailed(w, err)
+			}
+			continue
+		}
+
+		now := time.Now()
+		w.WrittenAt = &now
+		w.LastError = ""
+		if err := q.storage.Put(w); err != nil {
+			return &queueStorageError{err}
+		}
+		if q.OnWritten != nil {
+			q.OnWritten(w, ticket)
+		}
+	}
+
+	return nil
+}
+
+// Run drains the queue every interval until the context is done. Writes postponed by an
+// unavailable Zendesk are retried on the next interval, while an error of the storage
+// stops the run and is returned.
+func (q *WriteQueue) Run(ctx context.Context, interval time.Duration) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		var serr *queueStorageError
+		if err := q.Drain(); errors.As(err, &serr) {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+func (q *WriteQueue) send(w QueuedWrite) (*Ticket, error) {
+	switch w.Operation {
+	case QueueCreateTicket:
+		return q.client.WithHeader(IdempotencyKeyHeader, w.Key).CreateTicket(w.Ticket)
+	case QueueUpdateTicket:
+		return q.client.UpdateTicket(w.TicketID, w.Ticket)
+	}
+	return nil, fmt.Errorf("%w %q", errUnknownOperation, w.Operation)
+}
+
+var errUnknownOperation = errors.New("zendesk: unknown queued operation")
+
+// isOutage tells whether the error is temporary, from an unavailable or rate limiting
+// Zendesk, or a network failure, as opposed to a rejected request.
+func isOutage(err error) bool {
+	var apierr *APIError
+	if !errors.As(err, &apierr) {
+		return !errors.Is(err, errUnknownOperation)
+	}
+	return errors.Is(err, ErrServerError) || errors.Is(err, &ErrRateLimited{})
+}
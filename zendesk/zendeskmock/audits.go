@@ -0,0 +1,41 @@
+package zendeskmock
+
+import (
+	"github.com/phil-inc/zendesk/zendesk"
+)
+
+// Ticket audits
+
+func (c *Client) ListTicketAudits(ticketID int64) ([]zendesk.TicketAudit, error) {
+	c.lock()
+	defer c.unlock()
+
+	if _, ok := c.tickets[ticketID]; !ok {
+		return nil, notFound("ticket", ticketID)
+	}
+
+	ids := make([]int64, 0)
+	for id, audit := range c.audits {
+		if audit.TicketID == ticketID {
+			ids = append(ids, id)
+		}
+	}
+
+	result := make([]zendesk.TicketAudit, 0, len(ids))
+	for _, id := range sortedIDs(ids) {
+		result = append(result, *c.audits[id])
+	}
+	return result, nil
+}
+
+func (c *Client) ShowTicketAudit(ticketID, auditID int64) (*zendesk.TicketAudit, error) {
+	c.lock()
+	defer c.unlock()
+
+	audit, ok := c.audits[auditID]
+	if !ok || audit.TicketID != ticketID {
+		return nil, notFound("ticket audit", auditID)
+	}
+	a := *audit
+	return &a, nil
+}
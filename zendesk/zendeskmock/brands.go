@@ -0,0 +1,119 @@
+package zendeskmock
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/phil-inc/zendesk/zendesk"
+)
+
+// Brands
+
+func (c *Client) ListBrands() ([]zendesk.Brand, error) {
+	c.lock()
+	defer c.unlock()
+
+	ids := make([]int64, 0, len(c.brands))
+	for id := range c.brands {
+		ids = append(ids, id)
+	}
+
+	result := make([]zendesk.Brand, 0, len(ids))
+	for _, id := range sortedIDs(ids) {
+		result = append(result, *c.brands[id])
+	}
+	return result, nil
+}
+
+func (c *Client) ShowBrand(id int64) (*zendesk.Brand, error) {
+	c.lock()
+	defer c.unlock()
+
+	brand, ok := c.brands[id]
+	if !ok {
+		return nil, notFound("brand", id)
+	}
+	b := *brand
+	return &b, nil
+}
+
+// CachedBrands returns the current brands since the in-memory data is never stale.
+func (c *Client) CachedBrands() ([]zendesk.Brand, error) {
+	return c.ListBrands()
+}
+
+// InvalidateBrands does nothing since the brands are not cached.
+func (c *Client) InvalidateBrands() {}
+
+func (c *Client) CreateBrand(brand *zendesk.Brand) (*zendesk.Brand, error) {
+	c.lock()
+	defer c.unlock()
+
+	if brand.Name == "" || brand.Subdomain == "" {
+		return nil, &zendesk.ErrValidation{Type: "RecordInvalid", Description: "Name and subdomain can't be blank"}
+	}
+	for _, existing := range c.brands {
+		if existing.Subdomain == brand.Subdomain {
+			return nil, &zendesk.ErrValidation{Type: "RecordInvalid", Description: fmt.Sprintf("Subdomain %s has already been taken", brand.Subdomain)}
+		}
+	}
+
+	b := *brand
+	if b.ID == 0 {
+		b.ID = c.nextID()
+	}
+	c.seen(b.ID)
+	if b.CreatedAt == nil {
+		b.CreatedAt = c.now()
+	}
+	b.UpdatedAt = c.now()
+	c.brands[b.ID] = &b
+
+	created := b
+	return &created, nil
+}
+
+func (c *Client) UpdateBrand(id int64, brand *zendesk.Brand) (*zendesk.Brand, error) {
+	c.lock()
+	defer c.unlock()
+
+	existing, ok := c.brands[id]
+	if !ok {
+		return nil, notFound("brand", id)
+	}
+
+	update := *brand
+	update.ID = id
+	if err := merge(existing, &update); err != nil {
+		return nil, err
+	}
+	existing.UpdatedAt = c.now()
+
+	b := *existing
+	return &b, nil
+}
+
+func (c *Client) DeleteBrand(id int64) error {
+	c.lock()
+	defer c.unlock()
+
+	if _, ok := c.brands[id]; !ok {
+		return notFound("brand", id)
+	}
+	delete(c.brands, id)
+	return nil
+}
+
+// CheckHostMapping accepts any host mapping that is not used by another brand,
+// since the mock cannot resolve CNAME records.
+func (c *Client) CheckHostMapping(hostMapping, subdomain string) (*zendesk.HostMappingCheck, error) {
+	c.lock()
+	defer c.unlock()
+
+	for _, brand := range c.brands {
+		if strings.EqualFold(brand.HostMapping, hostMapping) && brand.Subdomain != subdomain {
+			return &zendesk.HostMappingCheck{Reason: "already_taken", CNAME: hostMapping}, nil
+		}
+	}
+	return &zendesk.HostMappingCheck{IsValid: true, CNAME: hostMapping, ExpectedCNAMEs: []string{subdomain + ".zendesk.com"}}, nil
+}
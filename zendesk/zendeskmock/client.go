@@ -0,0 +1,1570 @@
+// Package zendeskmock provides an in-memory implementation of the zendesk.Client
+// interface and a fake Zendesk server, so that services built on the zendesk
+// package can be unit tested without hitting the real API.
+package zendeskmock
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/phil-inc/zendesk/zendesk"
+)
+
+// Client is an in-memory implementation of zendesk.Client.
+//
+// All the clients derived from a Client with WithHeader and the like share the same data.
+// Records are kept as created or updated, with IDs and timestamps filled in like Zendesk would.
+type Client struct {
+	*store
+
+	headers map[string]string
+}
+
+var _ zendesk.Client = (*Client)(nil)
+
+type store struct {
+	mu sync.Mutex
+
+	// Now returns the time used for created_at and updated_at timestamps.
+	Now func() time.Time
+	// Limits are the limits returned by GetAccountLimits and enforced by ApplyProvisioningSpec.
+	Limits zendesk.AccountLimits
+	// CurrentUser is the authenticated user returned by ShowCurrentUser. It defaults to an admin.
+	CurrentUser zendesk.User
+	// Features are the capabilities returned by Capabilities and advertised by the server.
+	// They default to cursor pagination and webhooks.
+	Features zendesk.Capabilities
+	// Settings are the account settings returned by ShowAccountSettings, except for the custom
+	// statuses setting which follows Features. They default to allowing CCs.
+	Settings zendesk.AccountSettings
+
+	lastID          int64
+	tickets         map[int64]*zendesk.Ticket
+	deleted         map[int64]*deletedTicket
+	comments        map[int64][]zendesk.TicketComment
+	audits          map[int64]*zendesk.TicketAudit
+	users           map[int64]*zendesk.User
+	deletedUsers    map[int64]*zendesk.User
+	identities      map[int64]*zendesk.UserIdentity
+	orgs            map[int64]*zendesk.Organization
+	groups          map[int64]*zendesk.Group
+	brands          map[int64]*zendesk.Brand
+	roles           map[int64]*zendesk.CustomRole
+	memberships     map[int64]*zendesk.OrganizationMembership
+	locales         map[int64]*zendesk.Locale
+	fields          map[int64]*zendesk.TicketField
+	userFields      map[int64]*zendesk.FieldDefinition
+	orgFields       map[int64]*zendesk.FieldDefinition
+	forms           map[int64]*zendesk.TicketForm
+	triggers        map[int64]*zendesk.Trigger
+	metrics         map[int64]*zendesk.TicketMetric
+	metricEvents    []zendesk.TicketMetricEvent
+	skips           map[int64]*zendesk.TicketSkip
+	sessions        map[int64]*zendesk.Session
+	dynamicContent  map[int64]*zendesk.DynamicContentItem
+	agreements      map[int64]*zendesk.SharingAgreement
+	statuses        map[int64]*zendesk.CustomStatus
+	routing         map[string]*zendesk.RoutingAttribute
+	ticketSkills    map[int64][]string
+	agentSkills     map[int64][]string
+	scores          map[int64]*zendesk.Score
+	reasons         map[int64]*zendesk.SatisfactionReason
+	callLegs        map[int64]*zendesk.CallLeg
+	calls           map[int64]*zendesk.Call
+	phoneNumbers    map[int64]*zendesk.PhoneNumber
+	lines           map[int64]*zendesk.Line
+	greetings       map[int64]*zendesk.Greeting
+	availabilities  map[int64]zendesk.Availability
+	categories      map[int64]*zendesk.Category
+	sections        map[int64]*zendesk.Section
+	articles        map[int64]*zendesk.Article
+	translations    map[int64][]zendesk.Translation
+	articleFiles    map[int64][]zendesk.ArticleAttachment
+	votes           map[int64][]zendesk.Vote
+	articleComments map[int64][]zendesk.ArticleComment
+	jobs            map[string]*zendesk.JobStatus
+	uploads         map[string]*zendesk.Upload
+	callbacks       []zendesk.CallbackRequest
+	displays        []Display
+	requestCount    int
+}
+
+// New creates an empty in-memory client.
+func New() *Client {
+	return &Client{
+		store: &store{
+			Now:             time.Now,
+			Limits:          zendesk.DefaultAccountLimits,
+			CurrentUser:     zendesk.User{Name: "Agent", Email: "agent@example.com", Role: "admin", Active: true},
+			Features:        zendesk.Capabilities{CursorPagination: true, Webhooks: true},
+			tickets:         make(map[int64]*zendesk.Ticket),
+			deleted:         make(map[int64]*deletedTicket),
+			comments:        make(map[int64][]zendesk.TicketComment),
+			audits:          make(map[int64]*zendesk.TicketAudit),
+			users:           make(map[int64]*zendesk.User),
+			deletedUsers:    make(map[int64]*zendesk.User),
+			identities:      make(map[int64]*zendesk.UserIdentity),
+			orgs:            make(map[int64]*zendesk.Organization),
+			groups:          make(map[int64]*zendesk.Group),
+			brands:          make(map[int64]*zendesk.Brand),
+			roles:           make(map[int64]*zendesk.CustomRole),
+			memberships:     make(map[int64]*zendesk.OrganizationMembership),
+			locales:         make(map[int64]*zendesk.Locale),
+			fields:          make(map[int64]*zendesk.TicketField),
+			userFields:      make(map[int64]*zendesk.FieldDefinition),
+			orgFields:       make(map[int64]*zendesk.FieldDefinition),
+			forms:           make(map[int64]*zendesk.TicketForm),
+			triggers:        make(map[int64]*zendesk.Trigger),
+			metrics:         make(map[int64]*zendesk.TicketMetric),
+			skips:           make(map[int64]*zendesk.TicketSkip),
+			sessions:        make(map[int64]*zendesk.Session),
+			dynamicContent:  make(map[int64]*zendesk.DynamicContentItem),
+			agreements:      make(map[int64]*zendesk.SharingAgreement),
+			statuses:        make(map[int64]*zendesk.CustomStatus),
+			routing:         make(map[string]*zendesk.RoutingAttribute),
+			ticketSkills:    make(map[int64][]string),
+			agentSkills:     make(map[int64][]string),
+			scores:          make(map[int64]*zendesk.Score),
+			reasons:         make(map[int64]*zendesk.SatisfactionReason),
+			callLegs:        make(map[int64]*zendesk.CallLeg),
+			calls:           make(map[int64]*zendesk.Call),
+			phoneNumbers:    make(map[int64]*zendesk.PhoneNumber),
+			lines:           make(map[int64]*zendesk.Line),
+			greetings:       make(map[int64]*zendesk.Greeting),
+			availabilities:  make(map[int64]zendesk.Availability),
+			categories:      make(map[int64]*zendesk.Category),
+			sections:        make(map[int64]*zendesk.Section),
+			articles:        make(map[int64]*zendesk.Article),
+			translations:    make(map[int64][]zendesk.Translation),
+			articleFiles:    make(map[int64][]zendesk.ArticleAttachment),
+			votes:           make(map[int64][]zendesk.Vote),
+			articleComments: make(map[int64][]zendesk.ArticleComment),
+			jobs:            make(map[string]*zendesk.JobStatus),
+			uploads:         make(map[string]*zendesk.Upload),
+			Settings: zendesk.AccountSettings{
+				ActiveFeatures: &zendesk.ActiveFeatureSettings{AllowCCs: zendesk.Bool(true)},
+				Tickets:        &zendesk.TicketSettings{Collaboration: zendesk.Bool(true), CommentsPublicByDefault: zendesk.Bool(true)},
+			},
+		},
+		headers: make(map[string]string),
+	}
+}
+
+// Headers returns the headers set on the client with WithHeader and the like.
+func (c *Client) Headers() map[string]string {
+	headers := make(map[string]string)
+	for k, v := range c.headers {
+		headers[k] = v
+	}
+	return headers
+}
+
+// RequestCount returns the number of calls made to the client and the clients derived from it.
+func (c *Client) RequestCount() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.requestCount
+}
+
+// lock locks the store and counts the call.
+func (c *Client) lock() {
+	c.mu.Lock()
+	c.requestCount++
+}
+
+func (c *Client) unlock() {
+	c.mu.Unlock()
+}
+
+func (s *store) nextID() int64 {
+	s.lastID++
+	return s.lastID
+}
+
+// seen makes sure IDs generated later do not collide with the given one.
+func (s *store) seen(id int64) {
+	if id > s.lastID {
+		s.lastID = id
+	}
+}
+
+func (s *store) now() *time.Time {
+	now := s.Now().UTC()
+	return &now
+}
+
+func notFound(kind string, id interface{}) error {
+	return fmt.Errorf("%s %v: %w", kind, id, zendesk.ErrNotFound)
+}
+
+// merge overlays the non empty fields of src onto dst, the way Zendesk applies updates.
+func merge(dst, src interface{}) error {
+	data, err := json.Marshal(src)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, dst)
+}
+
+func sortedIDs(ids []int64) []int64 {
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	return ids
+}
+
+func updatedSince(updatedAt *time.Time, unixTime int64) bool {
+	return updatedAt == nil || !updatedAt.Before(time.Unix(unixTime, 0))
+}
+
+// WithHeader returns a client sharing the same data that records the header.
+func (c *Client) WithHeader(name, value string) zendesk.Client {
+	headers := c.Headers()
+	headers[name] = value
+	return &Client{store: c.store, headers: headers}
+}
+
+// WithMarketplaceApp returns a client sharing the same data that records the marketplace headers.
+func (c *Client) WithMarketplaceApp(name string, organizationID, appID int64) zendesk.Client {
+	return c.WithHeader("X-Zendesk-Marketplace-Name", name).
+		WithHeader("X-Zendesk-Marketplace-Organization-Id", strconv.FormatInt(organizationID, 10)).
+		WithHeader("X-Zendesk-Marketplace-App-Id", strconv.FormatInt(appID, 10))
+}
+
+// WithRetryPolicy returns the client itself since in-memory calls are never retried.
+func (c *Client) WithRetryPolicy(zendesk.RetryPolicy) zendesk.Client {
+	return c
+}
+
+// WithEndpointPolicy returns the client itself since in-memory calls are neither retried nor rate limited.
+func (c *Client) WithEndpointPolicy(zendesk.EndpointFamily, zendesk.EndpointPolicy) zendesk.Client {
+	return c
+}
+
+// WithConcurrency returns the client itself since in-memory calls are not fanned out.
+func (c *Client) WithConcurrency(int) zendesk.Client {
+	return c
+}
+
+// WithHTTPClient returns the client itself since in-memory calls make no HTTP requests.
+func (c *Client) WithHTTPClient(*http.Client) zendesk.Client {
+	return c
+}
+
+// WithTimeout returns the client itself since in-memory calls make no HTTP requests.
+func (c *Client) WithTimeout(time.Duration) zendesk.Client {
+	return c
+}
+
+// LastResponse returns nil since in-memory calls receive no HTTP responses.
+func (c *Client) LastResponse() *zendesk.ResponseMeta {
+	return nil
+}
+
+// Logger returns a logger discarding its output, since in-memory calls log nothing.
+func (c *Client) Logger() zendesk.Logger {
+	return log.New(ioutil.Discard, "", 0)
+}
+
+// Middleware returns no names since in-memory calls make no HTTP requests.
+func (c *Client) Middleware() []string {
+	return nil
+}
+
+// WithMiddlewareReplaced returns the client itself since in-memory calls make no HTTP requests.
+func (c *Client) WithMiddlewareReplaced(string, zendesk.MiddlewareFunction) zendesk.Client {
+	return c
+}
+
+// WithoutMiddleware returns the client itself since in-memory calls make no HTTP requests.
+func (c *Client) WithoutMiddleware(string) zendesk.Client {
+	return c
+}
+
+// Tickets
+
+func (c *Client) ShowTicket(id int64, includes ...zendesk.Include) (*zendesk.Ticket, error) {
+	c.lock()
+	defer c.unlock()
+
+	ticket, ok := c.tickets[id]
+	if !ok {
+		return nil, notFound("ticket", id)
+	}
+	t := *ticket
+	t.Sideloads = c.ticketSideloads([]zendesk.Ticket{t}, includes)
+	return &t, nil
+}
+
+// ShowManyTickets returns the existing tickets among the IDs, in the order of the IDs.
+func (c *Client) ShowManyTickets(ids []int64, includes ...zendesk.Include) ([]zendesk.Ticket, error) {
+	c.lock()
+	result := make([]zendesk.Ticket, 0, len(ids))
+	for _, id := range ids {
+		if ticket, ok := c.tickets[id]; ok {
+			result = append(result, *ticket)
+		}
+	}
+	c.unlock()
+	return c.withTicketSideloads(result, includes), nil
+}
+
+func (c *Client) CreateTicket(ticket *zendesk.Ticket) (*zendesk.Ticket, error) {
+	c.lock()
+	defer c.unlock()
+	if ticket.FollowupSourceID != 0 {
+		if source, ok := c.tickets[ticket.FollowupSourceID]; !ok || source.Status != "closed" {
+			return nil, &zendesk.ErrValidation{Description: "Record validation errors: via_followup_source_id must be a closed ticket"}
+		}
+	}
+	t := *ticket
+	if err := c.applyCustomStatus(&t); err != nil {
+		return nil, err
+	}
+	return c.createTicket(&t), nil
+}
+
+func (c *Client) CreateFollowupTicket(closedTicketID int64, ticket *zendesk.Ticket) (*zendesk.Ticket, error) {
+	c.lock()
+	defer c.unlock()
+	source, ok := c.tickets[closedTicketID]
+	if !ok {
+		return nil, notFound("ticket", closedTicketID)
+	}
+	if source.Status != "closed" {
+		return nil, fmt.Errorf("zendesk: ticket %d is %s, only closed tickets can be followed up", closedTicketID, source.Status)
+	}
+
+	followup := *ticket
+	followup.FollowupSourceID = source.ID
+	if followup.RequesterID == 0 && followup.Requester == nil {
+		followup.RequesterID = source.RequesterID
+	}
+	set := make(map[int64]bool)
+	for _, field := range followup.CustomFields {
+		set[field.ID] = true
+	}
+	fields := append([]zendesk.CustomField(nil), followup.CustomFields...)
+	for _, field := range source.CustomFields {
+		if !set[field.ID] && field.Value != nil {
+			fields = append(fields, field)
+		}
+	}
+	followup.CustomFields = fields
+	return c.createTicket(&followup), nil
+}
+
+func (c *Client) createTicket(ticket *zendesk.Ticket) *zendesk.Ticket {
+	t := *ticket
+	if t.ID == 0 {
+		t.ID = c.nextID()
+	}
+	c.seen(t.ID)
+	if t.Status == "" {
+		t.Status = "new"
+	}
+	if t.CreatedAt == nil {
+		t.CreatedAt = c.now()
+	}
+	t.UpdatedAt = c.now()
+
+	if t.Comment != nil {
+		c.addComment(t.ID, t.Comment)
+		if t.Description == "" {
+			t.Description = t.Comment.Body
+		}
+		t.Comment = nil
+	}
+
+	c.tickets[t.ID] = &t
+	created := t
+	return &created
+}
+
+func (c *Client) addComment(ticketID int64, comment *zendesk.TicketComment) {
+	cm := *comment
+	if cm.ID == 0 {
+		cm.ID = c.nextID()
+	}
+	if cm.CreatedAt == nil {
+		cm.CreatedAt = c.now()
+	}
+	if cm.PlainBody == "" {
+		cm.PlainBody = cm.Body
+	}
+	for _, token := range cm.Uploads {
+		if upload, ok := c.uploads[token]; ok {
+			cm.Attachments = append(cm.Attachments, upload.Attachments...)
+			delete(c.uploads, token)
+		}
+	}
+	cm.Uploads = nil
+	c.comments[ticketID] = append(c.comments[ticketID], cm)
+}
+
+func (c *Client) UpdateTicket(id int64, ticket *zendesk.Ticket) (*zendesk.Ticket, error) {
+	c.lock()
+	defer c.unlock()
+	return c.updateTicket(id, ticket)
+}
+
+// UpdateTicketSafe updates the ticket unless it was updated after lastUpdatedAt, failing
+// with an *UpdateConflictError like the client.
+func (c *Client) UpdateTicketSafe(id int64, ticket *zendesk.Ticket, lastUpdatedAt time.Time) (*zendesk.Ticket, error) {
+	update := *ticket
+	update.SafeUpdate = true
+	update.UpdatedStamp = &lastUpdatedAt
+	updated, err := c.UpdateTicket(id, &update)
+	if errors.Is(err, zendesk.ErrConflict) {
+		return nil, &zendesk.UpdateConflictError{TicketID: id, UpdatedStamp: lastUpdatedAt, Err: err}
+	}
+	return updated, err
+}
+
+func (c *Client) updateTicket(id int64, ticket *zendesk.Ticket) (*zendesk.Ticket, error) {
+	existing, ok := c.tickets[id]
+	if !ok {
+		return nil, notFound("ticket", id)
+	}
+
+	update := *ticket
+	update.ID = id
+	if err := c.applyCustomStatus(&update); err != nil {
+		return nil, err
+	}
+	if update.SafeUpdate {
+		if err := checkStamp(existing, update.UpdatedStamp); err != nil {
+			return nil, err
+		}
+	}
+	comment := update.Comment
+	emailCCs, followers := update.EmailCCs, update.Followers
+	update.Comment = nil
+	update.EmailCCs, update.Followers = nil, nil
+	update.SafeUpdate, update.UpdatedStamp = false, nil
+	if err := merge(existing, &update); err != nil {
+		return nil, err
+	}
+	if comment != nil {
+		c.addComment(id, comment)
+	}
+	if len(update.AdditionalTags) > 0 {
+		existing.Tags = addTags(existing.Tags, update.AdditionalTags)
+	}
+	if len(update.RemoveTags) > 0 {
+		existing.Tags = removeTags(existing.Tags, update.RemoveTags)
+	}
+	existing.AdditionalTags = nil
+	existing.RemoveTags = nil
+	existing.EmailCCIDs = applyCCUpdates(existing.EmailCCIDs, emailCCs)
+	existing.FollowerIDs = applyCCUpdates(existing.FollowerIDs, followers)
+	existing.UpdatedAt = c.now()
+
+	t := *existing
+	return &t, nil
+}
+
+// checkStamp fails with a conflict when the ticket was updated after the stamp of a safe update.
+func checkStamp(ticket *zendesk.Ticket, stamp *time.Time) error {
+	if stamp == nil || ticket.UpdatedAt == nil || !stamp.Equal(*ticket.UpdatedAt) {
+		return fmt.Errorf("ticket %d updated since %v: %w", ticket.ID, stamp, zendesk.ErrConflict)
+	}
+	return nil
+}
+
+// applyCCUpdates adds or removes the users of the updates identified by ID.
+func applyCCUpdates(ids []int64, updates []zendesk.TicketCCUpdate) []int64 {
+	for _, update := range updates {
+		if update.UserID == 0 {
+			continue
+		}
+		i := 0
+		for i < len(ids) && ids[i] != update.UserID {
+			i++
+		}
+		switch {
+		case update.Action == zendesk.CCActionDelete && i < len(ids):
+			ids = append(ids[:i:i], ids[i+1:]...)
+		case update.Action != zendesk.CCActionDelete && i == len(ids):
+			ids = append(ids, update.UserID)
+		}
+	}
+	return ids
+}
+
+func (c *Client) DeleteTicket(id int64) error {
+	c.lock()
+	defer c.unlock()
+
+	ticket, ok := c.tickets[id]
+	if !ok {
+		return notFound("ticket", id)
+	}
+	c.deleted[id] = &deletedTicket{ticket: ticket, comments: c.comments[id], deletedAt: c.now()}
+	delete(c.tickets, id)
+	delete(c.comments, id)
+	return nil
+}
+
+func (c *Client) DeleteManyTickets(ids []int64) (*zendesk.JobStatus, error) {
+	if len(ids) > 100 {
+		return nil, fmt.Errorf("zendesk: at most 100 tickets can be deleted at once, got %d", len(ids))
+	}
+
+	results := make([]zendesk.JobStatusResult, 0, len(ids))
+	for i, id := range ids {
+		err := c.DeleteTicket(id)
+		results = append(results, actionResult("delete", "Deleted", id, int64(i), err))
+	}
+
+	c.lock()
+	defer c.unlock()
+	return c.completedJob(results), nil
+}
+
+func (c *Client) DeleteTickets(ids []int64) ([]*zendesk.JobStatus, error) {
+	jobs := make([]*zendesk.JobStatus, 0)
+	for start := 0; start < len(ids); start += 100 {
+		end := start + 100
+		if end > len(ids) {
+			end = len(ids)
+		}
+		job, err := c.DeleteManyTickets(ids[start:end])
+		if err != nil {
+			return jobs, err
+		}
+		jobs = append(jobs, job)
+	}
+	return jobs, nil
+}
+
+// MergeTickets closes the sources, adding the comments to the target and the sources.
+func (c *Client) MergeTickets(targetID int64, sourceIDs []int64, targetComment, sourceComment string) (*zendesk.JobStatus, error) {
+	c.lock()
+	defer c.unlock()
+
+	if _, ok := c.tickets[targetID]; !ok {
+		return nil, notFound("ticket", targetID)
+	}
+
+	results := make([]zendesk.JobStatusResult, 0, len(sourceIDs))
+	for i, id := range sourceIDs {
+		var err error
+		if id == targetID {
+			err = fmt.Errorf("ticket %d cannot be merged into itself", id)
+		} else {
+			source := &zendesk.Ticket{Status: "closed"}
+			if sourceComment != "" {
+				source.Comment = &zendesk.TicketComment{Body: sourceComment}
+			}
+			_, err = c.updateTicket(id, source)
+		}
+		results = append(results, actionResult("merge", "Merged", id, int64(i), err))
+	}
+	if targetComment != "" {
+		if _, err := c.updateTicket(targetID, &zendesk.Ticket{Comment: &zendesk.TicketComment{Body: targetComment}}); err != nil {
+			return nil, err
+		}
+	}
+	return c.completedJob(results), nil
+}
+
+func (c *Client) BatchUpdateManyTickets(tickets []zendesk.Ticket) (*zendesk.JobStatus, error) {
+	c.lock()
+	defer c.unlock()
+
+	results := make([]zendesk.JobStatusResult, 0, len(tickets))
+	for i := range tickets {
+		_, err := c.updateTicket(tickets[i].ID, &tickets[i])
+		results = append(results, jobResult(tickets[i].ID, int64(i), err))
+	}
+	return c.completedJob(results), nil
+}
+
+func (c *Client) BulkUpdateManyTickets(ids []int64, ticket *zendesk.Ticket) (*zendesk.JobStatus, error) {
+	c.lock()
+	defer c.unlock()
+
+	results := make([]zendesk.JobStatusResult, 0, len(ids))
+	for i, id := range ids {
+		_, err := c.updateTicket(id, ticket)
+		results = append(results, jobResult(id, int64(i), err))
+	}
+	return c.completedJob(results), nil
+}
+
+func jobResult(id, index int64, err error) zendesk.JobStatusResult {
+	return actionResult("update", "Updated", id, index, err)
+}
+
+func actionResult(action, status string, id, index int64, err error) zendesk.JobStatusResult {
+	result := zendesk.JobStatusResult{ID: id, Index: index, Action: action, Success: err == nil, Status: status}
+	if err != nil {
+		result.Status = "Failed"
+		result.Error = err.Error()
+	}
+	return result
+}
+
+func (c *Client) completedJob(results []zendesk.JobStatusResult) *zendesk.JobStatus {
+	job := &zendesk.JobStatus{
+		ID:       fmt.Sprintf("job-%d", c.nextID()),
+		Total:    int64(len(results)),
+		Progress: int64(len(results)),
+		Status:   zendesk.JobStatusCompleted,
+		Message:  fmt.Sprintf("Completed at %s", c.now().Format(time.RFC3339)),
+		Results:  results,
+	}
+	c.jobs[job.ID] = job
+	j := *job
+	return &j
+}
+
+func (c *Client) ShowJobStatus(id string) (*zendesk.JobStatus, error) {
+	c.lock()
+	defer c.unlock()
+
+	job, ok := c.jobs[id]
+	if !ok {
+		return nil, notFound("job status", id)
+	}
+	j := *job
+	return &j, nil
+}
+
+// WaitForJobCompletion returns at once since in-memory jobs complete immediately.
+func (c *Client) WaitForJobCompletion(ctx context.Context, id string, pollInterval time.Duration) (*zendesk.JobStatus, error) {
+	return c.ShowJobStatus(id)
+}
+
+func (c *Client) ListRequestedTickets(userID int64, includes ...zendesk.Include) ([]zendesk.Ticket, error) {
+	tickets := c.filterTickets(func(t *zendesk.Ticket) bool { return t.RequesterID == userID })
+	return c.withTicketSideloads(tickets, includes), nil
+}
+
+func (c *Client) ListAssignedTickets(userID int64, includes ...zendesk.Include) ([]zendesk.Ticket, error) {
+	tickets := c.filterTickets(func(t *zendesk.Ticket) bool { return t.AssigneeID == userID })
+	return c.withTicketSideloads(tickets, includes), nil
+}
+
+func (c *Client) ListCCdTickets(userID int64, includes ...zendesk.Include) ([]zendesk.Ticket, error) {
+	tickets := c.filterTickets(func(t *zendesk.Ticket) bool {
+		return containsID(t.CollaboratorIDs, userID) || containsID(t.EmailCCIDs, userID)
+	})
+	return c.withTicketSideloads(tickets, includes), nil
+}
+
+func containsID(ids []int64, id int64) bool {
+	for _, i := range ids {
+		if i == id {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *Client) ListTicketIncidents(problemID int64, includes ...zendesk.Include) ([]zendesk.Ticket, error) {
+	tickets := c.filterTickets(func(t *zendesk.Ticket) bool { return t.ProblemID == problemID })
+	return c.withTicketSideloads(tickets, includes), nil
+}
+
+// GetTicketsByOrganization returns the tickets of the organization sorted by ID, ignoring
+// the sort options except the sort order.
+func (c *Client) GetTicketsByOrganization(orgID int64, opts *zendesk.ListTicketsOptions, includes ...zendesk.Include) ([]zendesk.Ticket, error) {
+	c.lock()
+	_, ok := c.orgs[orgID]
+	c.unlock()
+	if !ok {
+		return nil, notFound("organization", orgID)
+	}
+
+	tickets := c.filterTickets(func(t *zendesk.Ticket) bool { return t.OrganizationID == orgID })
+	if opts != nil && opts.SortOrder == "desc" {
+		for i, j := 0, len(tickets)-1; i < j; i, j = i+1, j-1 {
+			tickets[i], tickets[j] = tickets[j], tickets[i]
+		}
+	}
+	return c.withTicketSideloads(tickets, includes), nil
+}
+
+func (c *Client) withTicketSideloads(tickets []zendesk.Ticket, includes []zendesk.Include) []zendesk.Ticket {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if s := c.ticketSideloads(tickets, includes); s != nil {
+		for i := range tickets {
+			tickets[i].Sideloads = s
+		}
+	}
+	return tickets
+}
+
+func (c *Client) GetAllTickets() ([]zendesk.Ticket, error) {
+	return c.filterTickets(func(*zendesk.Ticket) bool { return true }), nil
+}
+
+// GetAllTicketsWithOptions reports a progress for each stored ticket, checking only the stored IDs.
+func (c *Client) GetAllTicketsWithOptions(opts *zendesk.GetAllTicketsOptions) ([]zendesk.Ticket, error) {
+	tickets, _ := c.GetAllTickets()
+	if opts != nil && opts.Progress != nil {
+		for i, t := range tickets {
+			opts.Progress(zendesk.TicketProgress{TicketID: t.ID, Checked: i + 1, Total: len(tickets), Found: i + 1})
+		}
+	}
+	return tickets, nil
+}
+
+// CreateTicketIfNotExists finds existing tickets right away, since in-memory writes are never lagging.
+func (c *Client) CreateTicketIfNotExists(ticket *zendesk.Ticket, opts *zendesk.SearchOptions) (*zendesk.Ticket, bool, error) {
+	if ticket.ExternalID == "" {
+		return nil, false, errors.New("zendesk: CreateTicketIfNotExists requires an external ID")
+	}
+
+	existing := c.filterTickets(func(t *zendesk.Ticket) bool { return t.ExternalID == ticket.ExternalID })
+	if len(existing) > 0 {
+		return &existing[0], false, nil
+	}
+
+	created, err := c.CreateTicket(ticket)
+	return created, err == nil, err
+}
+
+func (c *Client) GetTicketsInRange(startID, endID int64) ([]zendesk.Ticket, error) {
+	return c.filterTickets(func(t *zendesk.Ticket) bool { return t.ID >= startID && t.ID <= endID }), nil
+}
+
+func (c *Client) GetTicketsIncrementally(unixTime int64) ([]zendesk.Ticket, error) {
+	return c.filterTickets(func(t *zendesk.Ticket) bool { return updatedSince(t.UpdatedAt, unixTime) }), nil
+}
+
+// GetTicketsIncrementallyWithOptions returns the tickets updated between the start and end
+// times, in a single page. Cursors are the unix time of the last returned ticket update.
+func (c *Client) GetTicketsIncrementallyWithOptions(opts *zendesk.IncrementalExportOptions) (*zendesk.TicketExport, error) {
+	if opts == nil {
+		opts = new(zendesk.IncrementalExportOptions)
+	}
+	start := opts.StartTime
+	if opts.Cursor != "" {
+		cursor, err := strconv.ParseInt(opts.Cursor, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid cursor %q", opts.Cursor)
+		}
+		start = cursor + 1
+	}
+
+	reachedEnd := false
+	tickets := c.filterTickets(func(t *zendesk.Ticket) bool {
+		if opts.EndTime != 0 && t.UpdatedAt != nil && t.UpdatedAt.Unix() >= opts.EndTime {
+			reachedEnd = true
+			return false
+		}
+		return updatedSince(t.UpdatedAt, start)
+	})
+	includes := make([]zendesk.Include, 0, len(opts.Include))
+	for _, name := range opts.Include {
+		includes = append(includes, zendesk.Include(name))
+	}
+	tickets = c.withTicketSideloads(tickets, includes)
+
+	result := &zendesk.TicketExport{Tickets: tickets, AfterCursor: opts.Cursor, EndTime: start}
+	for _, ticket := range tickets {
+		if ticket.UpdatedAt != nil && ticket.UpdatedAt.Unix() >= result.EndTime {
+			result.EndTime = ticket.UpdatedAt.Unix()
+			result.AfterCursor = strconv.FormatInt(result.EndTime, 10)
+		}
+	}
+	if reachedEnd {
+		result.EndTime = opts.EndTime
+	}
+	return result, nil
+}
+
+func (c *Client) filterTickets(keep func(*zendesk.Ticket) bool) []zendesk.Ticket {
+	c.lock()
+	defer c.unlock()
+
+	ids := make([]int64, 0, len(c.tickets))
+	for id := range c.tickets {
+		ids = append(ids, id)
+	}
+
+	result := make([]zendesk.Ticket, 0)
+	for _, id := range sortedIDs(ids) {
+		if keep(c.tickets[id]) {
+			result = append(result, *c.tickets[id])
+		}
+	}
+	return result
+}
+
+func (c *Client) AddTicketTags(id int64, tags []string) ([]string, error) {
+	c.lock()
+	defer c.unlock()
+
+	ticket, ok := c.tickets[id]
+	if !ok {
+		return nil, notFound("ticket", id)
+	}
+	ticket.Tags = addTags(ticket.Tags, tags)
+	ticket.UpdatedAt = c.now()
+	return append([]string(nil), ticket.Tags...), nil
+}
+
+// AddTicketTagsSafely adds the tags unless the ticket was updated after updatedStamp.
+func (c *Client) AddTicketTagsSafely(id int64, tags []string, updatedStamp time.Time) ([]string, error) {
+	c.lock()
+	defer c.unlock()
+
+	ticket, ok := c.tickets[id]
+	if !ok {
+		return nil, notFound("ticket", id)
+	}
+	if err := checkStamp(ticket, &updatedStamp); err != nil {
+		return nil, err
+	}
+	ticket.Tags = addTags(ticket.Tags, tags)
+	ticket.UpdatedAt = c.now()
+	return append([]string(nil), ticket.Tags...), nil
+}
+
+func (c *Client) SetTicketTags(id int64, tags []string) ([]string, error) {
+	c.lock()
+	defer c.unlock()
+
+	ticket, ok := c.tickets[id]
+	if !ok {
+		return nil, notFound("ticket", id)
+	}
+	ticket.Tags = addTags(nil, tags)
+	ticket.UpdatedAt = c.now()
+	return append([]string(nil), ticket.Tags...), nil
+}
+
+func (c *Client) RemoveTicketTags(id int64, tags []string) error {
+	c.lock()
+	defer c.unlock()
+
+	ticket, ok := c.tickets[id]
+	if !ok {
+		return notFound("ticket", id)
+	}
+	ticket.Tags = removeTags(ticket.Tags, tags)
+	ticket.UpdatedAt = c.now()
+	return nil
+}
+
+// RemoveTicketTagsSafely removes the tags unless the ticket was updated after updatedStamp.
+func (c *Client) RemoveTicketTagsSafely(id int64, tags []string, updatedStamp time.Time) error {
+	c.lock()
+	defer c.unlock()
+
+	ticket, ok := c.tickets[id]
+	if !ok {
+		return notFound("ticket", id)
+	}
+	if err := checkStamp(ticket, &updatedStamp); err != nil {
+		return err
+	}
+	ticket.Tags = removeTags(ticket.Tags, tags)
+	ticket.UpdatedAt = c.now()
+	return nil
+}
+
+func addTags(tags, added []string) []string {
+	result := append([]string(nil), tags...)
+	for _, tag := range added {
+		found := false
+		for _, t := range result {
+			if t == tag {
+				found = true
+				break
+			}
+		}
+		if !found {
+			result = append(result, tag)
+		}
+	}
+	return result
+}
+
+func removeTags(tags, removed []string) []string {
+	result := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		keep := true
+		for _, r := range removed {
+			if tag == r {
+				keep = false
+				break
+			}
+		}
+		if keep {
+			result = append(result, tag)
+		}
+	}
+	return result
+}
+
+func (c *Client) ListTicketComments(id int64) ([]zendesk.TicketComment, error) {
+	c.lock()
+	defer c.unlock()
+
+	if _, ok := c.tickets[id]; !ok {
+		return nil, notFound("ticket", id)
+	}
+	return append([]zendesk.TicketComment{}, c.comments[id]...), nil
+}
+
+func (c *Client) CountTicketComments(id int64) (int64, error) {
+	c.lock()
+	defer c.unlock()
+
+	if _, ok := c.tickets[id]; !ok {
+		return 0, notFound("ticket", id)
+	}
+	return int64(len(c.comments[id])), nil
+}
+
+func (c *Client) AddTicketComment(ticketID int64, comment *zendesk.TicketComment) (*zendesk.Ticket, error) {
+	c.lock()
+	defer c.unlock()
+	return c.updateTicket(ticketID, &zendesk.Ticket{Comment: comment})
+}
+
+// RedactCommentString replaces each character of the text with a redaction mark, as Zendesk does.
+func (c *Client) RedactCommentString(ticketID, commentID int64, text string) (*zendesk.TicketComment, error) {
+	c.lock()
+	defer c.unlock()
+
+	comment, err := c.comment(ticketID, commentID)
+	if err != nil {
+		return nil, err
+	}
+	if !strings.Contains(comment.Body, text) {
+		return nil, &zendesk.ErrValidation{Type: "RecordInvalid", Description: "Text not found in comment"}
+	}
+
+	redacted := strings.Repeat("▇", utf8.RuneCountInString(text))
+	comment.Body = strings.ReplaceAll(comment.Body, text, redacted)
+	comment.PlainBody = strings.ReplaceAll(comment.PlainBody, text, redacted)
+	comment.HTMLBody = strings.ReplaceAll(comment.HTMLBody, text, redacted)
+	cm := *comment
+	return &cm, nil
+}
+
+// RedactTicketCommentAttachment replaces the attachment with an empty redacted.txt file,
+// as Zendesk does.
+func (c *Client) RedactTicketCommentAttachment(ticketID, commentID, attachmentID int64) (*zendesk.Attachment, error) {
+	c.lock()
+	defer c.unlock()
+
+	comment, err := c.comment(ticketID, commentID)
+	if err != nil {
+		return nil, err
+	}
+	for i := range comment.Attachments {
+		if comment.Attachments[i].ID == attachmentID {
+			redactAttachment(&comment.Attachments[i])
+			a := comment.Attachments[i]
+			return &a, nil
+		}
+	}
+	return nil, notFound("attachment", attachmentID)
+}
+
+func redactAttachment(attachment *zendesk.Attachment) {
+	attachment.FileName = "redacted.txt"
+	attachment.ContentType = "text/plain"
+	attachment.Size = 0
+	attachment.Thumbnails = nil
+}
+
+var redactTags = regexp.MustCompile(`(?s)<redact>(.*?)</redact>`)
+
+// RedactTicketComment redacts the text wrapped in <redact> tags in the HTML body of the
+// redaction, and the attachments of the listed content URLs.
+func (c *Client) RedactTicketComment(commentID int64, redaction *zendesk.CommentRedaction) (*zendesk.TicketComment, error) {
+	c.lock()
+	defer c.unlock()
+
+	comment, err := c.comment(redaction.TicketID, commentID)
+	if err != nil {
+		return nil, err
+	}
+	matches := redactTags.FindAllStringSubmatch(redaction.HTMLBody, -1)
+	if len(matches) == 0 && len(redaction.ExternalAttachmentURLs) == 0 {
+		return nil, &zendesk.ErrValidation{Type: "RecordInvalid", Description: "Nothing to redact"}
+	}
+
+	for _, match := range matches {
+		text := match[1]
+		redacted := strings.Repeat("▇", utf8.RuneCountInString(text))
+		comment.Body = strings.ReplaceAll(comment.Body, text, redacted)
+		comment.PlainBody = strings.ReplaceAll(comment.PlainBody, text, redacted)
+		comment.HTMLBody = strings.ReplaceAll(comment.HTMLBody, text, redacted)
+	}
+	for _, url := range redaction.ExternalAttachmentURLs {
+		for i := range comment.Attachments {
+			if comment.Attachments[i].ContentURL == url {
+				redactAttachment(&comment.Attachments[i])
+			}
+		}
+	}
+	cm := *comment
+	return &cm, nil
+}
+
+func (c *Client) MakeCommentPrivate(ticketID, commentID int64) error {
+	c.lock()
+	defer c.unlock()
+
+	comment, err := c.comment(ticketID, commentID)
+	if err != nil {
+		return err
+	}
+	comment.Public = false
+	return nil
+}
+
+func (c *Client) comment(ticketID, commentID int64) (*zendesk.TicketComment, error) {
+	if _, ok := c.tickets[ticketID]; !ok {
+		return nil, notFound("ticket", ticketID)
+	}
+	for i := range c.comments[ticketID] {
+		if c.comments[ticketID][i].ID == commentID {
+			return &c.comments[ticketID][i], nil
+		}
+	}
+	return nil, notFound("comment", commentID)
+}
+
+func (c *Client) GetAllTicketComments(ticketIDs []int64) (map[int64][]zendesk.TicketComment, error) {
+	c.lock()
+	defer c.unlock()
+
+	result := make(map[int64][]zendesk.TicketComment)
+	for _, id := range ticketIDs {
+		if _, ok := c.tickets[id]; ok {
+			result[id] = append([]zendesk.TicketComment{}, c.comments[id]...)
+		}
+	}
+	return result, nil
+}
+
+// ListTicketCommentsWithOptions honors the sort order; inline images are always among
+// the attachments of the comments.
+func (c *Client) ListTicketCommentsWithOptions(id int64, opts *zendesk.ListTicketCommentsOptions) ([]zendesk.TicketComment, error) {
+	comments, err := c.ListTicketComments(id)
+	if err != nil {
+		return nil, err
+	}
+	if opts != nil && opts.SortOrder == "desc" {
+		for i, j := 0, len(comments)-1; i < j; i, j = i+1, j-1 {
+			comments[i], comments[j] = comments[j], comments[i]
+		}
+	}
+	if opts != nil && opts.Authors != nil {
+		if err := opts.Authors.HydrateCommentAuthors(comments); err != nil {
+			return nil, err
+		}
+	}
+	return comments, nil
+}
+
+func (c *Client) GetAllTicketCommentsWithOptions(ticketIDs []int64, opts *zendesk.ListTicketCommentsOptions) (map[int64][]zendesk.TicketComment, error) {
+	comments, err := c.GetAllTicketComments(ticketIDs)
+	if err != nil {
+		return nil, err
+	}
+	if opts != nil && opts.Authors != nil {
+		for _, ticketComments := range comments {
+			if err := opts.Authors.HydrateCommentAuthors(ticketComments); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return comments, nil
+}
+
+func (c *Client) UploadFile(filename string, token string, filecontent io.Reader) (*zendesk.Upload, error) {
+	content, err := ioutil.ReadAll(filecontent)
+	if err != nil {
+		return nil, err
+	}
+
+	c.lock()
+	defer c.unlock()
+
+	upload, ok := c.uploads[token]
+	if token == "" || !ok {
+		upload = &zendesk.Upload{Token: fmt.Sprintf("upload-%d", c.nextID())}
+		c.uploads[upload.Token] = upload
+	}
+
+	attachment := zendesk.Attachment{
+		ID:          c.nextID(),
+		FileName:    filename,
+		ContentType: "application/binary",
+		Size:        int64(len(content)),
+	}
+	upload.Attachment = &attachment
+	upload.Attachments = append(upload.Attachments, attachment)
+
+	u := *upload
+	return &u, nil
+}
+
+// UploadFileWithResume uploads the content as a single attachment, refusing files over
+// 50 MB like the client does. Network failures cannot happen, so there are no retries.
+func (c *Client) UploadFileWithResume(filename string, content io.Reader, opts *zendesk.ResumableUploadOptions) (*zendesk.ResumableUpload, error) {
+	progress := &zendesk.ResumableUpload{FileName: filename}
+	if opts != nil && opts.Resume != nil {
+		*progress = *opts.Resume
+		if progress.Uploaded && progress.FileName == filename {
+			return progress, nil
+		}
+		progress.FileName = filename
+		progress.Uploaded = false
+	}
+
+	data, err := ioutil.ReadAll(content)
+	if err != nil {
+		return progress, err
+	}
+	if len(data) > 50<<20 {
+		return progress, fmt.Errorf("zendesk: %s is over the 50 MB limit of Zendesk attachments", filename)
+	}
+
+	upload, err := c.UploadFile(filename, progress.Token, bytes.NewReader(data))
+	if err != nil {
+		return progress, err
+	}
+	progress.Token = upload.Token
+	progress.Upload = upload
+	progress.Uploaded = true
+	return progress, nil
+}
+
+func (c *Client) DeleteUpload(token string) error {
+	c.lock()
+	defer c.unlock()
+
+	if _, ok := c.uploads[token]; !ok {
+		return notFound("upload", token)
+	}
+	delete(c.uploads, token)
+	return nil
+}
+
+// AttachFilesToTicketComment uploads the files under a single token, one attachment each,
+// and adds the comment with them attached, deleting the upload when an upload or the
+// comment fails.
+func (c *Client) AttachFilesToTicketComment(ticketID int64, comment *zendesk.TicketComment, files ...zendesk.AttachedFile) (*zendesk.Ticket, error) {
+	token := ""
+	for _, file := range files {
+		opts := &zendesk.ResumableUploadOptions{Resume: &zendesk.ResumableUpload{FileName: file.Name, Token: token}}
+		progress, err := c.UploadFileWithResume(file.Name, file.Content, opts)
+		if progress != nil && progress.Token != "" {
+			token = progress.Token
+		}
+		if err != nil {
+			if token != "" {
+				c.DeleteUpload(token)
+			}
+			return nil, err
+		}
+	}
+
+	cm := *comment
+	if token != "" {
+		cm.Uploads = append(cm.Uploads, token)
+	}
+	ticket, err := c.AddTicketComment(ticketID, &cm)
+	if err != nil && token != "" {
+		c.DeleteUpload(token)
+	}
+	return ticket, err
+}
+
+// Ticket metrics
+
+func (c *Client) ShowTicketMetric(id int64) (*zendesk.TicketMetric, error) {
+	c.lock()
+	defer c.unlock()
+
+	metric, ok := c.metrics[id]
+	if !ok {
+		return nil, notFound("ticket metric", id)
+	}
+	m := *metric
+	return &m, nil
+}
+
+func (c *Client) GetAllTicketMetrics() ([]zendesk.TicketMetric, error) {
+	c.lock()
+	defer c.unlock()
+
+	ids := make([]int64, 0, len(c.metrics))
+	for id := range c.metrics {
+		ids = append(ids, id)
+	}
+
+	result := make([]zendesk.TicketMetric, 0, len(ids))
+	for _, id := range sortedIDs(ids) {
+		result = append(result, *c.metrics[id])
+	}
+	return result, nil
+}
+
+func (c *Client) GetTicketMetricsIncrementally(ticketIDs []int64) ([]zendesk.TicketMetric, error) {
+	metrics, err := c.GetAllTicketMetrics()
+	if err != nil {
+		return nil, err
+	}
+
+	wanted := make(map[int64]bool)
+	for _, id := range ticketIDs {
+		wanted[id] = true
+	}
+
+	result := make([]zendesk.TicketMetric, 0)
+	for _, metric := range metrics {
+		if wanted[metric.TicketID] {
+			result = append(result, metric)
+		}
+	}
+	return result, nil
+}
+
+func (c *Client) GetTicketMetricEventsIncrementally(unixTime int64) ([]zendesk.TicketMetricEvent, error) {
+	c.lock()
+	defer c.unlock()
+
+	result := make([]zendesk.TicketMetricEvent, 0)
+	for _, event := range c.metricEvents {
+		if updatedSince(event.Time, unixTime) {
+			result = append(result, event)
+		}
+	}
+	return result, nil
+}
+
+// Satisfaction ratings
+
+func (c *Client) GetSatisfactionScores() ([]zendesk.Score, error) {
+	return c.ListSatisfactionRatings(nil)
+}
+
+func (c *Client) GetSatisfactionScoresIncrementally(unixTime int64) ([]zendesk.Score, error) {
+	result := make([]zendesk.Score, 0)
+	_, err := c.ExportSatisfactionRatings(&zendesk.IncrementalExportOptions{StartTime: unixTime}, zendesk.SinkFunc(func(record interface{}) error {
+		result = append(result, *record.(*zendesk.Score))
+		return nil
+	}))
+	return result, err
+}
+
+// ListSatisfactionRatings filters the ratings by score and creation time.
+func (c *Client) ListSatisfactionRatings(opts *zendesk.ListSatisfactionRatingsOptions) ([]zendesk.Score, error) {
+	c.lock()
+	defer c.unlock()
+
+	if opts == nil {
+		opts = new(zendesk.ListSatisfactionRatingsOptions)
+	}
+
+	ids := make([]int64, 0, len(c.scores))
+	for id := range c.scores {
+		ids = append(ids, id)
+	}
+
+	result := make([]zendesk.Score, 0)
+	for _, id := range sortedIDs(ids) {
+		score := c.scores[id]
+		if !matchScore(score, opts.Score) {
+			continue
+		}
+		if score.CreatedAt != nil {
+			if opts.StartTime != 0 && score.CreatedAt.Unix() < opts.StartTime {
+				continue
+			}
+			if opts.EndTime != 0 && score.CreatedAt.Unix() > opts.EndTime {
+				continue
+			}
+		}
+		result = append(result, *score)
+	}
+	return result, nil
+}
+
+// matchScore applies the score filter, such as good_with_comment or received.
+func matchScore(score *zendesk.Score, filter string) bool {
+	if filter == "" {
+		return true
+	}
+
+	rating := filter
+	switch {
+	case strings.HasSuffix(filter, "_without_comment"):
+		if score.Comment != "" {
+			return false
+		}
+		rating = strings.TrimSuffix(filter, "_without_comment")
+	case strings.HasSuffix(filter, "_with_comment"):
+		if score.Comment == "" {
+			return false
+		}
+		rating = strings.TrimSuffix(filter, "_with_comment")
+	}
+
+	if rating == zendesk.ScoreReceived {
+		return score.Score == zendesk.ScoreGood || score.Score == zendesk.ScoreBad
+	}
+	return score.Score == rating
+}
+
+func (c *Client) ShowSatisfactionRating(id int64) (*zendesk.Score, error) {
+	c.lock()
+	defer c.unlock()
+
+	score, ok := c.scores[id]
+	if !ok {
+		return nil, notFound("satisfaction rating", id)
+	}
+	s := *score
+	return &s, nil
+}
+
+// CreateSatisfactionRating rates the ticket, which must be solved.
+func (c *Client) CreateSatisfactionRating(ticketID int64, rating *zendesk.Score) (*zendesk.Score, error) {
+	c.lock()
+	defer c.unlock()
+
+	ticket, ok := c.tickets[ticketID]
+	if !ok {
+		return nil, notFound("ticket", ticketID)
+	}
+	if ticket.Status != "solved" {
+		return nil, &zendesk.ErrValidation{Type: "RecordInvalid", Description: "Ticket must be solved to be rated"}
+	}
+
+	s := *rating
+	s.ID = c.nextID()
+	s.TicketID = ticketID
+	s.RequesterID = ticket.RequesterID
+	s.AssigneeID = ticket.AssigneeID
+	s.GroupID = ticket.GroupID
+	s.CreatedAt = c.now()
+	s.UpdatedAt = s.CreatedAt
+	c.scores[s.ID] = &s
+	ticket.SatisfactionRating = &zendesk.SAT{ID: s.ID, Score: s.Score, Comment: s.Comment}
+
+	result := s
+	return &result, nil
+}
+
+func (c *Client) ListSatisfactionRatingReasons() ([]zendesk.SatisfactionReason, error) {
+	c.lock()
+	defer c.unlock()
+
+	ids := make([]int64, 0, len(c.reasons))
+	for id := range c.reasons {
+		ids = append(ids, id)
+	}
+
+	result := make([]zendesk.SatisfactionReason, 0, len(ids))
+	for _, id := range sortedIDs(ids) {
+		result = append(result, *c.reasons[id])
+	}
+	return result, nil
+}
+
+// Talk
+
+func (c *Client) GetCallLegIncrementally(unixTime int64) ([]zendesk.CallLeg, error) {
+	c.lock()
+	defer c.unlock()
+
+	ids := make([]int64, 0, len(c.callLegs))
+	for id := range c.callLegs {
+		ids = append(ids, id)
+	}
+
+	result := make([]zendesk.CallLeg, 0)
+	for _, id := range sortedIDs(ids) {
+		leg := c.callLegs[id]
+		if leg.UpdatedAt != nil && !leg.UpdatedAt.Before(time.Unix(unixTime, 0)) {
+			result = append(result, *leg)
+		}
+	}
+	return result, nil
+}
+
+// GetCallsIncrementally returns the calls updated since the given unix time.
+func (c *Client) GetCallsIncrementally(unixTime int64) ([]zendesk.Call, error) {
+	c.lock()
+	defer c.unlock()
+
+	ids := make([]int64, 0, len(c.calls))
+	for id := range c.calls {
+		ids = append(ids, id)
+	}
+
+	result := make([]zendesk.Call, 0)
+	for _, id := range sortedIDs(ids) {
+		call := c.calls[id]
+		if call.UpdatedAt != nil && !call.UpdatedAt.Before(time.Unix(unixTime, 0)) {
+			result = append(result, *call)
+		}
+	}
+	return result, nil
+}
+
+// GetCallLegsIncrementallyWithOptions returns the call legs updated since the start time.
+// Cursors are the unix time of the last returned call leg update.
+func (c *Client) GetCallLegsIncrementallyWithOptions(opts *zendesk.IncrementalCallExportOptions) (*zendesk.CallLegExport, error) {
+	var start int64
+	var include []string
+	if opts != nil {
+		start = opts.StartTime
+		include = opts.Include
+		if opts.Cursor != "" {
+			cursor, err := strconv.ParseInt(opts.Cursor, 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid cursor %q", opts.Cursor)
+			}
+			start = cursor + 1
+		}
+	}
+
+	legs, err := c.GetCallLegIncrementally(start)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &zendesk.CallLegExport{CallLegs: legs, AfterCursor: strconv.FormatInt(start, 10)}
+	for _, leg := range legs {
+		if leg.UpdatedAt != nil && leg.UpdatedAt.Unix() >= start {
+			result.AfterCursor = strconv.FormatInt(leg.UpdatedAt.Unix(), 10)
+		}
+	}
+
+	for _, i := range include {
+		if i != zendesk.IncludeAgents {
+			continue
+		}
+		seen := make(map[int64]bool)
+		for _, leg := range legs {
+			agentID := int64(leg.AgentID)
+			if seen[agentID] {
+				continue
+			}
+			seen[agentID] = true
+			if agent, err := c.ShowUser(agentID); err == nil {
+				result.Agents = append(result.Agents, *agent)
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// Locales
+
+func (c *Client) ListLocales() ([]zendesk.Locale, error) {
+	c.lock()
+	defer c.unlock()
+
+	ids := make([]int64, 0, len(c.locales))
+	for id := range c.locales {
+		ids = append(ids, id)
+	}
+
+	result := make([]zendesk.Locale, 0, len(ids))
+	for _, id := range sortedIDs(ids) {
+		result = append(result, *c.locales[id])
+	}
+	return result, nil
+}
+
+func (c *Client) ShowLocale(id int64) (*zendesk.Locale, error) {
+	c.lock()
+	defer c.unlock()
+
+	locale, ok := c.locales[id]
+	if !ok {
+		return nil, notFound("locale", id)
+	}
+	l := *locale
+	return &l, nil
+}
+
+func (c *Client) ShowLocaleByCode(code string) (*zendesk.Locale, error) {
+	locales, err := c.ListLocales()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, locale := range locales {
+		if strings.EqualFold(locale.Locale, code) {
+			l := locale
+			return &l, nil
+		}
+	}
+	return nil, notFound("locale", code)
+}
+
+// ListLocalesForAgent returns all the locales, which the mock enables for agents and end users alike.
+func (c *Client) ListLocalesForAgent() ([]zendesk.Locale, error) {
+	return c.ListLocales()
+}
+
+// ListAvailablePublicLocales returns all the locales.
+func (c *Client) ListAvailablePublicLocales() ([]zendesk.Locale, error) {
+	return c.ListLocales()
+}
+
+// DetectBestLocale returns the first locale matching a language exactly or by its primary
+// subtag, falling back to the default locale, then to the first one.
+func (c *Client) DetectBestLocale(acceptLanguages []string) (*zendesk.Locale, error) {
+	locales, err := c.ListLocales()
+	if err != nil {
+		return nil, err
+	}
+	if len(locales) == 0 {
+		return nil, notFound("locale", strings.Join(acceptLanguages, ","))
+	}
+
+	primary := func(code string) string {
+		return strings.ToLower(strings.SplitN(code, "-", 2)[0])
+	}
+	for _, language := range acceptLanguages {
+		for i := range locales {
+			if strings.EqualFold(locales[i].Locale, language) {
+				return &locales[i], nil
+			}
+		}
+		for i := range locales {
+			if primary(locales[i].Locale) == primary(language) {
+				return &locales[i], nil
+			}
+		}
+	}
+	for i := range locales {
+		if locales[i].Default {
+			return &locales[i], nil
+		}
+	}
+	return &locales[0], nil
+}
@@ -0,0 +1,186 @@
+package zendeskmock
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/phil-inc/zendesk/zendesk"
+)
+
+// User fields
+
+func (c *Client) ListUserFields() ([]zendesk.FieldDefinition, error) {
+	c.lock()
+	defer c.unlock()
+	return listFieldDefinitions(c.userFields), nil
+}
+
+func (c *Client) ShowUserField(id int64) (*zendesk.FieldDefinition, error) {
+	c.lock()
+	defer c.unlock()
+	return showFieldDefinition("user field", c.userFields, id)
+}
+
+func (c *Client) CreateUserField(field *zendesk.FieldDefinition) (*zendesk.FieldDefinition, error) {
+	c.lock()
+	defer c.unlock()
+	return c.createFieldDefinition(c.userFields, field)
+}
+
+func (c *Client) UpdateUserField(id int64, field *zendesk.FieldDefinition) (*zendesk.FieldDefinition, error) {
+	c.lock()
+	defer c.unlock()
+	return c.updateFieldDefinition("user field", c.userFields, id, field)
+}
+
+func (c *Client) DeleteUserField(id int64) error {
+	c.lock()
+	defer c.unlock()
+	return deleteFieldDefinition("user field", c.userFields, id)
+}
+
+func (c *Client) ReorderUserFields(ids []int64) error {
+	c.lock()
+	defer c.unlock()
+	return reorderFieldDefinitions("user field", c.userFields, ids)
+}
+
+// Organization fields
+
+func (c *Client) ListOrganizationFields() ([]zendesk.FieldDefinition, error) {
+	c.lock()
+	defer c.unlock()
+	return listFieldDefinitions(c.orgFields), nil
+}
+
+func (c *Client) ShowOrganizationField(id int64) (*zendesk.FieldDefinition, error) {
+	c.lock()
+	defer c.unlock()
+	return showFieldDefinition("organization field", c.orgFields, id)
+}
+
+func (c *Client) CreateOrganizationField(field *zendesk.FieldDefinition) (*zendesk.FieldDefinition, error) {
+	c.lock()
+	defer c.unlock()
+	return c.createFieldDefinition(c.orgFields, field)
+}
+
+func (c *Client) UpdateOrganizationField(id int64, field *zendesk.FieldDefinition) (*zendesk.FieldDefinition, error) {
+	c.lock()
+	defer c.unlock()
+	return c.updateFieldDefinition("organization field", c.orgFields, id, field)
+}
+
+func (c *Client) DeleteOrganizationField(id int64) error {
+	c.lock()
+	defer c.unlock()
+	return deleteFieldDefinition("organization field", c.orgFields, id)
+}
+
+func (c *Client) ReorderOrganizationFields(ids []int64) error {
+	c.lock()
+	defer c.unlock()
+	return reorderFieldDefinitions("organization field", c.orgFields, ids)
+}
+
+// listFieldDefinitions returns the fields sorted by position then ID.
+func listFieldDefinitions(fields map[int64]*zendesk.FieldDefinition) []zendesk.FieldDefinition {
+	ids := make([]int64, 0, len(fields))
+	for id := range fields {
+		ids = append(ids, id)
+	}
+
+	result := make([]zendesk.FieldDefinition, 0, len(ids))
+	for _, id := range sortedIDs(ids) {
+		result = append(result, *fields[id])
+	}
+	sort.SliceStable(result, func(i, j int) bool { return result[i].Position < result[j].Position })
+	return result
+}
+
+func showFieldDefinition(kind string, fields map[int64]*zendesk.FieldDefinition, id int64) (*zendesk.FieldDefinition, error) {
+	field, ok := fields[id]
+	if !ok {
+		return nil, notFound(kind, id)
+	}
+	f := *field
+	return &f, nil
+}
+
+// createFieldDefinition creates an active field, rejecting the fields without a key or a
+// type and the keys already taken.
+func (c *Client) createFieldDefinition(fields map[int64]*zendesk.FieldDefinition, field *zendesk.FieldDefinition) (*zendesk.FieldDefinition, error) {
+	if field.Key == "" || field.Type == "" {
+		return nil, &zendesk.ErrValidation{Type: "RecordInvalid", Description: "Key and type can't be blank"}
+	}
+	for _, existing := range fields {
+		if existing.Key == field.Key {
+			return nil, &zendesk.ErrValidation{Type: "RecordInvalid", Description: fmt.Sprintf("Key %s has already been taken", field.Key)}
+		}
+	}
+
+	f := *field
+	if f.ID == 0 {
+		f.ID = c.nextID()
+	}
+	c.seen(f.ID)
+	f.Active = true
+	if f.RawTitle == "" {
+		f.RawTitle = f.Title
+	}
+	if f.CreatedAt == nil {
+		f.CreatedAt = c.now()
+	}
+	f.UpdatedAt = c.now()
+	for i := range f.CustomFieldOptions {
+		if f.CustomFieldOptions[i].ID == 0 {
+			f.CustomFieldOptions[i].ID = c.nextID()
+		}
+	}
+	fields[f.ID] = &f
+
+	created := f
+	return &created, nil
+}
+
+// updateFieldDefinition updates a field, whose key and type cannot change.
+func (c *Client) updateFieldDefinition(kind string, fields map[int64]*zendesk.FieldDefinition, id int64, field *zendesk.FieldDefinition) (*zendesk.FieldDefinition, error) {
+	existing, ok := fields[id]
+	if !ok {
+		return nil, notFound(kind, id)
+	}
+	if (field.Key != "" && field.Key != existing.Key) || (field.Type != "" && field.Type != existing.Type) {
+		return nil, &zendesk.ErrValidation{Type: "RecordInvalid", Description: "Key and type can't be changed"}
+	}
+
+	update := *field
+	update.ID = id
+	if err := merge(existing, &update); err != nil {
+		return nil, err
+	}
+	existing.UpdatedAt = c.now()
+
+	f := *existing
+	return &f, nil
+}
+
+func deleteFieldDefinition(kind string, fields map[int64]*zendesk.FieldDefinition, id int64) error {
+	if _, ok := fields[id]; !ok {
+		return notFound(kind, id)
+	}
+	delete(fields, id)
+	return nil
+}
+
+// reorderFieldDefinitions sets the positions of the fields to their order in ids.
+func reorderFieldDefinitions(kind string, fields map[int64]*zendesk.FieldDefinition, ids []int64) error {
+	for _, id := range ids {
+		if _, ok := fields[id]; !ok {
+			return notFound(kind, id)
+		}
+	}
+	for i, id := range ids {
+		fields[id].Position = int64(i)
+	}
+	return nil
+}
@@ -0,0 +1,155 @@
+package zendeskmock
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/phil-inc/zendesk/zendesk"
+)
+
+// deletedTicket is a soft deleted ticket, kept with its comments until it is restored or purged.
+type deletedTicket struct {
+	ticket    *zendesk.Ticket
+	comments  []zendesk.TicketComment
+	deletedAt *time.Time
+}
+
+// Deleted tickets
+
+func (c *Client) ListDeletedTickets() ([]zendesk.DeletedTicket, error) {
+	c.lock()
+	defer c.unlock()
+
+	ids := make([]int64, 0, len(c.deleted))
+	for id := range c.deleted {
+		ids = append(ids, id)
+	}
+
+	result := make([]zendesk.DeletedTicket, 0, len(ids))
+	for _, id := range sortedIDs(ids) {
+		d := c.deleted[id]
+		result = append(result, zendesk.DeletedTicket{
+			ID:            id,
+			Subject:       d.ticket.Subject,
+			Description:   d.ticket.Description,
+			PreviousState: d.ticket.Status,
+			DeletedAt:     d.deletedAt,
+		})
+	}
+	return result, nil
+}
+
+func (c *Client) RestoreTicket(id int64) error {
+	c.lock()
+	defer c.unlock()
+	return c.restoreTicket(id)
+}
+
+func (c *Client) RestoreManyTickets(ids []int64) error {
+	if len(ids) > 100 {
+		return fmt.Errorf("zendesk: at most 100 tickets can be restored at once, got %d", len(ids))
+	}
+
+	c.lock()
+	defer c.unlock()
+
+	for _, id := range ids {
+		if _, ok := c.deleted[id]; !ok {
+			return notFound("deleted ticket", id)
+		}
+	}
+	for _, id := range ids {
+		c.restoreTicket(id)
+	}
+	return nil
+}
+
+func (c *Client) restoreTicket(id int64) error {
+	d, ok := c.deleted[id]
+	if !ok {
+		return notFound("deleted ticket", id)
+	}
+	c.tickets[id] = d.ticket
+	if d.comments != nil {
+		c.comments[id] = d.comments
+	}
+	delete(c.deleted, id)
+	return nil
+}
+
+func (c *Client) PurgeTicket(id int64) (*zendesk.JobStatus, error) {
+	c.lock()
+	defer c.unlock()
+
+	if _, ok := c.deleted[id]; !ok {
+		return nil, notFound("deleted ticket", id)
+	}
+	delete(c.deleted, id)
+	return c.completedJob([]zendesk.JobStatusResult{actionResult("delete", "Deleted", id, 0, nil)}), nil
+}
+
+func (c *Client) PurgeManyTickets(ids []int64) (*zendesk.JobStatus, error) {
+	if len(ids) > 100 {
+		return nil, fmt.Errorf("zendesk: at most 100 tickets can be purged at once, got %d", len(ids))
+	}
+
+	c.lock()
+	defer c.unlock()
+
+	results := make([]zendesk.JobStatusResult, 0, len(ids))
+	for i, id := range ids {
+		var err error
+		if _, ok := c.deleted[id]; ok {
+			delete(c.deleted, id)
+		} else {
+			err = notFound("deleted ticket", id)
+		}
+		results = append(results, actionResult("delete", "Deleted", id, int64(i), err))
+	}
+	return c.completedJob(results), nil
+}
+
+// Deleted users
+
+func (c *Client) ListDeletedUsers() ([]zendesk.User, error) {
+	c.lock()
+	defer c.unlock()
+
+	ids := make([]int64, 0, len(c.deletedUsers))
+	for id := range c.deletedUsers {
+		ids = append(ids, id)
+	}
+
+	result := make([]zendesk.User, 0, len(ids))
+	for _, id := range sortedIDs(ids) {
+		result = append(result, *c.deletedUsers[id])
+	}
+	return result, nil
+}
+
+func (c *Client) ShowDeletedUser(id int64) (*zendesk.User, error) {
+	c.lock()
+	defer c.unlock()
+
+	user, ok := c.deletedUsers[id]
+	if !ok {
+		return nil, notFound("deleted user", id)
+	}
+	u := *user
+	return &u, nil
+}
+
+// PermanentlyDeleteUser scrubs the personal data of the user, which Zendesk then
+// keeps as an anonymous record.
+func (c *Client) PermanentlyDeleteUser(id int64) (*zendesk.User, error) {
+	c.lock()
+	defer c.unlock()
+
+	user, ok := c.deletedUsers[id]
+	if !ok {
+		return nil, notFound("deleted user", id)
+	}
+	delete(c.deletedUsers, id)
+
+	return &zendesk.User{ID: user.ID, Name: "Permanently Deleted User", Active: false, CreatedAt: user.CreatedAt, UpdatedAt: c.now()}, nil
+}
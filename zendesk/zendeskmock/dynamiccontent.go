@@ -0,0 +1,248 @@
+package zendeskmock
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/phil-inc/zendesk/zendesk"
+)
+
+// Dynamic content
+
+func (c *Client) ListDynamicContentItems() ([]zendesk.DynamicContentItem, error) {
+	c.lock()
+	defer c.unlock()
+
+	ids := make([]int64, 0, len(c.dynamicContent))
+	for id := range c.dynamicContent {
+		ids = append(ids, id)
+	}
+
+	result := make([]zendesk.DynamicContentItem, 0, len(ids))
+	for _, id := range sortedIDs(ids) {
+		result = append(result, copyItem(c.dynamicContent[id]))
+	}
+	return result, nil
+}
+
+func (c *Client) ShowDynamicContentItem(id int64) (*zendesk.DynamicContentItem, error) {
+	c.lock()
+	defer c.unlock()
+
+	item, ok := c.dynamicContent[id]
+	if !ok {
+		return nil, notFound("dynamic content item", id)
+	}
+	i := copyItem(item)
+	return &i, nil
+}
+
+// CreateDynamicContentItem requires the variant of the default locale, like Zendesk. The
+// placeholder is derived from the name.
+func (c *Client) CreateDynamicContentItem(item *zendesk.DynamicContentItem) (*zendesk.DynamicContentItem, error) {
+	c.lock()
+	defer c.unlock()
+
+	if item.Name == "" {
+		return nil, &zendesk.ErrValidation{Type: "RecordInvalid", Description: "Name can't be blank"}
+	}
+	for _, existing := range c.dynamicContent {
+		if strings.EqualFold(existing.Name, item.Name) {
+			return nil, &zendesk.ErrValidation{Type: "RecordInvalid", Description: fmt.Sprintf("Name %s has already been taken", item.Name)}
+		}
+	}
+
+	i := copyItem(item)
+	if i.ID == 0 {
+		i.ID = c.nextID()
+	}
+	c.seen(i.ID)
+	i.Placeholder = "{{dc." + strings.ToLower(strings.Join(strings.Fields(i.Name), "_")) + "}}"
+	i.CreatedAt = c.now()
+	i.UpdatedAt = i.CreatedAt
+	for j := range i.Variants {
+		v := &i.Variants[j]
+		v.ID = c.nextID()
+		v.Active = true
+		v.Default = v.LocaleID == i.DefaultLocaleID
+		v.CreatedAt = i.CreatedAt
+		v.UpdatedAt = i.CreatedAt
+	}
+	if variantByLocale(&i, i.DefaultLocaleID) == nil {
+		return nil, &zendesk.ErrValidation{Type: "RecordInvalid", Description: "Variants must include the default locale"}
+	}
+	c.dynamicContent[i.ID] = &i
+
+	created := copyItem(&i)
+	return &created, nil
+}
+
+// UpdateDynamicContentItem ignores the variants, which are updated one by one.
+func (c *Client) UpdateDynamicContentItem(id int64, item *zendesk.DynamicContentItem) (*zendesk.DynamicContentItem, error) {
+	c.lock()
+	defer c.unlock()
+
+	existing, ok := c.dynamicContent[id]
+	if !ok {
+		return nil, notFound("dynamic content item", id)
+	}
+	if item.DefaultLocaleID != 0 {
+		if variantByLocale(existing, item.DefaultLocaleID) == nil {
+			return nil, &zendesk.ErrValidation{Type: "RecordInvalid", Description: fmt.Sprintf("No variant for locale %d", item.DefaultLocaleID)}
+		}
+		setDefaultVariant(existing, item.DefaultLocaleID)
+	}
+	if item.Name != "" {
+		existing.Name = item.Name
+	}
+	existing.UpdatedAt = c.now()
+
+	i := copyItem(existing)
+	return &i, nil
+}
+
+func (c *Client) DeleteDynamicContentItem(id int64) error {
+	c.lock()
+	defer c.unlock()
+
+	if _, ok := c.dynamicContent[id]; !ok {
+		return notFound("dynamic content item", id)
+	}
+	delete(c.dynamicContent, id)
+	return nil
+}
+
+func (c *Client) ListDynamicContentVariants(itemID int64) ([]zendesk.DynamicContentVariant, error) {
+	c.lock()
+	defer c.unlock()
+
+	item, ok := c.dynamicContent[itemID]
+	if !ok {
+		return nil, notFound("dynamic content item", itemID)
+	}
+	return copyItem(item).Variants, nil
+}
+
+func (c *Client) ShowDynamicContentVariant(itemID, id int64) (*zendesk.DynamicContentVariant, error) {
+	c.lock()
+	defer c.unlock()
+
+	variant, err := c.variant(itemID, id)
+	if err != nil {
+		return nil, err
+	}
+	v := *variant
+	return &v, nil
+}
+
+// CreateDynamicContentVariant allows one variant per locale. A default variant becomes the
+// only one, and its locale the default locale of the item.
+func (c *Client) CreateDynamicContentVariant(itemID int64, variant *zendesk.DynamicContentVariant) (*zendesk.DynamicContentVariant, error) {
+	c.lock()
+	defer c.unlock()
+
+	item, ok := c.dynamicContent[itemID]
+	if !ok {
+		return nil, notFound("dynamic content item", itemID)
+	}
+	if variantByLocale(item, variant.LocaleID) != nil {
+		return nil, &zendesk.ErrValidation{Type: "RecordInvalid", Description: fmt.Sprintf("Locale %d already has a variant", variant.LocaleID)}
+	}
+
+	v := *variant
+	v.ID = c.nextID()
+	v.Active = true
+	v.CreatedAt = c.now()
+	v.UpdatedAt = v.CreatedAt
+	item.Variants = append(item.Variants, v)
+	if v.Default {
+		setDefaultVariant(item, v.LocaleID)
+	}
+	item.UpdatedAt = c.now()
+
+	created := *variantByLocale(item, v.LocaleID)
+	return &created, nil
+}
+
+func (c *Client) UpdateDynamicContentVariant(itemID, id int64, variant *zendesk.DynamicContentVariant) (*zendesk.DynamicContentVariant, error) {
+	c.lock()
+	defer c.unlock()
+
+	existing, err := c.variant(itemID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	update := *variant
+	update.ID = id
+	if err := merge(existing, &update); err != nil {
+		return nil, err
+	}
+	existing.UpdatedAt = c.now()
+	if variant.Default {
+		setDefaultVariant(c.dynamicContent[itemID], existing.LocaleID)
+	}
+
+	v := *existing
+	return &v, nil
+}
+
+// DeleteDynamicContentVariant fails like Zendesk for the default variant.
+func (c *Client) DeleteDynamicContentVariant(itemID, id int64) error {
+	c.lock()
+	defer c.unlock()
+
+	variant, err := c.variant(itemID, id)
+	if err != nil {
+		return err
+	}
+	if variant.Default {
+		return &zendesk.ErrValidation{Type: "RecordInvalid", Description: "The default variant cannot be deleted"}
+	}
+
+	item := c.dynamicContent[itemID]
+	variants := item.Variants[:0]
+	for _, v := range item.Variants {
+		if v.ID != id {
+			variants = append(variants, v)
+		}
+	}
+	item.Variants = variants
+	return nil
+}
+
+func (c *Client) variant(itemID, id int64) (*zendesk.DynamicContentVariant, error) {
+	item, ok := c.dynamicContent[itemID]
+	if !ok {
+		return nil, notFound("dynamic content item", itemID)
+	}
+	for i := range item.Variants {
+		if item.Variants[i].ID == id {
+			return &item.Variants[i], nil
+		}
+	}
+	return nil, notFound("dynamic content variant", id)
+}
+
+func variantByLocale(item *zendesk.DynamicContentItem, localeID int64) *zendesk.DynamicContentVariant {
+	for i := range item.Variants {
+		if item.Variants[i].LocaleID == localeID {
+			return &item.Variants[i]
+		}
+	}
+	return nil
+}
+
+func setDefaultVariant(item *zendesk.DynamicContentItem, localeID int64) {
+	item.DefaultLocaleID = localeID
+	for i := range item.Variants {
+		item.Variants[i].Default = item.Variants[i].LocaleID == localeID
+	}
+}
+
+// copyItem copies the item with its variants.
+func copyItem(item *zendesk.DynamicContentItem) zendesk.DynamicContentItem {
+	i := *item
+	i.Variants = append([]zendesk.DynamicContentVariant{}, item.Variants...)
+	return i
+}
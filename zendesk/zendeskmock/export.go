@@ -0,0 +1,222 @@
+package zendeskmock
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/phil-inc/zendesk/zendesk"
+)
+
+// Exports
+
+// ExportTickets writes the tickets updated since the start point to the sink.
+// Cursors are the unix time of the last exported update.
+func (c *Client) ExportTickets(opts *zendesk.IncrementalExportOptions, sink zendesk.RecordSink) (*zendesk.ExportCheckpoint, error) {
+	return reported("tickets", opts, func(opts *zendesk.IncrementalExportOptions) (*zendesk.ExportCheckpoint, error) {
+		return c.exportTickets(opts, sink)
+	})
+}
+
+func (c *Client) exportTickets(opts *zendesk.IncrementalExportOptions, sink zendesk.RecordSink) (*zendesk.ExportCheckpoint, error) {
+	start, err := exportStart(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	tickets := c.filterTickets(func(t *zendesk.Ticket) bool { return updatedSince(t.UpdatedAt, start) })
+	checkpoint := &zendesk.ExportCheckpoint{Cursor: strconv.FormatInt(start-1, 10)}
+	for i := range tickets {
+		if err := sink.WriteRecord(&tickets[i]); err != nil {
+			return checkpoint, err
+		}
+		checkpoint.Records++
+		advance(checkpoint, tickets[i].UpdatedAt)
+	}
+	return checkpoint, nil
+}
+
+// ExportUsers writes the users updated since the start point to the sink.
+// Cursors are the unix time of the last exported update.
+func (c *Client) ExportUsers(opts *zendesk.IncrementalExportOptions, sink zendesk.RecordSink) (*zendesk.ExportCheckpoint, error) {
+	return reported("users", opts, func(opts *zendesk.IncrementalExportOptions) (*zendesk.ExportCheckpoint, error) {
+		return c.exportUsers(opts, sink)
+	})
+}
+
+func (c *Client) exportUsers(opts *zendesk.IncrementalExportOptions, sink zendesk.RecordSink) (*zendesk.ExportCheckpoint, error) {
+	start, err := exportStart(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	users := c.filterUsers(func(u *zendesk.User) bool { return updatedSince(u.UpdatedAt, start) })
+	checkpoint := &zendesk.ExportCheckpoint{Cursor: strconv.FormatInt(start-1, 10)}
+	for i := range users {
+		if err := sink.WriteRecord(&users[i]); err != nil {
+			return checkpoint, err
+		}
+		checkpoint.Records++
+		advance(checkpoint, users[i].UpdatedAt)
+	}
+	return checkpoint, nil
+}
+
+// ExportOrganizations writes the organizations updated since the start point to the sink.
+// As with Zendesk, cursors are unix times to resume from.
+func (c *Client) ExportOrganizations(opts *zendesk.IncrementalExportOptions, sink zendesk.RecordSink) (*zendesk.ExportCheckpoint, error) {
+	return reported("organizations", opts, func(opts *zendesk.IncrementalExportOptions) (*zendesk.ExportCheckpoint, error) {
+		return c.exportOrganizations(opts, sink)
+	})
+}
+
+func (c *Client) exportOrganizations(opts *zendesk.IncrementalExportOptions, sink zendesk.RecordSink) (*zendesk.ExportCheckpoint, error) {
+	start, err := exportStart(opts)
+	if err != nil {
+		return nil, err
+	}
+	if opts != nil && opts.Cursor != "" {
+		start--
+	}
+
+	c.lock()
+	ids := make([]int64, 0, len(c.orgs))
+	for id, org := range c.orgs {
+		if updatedSince(org.UpdatedAt, start) {
+			ids = append(ids, id)
+		}
+	}
+	orgs := make([]zendesk.Organization, 0, len(ids))
+	for _, id := range sortedIDs(ids) {
+		orgs = append(orgs, *c.orgs[id])
+	}
+	c.unlock()
+
+	checkpoint := &zendesk.ExportCheckpoint{Cursor: strconv.FormatInt(start, 10)}
+	for i := range orgs {
+		if err := sink.WriteRecord(&orgs[i]); err != nil {
+			return checkpoint, err
+		}
+		checkpoint.Records++
+		advance(checkpoint, orgs[i].UpdatedAt)
+	}
+	return checkpoint, nil
+}
+
+func (c *Client) GetOrganizationsIncrementally(unixTime int64) ([]zendesk.Organization, error) {
+	result := make([]zendesk.Organization, 0)
+	_, err := c.ExportOrganizations(&zendesk.IncrementalExportOptions{StartTime: unixTime}, zendesk.SinkFunc(func(record interface{}) error {
+		result = append(result, *record.(*zendesk.Organization))
+		return nil
+	}))
+	return result, err
+}
+
+// ExportSatisfactionRatings writes the ratings created since the start time to the sink.
+// Cursors are the ID of the last exported rating.
+func (c *Client) ExportSatisfactionRatings(opts *zendesk.IncrementalExportOptions, sink zendesk.RecordSink) (*zendesk.ExportCheckpoint, error) {
+	return reported("satisfaction_ratings", opts, func(opts *zendesk.IncrementalExportOptions) (*zendesk.ExportCheckpoint, error) {
+		return c.exportSatisfactionRatings(opts, sink)
+	})
+}
+
+func (c *Client) exportSatisfactionRatings(opts *zendesk.IncrementalExportOptions, sink zendesk.RecordSink) (*zendesk.ExportCheckpoint, error) {
+	if opts == nil {
+		opts = new(zendesk.IncrementalExportOptions)
+	}
+	var after int64
+	if opts.Cursor != "" {
+		cursor, err := strconv.ParseInt(opts.Cursor, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid cursor %q", opts.Cursor)
+		}
+		after = cursor
+	}
+
+	scores, err := c.ListSatisfactionRatings(&zendesk.ListSatisfactionRatingsOptions{StartTime: opts.StartTime})
+	if err != nil {
+		return nil, err
+	}
+
+	checkpoint := &zendesk.ExportCheckpoint{Cursor: opts.Cursor}
+	for i := range scores {
+		if scores[i].ID <= after {
+			continue
+		}
+		if err := sink.WriteRecord(&scores[i]); err != nil {
+			return checkpoint, err
+		}
+		checkpoint.Records++
+		checkpoint.Cursor = strconv.FormatInt(scores[i].ID, 10)
+	}
+	return checkpoint, nil
+}
+
+// reported runs an export and attaches its report to the checkpoint, as a run of a single page.
+// The cursor is loaded from and saved to the Checkpoint of the options like Zendesk exports do.
+func reported(resource string, opts *zendesk.IncrementalExportOptions, export func(*zendesk.IncrementalExportOptions) (*zendesk.ExportCheckpoint, error)) (*zendesk.ExportCheckpoint, error) {
+	stream := resource
+	if opts != nil && opts.Stream != "" {
+		stream = opts.Stream
+	}
+	if opts != nil && opts.Checkpoint != nil && opts.Cursor == "" {
+		o := *opts
+		cursor, err := o.Checkpoint.Load(stream)
+		if err != nil {
+			return new(zendesk.ExportCheckpoint), err
+		}
+		o.Cursor = cursor
+		opts = &o
+	}
+
+	started := time.Now()
+	checkpoint, err := export(opts)
+	if checkpoint == nil {
+		return nil, err
+	}
+	if opts != nil && opts.Checkpoint != nil && checkpoint.Cursor != "" {
+		if saveErr := opts.Checkpoint.Save(stream, checkpoint.Cursor); saveErr != nil && err == nil {
+			err = saveErr
+		}
+	}
+
+	report := &zendesk.RunReport{
+		Resource:  resource,
+		Records:   checkpoint.Records,
+		Pages:     1,
+		StartedAt: started,
+		Duration:  time.Since(started),
+		EndCursor: checkpoint.Cursor,
+	}
+	if opts != nil {
+		report.StartCursor = opts.Cursor
+	}
+	if err != nil {
+		report.Errors = []string{err.Error()}
+	}
+	checkpoint.Report = report
+	return checkpoint, err
+}
+
+func exportStart(opts *zendesk.IncrementalExportOptions) (int64, error) {
+	if opts == nil {
+		return 0, nil
+	}
+	if opts.Cursor != "" {
+		cursor, err := strconv.ParseInt(opts.Cursor, 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid cursor %q", opts.Cursor)
+		}
+		return cursor + 1, nil
+	}
+	return opts.StartTime, nil
+}
+
+func advance(checkpoint *zendesk.ExportCheckpoint, updatedAt *time.Time) {
+	if updatedAt == nil {
+		return
+	}
+	if cursor, _ := strconv.ParseInt(checkpoint.Cursor, 10, 64); updatedAt.Unix() > cursor {
+		checkpoint.Cursor = strconv.FormatInt(updatedAt.Unix(), 10)
+	}
+}
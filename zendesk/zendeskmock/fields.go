@@ -0,0 +1,613 @@
+package zendeskmock
+
+import (
+	"github.com/phil-inc/zendesk/zendesk"
+)
+
+// Ticket fields, forms and triggers
+
+func (c *Client) ListTicketFields() ([]zendesk.TicketField, error) {
+	c.lock()
+	defer c.unlock()
+
+	ids := make([]int64, 0, len(c.fields))
+	for id := range c.fields {
+		ids = append(ids, id)
+	}
+
+	result := make([]zendesk.TicketField, 0, len(ids))
+	for _, id := range sortedIDs(ids) {
+		result = append(result, *c.fields[id])
+	}
+	return result, nil
+}
+
+func (c *Client) ShowTicketField(id int64) (*zendesk.TicketField, error) {
+	c.lock()
+	defer c.unlock()
+
+	field, ok := c.fields[id]
+	if !ok {
+		return nil, notFound("ticket field", id)
+	}
+	f := *field
+	return &f, nil
+}
+
+func (c *Client) CreateTicketField(field *zendesk.TicketField) (*zendesk.TicketField, error) {
+	c.lock()
+	defer c.unlock()
+
+	if field.Type == "" || field.Title == "" {
+		return nil, &zendesk.ErrValidation{Type: "RecordInvalid", Description: "Type and title can't be blank"}
+	}
+
+	f := *field
+	if f.ID == 0 {
+		f.ID = c.nextID()
+	}
+	c.seen(f.ID)
+	f.Active = true
+	f.Removable = true
+	if f.CreatedAt == nil {
+		f.CreatedAt = c.now()
+	}
+	f.UpdatedAt = c.now()
+	f.CustomFieldOptions = c.newFieldOptions(f.CustomFieldOptions)
+	c.fields[f.ID] = &f
+
+	created := f
+	return &created, nil
+}
+
+func (c *Client) UpdateTicketField(id int64, field *zendesk.TicketField) (*zendesk.TicketField, error) {
+	c.lock()
+	defer c.unlock()
+
+	existing, ok := c.fields[id]
+	if !ok {
+		return nil, notFound("ticket field", id)
+	}
+
+	update := *field
+	update.ID = id
+	if len(update.CustomFieldOptions) > 0 {
+		// The options of an update replace the existing ones instead of being merged.
+		existing.CustomFieldOptions = c.newFieldOptions(update.CustomFieldOptions)
+		update.CustomFieldOptions = nil
+	}
+	if err := merge(existing, &update); err != nil {
+		return nil, err
+	}
+	existing.UpdatedAt = c.now()
+
+	f := *existing
+	return &f, nil
+}
+
+func (c *Client) DeleteTicketField(id int64) error {
+	c.lock()
+	defer c.unlock()
+
+	field, ok := c.fields[id]
+	if !ok {
+		return notFound("ticket field", id)
+	}
+	if isSystemField(field) {
+		return &zendesk.ErrValidation{Type: "RecordInvalid", Description: "System ticket fields can't be deleted"}
+	}
+	delete(c.fields, id)
+	return nil
+}
+
+func (c *Client) ListTicketFieldOptions(fieldID int64) ([]zendesk.CustomFieldOption, error) {
+	c.lock()
+	defer c.unlock()
+
+	field, ok := c.fields[fieldID]
+	if !ok {
+		return nil, notFound("ticket field", fieldID)
+	}
+	return append([]zendesk.CustomFieldOption{}, field.CustomFieldOptions...), nil
+}
+
+// CreateOrUpdateTicketFieldOption updates the option with the ID of the given option, if any,
+// and otherwise creates one.
+func (c *Client) CreateOrUpdateTicketFieldOption(fieldID int64, option *zendesk.CustomFieldOption) (*zendesk.CustomFieldOption, error) {
+	c.lock()
+	defer c.unlock()
+
+	field, ok := c.fields[fieldID]
+	if !ok {
+		return nil, notFound("ticket field", fieldID)
+	}
+	if field.Type != zendesk.TaggerType && field.Type != zendesk.MultiSelectType {
+		return nil, &zendesk.ErrValidation{Type: "RecordInvalid", Description: "Only drop-down and multi-select fields have options"}
+	}
+	if option.Name == "" || option.Value == "" {
+		return nil, &zendesk.ErrValidation{Type: "RecordInvalid", Description: "Name and value can't be blank"}
+	}
+
+	for i := range field.CustomFieldOptions {
+		if existing := &field.CustomFieldOptions[i]; option.ID != 0 && existing.ID == option.ID {
+			existing.Name, existing.RawName, existing.Value = option.Name, option.Name, option.Value
+			o := *existing
+			return &o, nil
+		}
+	}
+	created := c.newFieldOptions([]zendesk.CustomFieldOption{*option})[0]
+	field.CustomFieldOptions = append(field.CustomFieldOptions, created)
+	return &created, nil
+}
+
+func (c *Client) DeleteTicketFieldOption(fieldID, optionID int64) error {
+	c.lock()
+	defer c.unlock()
+
+	field, ok := c.fields[fieldID]
+	if !ok {
+		return notFound("ticket field", fieldID)
+	}
+	for i, option := range field.CustomFieldOptions {
+		if option.ID == optionID {
+			field.CustomFieldOptions = append(field.CustomFieldOptions[:i:i], field.CustomFieldOptions[i+1:]...)
+			return nil
+		}
+	}
+	return notFound("ticket field option", optionID)
+}
+
+// newFieldOptions copies the options, giving an ID to those without one.
+func (c *Client) newFieldOptions(options []zendesk.CustomFieldOption) []zendesk.CustomFieldOption {
+	result := make([]zendesk.CustomFieldOption, 0, len(options))
+	for _, option := range options {
+		if option.ID == 0 {
+			option.ID = c.nextID()
+		}
+		c.seen(option.ID)
+		if option.RawName == "" {
+			option.RawName = option.Name
+		}
+		result = append(result, option)
+	}
+	return result
+}
+
+// CachedTicketFields returns the current ticket fields since the in-memory data is never stale.
+func (c *Client) CachedTicketFields() ([]zendesk.TicketField, error) {
+	return c.ListTicketFields()
+}
+
+// CachedTicketForms returns the current ticket forms since the in-memory data is never stale.
+func (c *Client) CachedTicketForms() ([]zendesk.TicketForm, error) {
+	return c.ListTicketForms()
+}
+
+// ValidateTicket checks the custom field values of the ticket against the current ticket fields.
+func (c *Client) ValidateTicket(ticket *zendesk.Ticket) ([]zendesk.ValidationError, error) {
+	fields, err := c.ListTicketFields()
+	if err != nil {
+		return nil, err
+	}
+	return zendesk.ValidateTicketFields(fields, ticket), nil
+}
+
+// FilterWritableFields restricts the custom fields of the ticket to those writable by CurrentUser.
+func (c *Client) FilterWritableFields(ticket *zendesk.Ticket) (*zendesk.Ticket, error) {
+	fields, err := c.ListTicketFields()
+	if err != nil {
+		return nil, err
+	}
+	c.lock()
+	role := c.CurrentUser.Role
+	c.unlock()
+
+	filtered := *ticket
+	filtered.CustomFields = zendesk.CustomFieldsWritableByRole(fields, role, ticket.CustomFields)
+	return &filtered, nil
+}
+
+// InvalidateSchemas does nothing since the ticket fields and forms are not cached.
+func (c *Client) InvalidateSchemas() {}
+
+func (c *Client) ListTicketForms() ([]zendesk.TicketForm, error) {
+	c.lock()
+	defer c.unlock()
+
+	ids := make([]int64, 0, len(c.forms))
+	for id := range c.forms {
+		ids = append(ids, id)
+	}
+
+	result := make([]zendesk.TicketForm, 0, len(ids))
+	for _, id := range sortedIDs(ids) {
+		result = append(result, *c.forms[id])
+	}
+	return result, nil
+}
+
+func (c *Client) ShowTicketForm(id int64) (*zendesk.TicketForm, error) {
+	c.lock()
+	defer c.unlock()
+
+	form, ok := c.forms[id]
+	if !ok {
+		return nil, notFound("ticket form", id)
+	}
+	f := *form
+	return &f, nil
+}
+
+// CreateTicketForm makes the first form of the account its default form.
+func (c *Client) CreateTicketForm(form *zendesk.TicketForm) (*zendesk.TicketForm, error) {
+	c.lock()
+	defer c.unlock()
+
+	if form.Name == "" {
+		return nil, &zendesk.ErrValidation{Type: "RecordInvalid", Description: "Name can't be blank"}
+	}
+	return c.createTicketForm(form), nil
+}
+
+func (c *Client) createTicketForm(form *zendesk.TicketForm) *zendesk.TicketForm {
+	f := *form
+	if f.ID == 0 {
+		f.ID = c.nextID()
+	}
+	c.seen(f.ID)
+	f.Default = zendesk.Bool(len(c.forms) == 0)
+	f.Position = int64(len(c.forms))
+	f.CreatedAt = c.now()
+	f.UpdatedAt = f.CreatedAt
+	c.forms[f.ID] = &f
+
+	created := f
+	return &created
+}
+
+func (c *Client) UpdateTicketForm(id int64, form *zendesk.TicketForm) (*zendesk.TicketForm, error) {
+	c.lock()
+	defer c.unlock()
+
+	existing, ok := c.forms[id]
+	if !ok {
+		return nil, notFound("ticket form", id)
+	}
+
+	update := *form
+	update.ID = id
+	if err := merge(existing, &update); err != nil {
+		return nil, err
+	}
+	existing.UpdatedAt = c.now()
+
+	f := *existing
+	return &f, nil
+}
+
+// DeleteTicketForm fails like Zendesk for the default form.
+func (c *Client) DeleteTicketForm(id int64) error {
+	c.lock()
+	defer c.unlock()
+
+	form, ok := c.forms[id]
+	if !ok {
+		return notFound("ticket form", id)
+	}
+	if form.Default != nil && *form.Default {
+		return &zendesk.ErrValidation{Type: "RecordInvalid", Description: "The default ticket form cannot be deleted"}
+	}
+	delete(c.forms, id)
+	return nil
+}
+
+func (c *Client) ReorderTicketForms(ids []int64) error {
+	c.lock()
+	defer c.unlock()
+
+	for _, id := range ids {
+		if _, ok := c.forms[id]; !ok {
+			return notFound("ticket form", id)
+		}
+	}
+	for i, id := range ids {
+		c.forms[id].Position = int64(i)
+	}
+	return nil
+}
+
+// CloneTicketForm names the copy "Copy of" the original.
+func (c *Client) CloneTicketForm(id int64) (*zendesk.TicketForm, error) {
+	c.lock()
+	defer c.unlock()
+
+	form, ok := c.forms[id]
+	if !ok {
+		return nil, notFound("ticket form", id)
+	}
+	clone := *form
+	clone.ID = 0
+	clone.Name = "Copy of " + form.Name
+	clone.TicketFieldIDs = append([]int64(nil), form.TicketFieldIDs...)
+	clone.RestrictedBrandIDs = append([]int64(nil), form.RestrictedBrandIDs...)
+	return c.createTicketForm(&clone), nil
+}
+
+func (c *Client) listTriggers() []zendesk.Trigger {
+	ids := make([]int64, 0, len(c.triggers))
+	for id := range c.triggers {
+		ids = append(ids, id)
+	}
+
+	result := make([]zendesk.Trigger, 0, len(ids))
+	for _, id := range sortedIDs(ids) {
+		result = append(result, *c.triggers[id])
+	}
+	return result
+}
+
+func (c *Client) ExportProvisioningSpec() (*zendesk.ProvisioningSpec, error) {
+	fields, _ := c.ListTicketFields()
+	forms, _ := c.ListTicketForms()
+
+	c.lock()
+	defer c.unlock()
+	return &zendesk.ProvisioningSpec{TicketFields: fields, TicketForms: forms, Triggers: c.listTriggers()}, nil
+}
+
+// PlanProvisioning matches the spec with the stored resources by title, planning an
+// update for every resource present on both sides.
+func (c *Client) PlanProvisioning(spec *zendesk.ProvisioningSpec, opts *zendesk.ProvisioningOptions) (*zendesk.ProvisioningPlan, error) {
+	c.lock()
+	defer c.unlock()
+	return c.plan(spec, opts), nil
+}
+
+func (c *Client) plan(spec *zendesk.ProvisioningSpec, opts *zendesk.ProvisioningOptions) *zendesk.ProvisioningPlan {
+	plan := &zendesk.ProvisioningPlan{}
+	prune := opts != nil && opts.Prune
+
+	change := func(action zendesk.ProvisioningAction, resource, name string, id int64) {
+		plan.Changes = append(plan.Changes, zendesk.ProvisioningChange{Action: action, Resource: resource, Name: name, ID: id})
+	}
+
+	wanted := make(map[string]bool)
+	for _, field := range spec.TicketFields {
+		wanted[field.Title] = true
+		if existing := c.fieldByTitle(field.Title); existing != nil {
+			change(zendesk.ProvisioningUpdate, zendesk.ProvisioningTicketField, field.Title, existing.ID)
+		} else {
+			change(zendesk.ProvisioningCreate, zendesk.ProvisioningTicketField, field.Title, 0)
+		}
+	}
+	for _, form := range spec.TicketForms {
+		wanted["form:"+form.Name] = true
+		if existing := c.formByName(form.Name); existing != nil {
+			change(zendesk.ProvisioningUpdate, zendesk.ProvisioningTicketForm, form.Name, existing.ID)
+		} else {
+			change(zendesk.ProvisioningCreate, zendesk.ProvisioningTicketForm, form.Name, 0)
+		}
+	}
+	for _, trigger := range spec.Triggers {
+		wanted["trigger:"+trigger.Title] = true
+		if existing := c.triggerByTitle(trigger.Title); existing != nil {
+			change(zendesk.ProvisioningUpdate, zendesk.ProvisioningTrigger, trigger.Title, existing.ID)
+		} else {
+			change(zendesk.ProvisioningCreate, zendesk.ProvisioningTrigger, trigger.Title, 0)
+		}
+	}
+
+	if prune {
+		for _, field := range c.fields {
+			if !wanted[field.Title] {
+				change(zendesk.ProvisioningDelete, zendesk.ProvisioningTicketField, field.Title, field.ID)
+			}
+		}
+		for _, form := range c.forms {
+			if !wanted["form:"+form.Name] {
+				change(zendesk.ProvisioningDelete, zendesk.ProvisioningTicketForm, form.Name, form.ID)
+			}
+		}
+		for _, trigger := range c.triggers {
+			if !wanted["trigger:"+trigger.Title] {
+				change(zendesk.ProvisioningDelete, zendesk.ProvisioningTrigger, trigger.Title, trigger.ID)
+			}
+		}
+	}
+
+	return plan
+}
+
+// ApplyProvisioningSpec stores the resources of the spec, replacing the ones with the same title.
+// Form field IDs are stored as given.
+func (c *Client) ApplyProvisioningSpec(spec *zendesk.ProvisioningSpec, opts *zendesk.ProvisioningOptions) (*zendesk.ProvisioningPlan, error) {
+	c.lock()
+	defer c.unlock()
+
+	plan := c.plan(spec, opts)
+	if opts != nil && opts.Limits != nil {
+		if warnings := zendesk.CheckLimits(opts.Limits, c.usage(), growth(plan)); len(warnings) > 0 {
+			return &zendesk.ProvisioningPlan{}, &zendesk.ErrLimitExceeded{Warnings: warnings}
+		}
+	}
+	for i, change := range plan.Changes {
+		id := change.ID
+		if change.Action == zendesk.ProvisioningCreate {
+			id = c.nextID()
+			plan.Changes[i].ID = id
+		}
+
+		switch change.Resource {
+		case zendesk.ProvisioningTicketField:
+			if change.Action == zendesk.ProvisioningDelete {
+				delete(c.fields, id)
+				continue
+			}
+			for _, field := range spec.TicketFields {
+				if field.Title == change.Name {
+					f := field
+					f.ID = id
+					c.fields[id] = &f
+				}
+			}
+		case zendesk.ProvisioningTicketForm:
+			if change.Action == zendesk.ProvisioningDelete {
+				delete(c.forms, id)
+				continue
+			}
+			for _, form := range spec.TicketForms {
+				if form.Name == change.Name {
+					f := form
+					f.ID = id
+					c.forms[id] = &f
+				}
+			}
+		case zendesk.ProvisioningTrigger:
+			if change.Action == zendesk.ProvisioningDelete {
+				delete(c.triggers, id)
+				continue
+			}
+			for _, trigger := range spec.Triggers {
+				if trigger.Title == change.Name {
+					t := trigger
+					t.ID = id
+					c.triggers[id] = &t
+				}
+			}
+		}
+	}
+
+	return plan, nil
+}
+
+func (c *Client) fieldByTitle(title string) *zendesk.TicketField {
+	for _, field := range c.fields {
+		if field.Title == title {
+			return field
+		}
+	}
+	return nil
+}
+
+func (c *Client) formByName(name string) *zendesk.TicketForm {
+	for _, form := range c.forms {
+		if form.Name == name {
+			return form
+		}
+	}
+	return nil
+}
+
+func (c *Client) triggerByTitle(title string) *zendesk.Trigger {
+	for _, trigger := range c.triggers {
+		if trigger.Title == title {
+			return trigger
+		}
+	}
+	return nil
+}
+
+func (c *Client) GetAccountLimits() (*zendesk.AccountLimits, error) {
+	c.lock()
+	defer c.unlock()
+
+	limits := c.Limits
+	return &limits, nil
+}
+
+func (c *Client) Capabilities() (*zendesk.Capabilities, error) {
+	c.lock()
+	defer c.unlock()
+
+	capabilities := c.Features
+	return &capabilities, nil
+}
+
+func (c *Client) ShowAccountSettings() (*zendesk.AccountSettings, error) {
+	c.lock()
+	defer c.unlock()
+	return c.accountSettings()
+}
+
+// UpdateAccountSettings merges the settings into Settings, and the custom statuses setting
+// into Features.
+func (c *Client) UpdateAccountSettings(settings *zendesk.AccountSettings) (*zendesk.AccountSettings, error) {
+	c.lock()
+	defer c.unlock()
+
+	if err := merge(&c.Settings, settings); err != nil {
+		return nil, err
+	}
+	if settings.Tickets != nil && settings.Tickets.CustomStatusesEnabled != nil {
+		c.Features.CustomStatuses = *settings.Tickets.CustomStatusesEnabled
+	}
+	return c.accountSettings()
+}
+
+// accountSettings deep copies Settings, adding the custom statuses setting.
+func (c *Client) accountSettings() (*zendesk.AccountSettings, error) {
+	settings := new(zendesk.AccountSettings)
+	if err := merge(settings, &c.Settings); err != nil {
+		return nil, err
+	}
+	if settings.Tickets == nil {
+		settings.Tickets = new(zendesk.TicketSettings)
+	}
+	settings.Tickets.CustomStatusesEnabled = zendesk.Bool(c.Features.CustomStatuses)
+	return settings, nil
+}
+
+// GetAccountUsage counts the stored records. There are no webhooks in the mock.
+func (c *Client) GetAccountUsage() (*zendesk.AccountUsage, error) {
+	c.lock()
+	defer c.unlock()
+	return c.usage(), nil
+}
+
+func (c *Client) usage() *zendesk.AccountUsage {
+	usage := &zendesk.AccountUsage{TicketForms: len(c.forms), Triggers: len(c.triggers)}
+	for _, field := range c.fields {
+		if !isSystemField(field) {
+			usage.TicketFields++
+		}
+	}
+	for _, user := range c.users {
+		if user.Role == "agent" || user.Role == "admin" {
+			usage.Agents++
+		}
+	}
+	return usage
+}
+
+// growth returns the number of resources a plan adds, net of its deletions.
+func growth(plan *zendesk.ProvisioningPlan) *zendesk.AccountUsage {
+	growth := new(zendesk.AccountUsage)
+	for _, change := range plan.Changes {
+		delta := 0
+		switch change.Action {
+		case zendesk.ProvisioningCreate:
+			delta = 1
+		case zendesk.ProvisioningDelete:
+			delta = -1
+		}
+
+		switch change.Resource {
+		case zendesk.ProvisioningTicketField:
+			growth.TicketFields += delta
+		case zendesk.ProvisioningTicketForm:
+			growth.TicketForms += delta
+		case zendesk.ProvisioningTrigger:
+			growth.Triggers += delta
+		}
+	}
+	return growth
+}
+
+func isSystemField(field *zendesk.TicketField) bool {
+	switch field.Type {
+	case zendesk.SubjectType, zendesk.DescriptionType, zendesk.StatusType, zendesk.TicketType,
+		zendesk.PriorityType, zendesk.GroupType, zendesk.AssigneeType:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,281 @@
+package zendeskmock
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+
+	"github.com/phil-inc/zendesk/zendesk"
+)
+
+// Fixtures holds records to load into a Client. It is usually decoded from a JSON
+// file using the same keys as the Zendesk API, for instance:
+//
+//	{
+//	  "users": [{"id": 1, "name": "Jane", "email": "jane@example.com"}],
+//	  "tickets": [{"id": 10, "subject": "Help", "requester_id": 1}],
+//	  "comments": {"10": [{"id": 100, "body": "Help!", "author_id": 1}]}
+//	}
+type Fixtures struct {
+	Tickets                 []zendesk.Ticket                   `json:"tickets,omitempty"`
+	Comments                map[int64][]zendesk.TicketComment  `json:"comments,omitempty"`
+	Audits                  []zendesk.TicketAudit              `json:"audits,omitempty"`
+	Users                   []zendesk.User                     `json:"users,omitempty"`
+	Identities              []zendesk.UserIdentity             `json:"identities,omitempty"`
+	Sessions                []zendesk.Session                  `json:"sessions,omitempty"`
+	Organizations           []zendesk.Organization             `json:"organizations,omitempty"`
+	Groups                  []zendesk.Group                    `json:"groups,omitempty"`
+	Brands                  []zendesk.Brand                    `json:"brands,omitempty"`
+	SharingAgreements       []zendesk.SharingAgreement         `json:"sharing_agreements,omitempty"`
+	CustomStatuses          []zendesk.CustomStatus             `json:"custom_statuses,omitempty"`
+	RoutingAttributes       []zendesk.RoutingAttribute         `json:"routing_attributes,omitempty"`
+	CustomRoles             []zendesk.CustomRole               `json:"custom_roles,omitempty"`
+	OrganizationMemberships []zendesk.OrganizationMembership   `json:"organization_memberships,omitempty"`
+	Locales                 []zendesk.Locale                   `json:"locales,omitempty"`
+	TicketFields            []zendesk.TicketField              `json:"ticket_fields,omitempty"`
+	TicketForms             []zendesk.TicketForm               `json:"ticket_forms,omitempty"`
+	UserFields              []zendesk.FieldDefinition          `json:"user_fields,omitempty"`
+	OrganizationFields      []zendesk.FieldDefinition          `json:"organization_fields,omitempty"`
+	Triggers                []zendesk.Trigger                  `json:"triggers,omitempty"`
+	DynamicContentItems     []zendesk.DynamicContentItem       `json:"dynamic_content_items,omitempty"`
+	TicketMetrics           []zendesk.TicketMetric             `json:"ticket_metrics,omitempty"`
+	TicketMetricEvents      []zendesk.TicketMetricEvent        `json:"ticket_metric_events,omitempty"`
+	TicketSkips             []zendesk.TicketSkip               `json:"skips,omitempty"`
+	SatisfactionRatings     []zendesk.Score                    `json:"satisfaction_ratings,omitempty"`
+	SatisfactionReasons     []zendesk.SatisfactionReason       `json:"reasons,omitempty"`
+	CallLegs                []zendesk.CallLeg                  `json:"legs,omitempty"`
+	Calls                   []zendesk.Call                     `json:"calls,omitempty"`
+	PhoneNumbers            []zendesk.PhoneNumber              `json:"phone_numbers,omitempty"`
+	Lines                   []zendesk.Line                     `json:"lines,omitempty"`
+	Greetings               []zendesk.Greeting                 `json:"greetings,omitempty"`
+	Availabilities          map[int64]zendesk.Availability     `json:"availabilities,omitempty"`
+	Categories              []zendesk.Category                 `json:"categories,omitempty"`
+	Sections                []zendesk.Section                  `json:"sections,omitempty"`
+	Articles                []zendesk.Article                  `json:"articles,omitempty"`
+	ArticleComments         map[int64][]zendesk.ArticleComment `json:"article_comments,omitempty"`
+}
+
+// Load adds the fixtures to the client, replacing the records with the same IDs.
+// Records without an ID are given one.
+func (c *Client) Load(f *Fixtures) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	id := func(id int64) int64 {
+		if id == 0 {
+			return c.nextID()
+		}
+		c.seen(id)
+		return id
+	}
+
+	for _, t := range f.Tickets {
+		t := t
+		t.ID = id(t.ID)
+		c.tickets[t.ID] = &t
+	}
+	for ticketID, comments := range f.Comments {
+		for _, cm := range comments {
+			cm.ID = id(cm.ID)
+			c.comments[ticketID] = append(c.comments[ticketID], cm)
+		}
+	}
+	for _, a := range f.Audits {
+		a := a
+		a.ID = id(a.ID)
+		c.audits[a.ID] = &a
+	}
+	for _, u := range f.Users {
+		u := u
+		u.ID = id(u.ID)
+		c.users[u.ID] = &u
+	}
+	for _, i := range f.Identities {
+		i := i
+		i.ID = id(i.ID)
+		c.identities[i.ID] = &i
+	}
+	for _, s := range f.Sessions {
+		s := s
+		s.ID = id(s.ID)
+		c.sessions[s.ID] = &s
+	}
+	for _, o := range f.Organizations {
+		o := o
+		o.ID = id(o.ID)
+		c.orgs[o.ID] = &o
+	}
+	for _, g := range f.Groups {
+		g := g
+		g.ID = id(g.ID)
+		c.groups[g.ID] = &g
+	}
+	for _, b := range f.Brands {
+		b := b
+		b.ID = id(b.ID)
+		c.brands[b.ID] = &b
+	}
+	for _, a := range f.SharingAgreements {
+		a := a
+		a.ID = id(a.ID)
+		c.agreements[a.ID] = &a
+	}
+	for _, s := range f.CustomStatuses {
+		s := s
+		s.ID = id(s.ID)
+		c.statuses[s.ID] = &s
+	}
+	for _, a := range f.RoutingAttributes {
+		a := a
+		c.routing[a.ID] = &a
+	}
+	for _, r := range f.CustomRoles {
+		r := r
+		r.ID = id(r.ID)
+		c.roles[r.ID] = &r
+	}
+	for _, m := range f.OrganizationMemberships {
+		m := m
+		m.ID = id(m.ID)
+		c.memberships[m.ID] = &m
+	}
+	for _, l := range f.Locales {
+		l := l
+		l.ID = id(l.ID)
+		c.locales[l.ID] = &l
+	}
+	for _, tf := range f.TicketFields {
+		tf := tf
+		tf.ID = id(tf.ID)
+		c.fields[tf.ID] = &tf
+	}
+	for _, tf := range f.TicketForms {
+		tf := tf
+		tf.ID = id(tf.ID)
+		c.forms[tf.ID] = &tf
+	}
+	for _, uf := range f.UserFields {
+		uf := uf
+		uf.ID = id(uf.ID)
+		c.userFields[uf.ID] = &uf
+	}
+	for _, of := range f.OrganizationFields {
+		of := of
+		of.ID = id(of.ID)
+		c.orgFields[of.ID] = &of
+	}
+	for _, t := range f.Triggers {
+		t := t
+		t.ID = id(t.ID)
+		c.triggers[t.ID] = &t
+	}
+	for _, item := range f.DynamicContentItems {
+		item := item
+		item.ID = id(item.ID)
+		item.Variants = append([]zendesk.DynamicContentVariant(nil), item.Variants...)
+		for i := range item.Variants {
+			item.Variants[i].ID = id(item.Variants[i].ID)
+		}
+		c.dynamicContent[item.ID] = &item
+	}
+	for _, m := range f.TicketMetrics {
+		m := m
+		m.ID = id(m.ID)
+		c.metrics[m.ID] = &m
+	}
+	for _, e := range f.TicketMetricEvents {
+		e.ID = id(e.ID)
+		c.metricEvents = append(c.metricEvents, e)
+	}
+	for _, s := range f.TicketSkips {
+		s := s
+		s.ID = id(s.ID)
+		c.skips[s.ID] = &s
+	}
+	for _, s := range f.SatisfactionRatings {
+		s := s
+		s.ID = id(s.ID)
+		c.scores[s.ID] = &s
+	}
+	for _, r := range f.SatisfactionReasons {
+		r := r
+		r.ID = id(r.ID)
+		c.reasons[r.ID] = &r
+	}
+	for _, l := range f.CallLegs {
+		l := l
+		l.ID = int(id(int64(l.ID)))
+		c.callLegs[int64(l.ID)] = &l
+	}
+	for _, call := range f.Calls {
+		call := call
+		call.ID = int(id(int64(call.ID)))
+		c.calls[int64(call.ID)] = &call
+	}
+	for _, n := range f.PhoneNumbers {
+		n := n
+		n.ID = id(n.ID)
+		c.phoneNumbers[n.ID] = &n
+	}
+	for _, l := range f.Lines {
+		l := l
+		l.ID = id(l.ID)
+		c.lines[l.ID] = &l
+	}
+	for _, g := range f.Greetings {
+		g := g
+		g.ID = id(g.ID)
+		c.greetings[g.ID] = &g
+	}
+	for agentID, a := range f.Availabilities {
+		c.availabilities[agentID] = a
+	}
+	for _, cat := range f.Categories {
+		cat := cat
+		cat.ID = id(cat.ID)
+		cat.Locale = defaultLocale(cat.Locale)
+		c.categories[cat.ID] = &cat
+	}
+	for _, s := range f.Sections {
+		s := s
+		s.ID = id(s.ID)
+		s.Locale = defaultLocale(s.Locale)
+		c.sections[s.ID] = &s
+	}
+	for _, a := range f.Articles {
+		a := a
+		a.ID = id(a.ID)
+		a.Locale = defaultLocale(a.Locale)
+		c.articles[a.ID] = &a
+		c.translations[a.ID] = []zendesk.Translation{{ID: c.nextID(), SourceID: a.ID, SourceType: "Article", Locale: a.Locale, Title: a.Title, Body: a.Body, CreatedAt: a.CreatedAt, UpdatedAt: a.UpdatedAt}}
+	}
+	for articleID, comments := range f.ArticleComments {
+		for _, cm := range comments {
+			cm.ID = id(cm.ID)
+			cm.SourceID = articleID
+			cm.SourceType = "Article"
+			c.articleComments[articleID] = append(c.articleComments[articleID], cm)
+		}
+	}
+}
+
+// LoadJSON decodes fixtures from r and loads them into the client.
+func (c *Client) LoadJSON(r io.Reader) error {
+	f := new(Fixtures)
+	if err := json.NewDecoder(r).Decode(f); err != nil {
+		return err
+	}
+	c.Load(f)
+	return nil
+}
+
+// LoadFile loads the fixtures of a JSON file into the client.
+func (c *Client) LoadFile(path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	return c.LoadJSON(file)
+}
@@ -0,0 +1,524 @@
+package zendeskmock
+
+import (
+	"io"
+	"io/ioutil"
+	"mime"
+	"path/filepath"
+	"strings"
+
+	"github.com/phil-inc/zendesk/zendesk"
+)
+
+// Help Center
+
+func (c *Client) ListCategories() ([]zendesk.Category, error) {
+	c.lock()
+	defer c.unlock()
+
+	ids := make([]int64, 0, len(c.categories))
+	for id := range c.categories {
+		ids = append(ids, id)
+	}
+
+	result := make([]zendesk.Category, 0, len(ids))
+	for _, id := range sortedIDs(ids) {
+		result = append(result, *c.categories[id])
+	}
+	return result, nil
+}
+
+func (c *Client) ShowCategory(id int64) (*zendesk.Category, error) {
+	c.lock()
+	defer c.unlock()
+
+	category, ok := c.categories[id]
+	if !ok {
+		return nil, notFound("category", id)
+	}
+	shown := *category
+	return &shown, nil
+}
+
+func (c *Client) CreateCategory(category *zendesk.Category) (*zendesk.Category, error) {
+	c.lock()
+	defer c.unlock()
+
+	if category.Name == "" {
+		return nil, &zendesk.ErrValidation{Type: "RecordInvalid", Description: "name is required"}
+	}
+	created := *category
+	created.ID = c.nextID()
+	created.Locale = defaultLocale(created.Locale)
+	created.SourceLocale = created.Locale
+	created.CreatedAt = c.now()
+	created.UpdatedAt = created.CreatedAt
+	c.categories[created.ID] = &created
+
+	result := created
+	return &result, nil
+}
+
+func (c *Client) UpdateCategory(id int64, category *zendesk.Category) (*zendesk.Category, error) {
+	c.lock()
+	defer c.unlock()
+
+	existing, ok := c.categories[id]
+	if !ok {
+		return nil, notFound("category", id)
+	}
+	update := *category
+	update.ID = id
+	if err := merge(existing, &update); err != nil {
+		return nil, err
+	}
+	existing.UpdatedAt = c.now()
+
+	result := *existing
+	return &result, nil
+}
+
+// DeleteCategory deletes the category along with its sections and their articles.
+func (c *Client) DeleteCategory(id int64) error {
+	c.lock()
+	defer c.unlock()
+
+	if _, ok := c.categories[id]; !ok {
+		return notFound("category", id)
+	}
+	for sectionID, section := range c.sections {
+		if section.CategoryID == id {
+			c.deleteSection(sectionID)
+		}
+	}
+	delete(c.categories, id)
+	return nil
+}
+
+func (c *Client) ListSections(categoryID int64) ([]zendesk.Section, error) {
+	c.lock()
+	defer c.unlock()
+
+	if _, ok := c.categories[categoryID]; categoryID != 0 && !ok {
+		return nil, notFound("category", categoryID)
+	}
+
+	ids := make([]int64, 0)
+	for id, section := range c.sections {
+		if categoryID == 0 || section.CategoryID == categoryID {
+			ids = append(ids, id)
+		}
+	}
+
+	result := make([]zendesk.Section, 0, len(ids))
+	for _, id := range sortedIDs(ids) {
+		result = append(result, *c.sections[id])
+	}
+	return result, nil
+}
+
+func (c *Client) ShowSection(id int64) (*zendesk.Section, error) {
+	c.lock()
+	defer c.unlock()
+
+	section, ok := c.sections[id]
+	if !ok {
+		return nil, notFound("section", id)
+	}
+	shown := *section
+	return &shown, nil
+}
+
+func (c *Client) CreateSection(categoryID int64, section *zendesk.Section) (*zendesk.Section, error) {
+	c.lock()
+	defer c.unlock()
+
+	if _, ok := c.categories[categoryID]; !ok {
+		return nil, notFound("category", categoryID)
+	}
+	if section.Name == "" {
+		return nil, &zendesk.ErrValidation{Type: "RecordInvalid", Description: "name is required"}
+	}
+	created := *section
+	created.ID = c.nextID()
+	created.CategoryID = categoryID
+	created.Locale = defaultLocale(created.Locale)
+	created.SourceLocale = created.Locale
+	created.CreatedAt = c.now()
+	created.UpdatedAt = created.CreatedAt
+	c.sections[created.ID] = &created
+
+	result := created
+	return &result, nil
+}
+
+func (c *Client) UpdateSection(id int64, section *zendesk.Section) (*zendesk.Section, error) {
+	c.lock()
+	defer c.unlock()
+
+	existing, ok := c.sections[id]
+	if !ok {
+		return nil, notFound("section", id)
+	}
+	if _, ok := c.categories[section.CategoryID]; section.CategoryID != 0 && !ok {
+		return nil, notFound("category", section.CategoryID)
+	}
+	update := *section
+	update.ID = id
+	if err := merge(existing, &update); err != nil {
+		return nil, err
+	}
+	existing.UpdatedAt = c.now()
+
+	result := *existing
+	return &result, nil
+}
+
+// DeleteSection deletes the section along with its articles.
+func (c *Client) DeleteSection(id int64) error {
+	c.lock()
+	defer c.unlock()
+
+	if _, ok := c.sections[id]; !ok {
+		return notFound("section", id)
+	}
+	c.deleteSection(id)
+	return nil
+}
+
+func (c *Client) deleteSection(id int64) {
+	for articleID, article := range c.articles {
+		if article.SectionID == id {
+			c.deleteArticle(articleID)
+		}
+	}
+	delete(c.sections, id)
+}
+
+func (c *Client) ListArticles(sectionID int64) ([]zendesk.Article, error) {
+	c.lock()
+	defer c.unlock()
+
+	if _, ok := c.sections[sectionID]; sectionID != 0 && !ok {
+		return nil, notFound("section", sectionID)
+	}
+	return c.filterArticles(func(a *zendesk.Article) bool {
+		return sectionID == 0 || a.SectionID == sectionID
+	}), nil
+}
+
+func (c *Client) ShowArticle(id int64) (*zendesk.Article, error) {
+	c.lock()
+	defer c.unlock()
+
+	article, ok := c.articles[id]
+	if !ok {
+		return nil, notFound("article", id)
+	}
+	shown := *article
+	return &shown, nil
+}
+
+// CreateArticle creates the article along with its translation in the locale of the article.
+func (c *Client) CreateArticle(sectionID int64, article *zendesk.Article) (*zendesk.Article, error) {
+	c.lock()
+	defer c.unlock()
+
+	if _, ok := c.sections[sectionID]; !ok {
+		return nil, notFound("section", sectionID)
+	}
+	if article.Title == "" {
+		return nil, &zendesk.ErrValidation{Type: "RecordInvalid", Description: "title is required"}
+	}
+	created := *article
+	created.ID = c.nextID()
+	created.SectionID = sectionID
+	created.Locale = defaultLocale(created.Locale)
+	created.SourceLocale = created.Locale
+	created.CreatedAt = c.now()
+	created.UpdatedAt = created.CreatedAt
+	created.EditedAt = created.CreatedAt
+	c.articles[created.ID] = &created
+	c.translations[created.ID] = []zendesk.Translation{{
+		ID:         c.nextID(),
+		SourceID:   created.ID,
+		SourceType: "Article",
+		Locale:     created.Locale,
+		Title:      created.Title,
+		Body:       created.Body,
+		Draft:      created.Draft,
+		CreatedAt:  created.CreatedAt,
+		UpdatedAt:  created.CreatedAt,
+	}}
+
+	result := created
+	return &result, nil
+}
+
+// UpdateArticle updates the metadata of the article. As with Zendesk, its title and body
+// are left unchanged.
+func (c *Client) UpdateArticle(id int64, article *zendesk.Article) (*zendesk.Article, error) {
+	c.lock()
+	defer c.unlock()
+
+	existing, ok := c.articles[id]
+	if !ok {
+		return nil, notFound("article", id)
+	}
+	if _, ok := c.sections[article.SectionID]; article.SectionID != 0 && !ok {
+		return nil, notFound("section", article.SectionID)
+	}
+	update := *article
+	update.ID = id
+	update.Title = ""
+	update.Body = ""
+	if err := merge(existing, &update); err != nil {
+		return nil, err
+	}
+	existing.UpdatedAt = c.now()
+
+	result := *existing
+	return &result, nil
+}
+
+func (c *Client) DeleteArticle(id int64) error {
+	c.lock()
+	defer c.unlock()
+
+	if _, ok := c.articles[id]; !ok {
+		return notFound("article", id)
+	}
+	c.deleteArticle(id)
+	return nil
+}
+
+func (c *Client) deleteArticle(id int64) {
+	delete(c.articles, id)
+	delete(c.translations, id)
+	delete(c.articleFiles, id)
+	delete(c.votes, id)
+	delete(c.articleComments, id)
+}
+
+// SearchArticles returns the articles whose title, body or labels contain every word of
+// the query, case insensitively.
+func (c *Client) SearchArticles(query, locale string) ([]zendesk.Article, error) {
+	c.lock()
+	defer c.unlock()
+
+	words := strings.Fields(strings.ToLower(query))
+	return c.filterArticles(func(a *zendesk.Article) bool {
+		if locale != "" && a.Locale != locale {
+			return false
+		}
+		text := strings.ToLower(a.Title + " " + a.Body + " " + strings.Join(a.LabelNames, " "))
+		for _, word := range words {
+			if !strings.Contains(text, word) {
+				return false
+			}
+		}
+		return true
+	}), nil
+}
+
+func (c *Client) filterArticles(keep func(*zendesk.Article) bool) []zendesk.Article {
+	ids := make([]int64, 0)
+	for id, article := range c.articles {
+		if keep(article) {
+			ids = append(ids, id)
+		}
+	}
+
+	result := make([]zendesk.Article, 0, len(ids))
+	for _, id := range sortedIDs(ids) {
+		result = append(result, *c.articles[id])
+	}
+	return result
+}
+
+func (c *Client) ListArticleTranslations(articleID int64) ([]zendesk.Translation, error) {
+	c.lock()
+	defer c.unlock()
+
+	if _, ok := c.articles[articleID]; !ok {
+		return nil, notFound("article", articleID)
+	}
+	return append([]zendesk.Translation{}, c.translations[articleID]...), nil
+}
+
+func (c *Client) ShowArticleTranslation(articleID int64, locale string) (*zendesk.Translation, error) {
+	c.lock()
+	defer c.unlock()
+
+	translation, err := c.translation(articleID, locale)
+	if err != nil {
+		return nil, err
+	}
+	shown := *translation
+	return &shown, nil
+}
+
+func (c *Client) CreateArticleTranslation(articleID int64, translation *zendesk.Translation) (*zendesk.Translation, error) {
+	c.lock()
+	defer c.unlock()
+
+	if _, ok := c.articles[articleID]; !ok {
+		return nil, notFound("article", articleID)
+	}
+	if translation.Locale == "" || translation.Title == "" {
+		return nil, &zendesk.ErrValidation{Type: "RecordInvalid", Description: "locale and title are required"}
+	}
+	if _, err := c.translation(articleID, translation.Locale); err == nil {
+		return nil, &zendesk.ErrValidation{Type: "RecordInvalid", Description: "locale " + translation.Locale + " is already taken"}
+	}
+
+	created := *translation
+	created.ID = c.nextID()
+	created.SourceID = articleID
+	created.SourceType = "Article"
+	created.CreatedAt = c.now()
+	created.UpdatedAt = created.CreatedAt
+	c.translations[articleID] = append(c.translations[articleID], created)
+	return &created, nil
+}
+
+// UpdateArticleTranslation updates the translation, and the title and body of the article
+// when it is the translation in the locale of the article.
+func (c *Client) UpdateArticleTranslation(articleID int64, locale string, translation *zendesk.Translation) (*zendesk.Translation, error) {
+	c.lock()
+	defer c.unlock()
+
+	existing, err := c.translation(articleID, locale)
+	if err != nil {
+		return nil, err
+	}
+	update := *translation
+	update.ID = existing.ID
+	update.Locale = locale
+	if err := merge(existing, &update); err != nil {
+		return nil, err
+	}
+	existing.UpdatedAt = c.now()
+
+	if article := c.articles[articleID]; article.Locale == locale {
+		article.Title = existing.Title
+		article.Body = existing.Body
+		article.EditedAt = existing.UpdatedAt
+		article.UpdatedAt = existing.UpdatedAt
+	}
+
+	result := *existing
+	return &result, nil
+}
+
+func (c *Client) translation(articleID int64, locale string) (*zendesk.Translation, error) {
+	if _, ok := c.articles[articleID]; !ok {
+		return nil, notFound("article", articleID)
+	}
+	translations := c.translations[articleID]
+	for i := range translations {
+		if translations[i].Locale == locale {
+			return &translations[i], nil
+		}
+	}
+	return nil, notFound("translation", locale)
+}
+
+func (c *Client) ListArticleAttachments(articleID int64) ([]zendesk.ArticleAttachment, error) {
+	c.lock()
+	defer c.unlock()
+
+	if _, ok := c.articles[articleID]; !ok {
+		return nil, notFound("article", articleID)
+	}
+	return append([]zendesk.ArticleAttachment{}, c.articleFiles[articleID]...), nil
+}
+
+// CreateArticleAttachment records the attachment, with the size of the content and a
+// content type guessed from the file name. The content itself is not kept.
+func (c *Client) CreateArticleAttachment(articleID int64, filename string, content io.Reader, inline bool) (*zendesk.ArticleAttachment, error) {
+	size, err := io.Copy(ioutil.Discard, content)
+	if err != nil {
+		return nil, err
+	}
+
+	c.lock()
+	defer c.unlock()
+
+	if _, ok := c.articles[articleID]; !ok {
+		return nil, notFound("article", articleID)
+	}
+	contentType := mime.TypeByExtension(filepath.Ext(filename))
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+	attachment := zendesk.ArticleAttachment{
+		ID:          c.nextID(),
+		ArticleID:   articleID,
+		FileName:    filename,
+		ContentType: contentType,
+		Size:        size,
+		Inline:      inline,
+		CreatedAt:   c.now(),
+	}
+	attachment.UpdatedAt = attachment.CreatedAt
+	c.articleFiles[articleID] = append(c.articleFiles[articleID], attachment)
+	return &attachment, nil
+}
+
+func (c *Client) VoteArticleUp(articleID int64) (*zendesk.Vote, error) {
+	c.lock()
+	defer c.unlock()
+	return c.vote(articleID, 1)
+}
+
+func (c *Client) VoteArticleDown(articleID int64) (*zendesk.Vote, error) {
+	c.lock()
+	defer c.unlock()
+	return c.vote(articleID, -1)
+}
+
+// vote records the vote of the current user, replacing their previous one, and updates
+// the vote sum and count of the article.
+func (c *Client) vote(articleID, value int64) (*zendesk.Vote, error) {
+	article, ok := c.articles[articleID]
+	if !ok {
+		return nil, notFound("article", articleID)
+	}
+
+	vote := zendesk.Vote{ID: c.nextID(), UserID: c.CurrentUser.ID, Value: value, ItemID: articleID, ItemType: "Article", CreatedAt: c.now()}
+	vote.UpdatedAt = vote.CreatedAt
+	votes := make([]zendesk.Vote, 0, len(c.votes[articleID])+1)
+	for _, v := range c.votes[articleID] {
+		if v.UserID != vote.UserID {
+			votes = append(votes, v)
+		}
+	}
+	votes = append(votes, vote)
+	c.votes[articleID] = votes
+
+	article.VoteSum, article.VoteCount = 0, int64(len(votes))
+	for _, v := range votes {
+		article.VoteSum += v.Value
+	}
+	return &vote, nil
+}
+
+func (c *Client) ListArticleComments(articleID int64) ([]zendesk.ArticleComment, error) {
+	c.lock()
+	defer c.unlock()
+
+	if _, ok := c.articles[articleID]; !ok {
+		return nil, notFound("article", articleID)
+	}
+	return append([]zendesk.ArticleComment{}, c.articleComments[articleID]...), nil
+}
+
+// defaultLocale returns the locale of a Help Center record, the default locale of the
+// account when none is given.
+func defaultLocale(locale string) string {
+	if locale == "" {
+		return "en-us"
+	}
+	return locale
+}
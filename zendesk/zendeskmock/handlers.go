@@ -0,0 +1,73 @@
+package zendeskmock
+
+import (
+	"github.com/phil-inc/zendesk/zendesk"
+)
+
+// Batch handlers. Records are passed by batches of handlerBatchSize.
+
+const handlerBatchSize = 100
+
+func (c *Client) GetTicketsIncrementallyWithHandler(unixTime int64, fn func([]zendesk.Ticket) error) error {
+	tickets, err := c.GetTicketsIncrementally(unixTime)
+	if err != nil {
+		return err
+	}
+	for start := 0; start < len(tickets); start += handlerBatchSize {
+		if err := fn(tickets[start:batchEnd(start, len(tickets))]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *Client) GetUsersIncrementallyWithHandler(unixTime int64, fn func([]zendesk.User) error) error {
+	users, err := c.GetUsersIncrementally(unixTime)
+	if err != nil {
+		return err
+	}
+	for start := 0; start < len(users); start += handlerBatchSize {
+		if err := fn(users[start:batchEnd(start, len(users))]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *Client) GetTicketMetricsWithHandler(ticketIDs []int64, fn func([]zendesk.TicketMetric) error) error {
+	for start := 0; start < len(ticketIDs); start += handlerBatchSize {
+		metrics, err := c.GetTicketMetricsIncrementally(ticketIDs[start:batchEnd(start, len(ticketIDs))])
+		if err != nil {
+			return err
+		}
+		if len(metrics) == 0 {
+			continue
+		}
+		if err := fn(metrics); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *Client) GetAllTicketCommentsWithHandler(ticketIDs []int64, fn func(int64, []zendesk.TicketComment) error) error {
+	comments, err := c.GetAllTicketComments(ticketIDs)
+	if err != nil {
+		return err
+	}
+	for _, ticketID := range ticketIDs {
+		if batch, ok := comments[ticketID]; ok {
+			if err := fn(ticketID, batch); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func batchEnd(start, n int) int {
+	if start+handlerBatchSize > n {
+		return n
+	}
+	return start + handlerBatchSize
+}
@@ -0,0 +1,103 @@
+package zendeskmock
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/phil-inc/zendesk/zendesk"
+)
+
+// Ticket imports
+
+func (c *Client) ImportTicket(ticket *zendesk.TicketImport) (*zendesk.Ticket, error) {
+	c.lock()
+	defer c.unlock()
+	return c.importTicket(ticket), nil
+}
+
+func (c *Client) BulkImportTickets(tickets []zendesk.TicketImport) (*zendesk.JobStatus, error) {
+	if len(tickets) > 100 {
+		return nil, fmt.Errorf("zendesk: at most 100 tickets can be imported at once, got %d", len(tickets))
+	}
+
+	c.lock()
+	defer c.unlock()
+
+	results := make([]zendesk.JobStatusResult, 0, len(tickets))
+	for i := range tickets {
+		t := c.importTicket(&tickets[i])
+		results = append(results, zendesk.JobStatusResult{ID: t.ID, Index: int64(i), Action: "create", Success: true, Status: "Created"})
+	}
+	return c.completedJob(results), nil
+}
+
+// ImportTickets imports the tickets in batches of opts.BatchSize, each recorded as a
+// completed job. Unlike the client, it never imports long conversations on their own.
+func (c *Client) ImportTickets(tickets []zendesk.TicketImport, opts *zendesk.TicketImportOptions) (*zendesk.TicketImportResult, error) {
+	batchSize := 100
+	if opts != nil && opts.BatchSize > 0 && opts.BatchSize < batchSize {
+		batchSize = opts.BatchSize
+	}
+
+	result := new(zendesk.TicketImportResult)
+	for start := 0; start < len(tickets); start += batchSize {
+		end := start + batchSize
+		if end > len(tickets) {
+			end = len(tickets)
+		}
+		batch := make([]zendesk.TicketImport, 0, end-start)
+		for _, ticket := range tickets[start:end] {
+			ticket.Comments = append([]zendesk.TicketComment(nil), ticket.Comments...)
+			sort.SliceStable(ticket.Comments, func(a, b int) bool {
+				at, bt := ticket.Comments[a].CreatedAt, ticket.Comments[b].CreatedAt
+				return at != nil && bt != nil && at.Before(*bt)
+			})
+			batch = append(batch, ticket)
+		}
+
+		job, err := c.BulkImportTickets(batch)
+		if err != nil {
+			return result, err
+		}
+		result.Jobs = append(result.Jobs, job)
+	}
+	return result, nil
+}
+
+// importTicket creates the ticket and its comments, keeping their timestamps.
+func (c *Client) importTicket(ticket *zendesk.TicketImport) *zendesk.Ticket {
+	t := ticket.Ticket
+	if t.ID == 0 {
+		t.ID = c.nextID()
+	}
+	c.seen(t.ID)
+	if t.Status == "" {
+		t.Status = "new"
+	}
+	if t.CreatedAt == nil {
+		t.CreatedAt = c.now()
+	}
+	if t.UpdatedAt == nil {
+		t.UpdatedAt = t.CreatedAt
+	}
+
+	comments := ticket.Comments
+	if t.Comment != nil {
+		comments = append([]zendesk.TicketComment{*t.Comment}, comments...)
+		t.Comment = nil
+	}
+	for i := range comments {
+		comment := comments[i]
+		if comment.CreatedAt == nil {
+			comment.CreatedAt = t.CreatedAt
+		}
+		c.addComment(t.ID, &comment)
+		if t.Description == "" {
+			t.Description = comment.Body
+		}
+	}
+
+	c.tickets[t.ID] = &t
+	imported := t
+	return &imported
+}
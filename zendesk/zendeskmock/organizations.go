@@ -0,0 +1,407 @@
+package zendeskmock
+
+import (
+	"strings"
+
+	"github.com/phil-inc/zendesk/zendesk"
+)
+
+// Organizations
+
+func (c *Client) ShowOrganization(id int64, includes ...zendesk.Include) (*zendesk.Organization, error) {
+	c.lock()
+	defer c.unlock()
+
+	org, ok := c.orgs[id]
+	if !ok {
+		return nil, notFound("organization", id)
+	}
+	o := *org
+	return &o, nil
+}
+
+func (c *Client) ShowManyOrganizations(ids []int64) ([]zendesk.Organization, error) {
+	c.lock()
+	defer c.unlock()
+
+	result := make([]zendesk.Organization, 0, len(ids))
+	for _, id := range ids {
+		if org, ok := c.orgs[id]; ok {
+			result = append(result, *org)
+		}
+	}
+	return result, nil
+}
+
+// SearchOrganizations matches the organizations by `external_id:`, `tags:` or
+// `name:`, or by name when the query has no field.
+func (c *Client) SearchOrganizations(query string) ([]zendesk.Organization, error) {
+	c.lock()
+	defer c.unlock()
+
+	query = strings.TrimSpace(query)
+	field, value := "name", query
+	if parts := strings.SplitN(query, ":", 2); len(parts) == 2 {
+		field, value = parts[0], strings.Trim(parts[1], `"`)
+	}
+
+	ids := make([]int64, 0)
+	for id, org := range c.orgs {
+		match := false
+		switch field {
+		case "external_id":
+			match = org.ExternalID == value
+		case "tags":
+			match = containsTag(org.Tags, value)
+		case "name":
+			match = strings.Contains(strings.ToLower(org.Name), strings.ToLower(value))
+		}
+		if match {
+			ids = append(ids, id)
+		}
+	}
+	return c.orgsByID(ids), nil
+}
+
+func (c *Client) AutocompleteOrganizations(name string) ([]zendesk.Organization, error) {
+	c.lock()
+	defer c.unlock()
+
+	ids := make([]int64, 0)
+	for id, org := range c.orgs {
+		if strings.HasPrefix(strings.ToLower(org.Name), strings.ToLower(name)) {
+			ids = append(ids, id)
+		}
+	}
+	return c.orgsByID(ids), nil
+}
+
+func (c *Client) CreateOrganization(org *zendesk.Organization) (*zendesk.Organization, error) {
+	c.lock()
+	defer c.unlock()
+
+	o := *org
+	if o.ID == 0 {
+		o.ID = c.nextID()
+	}
+	c.seen(o.ID)
+	if o.CreatedAt == nil {
+		o.CreatedAt = c.now()
+	}
+	o.UpdatedAt = c.now()
+	c.orgs[o.ID] = &o
+
+	created := o
+	return &created, nil
+}
+
+func (c *Client) UpdateOrganization(id int64, org *zendesk.Organization) (*zendesk.Organization, error) {
+	c.lock()
+	defer c.unlock()
+
+	existing, ok := c.orgs[id]
+	if !ok {
+		return nil, notFound("organization", id)
+	}
+
+	update := *org
+	update.ID = id
+	if err := merge(existing, &update); err != nil {
+		return nil, err
+	}
+	existing.UpdatedAt = c.now()
+
+	o := *existing
+	return &o, nil
+}
+
+// ListOrganizations lists the organizations, honoring the page options.
+func (c *Client) ListOrganizations(opts *zendesk.ListOptions, includes ...zendesk.Include) ([]zendesk.Organization, error) {
+	c.lock()
+	defer c.unlock()
+
+	ids := make([]int64, 0, len(c.orgs))
+	for id := range c.orgs {
+		ids = append(ids, id)
+	}
+	ids = paginate(sortedIDs(ids), opts)
+
+	result := make([]zendesk.Organization, 0, len(ids))
+	for _, id := range ids {
+		result = append(result, *c.orgs[id])
+	}
+	return result, nil
+}
+
+// ListOrganizationsForUser lists the organizations of the user's memberships.
+func (c *Client) ListOrganizationsForUser(userID int64) ([]zendesk.Organization, error) {
+	c.lock()
+	defer c.unlock()
+
+	user, ok := c.users[userID]
+	if !ok {
+		return nil, notFound("user", userID)
+	}
+
+	ids := []int64{user.OrganizationID}
+	for _, membership := range c.memberships {
+		if membership.UserID == userID {
+			ids = append(ids, membership.OrganizationID)
+		}
+	}
+	return c.orgsByID(ids), nil
+}
+
+func paginate(ids []int64, opts *zendesk.ListOptions) []int64 {
+	if opts == nil || opts.PerPage <= 0 {
+		return ids
+	}
+
+	page := opts.Page
+	if page < 1 {
+		page = 1
+	}
+	start := (page - 1) * opts.PerPage
+	if start >= len(ids) {
+		return nil
+	}
+	end := start + opts.PerPage
+	if end > len(ids) {
+		end = len(ids)
+	}
+	return ids[start:end]
+}
+
+func (c *Client) DeleteOrganization(id int64) error {
+	c.lock()
+	defer c.unlock()
+
+	if _, ok := c.orgs[id]; !ok {
+		return notFound("organization", id)
+	}
+	delete(c.orgs, id)
+	for membershipID, membership := range c.memberships {
+		if membership.OrganizationID == id {
+			delete(c.memberships, membershipID)
+		}
+	}
+	return nil
+}
+
+func (c *Client) AddOrganizationTags(id int64, tags []string) ([]string, error) {
+	c.lock()
+	defer c.unlock()
+
+	org, ok := c.orgs[id]
+	if !ok {
+		return nil, notFound("organization", id)
+	}
+	org.Tags = addTags(org.Tags, tags)
+	org.UpdatedAt = c.now()
+	return append([]string(nil), org.Tags...), nil
+}
+
+func (c *Client) SetOrganizationTags(id int64, tags []string) ([]string, error) {
+	c.lock()
+	defer c.unlock()
+
+	org, ok := c.orgs[id]
+	if !ok {
+		return nil, notFound("organization", id)
+	}
+	org.Tags = addTags(nil, tags)
+	org.UpdatedAt = c.now()
+	return append([]string(nil), org.Tags...), nil
+}
+
+func (c *Client) RemoveOrganizationTags(id int64, tags []string) error {
+	c.lock()
+	defer c.unlock()
+
+	org, ok := c.orgs[id]
+	if !ok {
+		return notFound("organization", id)
+	}
+	org.Tags = removeTags(org.Tags, tags)
+	org.UpdatedAt = c.now()
+	return nil
+}
+
+// Organization memberships
+
+func (c *Client) CreateOrganizationMembership(orgMembership *zendesk.OrganizationMembership) (*zendesk.OrganizationMembership, error) {
+	c.lock()
+	defer c.unlock()
+	return c.createMembership(orgMembership)
+}
+
+func (c *Client) CreateManyOrganizationMemberships(memberships []zendesk.OrganizationMembership) (*zendesk.JobStatus, error) {
+	c.lock()
+	defer c.unlock()
+
+	results := make([]zendesk.JobStatusResult, 0, len(memberships))
+	for i := range memberships {
+		var id int64
+		m, err := c.createMembership(&memberships[i])
+		if err == nil {
+			id = m.ID
+		}
+		results = append(results, actionResult("create", "Created", id, int64(i), err))
+	}
+	return c.completedJob(results), nil
+}
+
+func (c *Client) createMembership(orgMembership *zendesk.OrganizationMembership) (*zendesk.OrganizationMembership, error) {
+	if _, ok := c.users[orgMembership.UserID]; !ok {
+		return nil, notFound("user", orgMembership.UserID)
+	}
+	if _, ok := c.orgs[orgMembership.OrganizationID]; !ok {
+		return nil, notFound("organization", orgMembership.OrganizationID)
+	}
+
+	m := *orgMembership
+	m.ID = c.nextID()
+	m.CreatedAt = c.now()
+	m.UpdatedAt = m.CreatedAt
+	if len(c.listMemberships(m.UserID)) == 0 {
+		m.Default = true
+		c.users[m.UserID].OrganizationID = m.OrganizationID
+	}
+	c.memberships[m.ID] = &m
+
+	created := m
+	return &created, nil
+}
+
+func (c *Client) ListOrganizationMemberships() ([]zendesk.OrganizationMembership, error) {
+	c.lock()
+	defer c.unlock()
+
+	ids := make([]int64, 0, len(c.memberships))
+	for id := range c.memberships {
+		ids = append(ids, id)
+	}
+
+	result := make([]zendesk.OrganizationMembership, 0, len(ids))
+	for _, id := range sortedIDs(ids) {
+		result = append(result, *c.memberships[id])
+	}
+	return result, nil
+}
+
+func (c *Client) ListOrganizationMembershipsByOrgID(orgID int64) ([]zendesk.OrganizationMembership, error) {
+	c.lock()
+	defer c.unlock()
+
+	if _, ok := c.orgs[orgID]; !ok {
+		return nil, notFound("organization", orgID)
+	}
+
+	ids := make([]int64, 0)
+	for id, membership := range c.memberships {
+		if membership.OrganizationID == orgID {
+			ids = append(ids, id)
+		}
+	}
+
+	result := make([]zendesk.OrganizationMembership, 0, len(ids))
+	for _, id := range sortedIDs(ids) {
+		result = append(result, *c.memberships[id])
+	}
+	return result, nil
+}
+
+func (c *Client) ShowOrganizationMembership(id int64) (*zendesk.OrganizationMembership, error) {
+	c.lock()
+	defer c.unlock()
+
+	membership, ok := c.memberships[id]
+	if !ok {
+		return nil, notFound("organization membership", id)
+	}
+	m := *membership
+	return &m, nil
+}
+
+func (c *Client) SetDefaultOrganizationMembership(userID, membershipID int64) ([]zendesk.OrganizationMembership, error) {
+	c.lock()
+	defer c.unlock()
+
+	if _, err := c.makeDefault(userID, membershipID); err != nil {
+		return nil, err
+	}
+	return c.listMemberships(userID), nil
+}
+
+func (c *Client) ListOrganizationMembershipsByUserID(id int64) ([]zendesk.OrganizationMembership, error) {
+	c.lock()
+	defer c.unlock()
+	return c.listMemberships(id), nil
+}
+
+func (c *Client) listMemberships(userID int64) []zendesk.OrganizationMembership {
+	ids := make([]int64, 0)
+	for id, membership := range c.memberships {
+		if membership.UserID == userID {
+			ids = append(ids, id)
+		}
+	}
+
+	result := make([]zendesk.OrganizationMembership, 0, len(ids))
+	for _, id := range sortedIDs(ids) {
+		result = append(result, *c.memberships[id])
+	}
+	return result
+}
+
+func (c *Client) DeleteOrganizationMembershipByID(id int64) error {
+	c.lock()
+	defer c.unlock()
+
+	if _, ok := c.memberships[id]; !ok {
+		return notFound("organization membership", id)
+	}
+	delete(c.memberships, id)
+	return nil
+}
+
+func (c *Client) EnsureDefaultOrganization(userID, orgID int64) (*zendesk.OrganizationMembership, error) {
+	memberships, err := c.ListOrganizationMembershipsByUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	var membership *zendesk.OrganizationMembership
+	for i := range memberships {
+		if memberships[i].OrganizationID == orgID {
+			membership = &memberships[i]
+		}
+	}
+	if membership == nil {
+		membership, err = c.CreateOrganizationMembership(&zendesk.OrganizationMembership{UserID: userID, OrganizationID: orgID})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	c.lock()
+	defer c.unlock()
+	return c.makeDefault(userID, membership.ID)
+}
+
+func (c *Client) makeDefault(userID, membershipID int64) (*zendesk.OrganizationMembership, error) {
+	membership, ok := c.memberships[membershipID]
+	if !ok || membership.UserID != userID {
+		return nil, notFound("organization membership", membershipID)
+	}
+
+	for _, m := range c.memberships {
+		if m.UserID == userID {
+			m.Default = m.ID == membershipID
+		}
+	}
+	c.users[userID].OrganizationID = membership.OrganizationID
+
+	m := *membership
+	return &m, nil
+}
@@ -0,0 +1,38 @@
+package zendeskmock
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/phil-inc/zendesk/zendesk"
+)
+
+// Problems
+
+func (c *Client) ListProblemTickets(includes ...zendesk.Include) ([]zendesk.Ticket, error) {
+	tickets := c.filterTickets(func(t *zendesk.Ticket) bool { return t.Type == zendesk.TicketTypeProblem })
+	return c.withTicketSideloads(tickets, includes), nil
+}
+
+// AutocompleteProblems matches the problems whose subject contains the text.
+func (c *Client) AutocompleteProblems(text string) ([]zendesk.Ticket, error) {
+	text = strings.ToLower(text)
+	return c.filterTickets(func(t *zendesk.Ticket) bool {
+		return t.Type == zendesk.TicketTypeProblem && strings.Contains(strings.ToLower(t.Subject), text)
+	}), nil
+}
+
+// LinkIncidentToProblem fails like Zendesk when the problem is not a problem ticket.
+func (c *Client) LinkIncidentToProblem(incidentID, problemID int64) (*zendesk.Ticket, error) {
+	c.lock()
+	defer c.unlock()
+
+	problem, ok := c.tickets[problemID]
+	if !ok {
+		return nil, notFound("ticket", problemID)
+	}
+	if problem.Type != zendesk.TicketTypeProblem {
+		return nil, &zendesk.ErrValidation{Type: "RecordInvalid", Description: fmt.Sprintf("Problem: ticket %d is not a problem", problemID)}
+	}
+	return c.updateTicket(incidentID, &zendesk.Ticket{Type: zendesk.TicketTypeIncident, ProblemID: problemID})
+}
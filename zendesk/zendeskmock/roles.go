@@ -0,0 +1,123 @@
+package zendeskmock
+
+import (
+	"fmt"
+
+	"github.com/phil-inc/zendesk/zendesk"
+)
+
+// Custom roles
+
+func (c *Client) ListCustomRoles() ([]zendesk.CustomRole, error) {
+	c.lock()
+	defer c.unlock()
+
+	ids := make([]int64, 0, len(c.roles))
+	for id := range c.roles {
+		ids = append(ids, id)
+	}
+
+	result := make([]zendesk.CustomRole, 0, len(ids))
+	for _, id := range sortedIDs(ids) {
+		result = append(result, c.role(id))
+	}
+	return result, nil
+}
+
+func (c *Client) ShowCustomRole(id int64) (*zendesk.CustomRole, error) {
+	c.lock()
+	defer c.unlock()
+
+	if _, ok := c.roles[id]; !ok {
+		return nil, notFound("custom role", id)
+	}
+	r := c.role(id)
+	return &r, nil
+}
+
+func (c *Client) CreateCustomRole(role *zendesk.CustomRole) (*zendesk.CustomRole, error) {
+	c.lock()
+	defer c.unlock()
+
+	if role.Name == "" {
+		return nil, &zendesk.ErrValidation{Type: "RecordInvalid", Description: "Name can't be blank"}
+	}
+
+	r := *role
+	if r.ID == 0 {
+		r.ID = c.nextID()
+	}
+	c.seen(r.ID)
+	if r.Configuration != nil {
+		configuration := *r.Configuration
+		r.Configuration = &configuration
+	} else {
+		r.Configuration = new(zendesk.CustomRoleConfiguration)
+	}
+	if r.CreatedAt == nil {
+		r.CreatedAt = c.now()
+	}
+	r.UpdatedAt = c.now()
+	c.roles[r.ID] = &r
+
+	created := c.role(r.ID)
+	return &created, nil
+}
+
+// UpdateCustomRole replaces the whole configuration when one is given, like Zendesk.
+func (c *Client) UpdateCustomRole(id int64, role *zendesk.CustomRole) (*zendesk.CustomRole, error) {
+	c.lock()
+	defer c.unlock()
+
+	existing, ok := c.roles[id]
+	if !ok {
+		return nil, notFound("custom role", id)
+	}
+
+	update := *role
+	update.ID = id
+	configuration := update.Configuration
+	update.Configuration = nil
+	if err := merge(existing, &update); err != nil {
+		return nil, err
+	}
+	if configuration != nil {
+		replaced := *configuration
+		existing.Configuration = &replaced
+	}
+	existing.UpdatedAt = c.now()
+
+	r := c.role(id)
+	return &r, nil
+}
+
+// DeleteCustomRole fails like Zendesk while agents have the role.
+func (c *Client) DeleteCustomRole(id int64) error {
+	c.lock()
+	defer c.unlock()
+
+	if _, ok := c.roles[id]; !ok {
+		return notFound("custom role", id)
+	}
+	if count := c.role(id).TeamMemberCount; count > 0 {
+		return &zendesk.ErrValidation{Type: "RecordInvalid", Description: fmt.Sprintf("Role is assigned to %d agents", count)}
+	}
+	delete(c.roles, id)
+	return nil
+}
+
+// role copies the custom role, counting the users who have it.
+func (c *Client) role(id int64) zendesk.CustomRole {
+	r := *c.roles[id]
+	if r.Configuration != nil {
+		configuration := *r.Configuration
+		r.Configuration = &configuration
+	}
+	r.TeamMemberCount = 0
+	for _, user := range c.users {
+		if user.CustomerRoleID == id {
+			r.TeamMemberCount++
+		}
+	}
+	return r
+}
@@ -0,0 +1,286 @@
+package zendeskmock
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/phil-inc/zendesk/zendesk"
+)
+
+// Skills-based routing
+
+func (c *Client) ListRoutingAttributes() ([]zendesk.RoutingAttribute, error) {
+	c.lock()
+	defer c.unlock()
+
+	ids := make([]string, 0, len(c.routing))
+	for id := range c.routing {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	result := make([]zendesk.RoutingAttribute, 0, len(ids))
+	for _, id := range ids {
+		result = append(result, copyAttribute(c.routing[id]))
+	}
+	return result, nil
+}
+
+func (c *Client) ShowRoutingAttribute(id string) (*zendesk.RoutingAttribute, error) {
+	c.lock()
+	defer c.unlock()
+
+	attribute, ok := c.routing[id]
+	if !ok {
+		return nil, notFound("routing attribute", id)
+	}
+	a := copyAttribute(attribute)
+	return &a, nil
+}
+
+func (c *Client) CreateRoutingAttribute(attribute *zendesk.RoutingAttribute) (*zendesk.RoutingAttribute, error) {
+	c.lock()
+	defer c.unlock()
+
+	if attribute.Name == "" {
+		return nil, &zendesk.ErrValidation{Type: "RecordInvalid", Description: "Name can't be blank"}
+	}
+
+	a := zendesk.RoutingAttribute{
+		ID:        fmt.Sprintf("attribute-%d", c.nextID()),
+		Name:      attribute.Name,
+		CreatedAt: c.now(),
+	}
+	a.UpdatedAt = a.CreatedAt
+	c.routing[a.ID] = &a
+
+	created := copyAttribute(&a)
+	return &created, nil
+}
+
+func (c *Client) UpdateRoutingAttribute(id string, attribute *zendesk.RoutingAttribute) (*zendesk.RoutingAttribute, error) {
+	c.lock()
+	defer c.unlock()
+
+	existing, ok := c.routing[id]
+	if !ok {
+		return nil, notFound("routing attribute", id)
+	}
+	if attribute.Name != "" {
+		existing.Name = attribute.Name
+	}
+	existing.UpdatedAt = c.now()
+
+	a := copyAttribute(existing)
+	return &a, nil
+}
+
+// DeleteRoutingAttribute deletes the attribute and unassigns its values.
+func (c *Client) DeleteRoutingAttribute(id string) error {
+	c.lock()
+	defer c.unlock()
+
+	attribute, ok := c.routing[id]
+	if !ok {
+		return notFound("routing attribute", id)
+	}
+	for _, value := range attribute.Values {
+		c.unassignSkill(value.ID)
+	}
+	delete(c.routing, id)
+	return nil
+}
+
+func (c *Client) ListRoutingAttributeValues(attributeID string) ([]zendesk.RoutingAttributeValue, error) {
+	c.lock()
+	defer c.unlock()
+
+	attribute, ok := c.routing[attributeID]
+	if !ok {
+		return nil, notFound("routing attribute", attributeID)
+	}
+	return append([]zendesk.RoutingAttributeValue{}, attribute.Values...), nil
+}
+
+func (c *Client) ShowRoutingAttributeValue(attributeID, id string) (*zendesk.RoutingAttributeValue, error) {
+	c.lock()
+	defer c.unlock()
+
+	value, err := c.attributeValue(attributeID, id)
+	if err != nil {
+		return nil, err
+	}
+	v := *value
+	return &v, nil
+}
+
+func (c *Client) CreateRoutingAttributeValue(attributeID string, value *zendesk.RoutingAttributeValue) (*zendesk.RoutingAttributeValue, error) {
+	c.lock()
+	defer c.unlock()
+
+	attribute, ok := c.routing[attributeID]
+	if !ok {
+		return nil, notFound("routing attribute", attributeID)
+	}
+	if value.Name == "" {
+		return nil, &zendesk.ErrValidation{Type: "RecordInvalid", Description: "Name can't be blank"}
+	}
+
+	v := zendesk.RoutingAttributeValue{
+		ID:        fmt.Sprintf("value-%d", c.nextID()),
+		Name:      value.Name,
+		CreatedAt: c.now(),
+	}
+	v.UpdatedAt = v.CreatedAt
+	attribute.Values = append(attribute.Values, v)
+
+	return &v, nil
+}
+
+func (c *Client) UpdateRoutingAttributeValue(attributeID, id string, value *zendesk.RoutingAttributeValue) (*zendesk.RoutingAttributeValue, error) {
+	c.lock()
+	defer c.unlock()
+
+	existing, err := c.attributeValue(attributeID, id)
+	if err != nil {
+		return nil, err
+	}
+	if value.Name != "" {
+		existing.Name = value.Name
+	}
+	existing.UpdatedAt = c.now()
+
+	v := *existing
+	return &v, nil
+}
+
+// DeleteRoutingAttributeValue deletes the value and unassigns it from the tickets and agents.
+func (c *Client) DeleteRoutingAttributeValue(attributeID, id string) error {
+	c.lock()
+	defer c.unlock()
+
+	attribute, ok := c.routing[attributeID]
+	if !ok {
+		return notFound("routing attribute", attributeID)
+	}
+	for i, value := range attribute.Values {
+		if value.ID == id {
+			attribute.Values = append(attribute.Values[:i:i], attribute.Values[i+1:]...)
+			c.unassignSkill(id)
+			return nil
+		}
+	}
+	return notFound("routing attribute value", id)
+}
+
+func (c *Client) ListTicketAttributes(ticketID int64) ([]zendesk.RoutingAttributeValue, error) {
+	c.lock()
+	defer c.unlock()
+
+	if _, ok := c.tickets[ticketID]; !ok {
+		return nil, notFound("ticket", ticketID)
+	}
+	return c.skillValues(c.ticketSkills[ticketID]), nil
+}
+
+func (c *Client) SetTicketAttributes(ticketID int64, valueIDs []string) ([]zendesk.RoutingAttributeValue, error) {
+	c.lock()
+	defer c.unlock()
+
+	if _, ok := c.tickets[ticketID]; !ok {
+		return nil, notFound("ticket", ticketID)
+	}
+	if err := c.checkSkills(valueIDs); err != nil {
+		return nil, err
+	}
+	c.ticketSkills[ticketID] = append([]string(nil), valueIDs...)
+	return c.skillValues(valueIDs), nil
+}
+
+func (c *Client) ListAgentAttributes(userID int64) ([]zendesk.RoutingAttributeValue, error) {
+	c.lock()
+	defer c.unlock()
+
+	if _, ok := c.users[userID]; !ok {
+		return nil, notFound("user", userID)
+	}
+	return c.skillValues(c.agentSkills[userID]), nil
+}
+
+func (c *Client) SetAgentAttributes(userID int64, valueIDs []string) ([]zendesk.RoutingAttributeValue, error) {
+	c.lock()
+	defer c.unlock()
+
+	if _, ok := c.users[userID]; !ok {
+		return nil, notFound("user", userID)
+	}
+	if err := c.checkSkills(valueIDs); err != nil {
+		return nil, err
+	}
+	c.agentSkills[userID] = append([]string(nil), valueIDs...)
+	return c.skillValues(valueIDs), nil
+}
+
+func copyAttribute(attribute *zendesk.RoutingAttribute) zendesk.RoutingAttribute {
+	a := *attribute
+	a.Values = append([]zendesk.RoutingAttributeValue(nil), attribute.Values...)
+	return a
+}
+
+func (c *Client) attributeValue(attributeID, id string) (*zendesk.RoutingAttributeValue, error) {
+	attribute, ok := c.routing[attributeID]
+	if !ok {
+		return nil, notFound("routing attribute", attributeID)
+	}
+	for i := range attribute.Values {
+		if attribute.Values[i].ID == id {
+			return &attribute.Values[i], nil
+		}
+	}
+	return nil, notFound("routing attribute value", id)
+}
+
+// skillValue finds an attribute value by its ID, whatever its attribute.
+func (c *Client) skillValue(id string) (*zendesk.RoutingAttributeValue, bool) {
+	for _, attribute := range c.routing {
+		for i := range attribute.Values {
+			if attribute.Values[i].ID == id {
+				return &attribute.Values[i], true
+			}
+		}
+	}
+	return nil, false
+}
+
+func (c *Client) checkSkills(valueIDs []string) error {
+	for _, id := range valueIDs {
+		if _, ok := c.skillValue(id); !ok {
+			return &zendesk.ErrValidation{Type: "RecordInvalid", Description: fmt.Sprintf("Attribute value %s not found", id)}
+		}
+	}
+	return nil
+}
+
+func (c *Client) skillValues(valueIDs []string) []zendesk.RoutingAttributeValue {
+	result := make([]zendesk.RoutingAttributeValue, 0, len(valueIDs))
+	for _, id := range valueIDs {
+		if value, ok := c.skillValue(id); ok {
+			result = append(result, *value)
+		}
+	}
+	return result
+}
+
+func (c *Client) unassignSkill(valueID string) {
+	for _, skills := range []map[int64][]string{c.ticketSkills, c.agentSkills} {
+		for id, valueIDs := range skills {
+			kept := valueIDs[:0]
+			for _, v := range valueIDs {
+				if v != valueID {
+					kept = append(kept, v)
+				}
+			}
+			skills[id] = kept
+		}
+	}
+}
@@ -0,0 +1,1606 @@
+package zendeskmock
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/phil-inc/zendesk/zendesk"
+)
+
+// Server is a fake Zendesk server backed by an in-memory Client. It serves the
+// endpoints used by the zendesk package so that the HTTP layer of a service,
+// including its middleware, can be tested end to end.
+type Server struct {
+	*httptest.Server
+
+	// Backend holds the data served by the server. Fixtures loaded into it are served right away.
+	Backend *Client
+
+	mu       sync.Mutex
+	requests []RecordedRequest
+	routes   []route
+}
+
+// RecordedRequest is a request received by a Server.
+type RecordedRequest struct {
+	Method string
+	URL    string
+	Header http.Header
+	Body   []byte
+}
+
+type route struct {
+	method  string
+	pattern *regexp.Regexp
+	handle  func(w http.ResponseWriter, r *http.Request, in *zendesk.APIPayload, args []string) (int, interface{}, error)
+}
+
+// NewServer starts a fake Zendesk server with an empty backend.
+// The server should be closed once done with it.
+func NewServer() *Server {
+	s := &Server{Backend: New()}
+	s.registerRoutes()
+	s.Server = httptest.NewServer(s)
+	return s
+}
+
+// ZendeskClient returns a zendesk.Client talking to the server. Its requests are
+// neither retried nor rate limited.
+func (s *Server) ZendeskClient(middleware ...zendesk.MiddlewareFunction) (zendesk.Client, error) {
+	c, err := zendesk.NewURLClient(s.URL, "agent@example.com/token", "token", middleware...)
+	if err != nil {
+		return nil, err
+	}
+	for family := range zendesk.DefaultEndpointPolicies {
+		c = c.WithEndpointPolicy(family, zendesk.EndpointPolicy{Retry: zendesk.NoRetryPolicy})
+	}
+	return c, nil
+}
+
+// Requests returns the requests received by the server so far.
+func (s *Server) Requests() []RecordedRequest {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]RecordedRequest(nil), s.requests...)
+}
+
+// ServeHTTP routes the request to the backend.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	recorded := RecordedRequest{Method: r.Method, URL: r.URL.String(), Header: r.Header.Clone()}
+
+	in := new(zendesk.APIPayload)
+	if r.Body != nil && r.Header.Get("Content-Type") == "application/json" {
+		var raw json.RawMessage
+		if err := json.NewDecoder(r.Body).Decode(&raw); err == nil {
+			recorded.Body = raw
+			json.Unmarshal(raw, in)
+			// The body is kept for the handlers decoding fields missing from APIPayload.
+			r.Body = ioutil.NopCloser(bytes.NewReader(raw))
+		}
+	}
+
+	s.mu.Lock()
+	s.requests = append(s.requests, recorded)
+	s.mu.Unlock()
+
+	for _, rt := range s.routes {
+		if rt.method != r.Method {
+			continue
+		}
+		args := rt.pattern.FindStringSubmatch(r.URL.Path)
+		if args == nil {
+			continue
+		}
+
+		status, out, err := rt.handle(w, r, in, args[1:])
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		writeJSON(w, status, out)
+		return
+	}
+
+	writeJSON(w, http.StatusNotFound, map[string]string{"error": "InvalidEndpoint", "description": "Not found"})
+}
+
+func writeJSON(w http.ResponseWriter, status int, out interface{}) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+	if out != nil {
+		json.NewEncoder(w).Encode(out)
+	}
+}
+
+func writeError(w http.ResponseWriter, err error) {
+	var validation *zendesk.ErrValidation
+	switch {
+	case errors.Is(err, zendesk.ErrNotFound):
+		writeJSON(w, http.StatusNotFound, map[string]string{"error": "RecordNotFound", "description": "Not found"})
+	case errors.Is(err, zendesk.ErrConflict):
+		writeJSON(w, http.StatusConflict, map[string]string{"error": "UpdateConflict", "description": err.Error()})
+	case errors.As(err, &validation):
+		writeJSON(w, http.StatusUnprocessableEntity, map[string]string{"error": "RecordInvalid", "description": err.Error()})
+	default:
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "InvalidRequest", "description": err.Error()})
+	}
+}
+
+// safeUpdate returns the updated stamp of the tag changes made with safe_update.
+func safeUpdate(r *http.Request) (time.Time, bool) {
+	var body struct {
+		SafeUpdate   bool      `json:"safe_update"`
+		UpdatedStamp time.Time `json:"updated_stamp"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		return time.Time{}, false
+	}
+	return body.UpdatedStamp, body.SafeUpdate
+}
+
+func id(s string) int64 {
+	i, _ := strconv.ParseInt(s, 10, 64)
+	return i
+}
+
+func ids(s string) []int64 {
+	result := make([]int64, 0)
+	for _, part := range strings.Split(s, ",") {
+		if part != "" {
+			result = append(result, id(part))
+		}
+	}
+	return result
+}
+
+func includes(r *http.Request) []zendesk.Include {
+	result := make([]zendesk.Include, 0)
+	for _, part := range strings.Split(r.URL.Query().Get("include"), ",") {
+		if part != "" {
+			result = append(result, zendesk.Include(part))
+		}
+	}
+	return result
+}
+
+// sideloaded adds the sideloaded records to the response, without replacing its primary records.
+func sideloaded(out *zendesk.APIPayload, s *zendesk.Sideloads) *zendesk.APIPayload {
+	if s == nil {
+		return out
+	}
+	if out.Users == nil {
+		out.Users = s.Users
+	}
+	if out.Organizations == nil {
+		out.Organizations = s.Organizations
+	}
+	out.Groups = s.Groups
+	out.MetricSets = s.MetricSets
+	out.Identities = s.Identities
+	return out
+}
+
+func ticketSideloads(tickets []zendesk.Ticket) *zendesk.Sideloads {
+	if len(tickets) == 0 {
+		return nil
+	}
+	return tickets[0].Sideloads
+}
+
+func userSideloads(users []zendesk.User) *zendesk.Sideloads {
+	if len(users) == 0 {
+		return nil
+	}
+	return users[0].Sideloads
+}
+
+// acceptLanguages returns the languages of the Accept-Language header by decreasing weight.
+func acceptLanguages(r *http.Request) []string {
+	type language struct {
+		code   string
+		weight float64
+	}
+	languages := make([]language, 0)
+	for _, part := range strings.Split(r.Header.Get("Accept-Language"), ",") {
+		fields := strings.Split(strings.TrimSpace(part), ";")
+		if fields[0] == "" {
+			continue
+		}
+		l := language{code: fields[0], weight: 1}
+		for _, param := range fields[1:] {
+			if param = strings.TrimSpace(param); strings.HasPrefix(param, "q=") {
+				l.weight, _ = strconv.ParseFloat(strings.TrimPrefix(param, "q="), 64)
+			}
+		}
+		languages = append(languages, l)
+	}
+	sort.SliceStable(languages, func(i, j int) bool { return languages[i].weight > languages[j].weight })
+
+	result := make([]string, 0, len(languages))
+	for _, l := range languages {
+		result = append(result, l.code)
+	}
+	return result
+}
+
+func exportOptions(r *http.Request) *zendesk.IncrementalExportOptions {
+	return &zendesk.IncrementalExportOptions{StartTime: startTime(r), Cursor: r.URL.Query().Get("cursor")}
+}
+
+func startTime(r *http.Request) int64 {
+	return id(r.URL.Query().Get("start_time"))
+}
+
+// incremental wraps a page of an incremental export. The next page points back
+// to the request itself, which is how Zendesk signals the end of an export.
+func (s *Server) incremental(r *http.Request, out *zendesk.APIPayload) *zendesk.APIPayload {
+	out.NextPage = s.URL + r.URL.RequestURI()
+	out.EndOfStream = true
+	return out
+}
+
+func (s *Server) handle(method, pattern string, handle func(w http.ResponseWriter, r *http.Request, in *zendesk.APIPayload, args []string) (int, interface{}, error)) {
+	s.routes = append(s.routes, route{method: method, pattern: regexp.MustCompile("^/api/v2/" + pattern + "$"), handle: handle})
+}
+
+func (s *Server) registerRoutes() {
+	b := s.Backend
+	type args = []string
+	type req = *http.Request
+	type res = http.ResponseWriter
+	type payload = *zendesk.APIPayload
+
+	ok := func(out *zendesk.APIPayload, err error) (int, interface{}, error) {
+		return http.StatusOK, out, err
+	}
+	created := func(out *zendesk.APIPayload, err error) (int, interface{}, error) {
+		return http.StatusCreated, out, err
+	}
+	noContent := func(err error) (int, interface{}, error) {
+		return http.StatusNoContent, nil, err
+	}
+
+	// Tickets
+	s.handle("GET", `search\.json`, func(w res, r req, in payload, a args) (int, interface{}, error) {
+		// Only ticket searches by external ID and organization searches are supported.
+		query := r.URL.Query().Get("query")
+		if strings.HasPrefix(query, "type:organization") {
+			orgs, err := b.SearchOrganizations(strings.TrimPrefix(query, "type:organization"))
+			return http.StatusOK, map[string]interface{}{"results": orgs, "count": len(orgs)}, err
+		}
+		results := make([]zendesk.Ticket, 0)
+		if i := strings.Index(query, "external_id:"); strings.Contains(query, "type:ticket") && i >= 0 {
+			externalID := strings.Trim(strings.SplitN(query[i+len("external_id:"):], " ", 2)[0], `"`)
+			tickets, _ := b.GetAllTickets()
+			for _, t := range tickets {
+				if t.ExternalID == externalID {
+					results = append(results, t)
+				}
+			}
+		}
+		return http.StatusOK, map[string]interface{}{"results": results, "count": len(results)}, nil
+	})
+	s.handle("GET", `tickets/count\.json`, func(w res, r req, in payload, a args) (int, interface{}, error) {
+		tickets, err := b.GetAllTickets()
+		return http.StatusOK, map[string]interface{}{"count": map[string]int{"value": len(tickets)}}, err
+	})
+	s.handle("GET", `tickets\.json`, func(w res, r req, in payload, a args) (int, interface{}, error) {
+		tickets, err := b.GetAllTickets()
+		if externalID := r.URL.Query().Get("external_id"); externalID != "" {
+			matching := make([]zendesk.Ticket, 0)
+			for _, t := range tickets {
+				if t.ExternalID == externalID {
+					matching = append(matching, t)
+				}
+			}
+			tickets = matching
+		}
+		if r.URL.Query().Get("sort_order") == "desc" {
+			for i, j := 0, len(tickets)-1; i < j; i, j = i+1, j-1 {
+				tickets[i], tickets[j] = tickets[j], tickets[i]
+			}
+		}
+		if n := int(id(r.URL.Query().Get("per_page"))); n > 0 && n < len(tickets) {
+			tickets = tickets[:n]
+		}
+		// Only the first page of cursor based pagination is supported. When it is disabled,
+		// page[size] is ignored as done by the accounts without it.
+		if capabilities, _ := b.Capabilities(); capabilities.CursorPagination && r.URL.Query().Get("page[size]") != "" {
+			size := r.URL.Query().Get("page[size]")
+			hasMore := false
+			if n := int(id(size)); n > 0 && n < len(tickets) {
+				tickets, hasMore = tickets[:n], true
+			}
+			return http.StatusOK, map[string]interface{}{"tickets": tickets, "meta": map[string]bool{"has_more": hasMore}}, err
+		}
+		return ok(&zendesk.APIPayload{Tickets: tickets}, err)
+	})
+	s.handle("GET", `tickets/(\d+)\.json`, func(w res, r req, in payload, a args) (int, interface{}, error) {
+		t, err := b.ShowTicket(id(a[0]), includes(r)...)
+		if err != nil {
+			return 0, nil, err
+		}
+		return ok(sideloaded(&zendesk.APIPayload{Ticket: t}, t.Sideloads), nil)
+	})
+	s.handle("GET", `problems\.json`, func(w res, r req, in payload, a args) (int, interface{}, error) {
+		tickets, err := b.ListProblemTickets(includes(r)...)
+		return ok(sideloaded(&zendesk.APIPayload{Tickets: tickets}, ticketSideloads(tickets)), err)
+	})
+	s.handle("POST", `problems/autocomplete\.json`, func(w res, r req, in payload, a args) (int, interface{}, error) {
+		body := struct {
+			Text string `json:"text"`
+		}{Text: r.URL.Query().Get("text")}
+		json.NewDecoder(r.Body).Decode(&body)
+		tickets, err := b.AutocompleteProblems(body.Text)
+		return ok(&zendesk.APIPayload{Tickets: tickets}, err)
+	})
+	s.handle("GET", `tickets/(\d+)/skips\.json`, func(w res, r req, in payload, a args) (int, interface{}, error) {
+		skips, err := b.ListTicketSkips(id(a[0]))
+		return http.StatusOK, map[string]interface{}{"skips": skips}, err
+	})
+	s.handle("GET", `users/(\d+)/skips\.json`, func(w res, r req, in payload, a args) (int, interface{}, error) {
+		skips, err := b.ListUserSkips(id(a[0]))
+		return http.StatusOK, map[string]interface{}{"skips": skips}, err
+	})
+	s.handle("POST", `skips\.json`, func(w res, r req, in payload, a args) (int, interface{}, error) {
+		var body struct {
+			Skip *zendesk.TicketSkip `json:"skip"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil || body.Skip == nil {
+			return 0, nil, fmt.Errorf("invalid skip: %v", err)
+		}
+		skip, err := b.CreateTicketSkip(body.Skip)
+		return http.StatusCreated, map[string]interface{}{"skip": skip}, err
+	})
+	s.handle("POST", `tickets/(\d+)/merge\.json`, func(w res, r req, in payload, a args) (int, interface{}, error) {
+		var body struct {
+			IDs           []int64 `json:"ids"`
+			TargetComment string  `json:"target_comment"`
+			SourceComment string  `json:"source_comment"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			return 0, nil, err
+		}
+		job, err := b.MergeTickets(id(a[0]), body.IDs, body.TargetComment, body.SourceComment)
+		return ok(&zendesk.APIPayload{JobStatus: job}, err)
+	})
+	s.handle("GET", `tickets/show_many\.json`, func(w res, r req, in payload, a args) (int, interface{}, error) {
+		tickets, err := b.ShowManyTickets(ids(r.URL.Query().Get("ids")), includes(r)...)
+		return ok(sideloaded(&zendesk.APIPayload{Tickets: tickets}, ticketSideloads(tickets)), err)
+	})
+	s.handle("POST", `tickets\.json`, func(w res, r req, in payload, a args) (int, interface{}, error) {
+		if in.Ticket == nil {
+			return 0, nil, fmt.Errorf("missing ticket")
+		}
+		t, err := b.CreateTicket(in.Ticket)
+		return created(&zendesk.APIPayload{Ticket: t}, err)
+	})
+	s.handle("POST", `imports/tickets\.json`, func(w res, r req, in payload, a args) (int, interface{}, error) {
+		var body struct {
+			Ticket *zendesk.TicketImport `json:"ticket"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil || body.Ticket == nil {
+			return 0, nil, fmt.Errorf("missing ticket")
+		}
+		t, err := b.ImportTicket(body.Ticket)
+		return created(&zendesk.APIPayload{Ticket: t}, err)
+	})
+	s.handle("POST", `imports/tickets/create_many\.json`, func(w res, r req, in payload, a args) (int, interface{}, error) {
+		var body struct {
+			Tickets []zendesk.TicketImport `json:"tickets"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			return 0, nil, fmt.Errorf("missing tickets")
+		}
+		job, err := b.BulkImportTickets(body.Tickets)
+		return ok(&zendesk.APIPayload{JobStatus: job}, err)
+	})
+	s.handle("GET", `deleted_tickets\.json`, func(w res, r req, in payload, a args) (int, interface{}, error) {
+		tickets, err := b.ListDeletedTickets()
+		return ok(&zendesk.APIPayload{DeletedTickets: tickets}, err)
+	})
+	s.handle("PUT", `deleted_tickets/restore_many\.json`, func(w res, r req, in payload, a args) (int, interface{}, error) {
+		return http.StatusOK, nil, b.RestoreManyTickets(ids(r.URL.Query().Get("ids")))
+	})
+	s.handle("PUT", `deleted_tickets/(\d+)/restore\.json`, func(w res, r req, in payload, a args) (int, interface{}, error) {
+		return http.StatusOK, nil, b.RestoreTicket(id(a[0]))
+	})
+	s.handle("DELETE", `deleted_tickets/destroy_many\.json`, func(w res, r req, in payload, a args) (int, interface{}, error) {
+		job, err := b.PurgeManyTickets(ids(r.URL.Query().Get("ids")))
+		return ok(&zendesk.APIPayload{JobStatus: job}, err)
+	})
+	s.handle("DELETE", `deleted_tickets/(\d+)\.json`, func(w res, r req, in payload, a args) (int, interface{}, error) {
+		job, err := b.PurgeTicket(id(a[0]))
+		return ok(&zendesk.APIPayload{JobStatus: job}, err)
+	})
+	s.handle("PUT", `tickets/update_many\.json`, func(w res, r req, in payload, a args) (int, interface{}, error) {
+		var job *zendesk.JobStatus
+		var err error
+		if list := r.URL.Query().Get("ids"); list != "" && in.Ticket != nil {
+			job, err = b.BulkUpdateManyTickets(ids(list), in.Ticket)
+		} else {
+			job, err = b.BatchUpdateManyTickets(in.Tickets)
+		}
+		return ok(&zendesk.APIPayload{JobStatus: job}, err)
+	})
+	s.handle("PUT", `tickets/(\d+)\.json`, func(w res, r req, in payload, a args) (int, interface{}, error) {
+		if in.Ticket == nil {
+			return 0, nil, fmt.Errorf("missing ticket")
+		}
+		t, err := b.UpdateTicket(id(a[0]), in.Ticket)
+		return ok(&zendesk.APIPayload{Ticket: t}, err)
+	})
+	s.handle("DELETE", `tickets/destroy_many\.json`, func(w res, r req, in payload, a args) (int, interface{}, error) {
+		job, err := b.DeleteManyTickets(ids(r.URL.Query().Get("ids")))
+		return ok(&zendesk.APIPayload{JobStatus: job}, err)
+	})
+	s.handle("DELETE", `tickets/(\d+)\.json`, func(w res, r req, in payload, a args) (int, interface{}, error) {
+		return noContent(b.DeleteTicket(id(a[0])))
+	})
+	s.handle("PUT", `tickets/(\d+)/tags\.json`, func(w res, r req, in payload, a args) (int, interface{}, error) {
+		if stamp, safe := safeUpdate(r); safe {
+			tags, err := b.AddTicketTagsSafely(id(a[0]), in.Tags, stamp)
+			return ok(&zendesk.APIPayload{Tags: tags}, err)
+		}
+		tags, err := b.AddTicketTags(id(a[0]), in.Tags)
+		return ok(&zendesk.APIPayload{Tags: tags}, err)
+	})
+	s.handle("POST", `tickets/(\d+)/tags\.json`, func(w res, r req, in payload, a args) (int, interface{}, error) {
+		tags, err := b.SetTicketTags(id(a[0]), in.Tags)
+		return created(&zendesk.APIPayload{Tags: tags}, err)
+	})
+	s.handle("DELETE", `tickets/(\d+)/tags\.json`, func(w res, r req, in payload, a args) (int, interface{}, error) {
+		if stamp, safe := safeUpdate(r); safe {
+			return noContent(b.RemoveTicketTagsSafely(id(a[0]), in.Tags, stamp))
+		}
+		return noContent(b.RemoveTicketTags(id(a[0]), in.Tags))
+	})
+	s.handle("GET", `tickets/(\d+)/comments\.json`, func(w res, r req, in payload, a args) (int, interface{}, error) {
+		opts := &zendesk.ListTicketCommentsOptions{SortOrder: r.URL.Query().Get("sort_order")}
+		comments, err := b.ListTicketCommentsWithOptions(id(a[0]), opts)
+		return ok(&zendesk.APIPayload{Comments: comments}, err)
+	})
+	s.handle("GET", `tickets/(\d+)/comments/count\.json`, func(w res, r req, in payload, a args) (int, interface{}, error) {
+		count, err := b.CountTicketComments(id(a[0]))
+		return http.StatusOK, map[string]interface{}{"count": map[string]int64{"value": count}}, err
+	})
+	s.handle("GET", `tickets/(\d+)/audits\.json`, func(w res, r req, in payload, a args) (int, interface{}, error) {
+		audits, err := b.ListTicketAudits(id(a[0]))
+		return ok(&zendesk.APIPayload{Audits: audits}, err)
+	})
+	s.handle("GET", `tickets/(\d+)/audits/(\d+)\.json`, func(w res, r req, in payload, a args) (int, interface{}, error) {
+		audit, err := b.ShowTicketAudit(id(a[0]), id(a[1]))
+		return ok(&zendesk.APIPayload{Audit: audit}, err)
+	})
+	s.handle("PUT", `tickets/(\d+)/comments/(\d+)/redact\.json`, func(w res, r req, in payload, a args) (int, interface{}, error) {
+		var body struct {
+			Text string `json:"text"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil || body.Text == "" {
+			return 0, nil, fmt.Errorf("missing text")
+		}
+		comment, err := b.RedactCommentString(id(a[0]), id(a[1]), body.Text)
+		return ok(&zendesk.APIPayload{Comment: comment}, err)
+	})
+	s.handle("PUT", `tickets/(\d+)/comments/(\d+)/attachments/(\d+)/redact\.json`, func(w res, r req, in payload, a args) (int, interface{}, error) {
+		attachment, err := b.RedactTicketCommentAttachment(id(a[0]), id(a[1]), id(a[2]))
+		return ok(&zendesk.APIPayload{Attachment: attachment}, err)
+	})
+	s.handle("PUT", `comment_redactions/(\d+)\.json`, func(w res, r req, in payload, a args) (int, interface{}, error) {
+		var redaction zendesk.CommentRedaction
+		if err := json.NewDecoder(r.Body).Decode(&redaction); err != nil {
+			return 0, nil, err
+		}
+		comment, err := b.RedactTicketComment(id(a[0]), &redaction)
+		return ok(&zendesk.APIPayload{Comment: comment}, err)
+	})
+	s.handle("PUT", `tickets/(\d+)/comments/(\d+)/make_private\.json`, func(w res, r req, in payload, a args) (int, interface{}, error) {
+		return ok(&zendesk.APIPayload{}, b.MakeCommentPrivate(id(a[0]), id(a[1])))
+	})
+	s.handle("GET", `users/(\d+)/tickets/assigned\.json`, func(w res, r req, in payload, a args) (int, interface{}, error) {
+		tickets, err := b.ListAssignedTickets(id(a[0]), includes(r)...)
+		return ok(sideloaded(&zendesk.APIPayload{Tickets: tickets}, ticketSideloads(tickets)), err)
+	})
+	s.handle("GET", `users/(\d+)/tickets/ccd\.json`, func(w res, r req, in payload, a args) (int, interface{}, error) {
+		tickets, err := b.ListCCdTickets(id(a[0]), includes(r)...)
+		return ok(sideloaded(&zendesk.APIPayload{Tickets: tickets}, ticketSideloads(tickets)), err)
+	})
+	s.handle("GET", `tickets/(\d+)/incidents\.json`, func(w res, r req, in payload, a args) (int, interface{}, error) {
+		tickets, err := b.ListTicketIncidents(id(a[0]), includes(r)...)
+		return ok(sideloaded(&zendesk.APIPayload{Tickets: tickets}, ticketSideloads(tickets)), err)
+	})
+	s.handle("GET", `users/(\d+)/tickets/requested\.json`, func(w res, r req, in payload, a args) (int, interface{}, error) {
+		tickets, err := b.ListRequestedTickets(id(a[0]), includes(r)...)
+		return ok(sideloaded(&zendesk.APIPayload{Tickets: tickets}, ticketSideloads(tickets)), err)
+	})
+	s.handle("GET", `incremental/tickets/cursor\.json`, func(w res, r req, in payload, a args) (int, interface{}, error) {
+		out := &zendesk.APIPayload{Tickets: make([]zendesk.Ticket, 0), EndOfStream: true}
+		checkpoint, err := b.ExportTickets(exportOptions(r), zendesk.SinkFunc(func(record interface{}) error {
+			out.Tickets = append(out.Tickets, *record.(*zendesk.Ticket))
+			return nil
+		}))
+		if err != nil {
+			return 0, nil, err
+		}
+		out.AfterCursor = checkpoint.Cursor
+		return ok(out, nil)
+	})
+	s.handle("GET", `incremental/tickets\.json`, func(w res, r req, in payload, a args) (int, interface{}, error) {
+		export, err := b.GetTicketsIncrementallyWithOptions(&zendesk.IncrementalExportOptions{StartTime: startTime(r)})
+		if err != nil {
+			return 0, nil, err
+		}
+		return ok(s.incremental(r, &zendesk.APIPayload{Tickets: export.Tickets, EndTime: export.EndTime}), nil)
+	})
+	s.handle("GET", `job_statuses/([^/]+)\.json`, func(w res, r req, in payload, a args) (int, interface{}, error) {
+		job, err := b.ShowJobStatus(a[0])
+		return ok(&zendesk.APIPayload{JobStatus: job}, err)
+	})
+	s.handle("POST", `uploads\.json`, func(w res, r req, in payload, a args) (int, interface{}, error) {
+		upload, err := b.UploadFile(r.URL.Query().Get("filename"), r.URL.Query().Get("token"), r.Body)
+		return created(&zendesk.APIPayload{Upload: upload}, err)
+	})
+	s.handle("DELETE", `uploads/([^/]+)\.json`, func(w res, r req, in payload, a args) (int, interface{}, error) {
+		return http.StatusNoContent, nil, b.DeleteUpload(a[0])
+	})
+
+	// Ticket metrics and satisfaction ratings
+	s.handle("GET", `ticket_metrics/(\d+)\.json`, func(w res, r req, in payload, a args) (int, interface{}, error) {
+		metric, err := b.ShowTicketMetric(id(a[0]))
+		return ok(&zendesk.APIPayload{TicketMetric: metric}, err)
+	})
+	s.handle("GET", `tickets/(\d+)/metrics\.json`, func(w res, r req, in payload, a args) (int, interface{}, error) {
+		metrics, err := b.GetTicketMetricsIncrementally([]int64{id(a[0])})
+		if err == nil && len(metrics) == 0 {
+			err = notFound("ticket metric for ticket", a[0])
+		}
+		if err != nil {
+			return 0, nil, err
+		}
+		return ok(&zendesk.APIPayload{TicketMetric: &metrics[0]}, nil)
+	})
+	s.handle("GET", `incremental/ticket_metric_events\.json`, func(w res, r req, in payload, a args) (int, interface{}, error) {
+		events, err := b.GetTicketMetricEventsIncrementally(startTime(r))
+		return ok(s.incremental(r, &zendesk.APIPayload{TicketMetricEvents: events}), err)
+	})
+	s.handle("GET", `satisfaction_ratings\.json`, func(w res, r req, in payload, a args) (int, interface{}, error) {
+		q := r.URL.Query()
+		if q.Get("page[size]") != "" {
+			scores := make([]zendesk.Score, 0)
+			checkpoint, err := b.ExportSatisfactionRatings(&zendesk.IncrementalExportOptions{StartTime: startTime(r), Cursor: q.Get("page[after]")}, zendesk.SinkFunc(func(record interface{}) error {
+				scores = append(scores, *record.(*zendesk.Score))
+				return nil
+			}))
+			if err != nil {
+				return 0, nil, err
+			}
+			return http.StatusOK, map[string]interface{}{
+				"satisfaction_ratings": scores,
+				"meta":                 map[string]interface{}{"has_more": false, "after_cursor": checkpoint.Cursor},
+				"links":                map[string]interface{}{"next": nil},
+			}, nil
+		}
+		opts := &zendesk.ListSatisfactionRatingsOptions{Score: q.Get("score"), StartTime: startTime(r), EndTime: id(q.Get("end_time"))}
+		scores, err := b.ListSatisfactionRatings(opts)
+		return ok(&zendesk.APIPayload{SatisfactionRatings: scores}, err)
+	})
+	s.handle("GET", `satisfaction_ratings/(\d+)\.json`, func(w res, r req, in payload, a args) (int, interface{}, error) {
+		score, err := b.ShowSatisfactionRating(id(a[0]))
+		return ok(&zendesk.APIPayload{SatisfactionRating: score}, err)
+	})
+	s.handle("POST", `tickets/(\d+)/satisfaction_rating\.json`, func(w res, r req, in payload, a args) (int, interface{}, error) {
+		if in.SatisfactionRating == nil {
+			return 0, nil, fmt.Errorf("missing satisfaction rating")
+		}
+		score, err := b.CreateSatisfactionRating(id(a[0]), in.SatisfactionRating)
+		return ok(&zendesk.APIPayload{SatisfactionRating: score}, err)
+	})
+	s.handle("GET", `satisfaction_reasons\.json`, func(w res, r req, in payload, a args) (int, interface{}, error) {
+		reasons, err := b.ListSatisfactionRatingReasons()
+		return ok(&zendesk.APIPayload{SatisfactionReasons: reasons}, err)
+	})
+	s.handle("GET", `channels/voice/stats/incremental/legs(?:\.json)?`, func(w res, r req, in payload, a args) (int, interface{}, error) {
+		opts := &zendesk.IncrementalCallExportOptions{StartTime: startTime(r), Cursor: r.URL.Query().Get("cursor")}
+		if include := r.URL.Query().Get("include"); include != "" {
+			opts.Include = strings.Split(include, ",")
+		}
+		export, err := b.GetCallLegsIncrementallyWithOptions(opts)
+		if err != nil {
+			return 0, nil, err
+		}
+		return ok(s.incremental(r, &zendesk.APIPayload{CallLegs: export.CallLegs, Agents: export.Agents, AfterCursor: export.AfterCursor}), nil)
+	})
+
+	s.handle("GET", `channels/voice/phone_numbers\.json`, func(w res, r req, in payload, a args) (int, interface{}, error) {
+		numbers, err := b.ListPhoneNumbers()
+		return ok(&zendesk.APIPayload{PhoneNumbers: numbers}, err)
+	})
+	s.handle("GET", `channels/voice/lines\.json`, func(w res, r req, in payload, a args) (int, interface{}, error) {
+		lines, err := b.ListLines()
+		return ok(&zendesk.APIPayload{Lines: lines}, err)
+	})
+	s.handle("GET", `channels/voice/greetings\.json`, func(w res, r req, in payload, a args) (int, interface{}, error) {
+		greetings, err := b.ListGreetings()
+		return ok(&zendesk.APIPayload{Greetings: greetings}, err)
+	})
+	s.handle("GET", `channels/voice/availabilities/(\d+)\.json`, func(w res, r req, in payload, a args) (int, interface{}, error) {
+		availability, err := b.ShowAgentAvailability(id(a[0]))
+		return ok(&zendesk.APIPayload{Availability: availability}, err)
+	})
+	s.handle("GET", `channels/voice/stats/incremental/calls(?:\.json)?`, func(w res, r req, in payload, a args) (int, interface{}, error) {
+		calls, err := b.GetCallsIncrementally(startTime(r))
+		return ok(s.incremental(r, &zendesk.APIPayload{Calls: calls}), err)
+	})
+
+	// Help Center
+	s.handle("GET", `help_center/categories\.json`, func(w res, r req, in payload, a args) (int, interface{}, error) {
+		categories, err := b.ListCategories()
+		return ok(&zendesk.APIPayload{Categories: categories}, err)
+	})
+	s.handle("POST", `help_center/categories\.json`, func(w res, r req, in payload, a args) (int, interface{}, error) {
+		if in.Category == nil {
+			return 0, nil, fmt.Errorf("missing category")
+		}
+		category, err := b.CreateCategory(in.Category)
+		return created(&zendesk.APIPayload{Category: category}, err)
+	})
+	s.handle("GET", `help_center/categories/(\d+)\.json`, func(w res, r req, in payload, a args) (int, interface{}, error) {
+		category, err := b.ShowCategory(id(a[0]))
+		return ok(&zendesk.APIPayload{Category: category}, err)
+	})
+	s.handle("PUT", `help_center/categories/(\d+)\.json`, func(w res, r req, in payload, a args) (int, interface{}, error) {
+		if in.Category == nil {
+			return 0, nil, fmt.Errorf("missing category")
+		}
+		category, err := b.UpdateCategory(id(a[0]), in.Category)
+		return ok(&zendesk.APIPayload{Category: category}, err)
+	})
+	s.handle("DELETE", `help_center/categories/(\d+)\.json`, func(w res, r req, in payload, a args) (int, interface{}, error) {
+		return noContent(b.DeleteCategory(id(a[0])))
+	})
+	s.handle("GET", `help_center/sections\.json`, func(w res, r req, in payload, a args) (int, interface{}, error) {
+		sections, err := b.ListSections(0)
+		return ok(&zendesk.APIPayload{Sections: sections}, err)
+	})
+	s.handle("GET", `help_center/categories/(\d+)/sections\.json`, func(w res, r req, in payload, a args) (int, interface{}, error) {
+		sections, err := b.ListSections(id(a[0]))
+		return ok(&zendesk.APIPayload{Sections: sections}, err)
+	})
+	s.handle("POST", `help_center/categories/(\d+)/sections\.json`, func(w res, r req, in payload, a args) (int, interface{}, error) {
+		if in.Section == nil {
+			return 0, nil, fmt.Errorf("missing section")
+		}
+		section, err := b.CreateSection(id(a[0]), in.Section)
+		return created(&zendesk.APIPayload{Section: section}, err)
+	})
+	s.handle("GET", `help_center/sections/(\d+)\.json`, func(w res, r req, in payload, a args) (int, interface{}, error) {
+		section, err := b.ShowSection(id(a[0]))
+		return ok(&zendesk.APIPayload{Section: section}, err)
+	})
+	s.handle("PUT", `help_center/sections/(\d+)\.json`, func(w res, r req, in payload, a args) (int, interface{}, error) {
+		if in.Section == nil {
+			return 0, nil, fmt.Errorf("missing section")
+		}
+		section, err := b.UpdateSection(id(a[0]), in.Section)
+		return ok(&zendesk.APIPayload{Section: section}, err)
+	})
+	s.handle("DELETE", `help_center/sections/(\d+)\.json`, func(w res, r req, in payload, a args) (int, interface{}, error) {
+		return noContent(b.DeleteSection(id(a[0])))
+	})
+	s.handle("GET", `help_center/articles\.json`, func(w res, r req, in payload, a args) (int, interface{}, error) {
+		articles, err := b.ListArticles(0)
+		return ok(&zendesk.APIPayload{Articles: articles}, err)
+	})
+	s.handle("GET", `help_center/articles/search\.json`, func(w res, r req, in payload, a args) (int, interface{}, error) {
+		articles, err := b.SearchArticles(r.URL.Query().Get("query"), r.URL.Query().Get("locale"))
+		return http.StatusOK, map[string]interface{}{"results": articles, "count": len(articles)}, err
+	})
+	s.handle("GET", `help_center/sections/(\d+)/articles\.json`, func(w res, r req, in payload, a args) (int, interface{}, error) {
+		articles, err := b.ListArticles(id(a[0]))
+		return ok(&zendesk.APIPayload{Articles: articles}, err)
+	})
+	s.handle("POST", `help_center/sections/(\d+)/articles\.json`, func(w res, r req, in payload, a args) (int, interface{}, error) {
+		if in.Article == nil {
+			return 0, nil, fmt.Errorf("missing article")
+		}
+		article, err := b.CreateArticle(id(a[0]), in.Article)
+		return created(&zendesk.APIPayload{Article: article}, err)
+	})
+	s.handle("GET", `help_center/articles/(\d+)\.json`, func(w res, r req, in payload, a args) (int, interface{}, error) {
+		article, err := b.ShowArticle(id(a[0]))
+		return ok(&zendesk.APIPayload{Article: article}, err)
+	})
+	s.handle("PUT", `help_center/articles/(\d+)\.json`, func(w res, r req, in payload, a args) (int, interface{}, error) {
+		if in.Article == nil {
+			return 0, nil, fmt.Errorf("missing article")
+		}
+		article, err := b.UpdateArticle(id(a[0]), in.Article)
+		return ok(&zendesk.APIPayload{Article: article}, err)
+	})
+	s.handle("DELETE", `help_center/articles/(\d+)\.json`, func(w res, r req, in payload, a args) (int, interface{}, error) {
+		return noContent(b.DeleteArticle(id(a[0])))
+	})
+	s.handle("GET", `help_center/articles/(\d+)/translations\.json`, func(w res, r req, in payload, a args) (int, interface{}, error) {
+		translations, err := b.ListArticleTranslations(id(a[0]))
+		return ok(&zendesk.APIPayload{Translations: translations}, err)
+	})
+	s.handle("POST", `help_center/articles/(\d+)/translations\.json`, func(w res, r req, in payload, a args) (int, interface{}, error) {
+		if in.Translation == nil {
+			return 0, nil, fmt.Errorf("missing translation")
+		}
+		translation, err := b.CreateArticleTranslation(id(a[0]), in.Translation)
+		return created(&zendesk.APIPayload{Translation: translation}, err)
+	})
+	s.handle("GET", `help_center/articles/(\d+)/translations/([\w-]+)\.json`, func(w res, r req, in payload, a args) (int, interface{}, error) {
+		translation, err := b.ShowArticleTranslation(id(a[0]), a[1])
+		return ok(&zendesk.APIPayload{Translation: translation}, err)
+	})
+	s.handle("PUT", `help_center/articles/(\d+)/translations/([\w-]+)\.json`, func(w res, r req, in payload, a args) (int, interface{}, error) {
+		if in.Translation == nil {
+			return 0, nil, fmt.Errorf("missing translation")
+		}
+		translation, err := b.UpdateArticleTranslation(id(a[0]), a[1], in.Translation)
+		return ok(&zendesk.APIPayload{Translation: translation}, err)
+	})
+
+	s.handle("GET", `help_center/articles/(\d+)/attachments\.json`, func(w res, r req, in payload, a args) (int, interface{}, error) {
+		attachments, err := b.ListArticleAttachments(id(a[0]))
+		return ok(&zendesk.APIPayload{ArticleAttachments: attachments}, err)
+	})
+	s.handle("POST", `help_center/articles/(\d+)/attachments\.json`, func(w res, r req, in payload, a args) (int, interface{}, error) {
+		file, header, err := r.FormFile("file")
+		if err != nil {
+			return 0, nil, err
+		}
+		defer file.Close()
+		inline, _ := strconv.ParseBool(r.FormValue("inline"))
+		attachment, err := b.CreateArticleAttachment(id(a[0]), header.Filename, file, inline)
+		return created(&zendesk.APIPayload{ArticleAttachment: attachment}, err)
+	})
+	s.handle("POST", `help_center/articles/(\d+)/(up|down)\.json`, func(w res, r req, in payload, a args) (int, interface{}, error) {
+		vote := b.VoteArticleUp
+		if a[1] == "down" {
+			vote = b.VoteArticleDown
+		}
+		v, err := vote(id(a[0]))
+		return ok(&zendesk.APIPayload{Vote: v}, err)
+	})
+	s.handle("GET", `help_center/articles/(\d+)/comments\.json`, func(w res, r req, in payload, a args) (int, interface{}, error) {
+		comments, err := b.ListArticleComments(id(a[0]))
+		return http.StatusOK, map[string]interface{}{"comments": comments}, err
+	})
+
+	// Users
+	s.handle("GET", `users\.json`, func(w res, r req, in payload, a args) (int, interface{}, error) {
+		users, err := b.ListUsers(&zendesk.ListUsersOptions{Role: r.URL.Query()["role"]}, includes(r)...)
+		return ok(sideloaded(&zendesk.APIPayload{Users: users}, userSideloads(users)), err)
+	})
+	s.handle("POST", `users\.json`, func(w res, r req, in payload, a args) (int, interface{}, error) {
+		if in.User == nil {
+			return 0, nil, fmt.Errorf("missing user")
+		}
+		u, err := b.CreateUser(in.User)
+		return created(&zendesk.APIPayload{User: u}, err)
+	})
+	s.handle("POST", `users/create_or_update\.json`, func(w res, r req, in payload, a args) (int, interface{}, error) {
+		if in.User == nil {
+			return 0, nil, fmt.Errorf("missing user")
+		}
+		u, err := b.CreateOrUpdateUser(in.User)
+		return ok(&zendesk.APIPayload{User: u}, err)
+	})
+	s.handle("GET", `users/show_many\.json`, func(w res, r req, in payload, a args) (int, interface{}, error) {
+		users, err := b.ShowManyUsers(ids(r.URL.Query().Get("ids")), includes(r)...)
+		return ok(sideloaded(&zendesk.APIPayload{Users: users}, userSideloads(users)), err)
+	})
+	s.handle("GET", `users/search\.json`, func(w res, r req, in payload, a args) (int, interface{}, error) {
+		users, err := b.SearchUsers(r.URL.Query().Get("query"), includes(r)...)
+		return ok(sideloaded(&zendesk.APIPayload{Users: users}, userSideloads(users)), err)
+	})
+	s.handle("GET", `deleted_users\.json`, func(w res, r req, in payload, a args) (int, interface{}, error) {
+		users, err := b.ListDeletedUsers()
+		return ok(&zendesk.APIPayload{DeletedUsers: users}, err)
+	})
+	s.handle("GET", `deleted_users/(\d+)\.json`, func(w res, r req, in payload, a args) (int, interface{}, error) {
+		user, err := b.ShowDeletedUser(id(a[0]))
+		return ok(&zendesk.APIPayload{DeletedUser: user}, err)
+	})
+	s.handle("DELETE", `deleted_users/(\d+)\.json`, func(w res, r req, in payload, a args) (int, interface{}, error) {
+		user, err := b.PermanentlyDeleteUser(id(a[0]))
+		return ok(&zendesk.APIPayload{DeletedUser: user}, err)
+	})
+	s.handle("POST", `users/create_many\.json`, func(w res, r req, in payload, a args) (int, interface{}, error) {
+		job, err := b.CreateManyUsers(in.Users)
+		return ok(&zendesk.APIPayload{JobStatus: job}, err)
+	})
+	s.handle("PUT", `users/update_many\.json`, func(w res, r req, in payload, a args) (int, interface{}, error) {
+		job, err := b.UpdateManyUsers(in.Users)
+		return ok(&zendesk.APIPayload{JobStatus: job}, err)
+	})
+	s.handle("POST", `users/create_or_update_many\.json`, func(w res, r req, in payload, a args) (int, interface{}, error) {
+		job, err := b.CreateOrUpdateManyUsers(in.Users)
+		return ok(&zendesk.APIPayload{JobStatus: job}, err)
+	})
+	s.handle("DELETE", `users/destroy_many\.json`, func(w res, r req, in payload, a args) (int, interface{}, error) {
+		job, err := b.DestroyManyUsers(ids(r.URL.Query().Get("ids")))
+		return ok(&zendesk.APIPayload{JobStatus: job}, err)
+	})
+	s.handle("GET", `users/me\.json`, func(w res, r req, in payload, a args) (int, interface{}, error) {
+		user, err := b.ShowCurrentUser()
+		return ok(&zendesk.APIPayload{User: user}, err)
+	})
+	s.handle("GET", `users/me/session\.json`, func(w res, r req, in payload, a args) (int, interface{}, error) {
+		session, err := b.ShowCurrentlyAuthenticatedSession()
+		return http.StatusOK, map[string]interface{}{"session": session}, err
+	})
+	s.handle("GET", `users/(\d+)/sessions\.json`, func(w res, r req, in payload, a args) (int, interface{}, error) {
+		sessions, err := b.ListSessions(id(a[0]))
+		return http.StatusOK, map[string]interface{}{"sessions": sessions}, err
+	})
+	s.handle("DELETE", `users/(\d+)/sessions\.json`, func(w res, r req, in payload, a args) (int, interface{}, error) {
+		return noContent(b.BulkDeleteSessionsByUserID(id(a[0])))
+	})
+	s.handle("DELETE", `users/(\d+)/sessions/(\d+)\.json`, func(w res, r req, in payload, a args) (int, interface{}, error) {
+		return noContent(b.DeleteSession(id(a[0]), id(a[1])))
+	})
+	s.handle("PUT", `users/me/merge\.json`, func(w res, r req, in payload, a args) (int, interface{}, error) {
+		var body struct {
+			User struct {
+				Email    string `json:"email"`
+				Password string `json:"password"`
+			} `json:"user"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			return 0, nil, err
+		}
+		user, err := b.MergeSelfWithUser(body.User.Email, body.User.Password)
+		return ok(&zendesk.APIPayload{User: user}, err)
+	})
+	s.handle("PUT", `users/(\d+)/merge\.json`, func(w res, r req, in payload, a args) (int, interface{}, error) {
+		if in.User == nil {
+			return 0, nil, fmt.Errorf("missing user")
+		}
+		user, err := b.MergeUsers(id(a[0]), in.User.ID)
+		return ok(&zendesk.APIPayload{User: user}, err)
+	})
+	s.handle("GET", `users/(\d+)/related\.json`, func(w res, r req, in payload, a args) (int, interface{}, error) {
+		related, err := b.ShowUserRelated(id(a[0]))
+		return ok(&zendesk.APIPayload{UserRelated: related}, err)
+	})
+	s.handle("GET", `users/(\d+)\.json`, func(w res, r req, in payload, a args) (int, interface{}, error) {
+		u, err := b.ShowUser(id(a[0]), includes(r)...)
+		if err != nil {
+			return 0, nil, err
+		}
+		return ok(sideloaded(&zendesk.APIPayload{User: u}, u.Sideloads), nil)
+	})
+	s.handle("PUT", `users/(\d+)\.json`, func(w res, r req, in payload, a args) (int, interface{}, error) {
+		if in.User == nil {
+			return 0, nil, fmt.Errorf("missing user")
+		}
+		u, err := b.UpdateUser(id(a[0]), in.User)
+		return ok(&zendesk.APIPayload{User: u}, err)
+	})
+	s.handle("DELETE", `users/(\d+)\.json`, func(w res, r req, in payload, a args) (int, interface{}, error) {
+		u, err := b.DeleteUser(id(a[0]))
+		return ok(&zendesk.APIPayload{User: u}, err)
+	})
+	s.handle("PUT", `users/(\d+)/tags\.json`, func(w res, r req, in payload, a args) (int, interface{}, error) {
+		tags, err := b.AddUserTags(id(a[0]), in.Tags)
+		return ok(&zendesk.APIPayload{Tags: tags}, err)
+	})
+	s.handle("POST", `users/(\d+)/tags\.json`, func(w res, r req, in payload, a args) (int, interface{}, error) {
+		tags, err := b.SetUserTags(id(a[0]), in.Tags)
+		return created(&zendesk.APIPayload{Tags: tags}, err)
+	})
+	s.handle("DELETE", `users/(\d+)/tags\.json`, func(w res, r req, in payload, a args) (int, interface{}, error) {
+		return noContent(b.RemoveUserTags(id(a[0]), in.Tags))
+	})
+	s.handle("GET", `tags\.json`, func(w res, r req, in payload, a args) (int, interface{}, error) {
+		tags, err := b.ListTags()
+		return http.StatusOK, map[string]interface{}{"tags": tags, "count": len(tags)}, err
+	})
+	s.handle("GET", `autocomplete/tags\.json`, func(w res, r req, in payload, a args) (int, interface{}, error) {
+		tags, err := b.AutocompleteTags(r.URL.Query().Get("name"))
+		return ok(&zendesk.APIPayload{Tags: tags}, err)
+	})
+	s.handle("GET", `incremental/users/cursor\.json`, func(w res, r req, in payload, a args) (int, interface{}, error) {
+		out := &zendesk.APIPayload{Users: make([]zendesk.User, 0), EndOfStream: true}
+		checkpoint, err := b.ExportUsers(exportOptions(r), zendesk.SinkFunc(func(record interface{}) error {
+			out.Users = append(out.Users, *record.(*zendesk.User))
+			return nil
+		}))
+		if err != nil {
+			return 0, nil, err
+		}
+		out.AfterCursor = checkpoint.Cursor
+		return ok(out, nil)
+	})
+	s.handle("GET", `incremental/users\.json`, func(w res, r req, in payload, a args) (int, interface{}, error) {
+		users, err := b.GetUsersIncrementally(startTime(r))
+		return ok(s.incremental(r, &zendesk.APIPayload{Users: users}), err)
+	})
+
+	// Identities
+	s.handle("GET", `users/(\d+)/identities\.json`, func(w res, r req, in payload, a args) (int, interface{}, error) {
+		identities, err := b.ListIdentities(id(a[0]))
+		return ok(&zendesk.APIPayload{Identities: identities}, err)
+	})
+	s.handle("POST", `users/(\d+)/identities\.json`, func(w res, r req, in payload, a args) (int, interface{}, error) {
+		if in.Identity == nil {
+			return 0, nil, fmt.Errorf("missing identity")
+		}
+		identity, err := b.CreateIdentity(id(a[0]), in.Identity)
+		return created(&zendesk.APIPayload{Identity: identity}, err)
+	})
+	s.handle("GET", `users/(\d+)/identities/(\d+)\.json`, func(w res, r req, in payload, a args) (int, interface{}, error) {
+		identity, err := b.ShowIdentity(id(a[0]), id(a[1]))
+		return ok(&zendesk.APIPayload{Identity: identity}, err)
+	})
+	s.handle("PUT", `users/(\d+)/identities/(\d+)\.json`, func(w res, r req, in payload, a args) (int, interface{}, error) {
+		if in.Identity == nil {
+			return 0, nil, fmt.Errorf("missing identity")
+		}
+		identity, err := b.UpdateIdentity(id(a[0]), id(a[1]), in.Identity)
+		return ok(&zendesk.APIPayload{Identity: identity}, err)
+	})
+	s.handle("DELETE", `users/(\d+)/identities/(\d+)\.json`, func(w res, r req, in payload, a args) (int, interface{}, error) {
+		return noContent(b.DeleteIdentity(id(a[0]), id(a[1])))
+	})
+	s.handle("PUT", `users/(\d+)/identities/(\d+)/make_primary\.json`, func(w res, r req, in payload, a args) (int, interface{}, error) {
+		identities, err := b.MakeIdentityPrimary(id(a[0]), id(a[1]))
+		return ok(&zendesk.APIPayload{Identities: identities}, err)
+	})
+	s.handle("PUT", `users/(\d+)/identities/(\d+)/verify\.json`, func(w res, r req, in payload, a args) (int, interface{}, error) {
+		identity, err := b.VerifyIdentity(id(a[0]), id(a[1]))
+		return ok(&zendesk.APIPayload{Identity: identity}, err)
+	})
+	s.handle("PUT", `users/(\d+)/identities/(\d+)/request_verification\.json`, func(w res, r req, in payload, a args) (int, interface{}, error) {
+		return ok(nil, b.RequestIdentityVerification(id(a[0]), id(a[1])))
+	})
+
+	// Organizations and memberships
+	s.handle("GET", `organizations\.json`, func(w res, r req, in payload, a args) (int, interface{}, error) {
+		opts := &zendesk.ListOptions{Page: int(id(r.URL.Query().Get("page"))), PerPage: int(id(r.URL.Query().Get("per_page")))}
+		orgs, err := b.ListOrganizations(opts)
+		return ok(&zendesk.APIPayload{Organizations: orgs}, err)
+	})
+	s.handle("GET", `incremental/organizations\.json`, func(w res, r req, in payload, a args) (int, interface{}, error) {
+		out := &zendesk.APIPayload{Organizations: make([]zendesk.Organization, 0)}
+		checkpoint, err := b.ExportOrganizations(exportOptions(r), zendesk.SinkFunc(func(record interface{}) error {
+			out.Organizations = append(out.Organizations, *record.(*zendesk.Organization))
+			return nil
+		}))
+		if err != nil {
+			return 0, nil, err
+		}
+		out.EndTime = id(checkpoint.Cursor)
+		return ok(s.incremental(r, out), nil)
+	})
+	s.handle("POST", `organizations\.json`, func(w res, r req, in payload, a args) (int, interface{}, error) {
+		if in.Organization == nil {
+			return 0, nil, fmt.Errorf("missing organization")
+		}
+		org, err := b.CreateOrganization(in.Organization)
+		return created(&zendesk.APIPayload{Organization: org}, err)
+	})
+	s.handle("GET", `organizations/show_many\.json`, func(w res, r req, in payload, a args) (int, interface{}, error) {
+		orgs, err := b.ShowManyOrganizations(ids(r.URL.Query().Get("ids")))
+		return ok(&zendesk.APIPayload{Organizations: orgs}, err)
+	})
+	s.handle("GET", `organizations/autocomplete\.json`, func(w res, r req, in payload, a args) (int, interface{}, error) {
+		orgs, err := b.AutocompleteOrganizations(r.URL.Query().Get("name"))
+		return ok(&zendesk.APIPayload{Organizations: orgs}, err)
+	})
+	s.handle("PUT", `organizations/(\d+)/tags\.json`, func(w res, r req, in payload, a args) (int, interface{}, error) {
+		tags, err := b.AddOrganizationTags(id(a[0]), in.Tags)
+		return ok(&zendesk.APIPayload{Tags: tags}, err)
+	})
+	s.handle("POST", `organizations/(\d+)/tags\.json`, func(w res, r req, in payload, a args) (int, interface{}, error) {
+		tags, err := b.SetOrganizationTags(id(a[0]), in.Tags)
+		return created(&zendesk.APIPayload{Tags: tags}, err)
+	})
+	s.handle("DELETE", `organizations/(\d+)/tags\.json`, func(w res, r req, in payload, a args) (int, interface{}, error) {
+		return noContent(b.RemoveOrganizationTags(id(a[0]), in.Tags))
+	})
+	s.handle("GET", `organizations/(\d+)\.json`, func(w res, r req, in payload, a args) (int, interface{}, error) {
+		org, err := b.ShowOrganization(id(a[0]))
+		return ok(&zendesk.APIPayload{Organization: org}, err)
+	})
+	s.handle("PUT", `organizations/(\d+)\.json`, func(w res, r req, in payload, a args) (int, interface{}, error) {
+		if in.Organization == nil {
+			return 0, nil, fmt.Errorf("missing organization")
+		}
+		org, err := b.UpdateOrganization(id(a[0]), in.Organization)
+		return ok(&zendesk.APIPayload{Organization: org}, err)
+	})
+	s.handle("DELETE", `organizations/(\d+)\.json`, func(w res, r req, in payload, a args) (int, interface{}, error) {
+		return noContent(b.DeleteOrganization(id(a[0])))
+	})
+	s.handle("GET", `organizations/(\d+)/users\.json`, func(w res, r req, in payload, a args) (int, interface{}, error) {
+		users, err := b.ListOrganizationUsers(id(a[0]), &zendesk.ListUsersOptions{Role: r.URL.Query()["role"]}, includes(r)...)
+		return ok(sideloaded(&zendesk.APIPayload{Users: users}, userSideloads(users)), err)
+	})
+	s.handle("GET", `organizations/(\d+)/tickets\.json`, func(w res, r req, in payload, a args) (int, interface{}, error) {
+		tickets, err := b.GetTicketsByOrganization(id(a[0]), &zendesk.ListTicketsOptions{SortOrder: r.URL.Query().Get("sort_order")}, includes(r)...)
+		return ok(sideloaded(&zendesk.APIPayload{Tickets: tickets}, ticketSideloads(tickets)), err)
+	})
+	s.handle("GET", `users/(\d+)/organizations\.json`, func(w res, r req, in payload, a args) (int, interface{}, error) {
+		orgs, err := b.ListOrganizationsForUser(id(a[0]))
+		return ok(&zendesk.APIPayload{Organizations: orgs}, err)
+	})
+	s.handle("GET", `organization_memberships\.json`, func(w res, r req, in payload, a args) (int, interface{}, error) {
+		memberships, err := b.ListOrganizationMemberships()
+		return ok(&zendesk.APIPayload{OrganizationMemberships: memberships}, err)
+	})
+	s.handle("POST", `organization_memberships\.json`, func(w res, r req, in payload, a args) (int, interface{}, error) {
+		if in.OrganizationMembership == nil {
+			return 0, nil, fmt.Errorf("missing organization membership")
+		}
+		m, err := b.CreateOrganizationMembership(in.OrganizationMembership)
+		return created(&zendesk.APIPayload{OrganizationMembership: m}, err)
+	})
+	s.handle("POST", `organization_memberships/create_many\.json`, func(w res, r req, in payload, a args) (int, interface{}, error) {
+		job, err := b.CreateManyOrganizationMemberships(in.OrganizationMemberships)
+		return ok(&zendesk.APIPayload{JobStatus: job}, err)
+	})
+	s.handle("GET", `organization_memberships/(\d+)\.json`, func(w res, r req, in payload, a args) (int, interface{}, error) {
+		m, err := b.ShowOrganizationMembership(id(a[0]))
+		return ok(&zendesk.APIPayload{OrganizationMembership: m}, err)
+	})
+	s.handle("GET", `organizations/(\d+)/organization_memberships\.json`, func(w res, r req, in payload, a args) (int, interface{}, error) {
+		memberships, err := b.ListOrganizationMembershipsByOrgID(id(a[0]))
+		return ok(&zendesk.APIPayload{OrganizationMemberships: memberships}, err)
+	})
+	s.handle("DELETE", `organization_memberships/(\d+)\.json`, func(w res, r req, in payload, a args) (int, interface{}, error) {
+		return noContent(b.DeleteOrganizationMembershipByID(id(a[0])))
+	})
+	s.handle("GET", `users/(\d+)/organization_memberships\.json`, func(w res, r req, in payload, a args) (int, interface{}, error) {
+		memberships, err := b.ListOrganizationMembershipsByUserID(id(a[0]))
+		return ok(&zendesk.APIPayload{OrganizationMemberships: memberships}, err)
+	})
+	s.handle("PUT", `users/(\d+)/organization_memberships/(\d+)/make_default\.json`, func(w res, r req, in payload, a args) (int, interface{}, error) {
+		memberships, err := b.SetDefaultOrganizationMembership(id(a[0]), id(a[1]))
+		return ok(&zendesk.APIPayload{OrganizationMemberships: memberships}, err)
+	})
+
+	// Ticket fields, forms and triggers
+	s.handle("GET", `ticket_fields\.json`, func(w res, r req, in payload, a args) (int, interface{}, error) {
+		fields, err := b.ListTicketFields()
+		return ok(&zendesk.APIPayload{TicketFields: fields}, err)
+	})
+	s.handle("POST", `ticket_fields\.json`, func(w res, r req, in payload, a args) (int, interface{}, error) {
+		if in.TicketField == nil {
+			return 0, nil, fmt.Errorf("missing ticket field")
+		}
+		field, err := b.CreateTicketField(in.TicketField)
+		return created(&zendesk.APIPayload{TicketField: field}, err)
+	})
+	s.handle("GET", `ticket_fields/(\d+)\.json`, func(w res, r req, in payload, a args) (int, interface{}, error) {
+		field, err := b.ShowTicketField(id(a[0]))
+		return ok(&zendesk.APIPayload{TicketField: field}, err)
+	})
+	s.handle("PUT", `ticket_fields/(\d+)\.json`, func(w res, r req, in payload, a args) (int, interface{}, error) {
+		if in.TicketField == nil {
+			return 0, nil, fmt.Errorf("missing ticket field")
+		}
+		field, err := b.UpdateTicketField(id(a[0]), in.TicketField)
+		return ok(&zendesk.APIPayload{TicketField: field}, err)
+	})
+	s.handle("DELETE", `ticket_fields/(\d+)\.json`, func(w res, r req, in payload, a args) (int, interface{}, error) {
+		return noContent(b.DeleteTicketField(id(a[0])))
+	})
+	s.handle("GET", `ticket_fields/(\d+)/options\.json`, func(w res, r req, in payload, a args) (int, interface{}, error) {
+		options, err := b.ListTicketFieldOptions(id(a[0]))
+		return ok(&zendesk.APIPayload{CustomFieldOptions: options}, err)
+	})
+	s.handle("POST", `ticket_fields/(\d+)/options\.json`, func(w res, r req, in payload, a args) (int, interface{}, error) {
+		if in.CustomFieldOption == nil {
+			return 0, nil, fmt.Errorf("missing custom field option")
+		}
+		option, err := b.CreateOrUpdateTicketFieldOption(id(a[0]), in.CustomFieldOption)
+		return ok(&zendesk.APIPayload{CustomFieldOption: option}, err)
+	})
+	s.handle("DELETE", `ticket_fields/(\d+)/options/(\d+)\.json`, func(w res, r req, in payload, a args) (int, interface{}, error) {
+		return noContent(b.DeleteTicketFieldOption(id(a[0]), id(a[1])))
+	})
+	s.handle("GET", `ticket_forms\.json`, func(w res, r req, in payload, a args) (int, interface{}, error) {
+		forms, err := b.ListTicketForms()
+		return ok(&zendesk.APIPayload{TicketForms: forms}, err)
+	})
+	s.handle("POST", `ticket_forms\.json`, func(w res, r req, in payload, a args) (int, interface{}, error) {
+		if in.TicketForm == nil {
+			return 0, nil, fmt.Errorf("missing ticket form")
+		}
+		form, err := b.CreateTicketForm(in.TicketForm)
+		return created(&zendesk.APIPayload{TicketForm: form}, err)
+	})
+	s.handle("PUT", `ticket_forms/reorder\.json`, func(w res, r req, in payload, a args) (int, interface{}, error) {
+		var body struct {
+			IDs []int64 `json:"ticket_form_ids"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			return 0, nil, err
+		}
+		return ok(nil, b.ReorderTicketForms(body.IDs))
+	})
+	s.handle("GET", `ticket_forms/(\d+)\.json`, func(w res, r req, in payload, a args) (int, interface{}, error) {
+		form, err := b.ShowTicketForm(id(a[0]))
+		return ok(&zendesk.APIPayload{TicketForm: form}, err)
+	})
+	s.handle("PUT", `ticket_forms/(\d+)\.json`, func(w res, r req, in payload, a args) (int, interface{}, error) {
+		if in.TicketForm == nil {
+			return 0, nil, fmt.Errorf("missing ticket form")
+		}
+		form, err := b.UpdateTicketForm(id(a[0]), in.TicketForm)
+		return ok(&zendesk.APIPayload{TicketForm: form}, err)
+	})
+	s.handle("DELETE", `ticket_forms/(\d+)\.json`, func(w res, r req, in payload, a args) (int, interface{}, error) {
+		return noContent(b.DeleteTicketForm(id(a[0])))
+	})
+	s.handle("POST", `ticket_forms/(\d+)/clone\.json`, func(w res, r req, in payload, a args) (int, interface{}, error) {
+		form, err := b.CloneTicketForm(id(a[0]))
+		return created(&zendesk.APIPayload{TicketForm: form}, err)
+	})
+	s.handle("GET", `triggers\.json`, func(w res, r req, in payload, a args) (int, interface{}, error) {
+		b.lock()
+		triggers := b.listTriggers()
+		b.unlock()
+		return ok(&zendesk.APIPayload{Triggers: triggers}, nil)
+	})
+
+	// Brands
+	s.handle("GET", `brands\.json`, func(w res, r req, in payload, a args) (int, interface{}, error) {
+		brands, err := b.ListBrands()
+		return ok(&zendesk.APIPayload{Brands: brands}, err)
+	})
+	s.handle("GET", `brands/check_host_mapping\.json`, func(w res, r req, in payload, a args) (int, interface{}, error) {
+		q := r.URL.Query()
+		check, err := b.CheckHostMapping(q.Get("host_mapping"), q.Get("subdomain"))
+		return http.StatusOK, check, err
+	})
+	s.handle("POST", `brands\.json`, func(w res, r req, in payload, a args) (int, interface{}, error) {
+		if in.Brand == nil {
+			return 0, nil, fmt.Errorf("missing brand")
+		}
+		brand, err := b.CreateBrand(in.Brand)
+		return created(&zendesk.APIPayload{Brand: brand}, err)
+	})
+	s.handle("GET", `brands/(\d+)\.json`, func(w res, r req, in payload, a args) (int, interface{}, error) {
+		brand, err := b.ShowBrand(id(a[0]))
+		return ok(&zendesk.APIPayload{Brand: brand}, err)
+	})
+	s.handle("PUT", `brands/(\d+)\.json`, func(w res, r req, in payload, a args) (int, interface{}, error) {
+		if in.Brand == nil {
+			return 0, nil, fmt.Errorf("missing brand")
+		}
+		brand, err := b.UpdateBrand(id(a[0]), in.Brand)
+		return ok(&zendesk.APIPayload{Brand: brand}, err)
+	})
+	s.handle("DELETE", `brands/(\d+)\.json`, func(w res, r req, in payload, a args) (int, interface{}, error) {
+		return noContent(b.DeleteBrand(id(a[0])))
+	})
+
+	// Dynamic content
+	s.handle("GET", `dynamic_content/items\.json`, func(w res, r req, in payload, a args) (int, interface{}, error) {
+		items, err := b.ListDynamicContentItems()
+		return http.StatusOK, map[string]interface{}{"items": items}, err
+	})
+	s.handle("POST", `dynamic_content/items\.json`, func(w res, r req, in payload, a args) (int, interface{}, error) {
+		var body struct {
+			Item *zendesk.DynamicContentItem `json:"item"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil || body.Item == nil {
+			return 0, nil, fmt.Errorf("missing item")
+		}
+		item, err := b.CreateDynamicContentItem(body.Item)
+		return http.StatusCreated, map[string]interface{}{"item": item}, err
+	})
+	s.handle("GET", `dynamic_content/items/(\d+)\.json`, func(w res, r req, in payload, a args) (int, interface{}, error) {
+		item, err := b.ShowDynamicContentItem(id(a[0]))
+		return http.StatusOK, map[string]interface{}{"item": item}, err
+	})
+	s.handle("PUT", `dynamic_content/items/(\d+)\.json`, func(w res, r req, in payload, a args) (int, interface{}, error) {
+		var body struct {
+			Item *zendesk.DynamicContentItem `json:"item"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil || body.Item == nil {
+			return 0, nil, fmt.Errorf("missing item")
+		}
+		item, err := b.UpdateDynamicContentItem(id(a[0]), body.Item)
+		return http.StatusOK, map[string]interface{}{"item": item}, err
+	})
+	s.handle("DELETE", `dynamic_content/items/(\d+)\.json`, func(w res, r req, in payload, a args) (int, interface{}, error) {
+		return noContent(b.DeleteDynamicContentItem(id(a[0])))
+	})
+	s.handle("GET", `dynamic_content/items/(\d+)/variants\.json`, func(w res, r req, in payload, a args) (int, interface{}, error) {
+		variants, err := b.ListDynamicContentVariants(id(a[0]))
+		return http.StatusOK, map[string]interface{}{"variants": variants}, err
+	})
+	s.handle("POST", `dynamic_content/items/(\d+)/variants\.json`, func(w res, r req, in payload, a args) (int, interface{}, error) {
+		var body struct {
+			Variant *zendesk.DynamicContentVariant `json:"variant"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil || body.Variant == nil {
+			return 0, nil, fmt.Errorf("missing variant")
+		}
+		variant, err := b.CreateDynamicContentVariant(id(a[0]), body.Variant)
+		return http.StatusCreated, map[string]interface{}{"variant": variant}, err
+	})
+	s.handle("GET", `dynamic_content/items/(\d+)/variants/(\d+)\.json`, func(w res, r req, in payload, a args) (int, interface{}, error) {
+		variant, err := b.ShowDynamicContentVariant(id(a[0]), id(a[1]))
+		return http.StatusOK, map[string]interface{}{"variant": variant}, err
+	})
+	s.handle("PUT", `dynamic_content/items/(\d+)/variants/(\d+)\.json`, func(w res, r req, in payload, a args) (int, interface{}, error) {
+		var body struct {
+			Variant *zendesk.DynamicContentVariant `json:"variant"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil || body.Variant == nil {
+			return 0, nil, fmt.Errorf("missing variant")
+		}
+		variant, err := b.UpdateDynamicContentVariant(id(a[0]), id(a[1]), body.Variant)
+		return http.StatusOK, map[string]interface{}{"variant": variant}, err
+	})
+	s.handle("DELETE", `dynamic_content/items/(\d+)/variants/(\d+)\.json`, func(w res, r req, in payload, a args) (int, interface{}, error) {
+		return noContent(b.DeleteDynamicContentVariant(id(a[0]), id(a[1])))
+	})
+
+	// Sharing agreements
+	s.handle("GET", `sharing_agreements\.json`, func(w res, r req, in payload, a args) (int, interface{}, error) {
+		agreements, err := b.ListSharingAgreements()
+		return ok(&zendesk.APIPayload{SharingAgreements: agreements}, err)
+	})
+	s.handle("POST", `sharing_agreements\.json`, func(w res, r req, in payload, a args) (int, interface{}, error) {
+		if in.SharingAgreement == nil {
+			return 0, nil, fmt.Errorf("missing sharing agreement")
+		}
+		agreement, err := b.CreateSharingAgreement(in.SharingAgreement)
+		return created(&zendesk.APIPayload{SharingAgreement: agreement}, err)
+	})
+	s.handle("GET", `sharing_agreements/(\d+)\.json`, func(w res, r req, in payload, a args) (int, interface{}, error) {
+		agreement, err := b.ShowSharingAgreement(id(a[0]))
+		return ok(&zendesk.APIPayload{SharingAgreement: agreement}, err)
+	})
+	s.handle("PUT", `sharing_agreements/(\d+)\.json`, func(w res, r req, in payload, a args) (int, interface{}, error) {
+		if in.SharingAgreement == nil {
+			return 0, nil, fmt.Errorf("missing sharing agreement")
+		}
+		agreement, err := b.UpdateSharingAgreement(id(a[0]), in.SharingAgreement)
+		return ok(&zendesk.APIPayload{SharingAgreement: agreement}, err)
+	})
+	s.handle("DELETE", `sharing_agreements/(\d+)\.json`, func(w res, r req, in payload, a args) (int, interface{}, error) {
+		return noContent(b.DeleteSharingAgreement(id(a[0])))
+	})
+
+	// Custom ticket statuses
+	s.handle("GET", `custom_statuses\.json`, func(w res, r req, in payload, a args) (int, interface{}, error) {
+		query := r.URL.Query()
+		opts := new(zendesk.CustomStatusListOptions)
+		if categories := query.Get("status_categories"); categories != "" {
+			opts.StatusCategories = strings.Split(categories, ",")
+		}
+		if active := query.Get("active"); active != "" {
+			opts.Active = zendesk.Bool(active == "true")
+		}
+		if def := query.Get("default"); def != "" {
+			opts.Default = zendesk.Bool(def == "true")
+		}
+		statuses, err := b.ListCustomTicketStatuses(opts)
+		return ok(&zendesk.APIPayload{CustomStatuses: statuses}, err)
+	})
+	s.handle("POST", `custom_statuses\.json`, func(w res, r req, in payload, a args) (int, interface{}, error) {
+		if in.CustomStatus == nil {
+			return 0, nil, fmt.Errorf("missing custom status")
+		}
+		status, err := b.CreateCustomStatus(in.CustomStatus)
+		return created(&zendesk.APIPayload{CustomStatus: status}, err)
+	})
+	s.handle("GET", `custom_statuses/(\d+)\.json`, func(w res, r req, in payload, a args) (int, interface{}, error) {
+		status, err := b.ShowCustomStatus(id(a[0]))
+		return ok(&zendesk.APIPayload{CustomStatus: status}, err)
+	})
+	s.handle("PUT", `custom_statuses/(\d+)\.json`, func(w res, r req, in payload, a args) (int, interface{}, error) {
+		if in.CustomStatus == nil {
+			return 0, nil, fmt.Errorf("missing custom status")
+		}
+		status, err := b.UpdateCustomStatus(id(a[0]), in.CustomStatus)
+		return ok(&zendesk.APIPayload{CustomStatus: status}, err)
+	})
+
+	// Skills-based routing
+	type attributePayload struct {
+		Attribute  *zendesk.RoutingAttribute  `json:"attribute,omitempty"`
+		Attributes []zendesk.RoutingAttribute `json:"attributes,omitempty"`
+	}
+	type valuePayload struct {
+		AttributeValue    *zendesk.RoutingAttributeValue  `json:"attribute_value,omitempty"`
+		AttributeValues   []zendesk.RoutingAttributeValue `json:"attribute_values,omitempty"`
+		AttributeValueIDs []string                        `json:"attribute_value_ids,omitempty"`
+	}
+	s.handle("GET", `routing/attributes\.json`, func(w res, r req, in payload, a args) (int, interface{}, error) {
+		attributes, err := b.ListRoutingAttributes()
+		return http.StatusOK, &attributePayload{Attributes: attributes}, err
+	})
+	s.handle("POST", `routing/attributes\.json`, func(w res, r req, in payload, a args) (int, interface{}, error) {
+		var body attributePayload
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil || body.Attribute == nil {
+			return 0, nil, fmt.Errorf("missing attribute")
+		}
+		attribute, err := b.CreateRoutingAttribute(body.Attribute)
+		return http.StatusCreated, &attributePayload{Attribute: attribute}, err
+	})
+	s.handle("GET", `routing/attributes/([^/]+)\.json`, func(w res, r req, in payload, a args) (int, interface{}, error) {
+		attribute, err := b.ShowRoutingAttribute(a[0])
+		return http.StatusOK, &attributePayload{Attribute: attribute}, err
+	})
+	s.handle("PUT", `routing/attributes/([^/]+)\.json`, func(w res, r req, in payload, a args) (int, interface{}, error) {
+		var body attributePayload
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil || body.Attribute == nil {
+			return 0, nil, fmt.Errorf("missing attribute")
+		}
+		attribute, err := b.UpdateRoutingAttribute(a[0], body.Attribute)
+		return http.StatusOK, &attributePayload{Attribute: attribute}, err
+	})
+	s.handle("DELETE", `routing/attributes/([^/]+)\.json`, func(w res, r req, in payload, a args) (int, interface{}, error) {
+		return noContent(b.DeleteRoutingAttribute(a[0]))
+	})
+	s.handle("GET", `routing/attributes/([^/]+)/values\.json`, func(w res, r req, in payload, a args) (int, interface{}, error) {
+		values, err := b.ListRoutingAttributeValues(a[0])
+		return http.StatusOK, &valuePayload{AttributeValues: values}, err
+	})
+	s.handle("POST", `routing/attributes/([^/]+)/values\.json`, func(w res, r req, in payload, a args) (int, interface{}, error) {
+		var body valuePayload
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil || body.AttributeValue == nil {
+			return 0, nil, fmt.Errorf("missing attribute value")
+		}
+		value, err := b.CreateRoutingAttributeValue(a[0], body.AttributeValue)
+		return http.StatusCreated, &valuePayload{AttributeValue: value}, err
+	})
+	s.handle("GET", `routing/attributes/([^/]+)/values/([^/]+)\.json`, func(w res, r req, in payload, a args) (int, interface{}, error) {
+		value, err := b.ShowRoutingAttributeValue(a[0], a[1])
+		return http.StatusOK, &valuePayload{AttributeValue: value}, err
+	})
+	s.handle("PUT", `routing/attributes/([^/]+)/values/([^/]+)\.json`, func(w res, r req, in payload, a args) (int, interface{}, error) {
+		var body valuePayload
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil || body.AttributeValue == nil {
+			return 0, nil, fmt.Errorf("missing attribute value")
+		}
+		value, err := b.UpdateRoutingAttributeValue(a[0], a[1], body.AttributeValue)
+		return http.StatusOK, &valuePayload{AttributeValue: value}, err
+	})
+	s.handle("DELETE", `routing/attributes/([^/]+)/values/([^/]+)\.json`, func(w res, r req, in payload, a args) (int, interface{}, error) {
+		return noContent(b.DeleteRoutingAttributeValue(a[0], a[1]))
+	})
+	s.handle("GET", `routing/tickets/(\d+)/instance_values\.json`, func(w res, r req, in payload, a args) (int, interface{}, error) {
+		values, err := b.ListTicketAttributes(id(a[0]))
+		return http.StatusOK, &valuePayload{AttributeValues: values}, err
+	})
+	s.handle("POST", `routing/tickets/(\d+)/instance_values\.json`, func(w res, r req, in payload, a args) (int, interface{}, error) {
+		var body valuePayload
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			return 0, nil, err
+		}
+		values, err := b.SetTicketAttributes(id(a[0]), body.AttributeValueIDs)
+		return http.StatusOK, &valuePayload{AttributeValues: values}, err
+	})
+	s.handle("GET", `routing/agents/(\d+)/instance_values\.json`, func(w res, r req, in payload, a args) (int, interface{}, error) {
+		values, err := b.ListAgentAttributes(id(a[0]))
+		return http.StatusOK, &valuePayload{AttributeValues: values}, err
+	})
+	s.handle("POST", `routing/agents/(\d+)/instance_values\.json`, func(w res, r req, in payload, a args) (int, interface{}, error) {
+		var body valuePayload
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			return 0, nil, err
+		}
+		values, err := b.SetAgentAttributes(id(a[0]), body.AttributeValueIDs)
+		return http.StatusOK, &valuePayload{AttributeValues: values}, err
+	})
+
+	// Custom roles
+	s.handle("GET", `custom_roles\.json`, func(w res, r req, in payload, a args) (int, interface{}, error) {
+		roles, err := b.ListCustomRoles()
+		return ok(&zendesk.APIPayload{CustomRoles: roles}, err)
+	})
+	s.handle("POST", `custom_roles\.json`, func(w res, r req, in payload, a args) (int, interface{}, error) {
+		if in.CustomRole == nil {
+			return 0, nil, fmt.Errorf("missing custom role")
+		}
+		role, err := b.CreateCustomRole(in.CustomRole)
+		return created(&zendesk.APIPayload{CustomRole: role}, err)
+	})
+	s.handle("GET", `custom_roles/(\d+)\.json`, func(w res, r req, in payload, a args) (int, interface{}, error) {
+		role, err := b.ShowCustomRole(id(a[0]))
+		return ok(&zendesk.APIPayload{CustomRole: role}, err)
+	})
+	s.handle("PUT", `custom_roles/(\d+)\.json`, func(w res, r req, in payload, a args) (int, interface{}, error) {
+		if in.CustomRole == nil {
+			return 0, nil, fmt.Errorf("missing custom role")
+		}
+		role, err := b.UpdateCustomRole(id(a[0]), in.CustomRole)
+		return ok(&zendesk.APIPayload{CustomRole: role}, err)
+	})
+	s.handle("DELETE", `custom_roles/(\d+)\.json`, func(w res, r req, in payload, a args) (int, interface{}, error) {
+		return noContent(b.DeleteCustomRole(id(a[0])))
+	})
+
+	// User fields
+	s.handle("GET", `user_fields\.json`, func(w res, r req, in payload, a args) (int, interface{}, error) {
+		fields, err := b.ListUserFields()
+		return ok(&zendesk.APIPayload{UserFields: fields}, err)
+	})
+	s.handle("POST", `user_fields\.json`, func(w res, r req, in payload, a args) (int, interface{}, error) {
+		if in.UserField == nil {
+			return 0, nil, fmt.Errorf("missing user field")
+		}
+		field, err := b.CreateUserField(in.UserField)
+		return created(&zendesk.APIPayload{UserField: field}, err)
+	})
+	s.handle("PUT", `user_fields/reorder\.json`, func(w res, r req, in payload, a args) (int, interface{}, error) {
+		var body struct {
+			IDs []int64 `json:"user_field_ids"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			return 0, nil, err
+		}
+		return ok(&zendesk.APIPayload{}, b.ReorderUserFields(body.IDs))
+	})
+	s.handle("GET", `user_fields/(\d+)\.json`, func(w res, r req, in payload, a args) (int, interface{}, error) {
+		field, err := b.ShowUserField(id(a[0]))
+		return ok(&zendesk.APIPayload{UserField: field}, err)
+	})
+	s.handle("PUT", `user_fields/(\d+)\.json`, func(w res, r req, in payload, a args) (int, interface{}, error) {
+		if in.UserField == nil {
+			return 0, nil, fmt.Errorf("missing user field")
+		}
+		field, err := b.UpdateUserField(id(a[0]), in.UserField)
+		return ok(&zendesk.APIPayload{UserField: field}, err)
+	})
+	s.handle("DELETE", `user_fields/(\d+)\.json`, func(w res, r req, in payload, a args) (int, interface{}, error) {
+		return noContent(b.DeleteUserField(id(a[0])))
+	})
+
+	// Organization fields
+	s.handle("GET", `organization_fields\.json`, func(w res, r req, in payload, a args) (int, interface{}, error) {
+		fields, err := b.ListOrganizationFields()
+		return ok(&zendesk.APIPayload{OrganizationFields: fields}, err)
+	})
+	s.handle("POST", `organization_fields\.json`, func(w res, r req, in payload, a args) (int, interface{}, error) {
+		if in.OrganizationField == nil {
+			return 0, nil, fmt.Errorf("missing organization field")
+		}
+		field, err := b.CreateOrganizationField(in.OrganizationField)
+		return created(&zendesk.APIPayload{OrganizationField: field}, err)
+	})
+	s.handle("PUT", `organization_fields/reorder\.json`, func(w res, r req, in payload, a args) (int, interface{}, error) {
+		var body struct {
+			IDs []int64 `json:"organization_field_ids"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			return 0, nil, err
+		}
+		return ok(&zendesk.APIPayload{}, b.ReorderOrganizationFields(body.IDs))
+	})
+	s.handle("GET", `organization_fields/(\d+)\.json`, func(w res, r req, in payload, a args) (int, interface{}, error) {
+		field, err := b.ShowOrganizationField(id(a[0]))
+		return ok(&zendesk.APIPayload{OrganizationField: field}, err)
+	})
+	s.handle("PUT", `organization_fields/(\d+)\.json`, func(w res, r req, in payload, a args) (int, interface{}, error) {
+		if in.OrganizationField == nil {
+			return 0, nil, fmt.Errorf("missing organization field")
+		}
+		field, err := b.UpdateOrganizationField(id(a[0]), in.OrganizationField)
+		return ok(&zendesk.APIPayload{OrganizationField: field}, err)
+	})
+	s.handle("DELETE", `organization_fields/(\d+)\.json`, func(w res, r req, in payload, a args) (int, interface{}, error) {
+		return noContent(b.DeleteOrganizationField(id(a[0])))
+	})
+
+	// Talk
+	s.handle("POST", `channels/voice/callback_requests\.json`, func(w res, r req, in payload, a args) (int, interface{}, error) {
+		var body struct {
+			CallbackRequest *zendesk.CallbackRequest `json:"callback_request"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil || body.CallbackRequest == nil {
+			return 0, nil, fmt.Errorf("missing callback request")
+		}
+		return http.StatusCreated, nil, b.CreateTalkCallbackRequest(body.CallbackRequest)
+	})
+	s.handle("POST", `channels/voice/tickets\.json`, func(w res, r req, in payload, a args) (int, interface{}, error) {
+		var body struct {
+			DisplayToAgent int64                `json:"display_to_agent"`
+			Ticket         *zendesk.VoiceTicket `json:"ticket"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil || body.Ticket == nil {
+			return 0, nil, fmt.Errorf("missing ticket")
+		}
+		t, err := b.CreateVoiceTicket(body.Ticket, body.DisplayToAgent)
+		return created(&zendesk.APIPayload{Ticket: t}, err)
+	})
+	s.handle("POST", `channels/voice/agents/(\d+)/tickets/(\d+)/display\.json`, func(w res, r req, in payload, a args) (int, interface{}, error) {
+		return http.StatusOK, nil, b.DisplayTicketToAgent(id(a[0]), id(a[1]))
+	})
+	s.handle("POST", `channels/voice/agents/(\d+)/users/(\d+)/display\.json`, func(w res, r req, in payload, a args) (int, interface{}, error) {
+		return http.StatusOK, nil, b.DisplayUserToAgent(id(a[0]), id(a[1]))
+	})
+
+	// Account limits
+	s.handle("GET", `account/subscription\.json`, func(w res, r req, in payload, a args) (int, interface{}, error) {
+		limits, err := b.GetAccountLimits()
+		if err != nil {
+			return 0, nil, err
+		}
+		return http.StatusOK, map[string]interface{}{"subscription": map[string]int{"max_agents": limits.Agents}}, nil
+	})
+	s.handle("GET", `users/count\.json`, func(w res, r req, in payload, a args) (int, interface{}, error) {
+		usage, err := b.GetAccountUsage()
+		if err != nil {
+			return 0, nil, err
+		}
+		return http.StatusOK, map[string]interface{}{"count": map[string]int{"value": usage.Agents}}, nil
+	})
+	s.handle("GET", `webhooks`, func(w res, r req, in payload, a args) (int, interface{}, error) {
+		if capabilities, _ := b.Capabilities(); !capabilities.Webhooks {
+			return 0, nil, fmt.Errorf("webhooks: %w", zendesk.ErrNotFound)
+		}
+		return http.StatusOK, map[string]interface{}{"webhooks": []interface{}{}, "meta": map[string]bool{"has_more": false}}, nil
+	})
+	s.handle("GET", `targets\.json`, func(w res, r req, in payload, a args) (int, interface{}, error) {
+		if capabilities, _ := b.Capabilities(); !capabilities.Targets {
+			return 0, nil, fmt.Errorf("targets: %w", zendesk.ErrNotFound)
+		}
+		return http.StatusOK, map[string]interface{}{"targets": []interface{}{}}, nil
+	})
+	s.handle("GET", `account/settings\.json`, func(w res, r req, in payload, a args) (int, interface{}, error) {
+		settings, err := b.ShowAccountSettings()
+		return http.StatusOK, map[string]interface{}{"settings": settings}, err
+	})
+	s.handle("PUT", `account/settings\.json`, func(w res, r req, in payload, a args) (int, interface{}, error) {
+		var body struct {
+			Settings *zendesk.AccountSettings `json:"settings"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil || body.Settings == nil {
+			return 0, nil, fmt.Errorf("missing settings")
+		}
+		settings, err := b.UpdateAccountSettings(body.Settings)
+		return http.StatusOK, map[string]interface{}{"settings": settings}, err
+	})
+
+	// Locales
+	s.handle("GET", `locales\.json`, func(w res, r req, in payload, a args) (int, interface{}, error) {
+		locales, err := b.ListLocales()
+		return ok(&zendesk.APIPayload{Locales: locales}, err)
+	})
+	s.handle("GET", `locales/agent\.json`, func(w res, r req, in payload, a args) (int, interface{}, error) {
+		locales, err := b.ListLocalesForAgent()
+		return ok(&zendesk.APIPayload{Locales: locales}, err)
+	})
+	s.handle("GET", `locales/public\.json`, func(w res, r req, in payload, a args) (int, interface{}, error) {
+		locales, err := b.ListAvailablePublicLocales()
+		return ok(&zendesk.APIPayload{Locales: locales}, err)
+	})
+	s.handle("GET", `locales/detect_best_locale\.json`, func(w res, r req, in payload, a args) (int, interface{}, error) {
+		locale, err := b.DetectBestLocale(acceptLanguages(r))
+		return ok(&zendesk.APIPayload{Locale: locale}, err)
+	})
+	s.handle("GET", `locales/([^/]+)\.json`, func(w res, r req, in payload, a args) (int, interface{}, error) {
+		var locale *zendesk.Locale
+		var err error
+		if n, perr := strconv.ParseInt(a[0], 10, 64); perr == nil {
+			locale, err = b.ShowLocale(n)
+		} else {
+			locale, err = b.ShowLocaleByCode(a[0])
+		}
+		return ok(&zendesk.APIPayload{Locale: locale}, err)
+	})
+}
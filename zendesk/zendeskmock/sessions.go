@@ -0,0 +1,72 @@
+package zendeskmock
+
+import (
+	"github.com/phil-inc/zendesk/zendesk"
+)
+
+// Sessions
+
+func (c *Client) ListSessions(userID int64) ([]zendesk.Session, error) {
+	c.lock()
+	defer c.unlock()
+
+	if _, ok := c.users[userID]; !ok {
+		return nil, notFound("user", userID)
+	}
+	return c.userSessions(userID), nil
+}
+
+func (c *Client) DeleteSession(userID, sessionID int64) error {
+	c.lock()
+	defer c.unlock()
+
+	session, ok := c.sessions[sessionID]
+	if !ok || session.UserID != userID {
+		return notFound("session", sessionID)
+	}
+	delete(c.sessions, sessionID)
+	return nil
+}
+
+func (c *Client) BulkDeleteSessionsByUserID(userID int64) error {
+	c.lock()
+	defer c.unlock()
+
+	if _, ok := c.users[userID]; !ok {
+		return notFound("user", userID)
+	}
+	for id, session := range c.sessions {
+		if session.UserID == userID {
+			delete(c.sessions, id)
+		}
+	}
+	return nil
+}
+
+// ShowCurrentlyAuthenticatedSession returns the latest session of the current user, or
+// ErrNotFound when they have none, as for token authenticated requests.
+func (c *Client) ShowCurrentlyAuthenticatedSession() (*zendesk.Session, error) {
+	c.lock()
+	defer c.unlock()
+
+	sessions := c.userSessions(c.CurrentUser.ID)
+	if len(sessions) == 0 {
+		return nil, notFound("session", "current")
+	}
+	return &sessions[len(sessions)-1], nil
+}
+
+func (c *Client) userSessions(userID int64) []zendesk.Session {
+	ids := make([]int64, 0)
+	for id, session := range c.sessions {
+		if session.UserID == userID {
+			ids = append(ids, id)
+		}
+	}
+
+	result := make([]zendesk.Session, 0, len(ids))
+	for _, id := range sortedIDs(ids) {
+		result = append(result, *c.sessions[id])
+	}
+	return result
+}
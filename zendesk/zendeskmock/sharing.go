@@ -0,0 +1,95 @@
+package zendeskmock
+
+import (
+	"fmt"
+
+	"github.com/phil-inc/zendesk/zendesk"
+)
+
+// Sharing agreements
+
+func (c *Client) ListSharingAgreements() ([]zendesk.SharingAgreement, error) {
+	c.lock()
+	defer c.unlock()
+
+	ids := make([]int64, 0, len(c.agreements))
+	for id := range c.agreements {
+		ids = append(ids, id)
+	}
+
+	result := make([]zendesk.SharingAgreement, 0, len(ids))
+	for _, id := range sortedIDs(ids) {
+		result = append(result, *c.agreements[id])
+	}
+	return result, nil
+}
+
+func (c *Client) ShowSharingAgreement(id int64) (*zendesk.SharingAgreement, error) {
+	c.lock()
+	defer c.unlock()
+
+	agreement, ok := c.agreements[id]
+	if !ok {
+		return nil, notFound("sharing agreement", id)
+	}
+	a := *agreement
+	return &a, nil
+}
+
+// CreateSharingAgreement creates a pending outbound agreement, as no other account can
+// accept it.
+func (c *Client) CreateSharingAgreement(agreement *zendesk.SharingAgreement) (*zendesk.SharingAgreement, error) {
+	c.lock()
+	defer c.unlock()
+
+	if agreement.RemoteSubdomain == "" {
+		return nil, &zendesk.ErrValidation{Type: "RecordInvalid", Description: "Remote subdomain can't be blank"}
+	}
+
+	a := *agreement
+	a.ID = c.nextID()
+	if a.Name == "" {
+		a.Name = a.RemoteSubdomain
+	}
+	a.Type = zendesk.SharingAgreementOutbound
+	a.Status = zendesk.SharingAgreementPending
+	a.CreatedAt = c.now()
+	a.UpdatedAt = a.CreatedAt
+	c.agreements[a.ID] = &a
+
+	created := a
+	return &created, nil
+}
+
+// UpdateSharingAgreement only changes the status, like Zendesk.
+func (c *Client) UpdateSharingAgreement(id int64, agreement *zendesk.SharingAgreement) (*zendesk.SharingAgreement, error) {
+	c.lock()
+	defer c.unlock()
+
+	existing, ok := c.agreements[id]
+	if !ok {
+		return nil, notFound("sharing agreement", id)
+	}
+	switch agreement.Status {
+	case "":
+	case zendesk.SharingAgreementPending, zendesk.SharingAgreementAccepted, zendesk.SharingAgreementDeclined, zendesk.SharingAgreementInactive:
+		existing.Status = agreement.Status
+	default:
+		return nil, &zendesk.ErrValidation{Type: "RecordInvalid", Description: fmt.Sprintf("Status %s is not valid", agreement.Status)}
+	}
+	existing.UpdatedAt = c.now()
+
+	a := *existing
+	return &a, nil
+}
+
+func (c *Client) DeleteSharingAgreement(id int64) error {
+	c.lock()
+	defer c.unlock()
+
+	if _, ok := c.agreements[id]; !ok {
+		return notFound("sharing agreement", id)
+	}
+	delete(c.agreements, id)
+	return nil
+}
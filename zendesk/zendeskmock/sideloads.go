@@ -0,0 +1,131 @@
+package zendeskmock
+
+import (
+	"github.com/phil-inc/zendesk/zendesk"
+)
+
+// Sideloads
+
+// ticketSideloads collects the records related to the tickets. The caller must hold the lock.
+func (c *Client) ticketSideloads(tickets []zendesk.Ticket, includes []zendesk.Include) *zendesk.Sideloads {
+	if len(includes) == 0 {
+		return nil
+	}
+
+	userIDs := make([]int64, 0)
+	orgIDs := make([]int64, 0)
+	groupIDs := make([]int64, 0)
+	ticketIDs := make(map[int64]bool)
+	for _, t := range tickets {
+		userIDs = append(userIDs, t.RequesterID, t.SubmitterID, t.AssigneeID)
+		userIDs = append(userIDs, t.CollaboratorIDs...)
+		orgIDs = append(orgIDs, t.OrganizationID)
+		groupIDs = append(groupIDs, t.GroupID)
+		ticketIDs[t.ID] = true
+	}
+
+	s := new(zendesk.Sideloads)
+	for _, include := range includes {
+		switch include {
+		case zendesk.IncludeUsers:
+			s.Users = c.usersByID(userIDs)
+		case zendesk.IncludeOrganizations:
+			s.Organizations = c.orgsByID(orgIDs)
+		case zendesk.IncludeGroups:
+			s.Groups = c.groupsByID(groupIDs)
+		case zendesk.IncludeMetricSets:
+			ids := make([]int64, 0)
+			for id, metric := range c.metrics {
+				if ticketIDs[metric.TicketID] {
+					ids = append(ids, id)
+				}
+			}
+			s.MetricSets = make([]zendesk.TicketMetric, 0, len(ids))
+			for _, id := range sortedIDs(ids) {
+				s.MetricSets = append(s.MetricSets, *c.metrics[id])
+			}
+		}
+	}
+	return s
+}
+
+// userSideloads collects the records related to the users. The caller must hold the lock.
+func (c *Client) userSideloads(users []zendesk.User, includes []zendesk.Include) *zendesk.Sideloads {
+	if len(includes) == 0 {
+		return nil
+	}
+
+	orgIDs := make([]int64, 0)
+	userIDs := make(map[int64]bool)
+	for _, u := range users {
+		orgIDs = append(orgIDs, u.OrganizationID)
+		userIDs[u.ID] = true
+	}
+	for _, m := range c.memberships {
+		if userIDs[m.UserID] {
+			orgIDs = append(orgIDs, m.OrganizationID)
+		}
+	}
+
+	s := new(zendesk.Sideloads)
+	for _, include := range includes {
+		switch include {
+		case zendesk.IncludeOrganizations:
+			s.Organizations = c.orgsByID(orgIDs)
+		case zendesk.IncludeIdentities:
+			ids := make([]int64, 0)
+			for id, identity := range c.identities {
+				if userIDs[identity.UserID] {
+					ids = append(ids, id)
+				}
+			}
+			s.Identities = make([]zendesk.UserIdentity, 0, len(ids))
+			for _, id := range sortedIDs(ids) {
+				s.Identities = append(s.Identities, *c.identities[id])
+			}
+		}
+	}
+	return s
+}
+
+func (c *Client) usersByID(ids []int64) []zendesk.User {
+	result := make([]zendesk.User, 0)
+	for _, id := range sortedIDs(uniq(ids)) {
+		if user, ok := c.users[id]; ok {
+			result = append(result, *user)
+		}
+	}
+	return result
+}
+
+func (c *Client) orgsByID(ids []int64) []zendesk.Organization {
+	result := make([]zendesk.Organization, 0)
+	for _, id := range sortedIDs(uniq(ids)) {
+		if org, ok := c.orgs[id]; ok {
+			result = append(result, *org)
+		}
+	}
+	return result
+}
+
+func (c *Client) groupsByID(ids []int64) []zendesk.Group {
+	result := make([]zendesk.Group, 0)
+	for _, id := range sortedIDs(uniq(ids)) {
+		if group, ok := c.groups[id]; ok {
+			result = append(result, *group)
+		}
+	}
+	return result
+}
+
+func uniq(ids []int64) []int64 {
+	seen := make(map[int64]bool)
+	result := make([]int64, 0, len(ids))
+	for _, id := range ids {
+		if id != 0 && !seen[id] {
+			seen[id] = true
+			result = append(result, id)
+		}
+	}
+	return result
+}
@@ -0,0 +1,63 @@
+package zendeskmock
+
+import (
+	"github.com/phil-inc/zendesk/zendesk"
+)
+
+// Ticket skips
+
+func (c *Client) ListTicketSkips(ticketID int64) ([]zendesk.TicketSkip, error) {
+	c.lock()
+	defer c.unlock()
+	return c.listSkips(func(s *zendesk.TicketSkip) bool { return s.TicketID == ticketID }), nil
+}
+
+func (c *Client) ListUserSkips(userID int64) ([]zendesk.TicketSkip, error) {
+	c.lock()
+	defer c.unlock()
+	return c.listSkips(func(s *zendesk.TicketSkip) bool { return s.UserID == userID }), nil
+}
+
+// listSkips returns the matching skips ordered by ID, with their tickets when they still exist.
+func (c *Client) listSkips(match func(*zendesk.TicketSkip) bool) []zendesk.TicketSkip {
+	ids := make([]int64, 0)
+	for id, skip := range c.skips {
+		if match(skip) {
+			ids = append(ids, id)
+		}
+	}
+
+	result := make([]zendesk.TicketSkip, 0, len(ids))
+	for _, id := range sortedIDs(ids) {
+		skip := *c.skips[id]
+		if t, ok := c.tickets[skip.TicketID]; ok {
+			ticket := *t
+			skip.Ticket = &ticket
+		}
+		result = append(result, skip)
+	}
+	return result
+}
+
+// CreateTicketSkip records the skip for the current user unless it names another one.
+func (c *Client) CreateTicketSkip(skip *zendesk.TicketSkip) (*zendesk.TicketSkip, error) {
+	c.lock()
+	defer c.unlock()
+
+	if _, ok := c.tickets[skip.TicketID]; !ok {
+		return nil, notFound("ticket", skip.TicketID)
+	}
+
+	s := *skip
+	s.ID = c.nextID()
+	if s.UserID == 0 {
+		s.UserID = c.CurrentUser.ID
+	}
+	s.CreatedAt = c.now()
+	s.UpdatedAt = s.CreatedAt
+	s.Ticket = nil
+	c.skips[s.ID] = &s
+
+	created := s
+	return &created, nil
+}
@@ -0,0 +1,139 @@
+package zendeskmock
+
+import (
+	"fmt"
+
+	"github.com/phil-inc/zendesk/zendesk"
+)
+
+// Custom ticket statuses
+
+func (c *Client) ListCustomTicketStatuses(opts *zendesk.CustomStatusListOptions) ([]zendesk.CustomStatus, error) {
+	c.lock()
+	defer c.unlock()
+
+	if opts == nil {
+		opts = new(zendesk.CustomStatusListOptions)
+	}
+	categories := make(map[string]bool)
+	for _, category := range opts.StatusCategories {
+		categories[category] = true
+	}
+	ids := make([]int64, 0, len(c.statuses))
+	for id := range c.statuses {
+		ids = append(ids, id)
+	}
+
+	result := make([]zendesk.CustomStatus, 0, len(ids))
+	for _, id := range sortedIDs(ids) {
+		status := c.statuses[id]
+		if len(categories) > 0 && !categories[status.StatusCategory] {
+			continue
+		}
+		if opts.Active != nil && *opts.Active != isActive(status) {
+			continue
+		}
+		if opts.Default != nil && *opts.Default != status.Default {
+			continue
+		}
+		result = append(result, *status)
+	}
+	return result, nil
+}
+
+func (c *Client) ShowCustomStatus(id int64) (*zendesk.CustomStatus, error) {
+	c.lock()
+	defer c.unlock()
+
+	status, ok := c.statuses[id]
+	if !ok {
+		return nil, notFound("custom status", id)
+	}
+	s := *status
+	return &s, nil
+}
+
+// CreateCustomStatus creates an active custom status, which is not the default of its category.
+func (c *Client) CreateCustomStatus(status *zendesk.CustomStatus) (*zendesk.CustomStatus, error) {
+	c.lock()
+	defer c.unlock()
+
+	switch status.StatusCategory {
+	case zendesk.StatusCategoryNew, zendesk.StatusCategoryOpen, zendesk.StatusCategoryPending, zendesk.StatusCategoryHold, zendesk.StatusCategorySolved:
+	default:
+		return nil, &zendesk.ErrValidation{Type: "RecordInvalid", Description: fmt.Sprintf("Status category %q is not valid", status.StatusCategory)}
+	}
+	if status.AgentLabel == "" {
+		return nil, &zendesk.ErrValidation{Type: "RecordInvalid", Description: "Agent label can't be blank"}
+	}
+
+	s := *status
+	s.ID = c.nextID()
+	if s.EndUserLabel == "" {
+		s.EndUserLabel = s.AgentLabel
+	}
+	s.RawAgentLabel = s.AgentLabel
+	s.RawEndUserLabel = s.EndUserLabel
+	s.RawDescription = s.Description
+	s.RawEndUserDescription = s.EndUserDescription
+	if s.Active == nil {
+		s.Active = zendesk.Bool(true)
+	}
+	s.Default = false
+	s.CreatedAt = c.now()
+	s.UpdatedAt = s.CreatedAt
+	c.statuses[s.ID] = &s
+
+	created := s
+	return &created, nil
+}
+
+// UpdateCustomStatus updates the labels, descriptions and activity of the status. The
+// default status of a category cannot be deactivated.
+func (c *Client) UpdateCustomStatus(id int64, status *zendesk.CustomStatus) (*zendesk.CustomStatus, error) {
+	c.lock()
+	defer c.unlock()
+
+	existing, ok := c.statuses[id]
+	if !ok {
+		return nil, notFound("custom status", id)
+	}
+	if status.StatusCategory != "" && status.StatusCategory != existing.StatusCategory {
+		return nil, &zendesk.ErrValidation{Type: "RecordInvalid", Description: "Status category can't be changed"}
+	}
+	if existing.Default && status.Active != nil && !*status.Active {
+		return nil, &zendesk.ErrValidation{Type: "RecordInvalid", Description: "Default status can't be deactivated"}
+	}
+
+	update := *status
+	update.ID = id
+	update.Default = existing.Default
+	if err := merge(existing, &update); err != nil {
+		return nil, err
+	}
+	existing.RawAgentLabel = existing.AgentLabel
+	existing.RawEndUserLabel = existing.EndUserLabel
+	existing.RawDescription = existing.Description
+	existing.RawEndUserDescription = existing.EndUserDescription
+	existing.UpdatedAt = c.now()
+
+	s := *existing
+	return &s, nil
+}
+
+func isActive(status *zendesk.CustomStatus) bool {
+	return status.Active == nil || *status.Active
+}
+
+// applyCustomStatus sets the status of a ticket to the category of its custom status.
+func (c *Client) applyCustomStatus(ticket *zendesk.Ticket) error {
+	if ticket.CustomStatusID == 0 {
+		return nil
+	}
+	status, ok := c.statuses[ticket.CustomStatusID]
+	if !ok || !isActive(status) {
+		return &zendesk.ErrValidation{Type: "RecordInvalid", Description: fmt.Sprintf("Custom status %d is not valid", ticket.CustomStatusID)}
+	}
+	ticket.Status = status.StatusCategory
+	return nil
+}
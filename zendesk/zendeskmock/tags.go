@@ -0,0 +1,72 @@
+package zendeskmock
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/phil-inc/zendesk/zendesk"
+)
+
+// Tags
+
+// ListTags counts the tags of the tickets, users and organizations, the most used first.
+func (c *Client) ListTags() ([]zendesk.Tag, error) {
+	c.lock()
+	defer c.unlock()
+
+	counts := c.tagCounts()
+	result := make([]zendesk.Tag, 0, len(counts))
+	for name, count := range counts {
+		result = append(result, zendesk.Tag{Name: name, Count: count})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Count != result[j].Count {
+			return result[i].Count > result[j].Count
+		}
+		return result[i].Name < result[j].Name
+	})
+	return result, nil
+}
+
+func (c *Client) AutocompleteTags(prefix string) ([]string, error) {
+	c.lock()
+	defer c.unlock()
+
+	result := make([]string, 0)
+	for name := range c.tagCounts() {
+		if strings.HasPrefix(name, prefix) {
+			result = append(result, name)
+		}
+	}
+	sort.Strings(result)
+	return result, nil
+}
+
+func (c *Client) tagCounts() map[string]int64 {
+	counts := make(map[string]int64)
+	for _, ticket := range c.tickets {
+		for _, tag := range ticket.Tags {
+			counts[tag]++
+		}
+	}
+	for _, user := range c.users {
+		for _, tag := range user.Tags {
+			counts[tag]++
+		}
+	}
+	for _, org := range c.orgs {
+		for _, tag := range org.Tags {
+			counts[tag]++
+		}
+	}
+	return counts
+}
+
+func containsTag(tags []string, tag string) bool {
+	for _, t := range tags {
+		if t == tag {
+			return true
+		}
+	}
+	return false
+}
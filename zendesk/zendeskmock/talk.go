@@ -0,0 +1,161 @@
+package zendeskmock
+
+import (
+	"fmt"
+
+	"github.com/phil-inc/zendesk/zendesk"
+)
+
+// Display is a ticket or user profile opened in the browser of an agent.
+type Display struct {
+	AgentID  int64
+	TicketID int64
+	UserID   int64
+}
+
+// Talk
+
+func (c *Client) CreateTalkCallbackRequest(request *zendesk.CallbackRequest) error {
+	c.lock()
+	defer c.unlock()
+
+	if request.PhoneNumberID == 0 || request.RequesterPhoneNumber == "" {
+		return &zendesk.ErrValidation{Description: "phone_number_id and requester_phone_number are required"}
+	}
+	c.callbacks = append(c.callbacks, *request)
+	return nil
+}
+
+func (c *Client) CreateVoiceTicket(ticket *zendesk.VoiceTicket, displayToAgentID int64) (*zendesk.Ticket, error) {
+	c.lock()
+	defer c.unlock()
+
+	t := ticket.Ticket
+	channel := "voice"
+	t.Via = &zendesk.Via{Channel: &channel}
+	if v := ticket.VoiceComment; v != nil && t.Comment == nil {
+		body := fmt.Sprintf("Call from: %s\nCall to: %s", v.From, v.To)
+		if v.TranscriptionText != "" {
+			body += "\n\n" + v.TranscriptionText
+		}
+		t.Comment = &zendesk.TicketComment{Body: body, Public: false, AuthorID: v.AnsweredByID}
+	}
+	created := c.createTicket(&t)
+	if displayToAgentID != 0 {
+		c.displays = append(c.displays, Display{AgentID: displayToAgentID, TicketID: created.ID})
+	}
+	return created, nil
+}
+
+func (c *Client) DisplayTicketToAgent(agentID, ticketID int64) error {
+	c.lock()
+	defer c.unlock()
+
+	if _, ok := c.users[agentID]; !ok {
+		return notFound("agent", agentID)
+	}
+	if _, ok := c.tickets[ticketID]; !ok {
+		return notFound("ticket", ticketID)
+	}
+	c.displays = append(c.displays, Display{AgentID: agentID, TicketID: ticketID})
+	return nil
+}
+
+func (c *Client) DisplayUserToAgent(agentID, userID int64) error {
+	c.lock()
+	defer c.unlock()
+
+	if _, ok := c.users[agentID]; !ok {
+		return notFound("agent", agentID)
+	}
+	if _, ok := c.users[userID]; !ok {
+		return notFound("user", userID)
+	}
+	c.displays = append(c.displays, Display{AgentID: agentID, UserID: userID})
+	return nil
+}
+
+func (c *Client) ListPhoneNumbers() ([]zendesk.PhoneNumber, error) {
+	c.lock()
+	defer c.unlock()
+
+	ids := make([]int64, 0, len(c.phoneNumbers))
+	for id := range c.phoneNumbers {
+		ids = append(ids, id)
+	}
+
+	result := make([]zendesk.PhoneNumber, 0, len(ids))
+	for _, id := range sortedIDs(ids) {
+		result = append(result, *c.phoneNumbers[id])
+	}
+	return result, nil
+}
+
+func (c *Client) ListLines() ([]zendesk.Line, error) {
+	c.lock()
+	defer c.unlock()
+
+	ids := make([]int64, 0, len(c.lines))
+	for id := range c.lines {
+		ids = append(ids, id)
+	}
+
+	result := make([]zendesk.Line, 0, len(ids))
+	for _, id := range sortedIDs(ids) {
+		result = append(result, *c.lines[id])
+	}
+	return result, nil
+}
+
+func (c *Client) ListGreetings() ([]zendesk.Greeting, error) {
+	c.lock()
+	defer c.unlock()
+
+	ids := make([]int64, 0, len(c.greetings))
+	for id := range c.greetings {
+		ids = append(ids, id)
+	}
+
+	result := make([]zendesk.Greeting, 0, len(ids))
+	for _, id := range sortedIDs(ids) {
+		result = append(result, *c.greetings[id])
+	}
+	return result, nil
+}
+
+// ShowAgentAvailability returns the availability set for the agent, agents being offline
+// by default.
+func (c *Client) ShowAgentAvailability(agentID int64) (*zendesk.Availability, error) {
+	c.lock()
+	defer c.unlock()
+
+	if _, ok := c.users[agentID]; !ok {
+		return nil, notFound("agent", agentID)
+	}
+	availability, ok := c.availabilities[agentID]
+	if !ok {
+		availability = zendesk.Availability{AgentState: "offline"}
+	}
+	return &availability, nil
+}
+
+// SetAgentAvailability sets the availability of an agent, such as to simulate an agent going online.
+func (c *Client) SetAgentAvailability(agentID int64, availability zendesk.Availability) {
+	c.lock()
+	defer c.unlock()
+	c.availabilities[agentID] = availability
+}
+
+// CallbackRequests returns the callback requests created so far.
+func (c *Client) CallbackRequests() []zendesk.CallbackRequest {
+	c.lock()
+	defer c.unlock()
+	return append([]zendesk.CallbackRequest(nil), c.callbacks...)
+}
+
+// Displays returns the tickets and user profiles opened in the browser of agents so far.
+func (c *Client) Displays() []Display {
+	c.lock()
+	defer c.unlock()
+	return append([]Display(nil), c.displays...)
+}
@@ -0,0 +1,664 @@
+package zendeskmock
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/phil-inc/zendesk/zendesk"
+)
+
+// Users
+
+func (c *Client) ShowUser(id int64, includes ...zendesk.Include) (*zendesk.User, error) {
+	c.lock()
+	defer c.unlock()
+
+	user, ok := c.users[id]
+	if !ok {
+		return nil, notFound("user", id)
+	}
+	u := *user
+	u.Sideloads = c.userSideloads([]zendesk.User{u}, includes)
+	return &u, nil
+}
+
+func (c *Client) ShowManyUsers(ids []int64, includes ...zendesk.Include) ([]zendesk.User, error) {
+	c.lock()
+	result := make([]zendesk.User, 0, len(ids))
+	for _, id := range ids {
+		if user, ok := c.users[id]; ok {
+			result = append(result, *user)
+		}
+	}
+	c.unlock()
+
+	return c.withUserSideloads(result, includes), nil
+}
+
+func (c *Client) withUserSideloads(users []zendesk.User, includes []zendesk.Include) []zendesk.User {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if s := c.userSideloads(users, includes); s != nil {
+		for i := range users {
+			users[i].Sideloads = s
+		}
+	}
+	return users
+}
+
+func (c *Client) ShowCurrentUser() (*zendesk.User, error) {
+	c.lock()
+	defer c.unlock()
+	u := c.CurrentUser
+	return &u, nil
+}
+
+func (c *Client) CreateUser(user *zendesk.User) (*zendesk.User, error) {
+	c.lock()
+	defer c.unlock()
+	return c.createUniqueUser(user)
+}
+
+// createUniqueUser creates the user unless its email is taken.
+func (c *Client) createUniqueUser(user *zendesk.User) (*zendesk.User, error) {
+	if user.Email != "" && c.userByEmail(user.Email) != nil {
+		return nil, fmt.Errorf("email %s already taken: %w", user.Email, &zendesk.ErrValidation{Description: "Record validation errors"})
+	}
+	return c.createUser(user), nil
+}
+
+func (c *Client) createUser(user *zendesk.User) *zendesk.User {
+	u := *user
+	if u.ID == 0 {
+		u.ID = c.nextID()
+	}
+	c.seen(u.ID)
+	if u.Role == "" {
+		u.Role = "end-user"
+	}
+	u.Active = true
+	if u.CreatedAt == nil {
+		u.CreatedAt = c.now()
+	}
+	u.UpdatedAt = c.now()
+	c.users[u.ID] = &u
+
+	if u.Email != "" {
+		identity := &zendesk.UserIdentity{ID: c.nextID(), UserID: u.ID, Type: "email", Value: u.Email, Primary: true, CreatedAt: u.CreatedAt, UpdatedAt: u.UpdatedAt}
+		c.identities[identity.ID] = identity
+	}
+
+	created := u
+	return &created
+}
+
+func (c *Client) userByEmail(email string) *zendesk.User {
+	for _, user := range c.users {
+		if strings.EqualFold(user.Email, email) {
+			return user
+		}
+	}
+	return nil
+}
+
+func (c *Client) CreateOrUpdateUser(user *zendesk.User) (*zendesk.User, error) {
+	c.lock()
+	defer c.unlock()
+	return c.createOrUpdateUser(user)
+}
+
+func (c *Client) createOrUpdateUser(user *zendesk.User) (*zendesk.User, error) {
+	var existing *zendesk.User
+	if user.ExternalID != "" {
+		for _, u := range c.users {
+			if u.ExternalID == user.ExternalID {
+				existing = u
+				break
+			}
+		}
+	}
+	if existing == nil && user.Email != "" {
+		existing = c.userByEmail(user.Email)
+	}
+
+	if existing == nil {
+		return c.createUser(user), nil
+	}
+	return c.updateUser(existing.ID, user)
+}
+
+func (c *Client) UpdateUser(id int64, user *zendesk.User) (*zendesk.User, error) {
+	c.lock()
+	defer c.unlock()
+	return c.updateUser(id, user)
+}
+
+func (c *Client) updateUser(id int64, user *zendesk.User) (*zendesk.User, error) {
+	existing, ok := c.users[id]
+	if !ok {
+		return nil, notFound("user", id)
+	}
+
+	update := *user
+	update.ID = id
+	if err := merge(existing, &update); err != nil {
+		return nil, err
+	}
+	existing.UpdatedAt = c.now()
+
+	u := *existing
+	return &u, nil
+}
+
+func (c *Client) DeleteUser(id int64) (*zendesk.User, error) {
+	c.lock()
+	defer c.unlock()
+	return c.deleteUser(id)
+}
+
+func (c *Client) deleteUser(id int64) (*zendesk.User, error) {
+	user, ok := c.users[id]
+	if !ok {
+		return nil, notFound("user", id)
+	}
+	delete(c.users, id)
+	for identityID, identity := range c.identities {
+		if identity.UserID == id {
+			delete(c.identities, identityID)
+		}
+	}
+	for membershipID, membership := range c.memberships {
+		if membership.UserID == id {
+			delete(c.memberships, membershipID)
+		}
+	}
+
+	user.Active = false
+	c.deletedUsers[id] = user
+
+	u := *user
+	return &u, nil
+}
+
+func (c *Client) ShowUserRelated(id int64) (*zendesk.UserRelated, error) {
+	c.lock()
+	defer c.unlock()
+
+	if _, ok := c.users[id]; !ok {
+		return nil, notFound("user", id)
+	}
+	related := new(zendesk.UserRelated)
+	for _, t := range c.tickets {
+		if t.AssigneeID == id {
+			related.AssignedTickets++
+		}
+		if t.RequesterID == id {
+			related.RequestedTickets++
+		}
+		if containsID(t.CollaboratorIDs, id) || containsID(t.EmailCCIDs, id) {
+			related.CCdTickets++
+		}
+	}
+	return related, nil
+}
+
+// MergeUsers moves the tickets, identities and organization memberships of the end user
+// sourceID to the end user targetID, then deletes the source user.
+func (c *Client) MergeUsers(sourceID, targetID int64) (*zendesk.User, error) {
+	c.lock()
+	defer c.unlock()
+
+	source, ok := c.users[sourceID]
+	if !ok {
+		return nil, notFound("user", sourceID)
+	}
+	target, ok := c.users[targetID]
+	if !ok {
+		return nil, notFound("user", targetID)
+	}
+	if sourceID == targetID || !isEndUser(source) || !isEndUser(target) {
+		return nil, &zendesk.ErrValidation{Description: "Only two distinct end users can be merged"}
+	}
+
+	for _, t := range c.tickets {
+		if t.RequesterID == sourceID {
+			t.RequesterID = targetID
+		}
+		if t.SubmitterID == sourceID {
+			t.SubmitterID = targetID
+		}
+		t.CollaboratorIDs = replaceID(t.CollaboratorIDs, sourceID, targetID)
+		t.EmailCCIDs = replaceID(t.EmailCCIDs, sourceID, targetID)
+	}
+	for _, identity := range c.identities {
+		if identity.UserID == sourceID {
+			identity.UserID = targetID
+			identity.Primary = false
+		}
+	}
+	orgs := make(map[int64]bool)
+	for _, membership := range c.memberships {
+		if membership.UserID == targetID {
+			orgs[membership.OrganizationID] = true
+		}
+	}
+	for _, membership := range c.memberships {
+		if membership.UserID == sourceID && !orgs[membership.OrganizationID] {
+			membership.UserID = targetID
+			membership.Default = false
+		}
+	}
+	if _, err := c.deleteUser(sourceID); err != nil {
+		return nil, err
+	}
+
+	target.UpdatedAt = c.now()
+	u := *target
+	return &u, nil
+}
+
+// MergeSelfWithUser returns the user with the given email. The password is not checked and
+// CurrentUser is left as is.
+func (c *Client) MergeSelfWithUser(email, password string) (*zendesk.User, error) {
+	c.lock()
+	defer c.unlock()
+
+	user := c.userByEmail(email)
+	if user != nil {
+		u := *user
+		return &u, nil
+	}
+	return nil, &zendesk.ErrValidation{Description: fmt.Sprintf("No user with email %s", email)}
+}
+
+func isEndUser(user *zendesk.User) bool {
+	return user.Role == "" || user.Role == "end-user"
+}
+
+// replaceID replaces old by new in ids, dropping the duplicates it would create.
+func replaceID(ids []int64, old, new int64) []int64 {
+	if !containsID(ids, old) {
+		return ids
+	}
+	result := make([]int64, 0, len(ids))
+	for _, id := range ids {
+		if id == old {
+			id = new
+		}
+		if !containsID(result, id) {
+			result = append(result, id)
+		}
+	}
+	return result
+}
+
+func (c *Client) CreateManyUsers(users []zendesk.User) (*zendesk.JobStatus, error) {
+	c.lock()
+	defer c.unlock()
+
+	results := make([]zendesk.JobStatusResult, 0, len(users))
+	for i := range users {
+		var id int64
+		u, err := c.createUniqueUser(&users[i])
+		if err == nil {
+			id = u.ID
+		}
+		results = append(results, actionResult("create", "Created", id, int64(i), err))
+	}
+	return c.completedJob(results), nil
+}
+
+func (c *Client) UpdateManyUsers(users []zendesk.User) (*zendesk.JobStatus, error) {
+	c.lock()
+	defer c.unlock()
+
+	results := make([]zendesk.JobStatusResult, 0, len(users))
+	for i := range users {
+		_, err := c.updateUser(users[i].ID, &users[i])
+		results = append(results, actionResult("update", "Updated", users[i].ID, int64(i), err))
+	}
+	return c.completedJob(results), nil
+}
+
+func (c *Client) CreateOrUpdateManyUsers(users []zendesk.User) (*zendesk.JobStatus, error) {
+	c.lock()
+	defer c.unlock()
+
+	results := make([]zendesk.JobStatusResult, 0, len(users))
+	for i := range users {
+		var id int64
+		u, err := c.createOrUpdateUser(&users[i])
+		if err == nil {
+			id = u.ID
+		}
+		results = append(results, actionResult("create_or_update", "Updated", id, int64(i), err))
+	}
+	return c.completedJob(results), nil
+}
+
+func (c *Client) DestroyManyUsers(ids []int64) (*zendesk.JobStatus, error) {
+	c.lock()
+	defer c.unlock()
+
+	results := make([]zendesk.JobStatusResult, 0, len(ids))
+	for i, id := range ids {
+		_, err := c.deleteUser(id)
+		results = append(results, actionResult("delete", "Deleted", id, int64(i), err))
+	}
+	return c.completedJob(results), nil
+}
+
+func (c *Client) ListUsers(opts *zendesk.ListUsersOptions, includes ...zendesk.Include) ([]zendesk.User, error) {
+	users := c.filterUsers(func(u *zendesk.User) bool { return hasRole(u, opts) })
+	return c.withUserSideloads(users, includes), nil
+}
+
+func (c *Client) ListOrganizationUsers(id int64, opts *zendesk.ListUsersOptions, includes ...zendesk.Include) ([]zendesk.User, error) {
+	c.lock()
+	members := make(map[int64]bool)
+	for _, membership := range c.memberships {
+		if membership.OrganizationID == id {
+			members[membership.UserID] = true
+		}
+	}
+	c.unlock()
+
+	users := c.filterUsers(func(u *zendesk.User) bool {
+		return (members[u.ID] || u.OrganizationID == id) && hasRole(u, opts)
+	})
+	return c.withUserSideloads(users, includes), nil
+}
+
+func hasRole(user *zendesk.User, opts *zendesk.ListUsersOptions) bool {
+	if opts == nil || len(opts.Role) == 0 {
+		return true
+	}
+	for _, role := range opts.Role {
+		if user.Role == role {
+			return true
+		}
+	}
+	return false
+}
+
+// SearchUsers matches the users whose name or email contains the query, ignoring case.
+// SearchUsers matches the query against the names and emails of the users. An email:
+// query only matches the email, exactly.
+func (c *Client) SearchUsers(query string, includes ...zendesk.Include) ([]zendesk.User, error) {
+	query = strings.ToLower(query)
+	if strings.HasPrefix(query, "email:") {
+		email := strings.Trim(strings.TrimPrefix(query, "email:"), `"`)
+		users := c.filterUsers(func(u *zendesk.User) bool { return strings.EqualFold(u.Email, email) })
+		return c.withUserSideloads(users, includes), nil
+	}
+	users := c.filterUsers(func(u *zendesk.User) bool {
+		return strings.Contains(strings.ToLower(u.Name), query) || strings.Contains(strings.ToLower(u.Email), query)
+	})
+	return c.withUserSideloads(users, includes), nil
+}
+
+func (c *Client) GetAllUsers() ([]zendesk.User, error) {
+	return c.filterUsers(func(*zendesk.User) bool { return true }), nil
+}
+
+// GetAllUsersWithOptions returns all the users, whatever the options.
+func (c *Client) GetAllUsersWithOptions(opts *zendesk.GetAllUsersOptions) ([]zendesk.User, error) {
+	return c.GetAllUsers()
+}
+
+func (c *Client) GetUsersIncrementally(unixTime int64) ([]zendesk.User, error) {
+	return c.filterUsers(func(u *zendesk.User) bool { return updatedSince(u.UpdatedAt, unixTime) }), nil
+}
+
+func (c *Client) filterUsers(keep func(*zendesk.User) bool) []zendesk.User {
+	c.lock()
+	defer c.unlock()
+
+	ids := make([]int64, 0, len(c.users))
+	for id := range c.users {
+		ids = append(ids, id)
+	}
+
+	result := make([]zendesk.User, 0)
+	for _, id := range sortedIDs(ids) {
+		if keep(c.users[id]) {
+			result = append(result, *c.users[id])
+		}
+	}
+	return result
+}
+
+func (c *Client) AddUserTags(id int64, tags []string) ([]string, error) {
+	c.lock()
+	defer c.unlock()
+
+	user, ok := c.users[id]
+	if !ok {
+		return nil, notFound("user", id)
+	}
+	user.Tags = addTags(user.Tags, tags)
+	user.UpdatedAt = c.now()
+	return append([]string(nil), user.Tags...), nil
+}
+
+func (c *Client) SetUserTags(id int64, tags []string) ([]string, error) {
+	c.lock()
+	defer c.unlock()
+
+	user, ok := c.users[id]
+	if !ok {
+		return nil, notFound("user", id)
+	}
+	user.Tags = addTags(nil, tags)
+	user.UpdatedAt = c.now()
+	return append([]string(nil), user.Tags...), nil
+}
+
+func (c *Client) RemoveUserTags(id int64, tags []string) error {
+	c.lock()
+	defer c.unlock()
+
+	user, ok := c.users[id]
+	if !ok {
+		return notFound("user", id)
+	}
+	user.Tags = removeTags(user.Tags, tags)
+	user.UpdatedAt = c.now()
+	return nil
+}
+
+// Identities
+
+func (c *Client) ListIdentities(userID int64) ([]zendesk.UserIdentity, error) {
+	c.lock()
+	defer c.unlock()
+
+	if _, ok := c.users[userID]; !ok {
+		return nil, notFound("user", userID)
+	}
+	return c.listIdentities(userID), nil
+}
+
+func (c *Client) listIdentities(userID int64) []zendesk.UserIdentity {
+	ids := make([]int64, 0)
+	for id, identity := range c.identities {
+		if identity.UserID == userID {
+			ids = append(ids, id)
+		}
+	}
+
+	result := make([]zendesk.UserIdentity, 0, len(ids))
+	for _, id := range sortedIDs(ids) {
+		result = append(result, *c.identities[id])
+	}
+	return result
+}
+
+func (c *Client) ShowIdentity(userID, id int64) (*zendesk.UserIdentity, error) {
+	c.lock()
+	defer c.unlock()
+
+	identity, ok := c.identities[id]
+	if !ok || identity.UserID != userID {
+		return nil, notFound("identity", id)
+	}
+	i := *identity
+	return &i, nil
+}
+
+func (c *Client) CreateIdentity(userID int64, identity *zendesk.UserIdentity) (*zendesk.UserIdentity, error) {
+	c.lock()
+	defer c.unlock()
+
+	if _, ok := c.users[userID]; !ok {
+		return nil, notFound("user", userID)
+	}
+
+	i := *identity
+	i.ID = c.nextID()
+	i.UserID = userID
+	i.Primary = false
+	i.CreatedAt = c.now()
+	i.UpdatedAt = i.CreatedAt
+	c.identities[i.ID] = &i
+
+	created := i
+	return &created, nil
+}
+
+func (c *Client) UpdateIdentity(userID, id int64, identity *zendesk.UserIdentity) (*zendesk.UserIdentity, error) {
+	c.lock()
+	defer c.unlock()
+
+	existing, ok := c.identities[id]
+	if !ok || existing.UserID != userID {
+		return nil, notFound("identity", id)
+	}
+
+	update := *identity
+	update.ID = id
+	update.UserID = userID
+	if err := merge(existing, &update); err != nil {
+		return nil, err
+	}
+	existing.UpdatedAt = c.now()
+
+	i := *existing
+	return &i, nil
+}
+
+func (c *Client) DeleteIdentity(userID, id int64) error {
+	c.lock()
+	defer c.unlock()
+
+	identity, ok := c.identities[id]
+	if !ok || identity.UserID != userID {
+		return notFound("identity", id)
+	}
+	delete(c.identities, id)
+	return nil
+}
+
+func (c *Client) MakeIdentityPrimary(userID, id int64) ([]zendesk.UserIdentity, error) {
+	c.lock()
+	defer c.unlock()
+
+	primary, ok := c.identities[id]
+	if !ok || primary.UserID != userID {
+		return nil, notFound("identity", id)
+	}
+
+	for _, identity := range c.identities {
+		if identity.UserID == userID && identity.Type == primary.Type {
+			identity.Primary = identity.ID == id
+		}
+	}
+	if primary.Type == "email" {
+		c.users[userID].Email = primary.Value
+	}
+
+	return c.listIdentities(userID), nil
+}
+
+func (c *Client) VerifyIdentity(userID, id int64) (*zendesk.UserIdentity, error) {
+	c.lock()
+	defer c.unlock()
+
+	identity, ok := c.identities[id]
+	if !ok || identity.UserID != userID {
+		return nil, notFound("identity", id)
+	}
+	identity.Verified = true
+	identity.UpdatedAt = c.now()
+
+	i := *identity
+	return &i, nil
+}
+
+// RequestIdentityVerification only checks the identity since the mock sends no email.
+func (c *Client) RequestIdentityVerification(userID, id int64) error {
+	c.lock()
+	defer c.unlock()
+
+	identity, ok := c.identities[id]
+	if !ok || identity.UserID != userID {
+		return notFound("identity", id)
+	}
+	return nil
+}
+
+// ChangeUserPrimaryEmail makes newEmail the primary email of the user, in a single step
+// since in-memory updates cannot fail midway.
+func (c *Client) ChangeUserPrimaryEmail(userID int64, newEmail string, opts *zendesk.ChangeEmailOptions) (*zendesk.UserIdentity, error) {
+	c.lock()
+	defer c.unlock()
+
+	if _, ok := c.users[userID]; !ok {
+		return nil, notFound("user", userID)
+	}
+	if opts == nil {
+		opts = new(zendesk.ChangeEmailOptions)
+	}
+
+	var previous, identity *zendesk.UserIdentity
+	for _, i := range c.identities {
+		if i.UserID != userID || i.Type != "email" {
+			continue
+		}
+		if i.Primary {
+			previous = i
+		}
+		if strings.EqualFold(i.Value, newEmail) {
+			identity = i
+		}
+	}
+
+	if identity == nil {
+		identity = &zendesk.UserIdentity{ID: c.nextID(), UserID: userID, Type: "email", Value: newEmail, CreatedAt: c.now()}
+		c.identities[identity.ID] = identity
+	}
+	identity.Verified = identity.Verified || opts.Verify
+	identity.UpdatedAt = c.now()
+
+	if previous != nil && previous != identity {
+		previous.Primary = false
+		if !opts.KeepOldEmail {
+			delete(c.identities, previous.ID)
+		}
+	}
+	identity.Primary = true
+	c.users[userID].Email = identity.Value
+
+	i := *identity
+	return &i, nil
+}
+
+// FindUserByEmail finds the user right away, since in-memory writes are never lagging.
+func (c *Client) FindUserByEmail(email string, opts *zendesk.SearchOptions) (*zendesk.User, error) {
+	users, _ := c.SearchUsers("email:" + email)
+	if len(users) == 0 {
+		return nil, notFound("user", email)
+	}
+	return &users[0], nil
+}